@@ -4,6 +4,7 @@ package activation
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -32,6 +33,27 @@ type PoetConfig struct {
 	GracePeriod       time.Duration `mapstructure:"grace-period"`
 	RequestRetryDelay time.Duration `mapstructure:"retry-delay"`
 	MaxRequestRetries int           `mapstructure:"retry-max"`
+	// ServicePubkeys are hex encoded public keys of trusted poet services.
+	// If not empty, challenges are submitted only to these services, and proofs, both
+	// downloaded and gossiped, must be signed by one of them. Otherwise any service
+	// is trusted and signatures are verified only if proofs are signed.
+	ServicePubkeys []string `mapstructure:"service-pubkeys"`
+}
+
+// ServiceKeys decodes ServicePubkeys.
+func (c PoetConfig) ServiceKeys() ([][]byte, error) {
+	keys := make([][]byte, 0, len(c.ServicePubkeys))
+	for _, encoded := range c.ServicePubkeys {
+		key, err := hex.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("decode poet service key %s: %w", encoded, err)
+		}
+		if len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("poet service key %s: invalid length %d", encoded, len(key))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
 }
 
 func DefaultPoetConfig() PoetConfig {
@@ -434,6 +456,11 @@ func (b *Builder) UpdatePoETServers(ctx context.Context, endpoints []string) err
 	return nil
 }
 
+// PoetOutcomes returns the outcomes of the latest challenge submitted to the PoET services.
+func (b *Builder) PoetOutcomes() []PoetOutcome {
+	return b.nipostBuilder.PoetOutcomes()
+}
+
 // SetCoinbase sets the address rewardAddress to be the coinbase account written into the activation transaction
 // the rewards for blocks made by this miner will go to this address.
 func (b *Builder) SetCoinbase(rewardAddress types.Address) {
@@ -637,7 +664,11 @@ func (b *Builder) GetPositioningAtx() (types.ATXID, error) {
 
 // SignAndFinalizeAtx signs the atx with specified signer and calculates the ID of the ATX.
 func SignAndFinalizeAtx(signer *signing.EdSigner, atx *types.ActivationTx) error {
-	atx.Signature = signer.Sign(signing.ATX, atx.SignedBytes())
+	sig, err := signer.TrySign(signing.ATX, atx.SignedBytes())
+	if err != nil {
+		return err
+	}
+	atx.Signature = sig
 	atx.SmesherID = signer.NodeID()
 	return atx.Initialize()
 }
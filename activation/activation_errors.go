@@ -12,6 +12,10 @@ var (
 	ErrPoetServiceUnstable = &PoetSvcUnstableError{}
 	// ErrPoetProofNotReceived is returned when no poet proof was received.
 	ErrPoetProofNotReceived = errors.New("builder: didn't receive any poet proof")
+	// ErrUnknownPoetService is returned when poet service key is not in the configured service-pubkeys.
+	ErrUnknownPoetService = errors.New("poet service key is not trusted")
+	// ErrInvalidPoetSignature is returned when poet proof is not signed by the poet service.
+	ErrInvalidPoetSignature = errors.New("invalid poet service signature")
 )
 
 // PoetSvcUnstableError means there was a problem communicating
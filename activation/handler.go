@@ -26,10 +26,27 @@ import (
 
 var (
 	errKnownAtx      = errors.New("known atx")
-	errMalformedData = errors.New("malformed data")
+	errMalformedData = fmt.Errorf("%w: malformed data", pubsub.ErrValidationReject)
 	errMaliciousATX  = errors.New("malicious atx")
+	// errInvalidAtx wraps the reasons an atx fails syntactic validation, as opposed to
+	// failures to read the data the validation depends on.
+	errInvalidAtx = fmt.Errorf("%w: invalid atx", pubsub.ErrValidationReject)
 )
 
+func invalidAtx(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errInvalidAtx, fmt.Sprintf(format, args...))
+}
+
+// validatorError marks the error of the nipost validator as a validation failure, unless
+// the validator failed to find the atxs or the poet proof referenced by the atx.
+func validatorError(err error) error {
+	var notFound *ErrAtxNotFound
+	if err == nil || errors.As(err, &notFound) || errors.Is(err, errPoetProofNotAvailable) {
+		return err
+	}
+	return invalidAtx("%v", err)
+}
+
 type atxChan struct {
 	ch        chan struct{}
 	listeners int
@@ -51,6 +68,21 @@ type Handler struct {
 	atxChannels     map[types.ATXID]*atxChan
 	fetcher         system.Fetcher
 	poetCfg         PoetConfig
+
+	denier       peerDenier
+	denyDuration time.Duration
+}
+
+// HandlerOption is an option for Handler.
+type HandlerOption func(*Handler)
+
+// WithPeerDenier configures Handler to deny connections for the duration with peers
+// that sent syntactically invalid atxs.
+func WithPeerDenier(denier peerDenier, duration time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.denier = denier
+		h.denyDuration = duration
+	}
 }
 
 // NewHandler returns a data handler for ATX.
@@ -67,8 +99,9 @@ func NewHandler(
 	atxReceivers []AtxReceiver,
 	log log.Log,
 	poetCfg PoetConfig,
+	opts ...HandlerOption,
 ) *Handler {
-	return &Handler{
+	h := &Handler{
 		cdb:             cdb,
 		edVerifier:      edVerifier,
 		clock:           c,
@@ -83,6 +116,10 @@ func NewHandler(
 		fetcher:         fetcher,
 		poetCfg:         poetCfg,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 var closedChan = make(chan struct{})
@@ -196,7 +233,7 @@ func (h *Handler) SyntacticallyValidateAtx(ctx context.Context, atx *types.Activ
 	}
 
 	if err := h.nipostValidator.PositioningAtx(&atx.PositioningATX, h.cdb, h.goldenATXID, atx.PublishEpoch, h.layersPerEpoch); err != nil {
-		return nil, err
+		return nil, validatorError(err)
 	}
 
 	var baseTickHeight uint64
@@ -210,7 +247,7 @@ func (h *Handler) SyntacticallyValidateAtx(ctx context.Context, atx *types.Activ
 
 	leaves, err := h.nipostValidator.NIPost(atx.SmesherID, *commitmentATX, atx.NIPost, expectedChallengeHash, atx.NumUnits)
 	if err != nil {
-		return nil, fmt.Errorf("invalid nipost: %w", err)
+		return nil, fmt.Errorf("invalid nipost: %w", validatorError(err))
 	}
 
 	return atx.Verify(baseTickHeight, leaves/h.tickSize)
@@ -218,15 +255,15 @@ func (h *Handler) SyntacticallyValidateAtx(ctx context.Context, atx *types.Activ
 
 func (h *Handler) validateInitialAtx(_ context.Context, atx *types.ActivationTx) error {
 	if atx.InitialPost == nil {
-		return fmt.Errorf("no prevATX declared, but initial Post is not included")
+		return invalidAtx("no prevATX declared, but initial Post is not included")
 	}
 
 	if atx.InnerActivationTx.NodeID == nil {
-		return fmt.Errorf("no prevATX declared, but NodeID is missing")
+		return invalidAtx("no prevATX declared, but NodeID is missing")
 	}
 
 	if err := h.nipostValidator.InitialNIPostChallenge(&atx.NIPostChallenge, h.cdb, h.goldenATXID, atx.InitialPost.Indices); err != nil {
-		return err
+		return validatorError(err)
 	}
 
 	// Use the NIPost's Post metadata, while overriding the challenge to a zero challenge,
@@ -235,15 +272,15 @@ func (h *Handler) validateInitialAtx(_ context.Context, atx *types.ActivationTx)
 	initialPostMetadata.Challenge = shared.ZeroChallenge
 
 	if err := h.nipostValidator.Post(atx.SmesherID, *atx.CommitmentATX, atx.InitialPost, &initialPostMetadata, atx.NumUnits); err != nil {
-		return fmt.Errorf("invalid initial Post: %w", err)
+		return invalidAtx("invalid initial Post: %v", err)
 	}
 
 	if atx.VRFNonce == nil {
-		return fmt.Errorf("no prevATX declared, but VRFNonce is missing")
+		return invalidAtx("no prevATX declared, but VRFNonce is missing")
 	}
 
 	if err := h.nipostValidator.VRFNonce(atx.SmesherID, *atx.CommitmentATX, atx.VRFNonce, &initialPostMetadata, atx.NumUnits); err != nil {
-		return fmt.Errorf("invalid VRFNonce: %w", err)
+		return invalidAtx("invalid VRFNonce: %v", err)
 	}
 
 	atx.SetEffectiveNumUnits(atx.NumUnits)
@@ -252,11 +289,11 @@ func (h *Handler) validateInitialAtx(_ context.Context, atx *types.ActivationTx)
 
 func (h *Handler) validateNonInitialAtx(ctx context.Context, atx *types.ActivationTx, commitmentATX types.ATXID) error {
 	if atx.InnerActivationTx.NodeID != nil {
-		return fmt.Errorf("prevATX declared, but NodeID is included")
+		return invalidAtx("prevATX declared, but NodeID is included")
 	}
 
 	if err := h.nipostValidator.NIPostChallenge(&atx.NIPostChallenge, h.cdb, atx.SmesherID); err != nil {
-		return err
+		return validatorError(err)
 	}
 
 	prevAtx, err := h.cdb.GetAtxHeader(atx.PrevATXID)
@@ -281,12 +318,12 @@ func (h *Handler) validateNonInitialAtx(ctx context.Context, atx *types.Activati
 	if nonce != nil {
 		err = h.nipostValidator.VRFNonce(atx.SmesherID, commitmentATX, nonce, atx.NIPost.PostMetadata, atx.NumUnits)
 		if err != nil {
-			return fmt.Errorf("invalid VRFNonce: %w", err)
+			return invalidAtx("invalid VRFNonce: %v", err)
 		}
 	}
 
 	if atx.InitialPost != nil {
-		return fmt.Errorf("prevATX declared, but initial Post is included")
+		return invalidAtx("prevATX declared, but initial Post is included")
 	}
 
 	if prevAtx.NumUnits < atx.NumUnits {
@@ -470,6 +507,21 @@ func (h *Handler) HandleAtxData(ctx context.Context, peer p2p.Peer, data []byte)
 	return err
 }
 
+// denyPeer denies connections with the peer that sent an invalid atx, if Handler was configured with a peerDenier.
+func (h *Handler) denyPeer(ctx context.Context, peer p2p.Peer, id types.ATXID) {
+	if h.denier == nil || p2p.IsNoPeer(peer) {
+		return
+	}
+	h.log.WithContext(ctx).With().Warning("denying peer that sent invalid atx",
+		log.Stringer("peer", peer),
+		id,
+		log.Duration("duration", h.denyDuration),
+	)
+	if err := h.denier.DenyPeer(peer, h.denyDuration); err != nil {
+		h.log.WithContext(ctx).With().Error("failed to deny peer", log.Stringer("peer", peer), log.Err(err))
+	}
+}
+
 func (h *Handler) registerHashes(atx *types.ActivationTx, peer p2p.Peer) {
 	hashes := map[types.Hash32]struct{}{}
 	for _, id := range []types.ATXID{atx.PositioningATX, atx.PrevATXID} {
@@ -525,13 +577,17 @@ func (h *Handler) handleAtxData(ctx context.Context, peer p2p.Peer, data []byte)
 	}
 
 	vAtx, err := h.SyntacticallyValidateAtx(ctx, &atx)
-	if err != nil {
-		return fmt.Errorf("received syntactically invalid atx %v: %v", atx.ShortString(), err)
+	switch {
+	case errors.Is(err, errInvalidAtx):
+		h.denyPeer(ctx, peer, atx.ID())
+		return fmt.Errorf("syntactically invalid atx %v: %w", atx.ShortString(), err)
+	case err != nil:
+		// the peer isn't blamed for failures of the node, e.g. of the database
+		return fmt.Errorf("validate atx %v: %w", atx.ShortString(), err)
 	}
 	err = h.ProcessAtx(ctx, vAtx)
 	if err != nil {
 		return fmt.Errorf("cannot process atx %v: %v", atx.ShortString(), err)
-		// TODO(anton): blacklist peer
 	}
 	header, err := h.cdb.GetAtxHeader(vAtx.ID())
 	if err != nil {
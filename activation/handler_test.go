@@ -282,7 +282,8 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 		validator.EXPECT().NIPostChallenge(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("nipost error")).Times(1)
 
 		_, err := atxHdlr.SyntacticallyValidateAtx(context.Background(), atx)
-		require.EqualError(t, err, "nipost error")
+		require.ErrorIs(t, err, errInvalidAtx)
+		require.ErrorContains(t, err, "nipost error")
 	})
 
 	t.Run("failing positioning atx validation", func(t *testing.T) {
@@ -294,7 +295,8 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 		validator.EXPECT().PositioningAtx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("bad positioning atx")).Times(1)
 
 		_, err := atxHdlr.SyntacticallyValidateAtx(context.Background(), atx)
-		require.EqualError(t, err, "bad positioning atx")
+		require.ErrorIs(t, err, errInvalidAtx)
+		require.ErrorContains(t, err, "bad positioning atx")
 	})
 
 	t.Run("bad initial nipost challenge", func(t *testing.T) {
@@ -312,7 +314,8 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 		validator.EXPECT().InitialNIPostChallenge(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("bad initial nipost")).Times(1)
 
 		_, err := atxHdlr.SyntacticallyValidateAtx(context.Background(), atx)
-		require.EqualError(t, err, "bad initial nipost")
+		require.ErrorIs(t, err, errInvalidAtx)
+		require.ErrorContains(t, err, "bad initial nipost")
 	})
 
 	t.Run("missing NodeID in initial atx", func(t *testing.T) {
@@ -372,7 +375,8 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 		atx.CommitmentATX = &goldenATXID
 
 		_, err := atxHdlr.SyntacticallyValidateAtx(context.Background(), atx)
-		require.EqualError(t, err, "no prevATX declared, but initial Post is not included")
+		require.ErrorIs(t, err, errInvalidAtx)
+		require.ErrorContains(t, err, "no prevATX declared, but initial Post is not included")
 	})
 
 	t.Run("prevAtx not declared but validation of initial post fails", func(t *testing.T) {
@@ -402,7 +406,8 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 		validator.EXPECT().NIPostChallenge(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 
 		_, err := atxHdlr.SyntacticallyValidateAtx(context.Background(), atx)
-		require.EqualError(t, err, "prevATX declared, but initial Post is included")
+		require.ErrorIs(t, err, errInvalidAtx)
+		require.ErrorContains(t, err, "prevATX declared, but initial Post is included")
 	})
 
 	t.Run("prevAtx declared but NodeID is included", func(t *testing.T) {
@@ -414,7 +419,8 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 		require.NoError(t, SignAndFinalizeAtx(sig, atx))
 
 		_, err = atxHdlr.SyntacticallyValidateAtx(context.Background(), atx)
-		require.EqualError(t, err, "prevATX declared, but NodeID is included")
+		require.ErrorIs(t, err, errInvalidAtx)
+		require.ErrorContains(t, err, "prevATX declared, but NodeID is included")
 	})
 }
 
@@ -1132,3 +1138,79 @@ func TestHandler_AtxWeight(t *testing.T) {
 	require.Equal(t, stored1.TickHeight()+leaves/tickSize, stored2.TickHeight())
 	require.Equal(t, int(leaves/tickSize)*units, int(stored2.GetWeight()))
 }
+
+func TestHandler_DenyPeerForInvalidAtx(t *testing.T) {
+	lg := logtest.New(t)
+	cdb := datastore.NewCachedDB(sql.InMemory(), lg)
+	ctrl := gomock.NewController(t)
+	mockFetch := mocks.NewMockFetcher(ctrl)
+	validator := NewMocknipostValidator(ctrl)
+	mclock := NewMocklayerClock(ctrl)
+	mclock.EXPECT().LayerToTime(gomock.Any()).Return(time.Now()).AnyTimes()
+	mpub := pubsubmocks.NewMockPublisher(ctrl)
+	denier := NewMockpeerDenier(ctrl)
+	goldenATXID := types.ATXID{2, 3, 4}
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	verifier, err := signing.NewEdVerifier()
+	require.NoError(t, err)
+
+	atxHdlr := NewHandler(cdb, verifier, mclock, mpub, mockFetch, layersPerEpochBig, testTickSize, goldenATXID, validator, nil, lg, PoetConfig{},
+		WithPeerDenier(denier, time.Hour))
+
+	// initial atx without initial post
+	challenge := newChallenge(0, types.EmptyATXID, goldenATXID, types.LayerID(12).GetEpoch(), &goldenATXID)
+	atx := newAtx(t, sig, challenge, newNIPostWithChallenge(challenge.Hash(), []byte("poet")), 2, types.Address{1})
+	require.NoError(t, SignAndFinalizeAtx(sig, atx))
+	buf, err := codec.Encode(atx)
+	require.NoError(t, err)
+
+	peer := p2p.Peer("buddy")
+	mockFetch.EXPECT().RegisterPeerHashes(gomock.Any(), gomock.Any()).Times(2)
+	mockFetch.EXPECT().GetPoetProof(gomock.Any(), atx.GetPoetProofRef()).Return(nil).Times(2)
+	denier.EXPECT().DenyPeer(peer, time.Hour)
+	err = atxHdlr.HandleAtxData(context.Background(), peer, buf)
+	require.ErrorIs(t, err, pubsub.ErrValidationReject)
+	require.ErrorContains(t, err, "initial Post is not included")
+
+	// local atxs don't deny anyone
+	require.ErrorContains(t, atxHdlr.HandleAtxData(context.Background(), p2p.NoPeer, buf), "initial Post is not included")
+}
+
+func TestHandler_DontDenyPeerForFailedLookup(t *testing.T) {
+	lg := logtest.New(t)
+	cdb := datastore.NewCachedDB(sql.InMemory(), lg)
+	ctrl := gomock.NewController(t)
+	mockFetch := mocks.NewMockFetcher(ctrl)
+	validator := NewMocknipostValidator(ctrl)
+	mclock := NewMocklayerClock(ctrl)
+	mclock.EXPECT().LayerToTime(gomock.Any()).Return(time.Now()).AnyTimes()
+	mpub := pubsubmocks.NewMockPublisher(ctrl)
+	denier := NewMockpeerDenier(ctrl)
+	goldenATXID := types.ATXID{2, 3, 4}
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	verifier, err := signing.NewEdVerifier()
+	require.NoError(t, err)
+
+	atxHdlr := NewHandler(cdb, verifier, mclock, mpub, mockFetch, layersPerEpochBig, testTickSize, goldenATXID, validator, nil, lg, PoetConfig{},
+		WithPeerDenier(denier, time.Hour))
+
+	challenge := newChallenge(0, types.EmptyATXID, goldenATXID, types.LayerID(12).GetEpoch(), &goldenATXID)
+	atx := newAtx(t, sig, challenge, newNIPostWithChallenge(challenge.Hash(), []byte("poet")), 2, types.Address{1})
+	atx.InitialPost = &types.Post{}
+	atx.InnerActivationTx.NodeID = new(types.NodeID)
+	*atx.InnerActivationTx.NodeID = sig.NodeID()
+	require.NoError(t, SignAndFinalizeAtx(sig, atx))
+	buf, err := codec.Encode(atx)
+	require.NoError(t, err)
+
+	peer := p2p.Peer("buddy")
+	mockFetch.EXPECT().RegisterPeerHashes(gomock.Any(), gomock.Any())
+	mockFetch.EXPECT().GetPoetProof(gomock.Any(), atx.GetPoetProofRef()).Return(nil)
+	validator.EXPECT().InitialNIPostChallenge(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&ErrAtxNotFound{Id: goldenATXID, source: sql.ErrNotFound})
+	err = atxHdlr.HandleAtxData(context.Background(), peer, buf)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	require.NotErrorIs(t, err, pubsub.ErrValidationReject)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spacemeshos/post/verifying"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/p2p"
 )
 
 //go:generate mockgen -package=activation -destination=./mocks.go -source=./interface.go
@@ -15,6 +16,11 @@ type AtxReceiver interface {
 	OnAtx(*types.ActivationTxHeader)
 }
 
+// peerDenier denies connections with misbehaving peers.
+type peerDenier interface {
+	DenyPeer(p2p.Peer, time.Duration) error
+}
+
 type nipostValidator interface {
 	InitialNIPostChallenge(challenge *types.NIPostChallenge, atxs atxProvider, goldenATXID types.ATXID, expectedPostIndices []byte) error
 	NIPostChallenge(challenge *types.NIPostChallenge, atxs atxProvider, nodeID types.NodeID) error
@@ -38,6 +44,7 @@ type nipostBuilder interface {
 	UpdatePoETProvers([]PoetProvingServiceClient)
 	BuildNIPost(ctx context.Context, challenge *types.NIPostChallenge) (*types.NIPost, time.Duration, error)
 	DataDir() string
+	PoetOutcomes() []PoetOutcome
 }
 
 type atxHandler interface {
@@ -78,4 +85,5 @@ type SmeshingProvider interface {
 	Coinbase() types.Address
 	SetCoinbase(coinbase types.Address)
 	UpdatePoETServers(ctx context.Context, endpoints []string) error
+	PoetOutcomes() []PoetOutcome
 }
@@ -11,6 +11,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	types "github.com/spacemeshos/go-spacemesh/common/types"
+	p2p "github.com/spacemeshos/go-spacemesh/p2p"
 	verifying "github.com/spacemeshos/post/verifying"
 )
 
@@ -49,6 +50,43 @@ func (mr *MockAtxReceiverMockRecorder) OnAtx(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnAtx", reflect.TypeOf((*MockAtxReceiver)(nil).OnAtx), arg0)
 }
 
+// MockpeerDenier is a mock of peerDenier interface.
+type MockpeerDenier struct {
+	ctrl     *gomock.Controller
+	recorder *MockpeerDenierMockRecorder
+}
+
+// MockpeerDenierMockRecorder is the mock recorder for MockpeerDenier.
+type MockpeerDenierMockRecorder struct {
+	mock *MockpeerDenier
+}
+
+// NewMockpeerDenier creates a new mock instance.
+func NewMockpeerDenier(ctrl *gomock.Controller) *MockpeerDenier {
+	mock := &MockpeerDenier{ctrl: ctrl}
+	mock.recorder = &MockpeerDenierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpeerDenier) EXPECT() *MockpeerDenierMockRecorder {
+	return m.recorder
+}
+
+// DenyPeer mocks base method.
+func (m *MockpeerDenier) DenyPeer(arg0 p2p.Peer, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyPeer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DenyPeer indicates an expected call of DenyPeer.
+func (mr *MockpeerDenierMockRecorder) DenyPeer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyPeer", reflect.TypeOf((*MockpeerDenier)(nil).DenyPeer), arg0, arg1)
+}
+
 // MocknipostValidator is a mock of nipostValidator interface.
 type MocknipostValidator struct {
 	ctrl     *gomock.Controller
@@ -313,6 +351,20 @@ func (mr *MocknipostBuilderMockRecorder) DataDir() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataDir", reflect.TypeOf((*MocknipostBuilder)(nil).DataDir))
 }
 
+// PoetOutcomes mocks base method.
+func (m *MocknipostBuilder) PoetOutcomes() []PoetOutcome {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PoetOutcomes")
+	ret0, _ := ret[0].([]PoetOutcome)
+	return ret0
+}
+
+// PoetOutcomes indicates an expected call of PoetOutcomes.
+func (mr *MocknipostBuilderMockRecorder) PoetOutcomes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PoetOutcomes", reflect.TypeOf((*MocknipostBuilder)(nil).PoetOutcomes))
+}
+
 // UpdatePoETProvers mocks base method.
 func (m *MocknipostBuilder) UpdatePoETProvers(arg0 []PoetProvingServiceClient) {
 	m.ctrl.T.Helper()
@@ -684,6 +736,20 @@ func (mr *MockSmeshingProviderMockRecorder) Coinbase() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Coinbase", reflect.TypeOf((*MockSmeshingProvider)(nil).Coinbase))
 }
 
+// PoetOutcomes mocks base method.
+func (m *MockSmeshingProvider) PoetOutcomes() []PoetOutcome {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PoetOutcomes")
+	ret0, _ := ret[0].([]PoetOutcome)
+	return ret0
+}
+
+// PoetOutcomes indicates an expected call of PoetOutcomes.
+func (mr *MockSmeshingProviderMockRecorder) PoetOutcomes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PoetOutcomes", reflect.TypeOf((*MockSmeshingProvider)(nil).PoetOutcomes))
+}
+
 // SetCoinbase mocks base method.
 func (m *MockSmeshingProvider) SetCoinbase(coinbase types.Address) {
 	m.ctrl.T.Helper()
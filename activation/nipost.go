@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/spacemeshos/poet/shared"
@@ -53,6 +54,37 @@ func (nb *NIPostBuilder) persist() {
 	}
 }
 
+// PoetOutcomeStatus is the state of the challenge submitted to a PoET service.
+type PoetOutcomeStatus string
+
+const (
+	// PoetSubmitted is set when the challenge was registered in the PoET round.
+	PoetSubmitted PoetOutcomeStatus = "submitted"
+	// PoetSubmitFailed is set when the challenge couldn't be registered.
+	PoetSubmitFailed PoetOutcomeStatus = "submit failed"
+	// PoetProofFailed is set when the proof couldn't be fetched from the PoET service.
+	PoetProofFailed PoetOutcomeStatus = "proof failed"
+	// PoetProofInvalid is set when the proof failed validation.
+	PoetProofInvalid PoetOutcomeStatus = "proof invalid"
+	// PoetNotMember is set when the proof doesn't include the challenge.
+	PoetNotMember PoetOutcomeStatus = "not member"
+	// PoetProofReceived is set when a valid proof was received but a proof with more ticks was selected.
+	PoetProofReceived PoetOutcomeStatus = "received"
+	// PoetProofSelected is set for the proof that was selected for the NIPost.
+	PoetProofSelected PoetOutcomeStatus = "selected"
+)
+
+// PoetOutcome is the outcome of the latest NIPost challenge for a single PoET service.
+type PoetOutcome struct {
+	// ServiceID is empty if the PoET service ID couldn't be queried.
+	ServiceID types.PoetServiceID
+	Round     string
+	Status    PoetOutcomeStatus
+	// LeafCount is the number of ticks in the proof.
+	LeafCount uint64
+	Err       error
+}
+
 // NIPostBuilder holds the required state and dependencies to create Non-Interactive Proofs of Space-Time (NIPost).
 type NIPostBuilder struct {
 	nodeID            types.NodeID
@@ -65,6 +97,9 @@ type NIPostBuilder struct {
 	signer            *signing.EdSigner
 	layerClock        layerClock
 	poetCfg           PoetConfig
+
+	outcomesMu sync.Mutex
+	outcomes   []PoetOutcome
 }
 
 type poetDbAPI interface {
@@ -102,6 +137,36 @@ func (nb *NIPostBuilder) DataDir() string {
 	return nb.dataDir
 }
 
+// PoetOutcomes returns outcomes of the latest NIPost challenge, one per PoET service.
+func (nb *NIPostBuilder) PoetOutcomes() []PoetOutcome {
+	nb.outcomesMu.Lock()
+	defer nb.outcomesMu.Unlock()
+	outcomes := make([]PoetOutcome, len(nb.outcomes))
+	copy(outcomes, nb.outcomes)
+	return outcomes
+}
+
+func (nb *NIPostBuilder) resetOutcomes() {
+	nb.outcomesMu.Lock()
+	defer nb.outcomesMu.Unlock()
+	nb.outcomes = nil
+}
+
+// setOutcome updates the outcome for the service and round, or adds a new one.
+func (nb *NIPostBuilder) setOutcome(outcome PoetOutcome) {
+	nb.outcomesMu.Lock()
+	defer nb.outcomesMu.Unlock()
+	for i := range nb.outcomes {
+		if outcome.ServiceID.ServiceID != nil &&
+			bytes.Equal(nb.outcomes[i].ServiceID.ServiceID, outcome.ServiceID.ServiceID) &&
+			nb.outcomes[i].Round == outcome.Round {
+			nb.outcomes[i] = outcome
+			return
+		}
+	}
+	nb.outcomes = append(nb.outcomes, outcome)
+}
+
 // UpdatePoETProvers updates poetProver reference. It should not be executed concurrently with BuildNIPoST.
 func (nb *NIPostBuilder) UpdatePoETProvers(poetProvers []PoetProvingServiceClient) {
 	// reset the state for safety to avoid accidental erroneous wait in Phase 1.
@@ -165,7 +230,10 @@ func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.NIPos
 			return nil, 0, fmt.Errorf("%w: poet round has already started at %s (now: %s)", ErrATXChallengeExpired, poetRoundStart, now)
 		}
 
-		signature := nb.signer.Sign(signing.POET, challengeHash.Bytes())
+		signature, err := nb.signer.TrySign(signing.POET, challengeHash.Bytes())
+		if err != nil {
+			return nil, 0, fmt.Errorf("sign poet challenge: %w", err)
+		}
 		prefix := bytes.Join([][]byte{nb.signer.Prefix(), {byte(signing.POET)}}, nil)
 		submitCtx, cancel := context.WithDeadline(ctx, poetRoundStart)
 		defer cancel()
@@ -271,15 +339,24 @@ func (nb *NIPostBuilder) submitPoetChallenge(ctx context.Context, poet PoetProvi
 
 // Submit the challenge to all registered PoETs.
 func (nb *NIPostBuilder) submitPoetChallenges(ctx context.Context, prefix, challenge []byte, signature types.EdSignature, nodeID types.NodeID) []types.PoetRequest {
+	nb.resetOutcomes()
 	g, ctx := errgroup.WithContext(ctx)
 	poetRequestsChannel := make(chan types.PoetRequest, len(nb.poetProvers))
 	for _, poetProver := range nb.poetProvers {
 		poet := poetProver
 		g.Go(func() error {
 			if poetRequest, err := nb.submitPoetChallenge(ctx, poet, prefix, challenge, signature, nodeID); err == nil {
+				nb.setOutcome(PoetOutcome{
+					ServiceID: poetRequest.PoetServiceID,
+					Round:     poetRequest.PoetRound.ID,
+					Status:    PoetSubmitted,
+				})
 				poetRequestsChannel <- *poetRequest
 			} else {
 				nb.log.With().Warning("failed to submit challenge to PoET", log.Err(err))
+				// service ID is cached by the client if it was queried successfully
+				sid, _ := poet.PoetServiceID(ctx)
+				nb.setOutcome(PoetOutcome{ServiceID: sid, Status: PoetSubmitFailed, Err: err})
 			}
 			return nil
 		})
@@ -313,17 +390,24 @@ func membersContain(members []types.Member, challenge *types.Hash32) bool {
 }
 
 func (nb *NIPostBuilder) getBestProof(ctx context.Context, challenge *types.Hash32) (types.PoetProofRef, error) {
-	proofs := make(chan *types.PoetProofMessage, len(nb.state.PoetRequests))
+	type received struct {
+		proof   *types.PoetProofMessage
+		outcome PoetOutcome
+	}
+	proofs := make(chan received, len(nb.state.PoetRequests))
 
 	var eg errgroup.Group
 	for _, r := range nb.state.PoetRequests {
 		logger := nb.log.WithContext(ctx).WithFields(log.String("poet_id", hex.EncodeToString(r.PoetServiceID.ServiceID)), log.String("round", r.PoetRound.ID))
 		client := nb.getPoetClient(ctx, r.PoetServiceID)
+		round := r.PoetRound.ID
+		outcome := PoetOutcome{ServiceID: r.PoetServiceID, Round: round}
 		if client == nil {
 			logger.Warning("poet client not found")
+			outcome.Status, outcome.Err = PoetProofFailed, errors.New("poet client not found")
+			nb.setOutcome(outcome)
 			continue
 		}
-		round := r.PoetRound.ID
 		// Time to wait before querying for the proof
 		// The additional second is an optimization to be nicer to poet
 		// and don't accidentally ask it to soon and have to retry.
@@ -342,21 +426,30 @@ func (nb *NIPostBuilder) getBestProof(ctx context.Context, challenge *types.Hash
 				return fmt.Errorf("querying proof: %w", ctx.Err())
 			case err != nil:
 				logger.With().Warning("failed to get proof from poet", log.Err(err))
+				outcome.Status, outcome.Err = PoetProofFailed, err
+				nb.setOutcome(outcome)
 				return nil
 			}
+			outcome.LeafCount = proof.LeafCount
 
 			if err := nb.poetDB.ValidateAndStore(ctx, proof); err != nil && !errors.Is(err, ErrObjectExists) {
 				logger.With().Warning("failed to validate and store proof", log.Err(err), log.Object("proof", proof))
+				outcome.Status, outcome.Err = PoetProofInvalid, err
+				nb.setOutcome(outcome)
 				return nil
 			}
 
 			// We are interested only in proofs that we are members of
 			if !membersContain(proof.Members, challenge) {
 				logger.With().Warning("poet proof membership doesn't contain the challenge", challenge)
+				outcome.Status = PoetNotMember
+				nb.setOutcome(outcome)
 				return nil
 			}
+			outcome.Status = PoetProofReceived
+			nb.setOutcome(outcome)
 
-			proofs <- proof
+			proofs <- received{proof: proof, outcome: outcome}
 			return nil
 		})
 	}
@@ -365,12 +458,15 @@ func (nb *NIPostBuilder) getBestProof(ctx context.Context, challenge *types.Hash
 	}
 	close(proofs)
 
-	var bestProof *types.PoetProofMessage
+	var (
+		bestProof   *types.PoetProofMessage
+		bestOutcome PoetOutcome
+	)
 
-	for proof := range proofs {
-		nb.log.With().Info("got poet proof", log.Uint64("leaf count", proof.LeafCount))
-		if bestProof == nil || bestProof.LeafCount < proof.LeafCount {
-			bestProof = proof
+	for r := range proofs {
+		nb.log.With().Info("got poet proof", log.Uint64("leaf count", r.proof.LeafCount))
+		if bestProof == nil || bestProof.LeafCount < r.proof.LeafCount {
+			bestProof, bestOutcome = r.proof, r.outcome
 		}
 	}
 
@@ -380,6 +476,8 @@ func (nb *NIPostBuilder) getBestProof(ctx context.Context, challenge *types.Hash
 			return types.PoetProofRef{}, err
 		}
 		nb.log.With().Info("selected the best proof", log.Uint64("leafCount", bestProof.LeafCount), log.Binary("ref", ref[:]))
+		bestOutcome.Status = PoetProofSelected
+		nb.setOutcome(bestOutcome)
 		return ref, nil
 	}
 
@@ -504,6 +504,71 @@ func TestNIPostBuilder_ManyPoETs_AllFinished(t *testing.T) {
 	req.EqualValues(ref[:], nipost.PostMetadata.Challenge)
 }
 
+func TestNIPostBuilder_ManyPoETs_Outcomes(t *testing.T) {
+	t.Parallel()
+	challenge := types.NIPostChallenge{
+		PublishEpoch: postGenesisEpoch + 2,
+	}
+	newProof := func(leafs uint64) *types.PoetProofMessage {
+		return &types.PoetProofMessage{
+			PoetProof: types.PoetProof{
+				Members:   []types.Member{types.Member(challenge.Hash())},
+				LeafCount: leafs,
+			},
+		}
+	}
+	proofWorse, proofBetter, proofInvalid := newProof(111), newProof(999), newProof(5000)
+	errInvalidProof := errors.New("invalid proof")
+
+	ctrl := gomock.NewController(t)
+	poetDb := NewMockpoetDbAPI(ctrl)
+	poetDb.EXPECT().ValidateAndStore(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, proof *types.PoetProofMessage) error {
+			if proof == proofInvalid {
+				return errInvalidProof
+			}
+			return nil
+		})
+	mclock := defaultLayerClockMock(t)
+
+	poets := make([]PoetProvingServiceClient, 0, 3)
+	for i, proof := range []*types.PoetProofMessage{proofWorse, proofBetter, proofInvalid} {
+		poet := defaultPoetServiceMock(t, []byte(fmt.Sprintf("poet%d", i)))
+		poet.EXPECT().Proof(gomock.Any(), "").Return(proof, nil)
+		poets = append(poets, poet)
+	}
+
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	postProvider := NewMockpostSetupProvider(ctrl)
+	postProvider.EXPECT().Status().Return(&PostSetupStatus{State: PostSetupStateComplete})
+	postProvider.EXPECT().GenerateProof(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, challenge []byte) (*types.Post, *types.PostMetadata, error) {
+			return &types.Post{}, &types.PostMetadata{
+				Challenge: challenge,
+			}, nil
+		},
+	)
+	nb := NewNIPostBuilder(types.NodeID{1}, postProvider, poets, poetDb, t.TempDir(), logtest.New(t), sig, PoetConfig{}, mclock)
+
+	nipost, _, err := nb.BuildNIPost(context.Background(), &challenge)
+	require.NoError(t, err)
+	ref, _ := proofBetter.Ref()
+	require.EqualValues(t, ref[:], nipost.PostMetadata.Challenge)
+
+	outcomes := map[string]PoetOutcome{}
+	for _, outcome := range nb.PoetOutcomes() {
+		outcomes[string(outcome.ServiceID.ServiceID)] = outcome
+	}
+	require.Len(t, outcomes, 3)
+	require.Equal(t, PoetProofReceived, outcomes["poet0"].Status)
+	require.EqualValues(t, 111, outcomes["poet0"].LeafCount)
+	require.Equal(t, PoetProofSelected, outcomes["poet1"].Status)
+	require.EqualValues(t, 999, outcomes["poet1"].LeafCount)
+	require.Equal(t, PoetProofInvalid, outcomes["poet2"].Status)
+	require.ErrorIs(t, outcomes["poet2"].Err, errInvalidProof)
+}
+
 func TestNIPostBuilder_Close(t *testing.T) {
 	t.Parallel()
 	r := require.New(t)
@@ -1,7 +1,9 @@
 package activation
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -39,6 +41,7 @@ type PoetPoW struct {
 type HTTPPoetClient struct {
 	baseURL       *url.URL
 	poetServiceID types.PoetServiceID
+	serviceKeys   [][]byte
 	client        *retryablehttp.Client
 }
 
@@ -82,10 +85,15 @@ func NewHTTPPoetClient(baseUrl string, cfg PoetConfig, opts ...PoetClientOpts) (
 	if baseURL.Scheme == "" {
 		baseURL.Scheme = "http"
 	}
+	keys, err := cfg.ServiceKeys()
+	if err != nil {
+		return nil, err
+	}
 
 	poetClient := &HTTPPoetClient{
-		baseURL: baseURL,
-		client:  client,
+		baseURL:     baseURL,
+		serviceKeys: keys,
+		client:      client,
 	}
 	for _, opt := range opts {
 		opt(poetClient)
@@ -119,6 +127,12 @@ func (c *HTTPPoetClient) Submit(ctx context.Context, prefix, challenge []byte, s
 			Difficulty: uint32(pow.Params.Difficulty),
 		},
 	}
+	if len(c.serviceKeys) > 0 {
+		// challenge is not revealed to the service that is not trusted
+		if _, err := c.PoetServiceID(ctx); err != nil {
+			return nil, err
+		}
+	}
 	resBody := rpcapi.SubmitResponse{}
 	if err := c.req(ctx, http.MethodPost, "/v1/submit", &request, &resBody); err != nil {
 		return nil, fmt.Errorf("submitting challenge: %w", err)
@@ -141,6 +155,10 @@ func (c *HTTPPoetClient) PoetServiceID(ctx context.Context) (types.PoetServiceID
 	if err := c.req(ctx, http.MethodGet, "/v1/info", nil, &resBody); err != nil {
 		return types.PoetServiceID{}, fmt.Errorf("getting poet ID: %w", err)
 	}
+	// the key is reported by the service itself, it is authenticated only by the signature of the proof
+	if len(c.serviceKeys) > 0 && !containsKey(c.serviceKeys, resBody.ServicePubkey) {
+		return types.PoetServiceID{}, fmt.Errorf("%w: %x", ErrUnknownPoetService, resBody.ServicePubkey)
+	}
 
 	c.poetServiceID.ServiceID = resBody.ServicePubkey
 	return c.poetServiceID, nil
@@ -148,10 +166,22 @@ func (c *HTTPPoetClient) PoetServiceID(ctx context.Context) (types.PoetServiceID
 
 // Proof implements PoetProvingServiceClient.
 func (c *HTTPPoetClient) Proof(ctx context.Context, roundID string) (*types.PoetProofMessage, error) {
-	resBody := rpcapi.ProofResponse{}
-	if err := c.req(ctx, http.MethodGet, fmt.Sprintf("/v1/proofs/%s", roundID), nil, &resBody); err != nil {
+	data, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/v1/proofs/%s", roundID), nil)
+	if err != nil {
 		return nil, fmt.Errorf("getting proof: %w", err)
 	}
+	// the signature is not a part of the released poet api, services that sign proofs
+	// add it to the response
+	resBody := rpcapi.ProofResponse{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, &resBody); err != nil {
+		return nil, fmt.Errorf("decoding response body to proto: %w", err)
+	}
+	var signed struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, fmt.Errorf("decoding proof signature: %w", err)
+	}
 
 	p := resBody.Proof.GetProof()
 
@@ -174,6 +204,19 @@ func (c *HTTPPoetClient) Proof(ctx context.Context, roundID string) (*types.Poet
 		PoetServiceID: resBody.Pubkey,
 		RoundID:       roundID,
 	}
+	if len(signed.Signature) > 0 {
+		if len(signed.Signature) != len(proof.Signature) {
+			return nil, fmt.Errorf("%w: invalid length %d", ErrInvalidPoetSignature, len(signed.Signature))
+		}
+		copy(proof.Signature[:], signed.Signature)
+	}
+	if c.poetServiceID.ServiceID != nil && !bytes.Equal(c.poetServiceID.ServiceID, proof.PoetServiceID) {
+		return nil, fmt.Errorf("%w: proof key %x doesn't match service key %x",
+			ErrUnknownPoetService, proof.PoetServiceID, c.poetServiceID.ServiceID)
+	}
+	if err := verifyPoetService(c.serviceKeys, &proof); err != nil {
+		return nil, err
+	}
 	if c.poetServiceID.ServiceID == nil {
 		c.poetServiceID.ServiceID = proof.PoetServiceID
 	}
@@ -181,27 +224,50 @@ func (c *HTTPPoetClient) Proof(ctx context.Context, roundID string) (*types.Poet
 	return &proof, nil
 }
 
+func containsKey(keys [][]byte, key []byte) bool {
+	for _, k := range keys {
+		if bytes.Equal(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *HTTPPoetClient) req(ctx context.Context, method string, path string, reqBody proto.Message, resBody proto.Message) error {
+	data, err := c.do(ctx, method, path, reqBody)
+	if err != nil {
+		return err
+	}
+	if resBody != nil {
+		if err := protojson.Unmarshal(data, resBody); err != nil {
+			return fmt.Errorf("decoding response body to proto: %w", err)
+		}
+	}
+	return nil
+}
+
+// do sends the request and returns the body of the successful response.
+func (c *HTTPPoetClient) do(ctx context.Context, method string, path string, reqBody proto.Message) ([]byte, error) {
 	jsonReqBody, err := protojson.Marshal(reqBody)
 	if err != nil {
-		return fmt.Errorf("marshaling request body: %w", err)
+		return nil, fmt.Errorf("marshaling request body: %w", err)
 	}
 
 	req, err := retryablehttp.NewRequestWithContext(ctx, method, c.baseURL.JoinPath(path).String(), jsonReqBody)
 	if err != nil {
-		return fmt.Errorf("creating HTTP request: %w", err)
+		return nil, fmt.Errorf("creating HTTP request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	res, err := c.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("doing request: %w", err)
+		return nil, fmt.Errorf("doing request: %w", err)
 	}
 	defer res.Body.Close()
 
 	data, err := io.ReadAll(res.Body)
 	if err != nil {
-		return fmt.Errorf("reading response body (%w)", err)
+		return nil, fmt.Errorf("reading response body (%w)", err)
 	}
 
 	log.GetLogger().WithContext(ctx).With().Debug("response from poet", log.String("status", res.Status), log.String("body", string(data)))
@@ -209,20 +275,14 @@ func (c *HTTPPoetClient) req(ctx context.Context, method string, path string, re
 	switch res.StatusCode {
 	case http.StatusOK:
 	case http.StatusNotFound:
-		return fmt.Errorf("%w: response status code: %s, body: %s", ErrNotFound, res.Status, string(data))
+		return nil, fmt.Errorf("%w: response status code: %s, body: %s", ErrNotFound, res.Status, string(data))
 	case http.StatusServiceUnavailable:
-		return fmt.Errorf("%w: response status code: %s, body: %s", ErrUnavailable, res.Status, string(data))
+		return nil, fmt.Errorf("%w: response status code: %s, body: %s", ErrUnavailable, res.Status, string(data))
 	case http.StatusBadRequest:
-		return fmt.Errorf("%w: response status code: %s, body: %s", ErrInvalidRequest, res.Status, string(data))
+		return nil, fmt.Errorf("%w: response status code: %s, body: %s", ErrInvalidRequest, res.Status, string(data))
 	default:
-		return fmt.Errorf("unrecognized error: status code: %s, body: %s", res.Status, string(data))
+		return nil, fmt.Errorf("unrecognized error: status code: %s, body: %s", res.Status, string(data))
 	}
 
-	if resBody != nil {
-		if err := protojson.Unmarshal(data, resBody); err != nil {
-			return fmt.Errorf("decoding response body to proto: %w", err)
-		}
-	}
-
-	return nil
+	return data, nil
 }
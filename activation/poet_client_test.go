@@ -1,7 +1,11 @@
 package activation
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -107,3 +111,80 @@ func Test_HTTPPoetClient_PoetServiceID(t *testing.T) {
 	_, err = client.PoetServiceID(context.Background())
 	require.NoError(t, err)
 }
+
+func Test_HTTPPoetClient_UntrustedService(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		resp, err := protojson.Marshal(&rpcapi.InfoResponse{ServicePubkey: bytes.Repeat([]byte{1}, ed25519.PublicKeySize)})
+		require.NoError(t, err)
+		w.Write(resp)
+	}))
+	defer ts.Close()
+
+	_, err := NewHTTPPoetClient(ts.URL, PoetConfig{ServicePubkeys: []string{"ab"}}, withCustomHttpClient(ts.Client()))
+	require.Error(t, err)
+
+	trusted := hex.EncodeToString(bytes.Repeat([]byte{2}, ed25519.PublicKeySize))
+	client, err := NewHTTPPoetClient(ts.URL, PoetConfig{ServicePubkeys: []string{trusted}}, withCustomHttpClient(ts.Client()))
+	require.NoError(t, err)
+	_, err = client.PoetServiceID(context.Background())
+	require.ErrorIs(t, err, ErrUnknownPoetService)
+	_, err = client.Submit(context.Background(), nil, nil, types.EmptyEdSignature, types.NodeID{}, PoetPoW{})
+	require.ErrorIs(t, err, ErrUnknownPoetService)
+}
+
+func Test_HTTPPoetClient_ProofUntrustedService(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		resp, err := protojson.Marshal(&rpcapi.ProofResponse{Pubkey: bytes.Repeat([]byte{1}, ed25519.PublicKeySize)})
+		require.NoError(t, err)
+		w.Write(resp)
+	}))
+	defer ts.Close()
+
+	trusted := hex.EncodeToString(bytes.Repeat([]byte{2}, ed25519.PublicKeySize))
+	client, err := NewHTTPPoetClient(ts.URL, PoetConfig{ServicePubkeys: []string{trusted}}, withCustomHttpClient(ts.Client()))
+	require.NoError(t, err)
+	_, err = client.Proof(context.Background(), "1")
+	require.ErrorIs(t, err, ErrUnknownPoetService)
+
+	client, err = NewHTTPPoetClient(ts.URL, PoetConfig{}, withCustomHttpClient(ts.Client()))
+	require.NoError(t, err)
+	_, err = client.Proof(context.Background(), "1")
+	require.NoError(t, err)
+}
+
+func Test_HTTPPoetClient_ProofSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	var signature []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		resp, err := protojson.Marshal(&rpcapi.ProofResponse{Pubkey: pub})
+		require.NoError(t, err)
+		if signature != nil {
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(resp, &body))
+			body["signature"] = signature
+			resp, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		w.Write(resp)
+	}))
+	defer ts.Close()
+
+	trusted := PoetConfig{ServicePubkeys: []string{hex.EncodeToString(pub)}}
+	client, err := NewHTTPPoetClient(ts.URL, trusted, withCustomHttpClient(ts.Client()))
+	require.NoError(t, err)
+	_, err = client.Proof(context.Background(), "1")
+	require.ErrorIs(t, err, ErrInvalidPoetSignature)
+
+	msg := types.PoetProofMessage{PoetServiceID: pub, RoundID: "2"}
+	signature = ed25519.Sign(priv, msg.SignedBytes())
+	_, err = client.Proof(context.Background(), "1")
+	require.ErrorIs(t, err, ErrInvalidPoetSignature)
+
+	proof, err := client.Proof(context.Background(), "2")
+	require.NoError(t, err)
+	require.Equal(t, signature, proof.Signature.Bytes())
+}
@@ -2,6 +2,7 @@ package activation
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
 
 	"github.com/spacemeshos/merkle-tree"
@@ -23,11 +24,27 @@ var ErrObjectExists = sql.ErrObjectExists
 type PoetDb struct {
 	sqlDB *sql.Database
 	log   log.Log
+
+	serviceKeys [][]byte
+}
+
+// PoetDbOption is an option for PoetDb.
+type PoetDbOption func(*PoetDb)
+
+// WithPoetServiceKeys configures PoetDb to accept only proofs signed by the poet services with the given keys.
+func WithPoetServiceKeys(keys [][]byte) PoetDbOption {
+	return func(db *PoetDb) {
+		db.serviceKeys = keys
+	}
 }
 
 // NewPoetDb returns a new PoET handler.
-func NewPoetDb(db *sql.Database, log log.Log) *PoetDb {
-	return &PoetDb{sqlDB: db, log: log}
+func NewPoetDb(db *sql.Database, log log.Log, opts ...PoetDbOption) *PoetDb {
+	poetDb := &PoetDb{sqlDB: db, log: log}
+	for _, opt := range opts {
+		opt(poetDb)
+	}
+	return poetDb
 }
 
 // HasProof returns true if the database contains a proof with the given reference, or false otherwise.
@@ -69,6 +86,10 @@ func (db *PoetDb) Validate(proof types.PoetProof, poetID []byte, roundID string,
 	if len(poetID) < shortIDlth {
 		return types.ProcessingError{Err: fmt.Sprintf("invalid poet id %x", poetID)}
 	}
+	msg := &types.PoetProofMessage{PoetProof: proof, PoetServiceID: poetID, RoundID: roundID, Signature: signature}
+	if err := verifyPoetService(db.serviceKeys, msg); err != nil {
+		return fmt.Errorf("poetID %x round %s: %w", poetID[:shortIDlth], roundID, err)
+	}
 	root, err := calcRoot(proof.Members)
 	// we shouldn't care about poet proof with empty membership as it's not relevant.
 	if len(proof.Members) == 0 {
@@ -82,11 +103,31 @@ func (db *PoetDb) Validate(proof types.PoetProof, poetID []byte, roundID string,
 	if err := validatePoet(root, proof.MerkleProof, proof.LeafCount); err != nil {
 		return fmt.Errorf("failed to validate poet proof for poetID %x round %s: %w", poetID[:shortIDlth], roundID, err)
 	}
-	// TODO(noamnelke): validate signature (or extract public key and use for salting merkle hashes)
 
 	return nil
 }
 
+// verifyPoetService checks that the proof is signed by the poet service. If trusted keys are configured
+// the proof must be signed by one of them, otherwise the signature is verified only if the proof is signed.
+func verifyPoetService(keys [][]byte, proof *types.PoetProofMessage) error {
+	if len(keys) > 0 && !containsKey(keys, proof.PoetServiceID) {
+		return fmt.Errorf("%w: %x", ErrUnknownPoetService, proof.PoetServiceID)
+	}
+	if proof.Signature == types.EmptyEdSignature {
+		if len(keys) > 0 {
+			return fmt.Errorf("%w: proof is not signed", ErrInvalidPoetSignature)
+		}
+		return nil
+	}
+	if len(proof.PoetServiceID) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: poet id is not an ed25519 key", ErrInvalidPoetSignature)
+	}
+	if !ed25519.Verify(proof.PoetServiceID, proof.SignedBytes(), proof.Signature.Bytes()) {
+		return ErrInvalidPoetSignature
+	}
+	return nil
+}
+
 // StoreProof saves the poet proof in local db.
 func (db *PoetDb) StoreProof(ctx context.Context, ref types.PoetProofRef, proofMessage *types.PoetProofMessage) error {
 	messageBytes, err := codec.Encode(proofMessage)
@@ -2,6 +2,7 @@ package activation
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"sync"
@@ -113,6 +114,51 @@ func TestPoetDbInvalidPoetProof(t *testing.T) {
 	r.False(errors.As(err, &pErr))
 }
 
+func TestPoetDbSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	signed := func(t *testing.T) types.PoetProofMessage {
+		msg := getPoetProof(t)
+		msg.PoetServiceID = pub
+		copy(msg.Signature[:], ed25519.Sign(priv, msg.SignedBytes()))
+		return msg
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		msg := signed(t)
+		poetDb := NewPoetDb(sql.InMemory(), logtest.New(t), WithPoetServiceKeys([][]byte{pub}))
+		require.NoError(t, poetDb.ValidateAndStore(context.Background(), &msg))
+		ref, err := poetDb.GetProofRef(pub, msg.RoundID)
+		require.NoError(t, err)
+		expected, err := msg.Ref()
+		require.NoError(t, err)
+		require.Equal(t, expected, ref)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		msg := signed(t)
+		msg.RoundID = "1338"
+		poetDb := NewPoetDb(sql.InMemory(), logtest.New(t))
+		require.ErrorIs(t, poetDb.ValidateAndStore(context.Background(), &msg), ErrInvalidPoetSignature)
+	})
+	t.Run("unknown service", func(t *testing.T) {
+		other, _, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		msg := signed(t)
+		poetDb := NewPoetDb(sql.InMemory(), logtest.New(t), WithPoetServiceKeys([][]byte{other}))
+		require.ErrorIs(t, poetDb.ValidateAndStore(context.Background(), &msg), ErrUnknownPoetService)
+	})
+	t.Run("not signed", func(t *testing.T) {
+		msg := signed(t)
+		msg.Signature = types.EmptyEdSignature
+		poetDb := NewPoetDb(sql.InMemory(), logtest.New(t), WithPoetServiceKeys([][]byte{pub}))
+		require.ErrorIs(t, poetDb.ValidateAndStore(context.Background(), &msg), ErrInvalidPoetSignature)
+
+		// any service is trusted if no keys are configured
+		poetDb = NewPoetDb(sql.InMemory(), logtest.New(t))
+		require.NoError(t, poetDb.ValidateAndStore(context.Background(), &msg))
+	})
+}
+
 func TestPoetDbNonExistingKeys(t *testing.T) {
 	r := require.New(t)
 	msg := getPoetProof(t)
@@ -14,6 +14,9 @@ import (
 	"github.com/spacemeshos/go-spacemesh/common/types"
 )
 
+// errPoetProofNotAvailable is returned if the poet proof referenced by the nipost can't be read.
+var errPoetProofNotAvailable = errors.New("poet proof is not available")
+
 type ErrAtxNotFound struct {
 	Id types.ATXID
 	// the source (if any) that caused the error
@@ -66,7 +69,7 @@ func (v *Validator) NIPost(nodeId types.NodeID, commitmentAtxId types.ATXID, nip
 	copy(ref[:], nipost.PostMetadata.Challenge)
 	proof, err := v.poetDb.GetProof(ref)
 	if err != nil {
-		return 0, fmt.Errorf("poet proof is not available %x: %w", nipost.PostMetadata.Challenge, err)
+		return 0, fmt.Errorf("%w %x: %v", errPoetProofNotAvailable, nipost.PostMetadata.Challenge, err)
 	}
 
 	if !contains(proof, nipost.Challenge.Bytes()) {
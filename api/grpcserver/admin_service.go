@@ -6,35 +6,77 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
 const chunksize = 1024
 
 type CheckpointRunnerFunc func() CheckpointRunner
 
+// RecoverFunc prepares the node to recover from the checkpoint at the uri.
+type RecoverFunc func(ctx context.Context, uri string) error
+
 // AdminService exposes endpoints for node administration.
 type AdminService struct {
 	checkpoint CheckpointRunnerFunc
+	recover    RecoverFunc
+	access     accessList
+	backup     databaseBackup
+	migrations databaseMigrations
+}
+
+// AdminOpt is an option for AdminService.
+type AdminOpt func(*AdminService)
+
+// WithAccessList enables updating the p2p access list at runtime.
+func WithAccessList(access accessList) AdminOpt {
+	return func(a *AdminService) {
+		a.access = access
+	}
+}
+
+// WithDatabaseBackup enables online backups of the node database.
+func WithDatabaseBackup(db databaseBackup) AdminOpt {
+	return func(a *AdminService) {
+		a.backup = db
+	}
+}
+
+// WithDatabaseMigrations enables inspecting and rolling back migrations of the node database.
+func WithDatabaseMigrations(migrations databaseMigrations) AdminOpt {
+	return func(a *AdminService) {
+		a.migrations = migrations
+	}
 }
 
 // NewAdminService creates a new admin grpc service.
-func NewAdminService(cp CheckpointRunnerFunc) *AdminService {
-	return &AdminService{
+func NewAdminService(cp CheckpointRunnerFunc, recover RecoverFunc, opts ...AdminOpt) *AdminService {
+	a := &AdminService{
 		checkpoint: cp,
+		recover:    recover,
+	}
+	for _, opt := range opts {
+		opt(a)
 	}
+	return a
 }
 
 // RegisterService registers this service with a grpc server instance.
 func (a AdminService) RegisterService(server *Server) {
 	pb.RegisterAdminServiceServer(server.GrpcServer, a)
+	extpb.RegisterAdminServiceServer(server.GrpcServer, a)
 }
 
 func (a AdminService) CheckpointStream(req *pb.CheckpointStreamRequest, stream pb.AdminService_CheckpointStreamServer) error {
@@ -78,6 +120,183 @@ func (a AdminService) CheckpointStream(req *pb.CheckpointStreamRequest, stream p
 	}
 }
 
-func (a AdminService) Recover(_ context.Context, _ *pb.RecoverRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+// Recover validates the checkpoint at the requested uri and restarts the node to recover from it.
+func (a AdminService) Recover(ctx context.Context, req *pb.RecoverRequest) (*empty.Empty, error) {
+	if req.Uri == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "uri must be provided")
+	}
+	if err := a.recover(ctx, req.Uri); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to recover from %s: %s", req.Uri, err.Error()))
+	}
+	return &empty.Empty{}, nil
+}
+
+// AddAccessEntry adds the entry to the p2p access list, or updates the expiration of the existing entry.
+func (a AdminService) AddAccessEntry(_ context.Context, req *extpb.AddAccessEntryRequest) (*extpb.AddAccessEntryResponse, error) {
+	if a.access == nil {
+		return nil, status.Error(codes.Unavailable, "access list is not available")
+	}
+	if req.Entry == nil {
+		return nil, status.Error(codes.InvalidArgument, "entry must be provided")
+	}
+	kind, err := accessKind(req.Entry.Kind)
+	if err != nil {
+		return nil, err
+	}
+	entry := p2p.AccessEntry{Kind: kind, Value: req.Entry.Value}
+	if req.Entry.Expires != nil {
+		entry.Expires = req.Entry.Expires.AsTime()
+	}
+	if err := accessListError(a.access.Add(entry)); err != nil {
+		return nil, err
+	}
+	return &extpb.AddAccessEntryResponse{}, nil
+}
+
+// RemoveAccessEntry removes the entry from the p2p access list.
+func (a AdminService) RemoveAccessEntry(_ context.Context, req *extpb.RemoveAccessEntryRequest) (*extpb.RemoveAccessEntryResponse, error) {
+	if a.access == nil {
+		return nil, status.Error(codes.Unavailable, "access list is not available")
+	}
+	kind, err := accessKind(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := accessListError(a.access.Remove(kind, req.Value)); err != nil {
+		return nil, err
+	}
+	return &extpb.RemoveAccessEntryResponse{}, nil
+}
+
+// AccessEntries lists the entries of the p2p access list.
+func (a AdminService) AccessEntries(context.Context, *extpb.AccessEntriesRequest) (*extpb.AccessEntriesResponse, error) {
+	if a.access == nil {
+		return nil, status.Error(codes.Unavailable, "access list is not available")
+	}
+	resp := &extpb.AccessEntriesResponse{}
+	for _, entry := range a.access.Entries() {
+		casted := &extpb.AccessEntry{Kind: accessKinds[entry.Kind], Value: entry.Value}
+		if !entry.Expires.IsZero() {
+			casted.Expires = timestamppb.New(entry.Expires)
+		}
+		resp.Entries = append(resp.Entries, casted)
+	}
+	return resp, nil
+}
+
+// Backup writes a consistent copy of the node database to the file at path on the node's
+// machine. The path must be absolute and must not exist. The backup is verified before
+// it is written to path.
+func (a AdminService) Backup(ctx context.Context, req *extpb.BackupRequest) (*extpb.BackupResponse, error) {
+	if a.backup == nil {
+		return nil, status.Error(codes.Unavailable, "database backup is not available")
+	}
+	if !filepath.IsAbs(req.Path) {
+		return nil, status.Errorf(codes.InvalidArgument, "backup path %q must be absolute", req.Path)
+	}
+	if err := a.backup.Backup(ctx, req.Path); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to backup database: %s", err.Error())
+	}
+	return &extpb.BackupResponse{}, nil
+}
+
+// MigrationStatus returns the status of the migrations of the node database.
+func (a AdminService) MigrationStatus(context.Context, *extpb.MigrationStatusRequest) (*extpb.MigrationStatusResponse, error) {
+	if a.migrations == nil {
+		return nil, status.Error(codes.Unavailable, "database migrations are not available")
+	}
+	statuses, err := a.migrations.Status()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get migrations status: %s", err.Error())
+	}
+	return &extpb.MigrationStatusResponse{Migrations: castMigrations(statuses)}, nil
+}
+
+// MigrationDryRun applies pending migrations of the node database without committing them.
+func (a AdminService) MigrationDryRun(context.Context, *extpb.MigrationDryRunRequest) (*extpb.MigrationDryRunResponse, error) {
+	if a.migrations == nil {
+		return nil, status.Error(codes.Unavailable, "database migrations are not available")
+	}
+	pending, err := a.migrations.DryRun()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to apply pending migrations: %s", err.Error())
+	}
+	return &extpb.MigrationDryRunResponse{Pending: castMigrations(pending)}, nil
+}
+
+// MigrationRollback rolls back the latest applied migration of the node database.
+func (a AdminService) MigrationRollback(context.Context, *extpb.MigrationRollbackRequest) (*extpb.MigrationRollbackResponse, error) {
+	if a.migrations == nil {
+		return nil, status.Error(codes.Unavailable, "database migrations are not available")
+	}
+	reverted, err := a.migrations.Rollback()
+	switch {
+	case errors.Is(err, sql.ErrIrreversible),
+		errors.Is(err, sql.ErrNoMigrations),
+		errors.Is(err, sql.ErrChecksumMismatch),
+		errors.Is(err, sql.ErrUnknownMigration):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to roll back migration: %s", err.Error())
+	}
+	return &extpb.MigrationRollbackResponse{Reverted: castMigration(*reverted)}, nil
+}
+
+var migrationStates = map[sql.MigrationState]extpb.MigrationState{
+	sql.MigrationPending:  extpb.MigrationState_MIGRATION_STATE_PENDING,
+	sql.MigrationApplied:  extpb.MigrationState_MIGRATION_STATE_APPLIED,
+	sql.MigrationMismatch: extpb.MigrationState_MIGRATION_STATE_CHECKSUM_MISMATCH,
+	sql.MigrationUnknown:  extpb.MigrationState_MIGRATION_STATE_UNKNOWN,
+}
+
+func castMigration(ms sql.MigrationStatus) *extpb.Migration {
+	casted := &extpb.Migration{
+		Version:  uint32(ms.Version),
+		Name:     ms.Name,
+		Checksum: ms.Checksum,
+		State:    migrationStates[ms.State],
+	}
+	if !ms.AppliedAt.IsZero() {
+		casted.AppliedAt = timestamppb.New(ms.AppliedAt)
+	}
+	return casted
+}
+
+func castMigrations(statuses []sql.MigrationStatus) []*extpb.Migration {
+	casted := make([]*extpb.Migration, 0, len(statuses))
+	for _, ms := range statuses {
+		casted = append(casted, castMigration(ms))
+	}
+	return casted
+}
+
+var accessKinds = map[p2p.AccessKind]extpb.AccessKind{
+	p2p.AccessDenyPeer:      extpb.AccessKind_ACCESS_KIND_DENY_PEER,
+	p2p.AccessDenyCIDR:      extpb.AccessKind_ACCESS_KIND_DENY_CIDR,
+	p2p.AccessAlwaysConnect: extpb.AccessKind_ACCESS_KIND_ALWAYS_CONNECT,
+}
+
+func accessKind(kind extpb.AccessKind) (p2p.AccessKind, error) {
+	for casted, value := range accessKinds {
+		if value == kind {
+			return casted, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown access kind %s", kind)
+}
+
+func accessListError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, p2p.ErrInvalidAccessEntry):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, p2p.ErrAccessEntryNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
 }
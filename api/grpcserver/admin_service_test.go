@@ -3,6 +3,7 @@ package grpcserver
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -12,9 +13,15 @@ import (
 	"github.com/golang/mock/gomock"
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
 const (
@@ -29,7 +36,7 @@ func TestAdminService_Checkpoint(t *testing.T) {
 	mockFunc := func() CheckpointRunner {
 		return runner
 	}
-	svc := NewAdminService(mockFunc)
+	svc := NewAdminService(mockFunc, nil)
 	t.Cleanup(launchServer(t, cfg, svc))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -63,7 +70,7 @@ func TestAdminService_CheckpointError(t *testing.T) {
 	mockFunc := func() CheckpointRunner {
 		return runner
 	}
-	svc := NewAdminService(mockFunc)
+	svc := NewAdminService(mockFunc, nil)
 	t.Cleanup(launchServer(t, cfg, svc))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -78,3 +85,182 @@ func TestAdminService_CheckpointError(t *testing.T) {
 	_, err = stream.Recv()
 	require.ErrorContains(t, err, gerr.Error())
 }
+
+func TestAdminService_Recover(t *testing.T) {
+	logtest.SetupGlobal(t)
+	var (
+		uris []string
+		rerr error
+	)
+	svc := NewAdminService(nil, func(_ context.Context, uri string) error {
+		uris = append(uris, uri)
+		return rerr
+	})
+	t.Cleanup(launchServer(t, cfg, svc))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn := dialGrpc(ctx, t, cfg.PublicListener)
+	c := pb.NewAdminServiceClient(conn)
+
+	_, err := c.Recover(ctx, &pb.RecoverRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Empty(t, uris)
+
+	uri := "https://example.com/checkpoint"
+	_, err = c.Recover(ctx, &pb.RecoverRequest{Uri: uri})
+	require.NoError(t, err)
+	require.Equal(t, []string{uri}, uris)
+
+	rerr = errors.New("disaster")
+	_, err = c.Recover(ctx, &pb.RecoverRequest{Uri: uri})
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.ErrorContains(t, err, rerr.Error())
+}
+
+func TestAdminService_AccessList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	access := NewMockaccessList(ctrl)
+	svc := NewAdminService(nil, nil, WithAccessList(access))
+	t.Cleanup(launchServer(t, cfg, svc))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c := extpb.NewAdminServiceClient(dialGrpc(ctx, t, cfg.PublicListener))
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	entry := p2p.AccessEntry{Kind: p2p.AccessDenyCIDR, Value: "10.0.0.0/8", Expires: expires}
+	access.EXPECT().Add(gomock.Any()).DoAndReturn(func(got p2p.AccessEntry) error {
+		require.Equal(t, entry.Kind, got.Kind)
+		require.Equal(t, entry.Value, got.Value)
+		require.True(t, entry.Expires.Equal(got.Expires))
+		return nil
+	})
+	_, err := c.AddAccessEntry(ctx, &extpb.AddAccessEntryRequest{Entry: &extpb.AccessEntry{
+		Kind:    extpb.AccessKind_ACCESS_KIND_DENY_CIDR,
+		Value:   entry.Value,
+		Expires: timestamppb.New(expires),
+	}})
+	require.NoError(t, err)
+
+	access.EXPECT().Add(gomock.Any()).Return(p2p.ErrInvalidAccessEntry)
+	_, err = c.AddAccessEntry(ctx, &extpb.AddAccessEntryRequest{Entry: &extpb.AccessEntry{
+		Kind:  extpb.AccessKind_ACCESS_KIND_DENY_CIDR,
+		Value: "10.0.0.0",
+	}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = c.AddAccessEntry(ctx, &extpb.AddAccessEntryRequest{Entry: &extpb.AccessEntry{Value: entry.Value}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	access.EXPECT().Entries().Return([]p2p.AccessEntry{entry, {Kind: p2p.AccessDenyPeer, Value: "peer"}})
+	entries, err := c.AccessEntries(ctx, &extpb.AccessEntriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 2)
+	require.Equal(t, extpb.AccessKind_ACCESS_KIND_DENY_CIDR, entries.Entries[0].Kind)
+	require.Equal(t, entry.Value, entries.Entries[0].Value)
+	require.True(t, expires.Equal(entries.Entries[0].Expires.AsTime()))
+	require.Equal(t, extpb.AccessKind_ACCESS_KIND_DENY_PEER, entries.Entries[1].Kind)
+	require.Nil(t, entries.Entries[1].Expires)
+
+	remove := &extpb.RemoveAccessEntryRequest{Kind: extpb.AccessKind_ACCESS_KIND_DENY_CIDR, Value: entry.Value}
+	access.EXPECT().Remove(entry.Kind, entry.Value).Return(nil)
+	_, err = c.RemoveAccessEntry(ctx, remove)
+	require.NoError(t, err)
+	access.EXPECT().Remove(entry.Kind, entry.Value).Return(p2p.ErrAccessEntryNotFound)
+	_, err = c.RemoveAccessEntry(ctx, remove)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = NewAdminService(nil, nil).AccessEntries(ctx, &extpb.AccessEntriesRequest{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestAdminService_Backup(t *testing.T) {
+	dir := t.TempDir()
+	db, err := sql.Open("file:" + filepath.Join(dir, "state.sql"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	svc := NewAdminService(nil, nil, WithDatabaseBackup(db))
+	t.Cleanup(launchServer(t, cfg, svc))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c := extpb.NewAdminServiceClient(dialGrpc(ctx, t, cfg.PublicListener))
+
+	path := filepath.Join(dir, "backup.sql")
+	_, err = c.Backup(ctx, &extpb.BackupRequest{Path: path})
+	require.NoError(t, err)
+	_, err = sql.Verify(path)
+	require.NoError(t, err)
+
+	_, err = c.Backup(ctx, &extpb.BackupRequest{Path: path})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = c.Backup(ctx, &extpb.BackupRequest{Path: "backup.sql"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = NewAdminService(nil, nil).Backup(ctx, &extpb.BackupRequest{Path: path})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestAdminService_Migrations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	migrations := NewMockdatabaseMigrations(ctrl)
+	svc := NewAdminService(nil, nil, WithDatabaseMigrations(migrations))
+	t.Cleanup(launchServer(t, cfg, svc))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c := extpb.NewAdminServiceClient(dialGrpc(ctx, t, cfg.PublicListener))
+
+	applied := time.Now()
+	statuses := []sql.MigrationStatus{
+		{Version: 1, Name: "initial", Checksum: "aa", State: sql.MigrationApplied, AppliedAt: applied},
+		{Version: 2, Name: "recovery", Checksum: "bb", State: sql.MigrationPending},
+	}
+	migrations.EXPECT().Status().Return(statuses, nil)
+	resp, err := c.MigrationStatus(ctx, &extpb.MigrationStatusRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Migrations, 2)
+	require.Equal(t, uint32(1), resp.Migrations[0].Version)
+	require.Equal(t, "initial", resp.Migrations[0].Name)
+	require.Equal(t, "aa", resp.Migrations[0].Checksum)
+	require.Equal(t, extpb.MigrationState_MIGRATION_STATE_APPLIED, resp.Migrations[0].State)
+	require.True(t, applied.Equal(resp.Migrations[0].AppliedAt.AsTime()))
+	require.Equal(t, extpb.MigrationState_MIGRATION_STATE_PENDING, resp.Migrations[1].State)
+	require.Nil(t, resp.Migrations[1].AppliedAt)
+
+	migrations.EXPECT().Status().Return(nil, errors.New("test"))
+	_, err = c.MigrationStatus(ctx, &extpb.MigrationStatusRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	migrations.EXPECT().DryRun().Return(statuses[1:], nil)
+	dry, err := c.MigrationDryRun(ctx, &extpb.MigrationDryRunRequest{})
+	require.NoError(t, err)
+	require.Len(t, dry.Pending, 1)
+	require.Equal(t, "recovery", dry.Pending[0].Name)
+
+	migrations.EXPECT().DryRun().Return(statuses[1:], errors.New("test"))
+	_, err = c.MigrationDryRun(ctx, &extpb.MigrationDryRunRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	migrations.EXPECT().Rollback().Return(&sql.MigrationStatus{Version: 2, Name: "recovery", State: sql.MigrationPending}, nil)
+	rolled, err := c.MigrationRollback(ctx, &extpb.MigrationRollbackRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), rolled.Reverted.Version)
+	require.Equal(t, extpb.MigrationState_MIGRATION_STATE_PENDING, rolled.Reverted.State)
+
+	migrations.EXPECT().Rollback().Return(nil, fmt.Errorf("rollback: %w", sql.ErrIrreversible))
+	_, err = c.MigrationRollback(ctx, &extpb.MigrationRollbackRequest{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	migrations.EXPECT().Rollback().Return(nil, errors.New("test"))
+	_, err = c.MigrationRollback(ctx, &extpb.MigrationRollbackRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	svc = NewAdminService(nil, nil)
+	_, err = svc.MigrationStatus(ctx, &extpb.MigrationStatusRequest{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = svc.MigrationDryRun(ctx, &extpb.MigrationDryRunRequest{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = svc.MigrationRollback(ctx, &extpb.MigrationRollbackRequest{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,173 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerScheme        = "bearer "
+)
+
+var errUnauthenticated = status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+
+// AuthConfig configures transport security and authentication of the listener.
+// Listener is plain-text and doesn't require authentication if config is empty.
+type AuthConfig struct {
+	// TLSCert and TLSKey are paths to the PEM encoded certificate and key of the server.
+	// Both must be set to enable TLS.
+	TLSCert string `mapstructure:"tls-cert"`
+	TLSKey  string `mapstructure:"tls-key"`
+	// TLSClientCA is a path to the PEM encoded certificates. If set clients must present
+	// a certificate signed by one of them (mutual TLS).
+	TLSClientCA string `mapstructure:"tls-client-ca"`
+	// Tokens accepted in the authorization header with the bearer scheme.
+	// If not empty, calls without one of the tokens are rejected.
+	Tokens []string `mapstructure:"tokens"`
+}
+
+// Auth enforces transport security and authentication configured for the listener.
+type Auth struct {
+	tls    *tls.Config
+	tokens [][]byte
+}
+
+// NewAuth loads certificates configured for the listener.
+func NewAuth(cfg AuthConfig) (*Auth, error) {
+	auth := &Auth{}
+	for _, token := range cfg.Tokens {
+		if len(token) == 0 {
+			return nil, errors.New("empty bearer token")
+		}
+		auth.tokens = append(auth.tokens, []byte(token))
+	}
+	if len(cfg.TLSCert) == 0 && len(cfg.TLSKey) == 0 {
+		if len(cfg.TLSClientCA) > 0 {
+			return nil, errors.New("client certificates can't be verified without tls certificate of the server")
+		}
+		return auth, nil
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCert, cfg.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("load tls certificate: %w", err)
+	}
+	auth.tls = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if len(cfg.TLSClientCA) > 0 {
+		pem, err := os.ReadFile(cfg.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("read client ca %s: %w", cfg.TLSClientCA, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in client ca %s", cfg.TLSClientCA)
+		}
+		auth.tls.ClientCAs = pool
+		auth.tls.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return auth, nil
+}
+
+// TLSConfig returns nil if TLS is not enabled.
+func (a *Auth) TLSConfig() *tls.Config {
+	if a == nil || a.tls == nil {
+		return nil
+	}
+	return a.tls.Clone()
+}
+
+// ServerOptions returns grpc options that enable TLS and reject unauthenticated calls.
+func (a *Auth) ServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if a == nil {
+		return opts
+	}
+	if a.tls != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(a.TLSConfig())))
+	}
+	if len(a.tokens) > 0 {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(a.unaryInterceptor),
+			grpc.ChainStreamInterceptor(a.streamInterceptor),
+		)
+	}
+	return opts
+}
+
+// Handler rejects http requests without a valid bearer token.
+func (a *Auth) Handler(next http.Handler) http.Handler {
+	if a == nil || len(a.tokens) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.authorized(r.Header.Values(authorizationHeader)) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *Auth) authorized(values []string) bool {
+	for _, value := range values {
+		if len(value) < len(bearerScheme) || !strings.EqualFold(value[:len(bearerScheme)], bearerScheme) {
+			continue
+		}
+		token := []byte(strings.TrimSpace(value[len(bearerScheme):]))
+		for _, expected := range a.tokens {
+			if subtle.ConstantTimeCompare(token, expected) == 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *Auth) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if !a.authorized(md.Get(authorizationHeader)) {
+		return errUnauthenticated
+	}
+	return nil
+}
+
+func (a *Auth) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if err := a.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *Auth) streamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := a.authenticate(stream.Context()); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
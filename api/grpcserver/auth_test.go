@@ -0,0 +1,246 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+const (
+	authGrpcListener = "127.0.0.1:19095"
+	authJSONListener = "127.0.0.1:19096"
+	testToken        = "secret"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	// certFile and keyFile are PEM encoded cert and key.
+	certFile, keyFile string
+}
+
+func (c *testCert) tlsCertificate(tb testing.TB) tls.Certificate {
+	tb.Helper()
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	require.NoError(tb, err)
+	return cert
+}
+
+// genCert creates a certificate signed by the parent, or a self-signed CA certificate if parent is nil.
+func genCert(tb testing.TB, name string, parent *testCert) *testCert {
+	tb.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(tb, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(tb, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(tb, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(tb, err)
+
+	dir := tb.TempDir()
+	rst := &testCert{
+		cert:     cert,
+		key:      key,
+		certFile: filepath.Join(dir, name+".crt"),
+		keyFile:  filepath.Join(dir, name+".key"),
+	}
+	require.NoError(tb, os.WriteFile(rst.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(tb, os.WriteFile(rst.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return rst
+}
+
+func launchAuthServer(tb testing.TB, auth *Auth) {
+	tb.Helper()
+	svc := NewNodeService(context.Background(), nil, nil, nil, nil, "v0.0.0", "cafebabe")
+	grpcService := New(authGrpcListener, auth.ServerOptions()...)
+	svc.RegisterService(grpcService)
+	jsonService := NewJSONHTTPServer(authJSONListener, WithAuth(auth))
+	for _, ch := range []<-chan struct{}{grpcService.Start(), jsonService.StartService(context.Background(), svc)} {
+		select {
+		case <-ch:
+		case <-time.After(3 * time.Second):
+			require.FailNow(tb, "server didn't start")
+		}
+	}
+	tb.Cleanup(func() {
+		require.NoError(tb, jsonService.Shutdown(context.Background()))
+		_ = grpcService.Close()
+	})
+}
+
+func dialAuth(tb testing.TB, creds credentials.TransportCredentials) *grpc.ClientConn {
+	tb.Helper()
+	conn, err := grpc.Dial(authGrpcListener, grpc.WithTransportCredentials(creds))
+	require.NoError(tb, err)
+	tb.Cleanup(func() { require.NoError(tb, conn.Close()) })
+	return conn
+}
+
+func echo(ctx context.Context, conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err := pb.NewNodeServiceClient(conn).Echo(ctx, &pb.EchoRequest{Msg: &pb.SimpleString{Value: "hello"}}, grpc.WaitForReady(true))
+	return err
+}
+
+func postEcho(tb testing.TB, client *http.Client, scheme, token string) int {
+	tb.Helper()
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s://%s/v1/node/echo", scheme, authJSONListener),
+		strings.NewReader(`{"msg": {"value": "hello"}}`))
+	require.NoError(tb, err)
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	var resp *http.Response
+	require.Eventually(tb, func() bool {
+		resp, err = client.Do(req)
+		return err == nil
+	}, 3*time.Second, 50*time.Millisecond)
+	require.NoError(tb, resp.Body.Close())
+	return resp.StatusCode
+}
+
+func TestNewAuth(t *testing.T) {
+	ca := genCert(t, "ca", nil)
+	server := genCert(t, "server", ca)
+
+	auth, err := NewAuth(AuthConfig{})
+	require.NoError(t, err)
+	require.Nil(t, auth.TLSConfig())
+	require.Empty(t, auth.ServerOptions())
+
+	_, err = NewAuth(AuthConfig{Tokens: []string{""}})
+	require.Error(t, err)
+	_, err = NewAuth(AuthConfig{TLSClientCA: ca.certFile})
+	require.Error(t, err)
+	_, err = NewAuth(AuthConfig{TLSCert: server.certFile})
+	require.Error(t, err)
+	_, err = NewAuth(AuthConfig{TLSCert: server.certFile, TLSKey: server.keyFile, TLSClientCA: server.keyFile})
+	require.Error(t, err)
+
+	auth, err = NewAuth(AuthConfig{TLSCert: server.certFile, TLSKey: server.keyFile, TLSClientCA: ca.certFile})
+	require.NoError(t, err)
+	require.Equal(t, tls.RequireAndVerifyClientCert, auth.TLSConfig().ClientAuth)
+}
+
+func TestAuth_Token(t *testing.T) {
+	logtest.SetupGlobal(t)
+	auth, err := NewAuth(AuthConfig{Tokens: []string{"other", testToken}})
+	require.NoError(t, err)
+	launchAuthServer(t, auth)
+	conn := dialAuth(t, insecure.NewCredentials())
+
+	err = echo(context.Background(), conn)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	err = echo(metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong"), conn)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	err = echo(metadata.AppendToOutgoingContext(context.Background(), "authorization", testToken), conn)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.NoError(t, echo(metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testToken), conn))
+	require.NoError(t, echo(metadata.AppendToOutgoingContext(context.Background(), "authorization", "bearer "+testToken), conn))
+
+	// streams are rejected too
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	}))
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	require.Equal(t, http.StatusUnauthorized, postEcho(t, http.DefaultClient, "http", ""))
+	require.Equal(t, http.StatusUnauthorized, postEcho(t, http.DefaultClient, "http", "wrong"))
+	require.Equal(t, http.StatusOK, postEcho(t, http.DefaultClient, "http", testToken))
+}
+
+func TestAuth_MutualTLS(t *testing.T) {
+	logtest.SetupGlobal(t)
+	ca := genCert(t, "ca", nil)
+	server := genCert(t, "server", ca)
+	client := genCert(t, "client", ca)
+	untrusted := genCert(t, "untrusted", genCert(t, "other-ca", nil))
+
+	auth, err := NewAuth(AuthConfig{
+		TLSCert:     server.certFile,
+		TLSKey:      server.keyFile,
+		TLSClientCA: ca.certFile,
+	})
+	require.NoError(t, err)
+	launchAuthServer(t, auth)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientTLS := func(certs ...tls.Certificate) *tls.Config {
+		return &tls.Config{RootCAs: roots, Certificates: certs, MinVersion: tls.VersionTLS12}
+	}
+
+	conn := dialAuth(t, credentials.NewTLS(clientTLS(client.tlsCertificate(t))))
+	require.NoError(t, echo(context.Background(), conn))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for _, cfg := range []*tls.Config{clientTLS(), clientTLS(untrusted.tlsCertificate(t))} {
+		_, err := grpc.DialContext(ctx, authGrpcListener,
+			grpc.WithTransportCredentials(credentials.NewTLS(cfg)),
+			grpc.WithBlock(),
+			grpc.FailOnNonTempDialError(true),
+		)
+		require.Error(t, err)
+	}
+	_, err = grpc.DialContext(ctx, authGrpcListener,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+		grpc.FailOnNonTempDialError(true),
+	)
+	require.Error(t, err)
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS(client.tlsCertificate(t))}}
+	require.Equal(t, http.StatusOK, postEcho(t, httpClient, "https", ""))
+	httpClient = &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS()}}
+	_, err = httpClient.Post(fmt.Sprintf("https://%s/v1/node/echo", authJSONListener), "application/json",
+		strings.NewReader(`{"msg": {"value": "hello"}}`))
+	require.Error(t, err)
+}
@@ -13,6 +13,12 @@ type Config struct {
 	GrpcSendMsgSize int       `mapstructure:"grpc-send-msg-size"`
 	GrpcRecvMsgSize int       `mapstructure:"grpc-recv-msg-size"`
 	JSONListener    string    `mapstructure:"grpc-json-listener"`
+	// PublicAuth is used for the public listener and the json gateway.
+	PublicAuth  AuthConfig `mapstructure:"grpc-public-auth"`
+	PrivateAuth AuthConfig `mapstructure:"grpc-private-auth"`
+	// PublicLimits are shared by the public listener and the json gateway.
+	PublicLimits  LimitsConfig `mapstructure:"grpc-public-limits"`
+	PrivateLimits LimitsConfig `mapstructure:"grpc-private-limits"`
 
 	SmesherStreamInterval time.Duration
 }
@@ -38,6 +44,7 @@ func DefaultConfig() Config {
 		PrivateServices:       []Service{Admin, Smesher},
 		PrivateListener:       "127.0.0.1:9093",
 		JSONListener:          "",
+		PublicLimits:          DefaultPublicLimits(),
 		GrpcSendMsgSize:       1024 * 1024 * 10,
 		GrpcRecvMsgSize:       1024 * 1024 * 10,
 		SmesherStreamInterval: time.Second,
@@ -9,10 +9,14 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/fetch"
 	"github.com/spacemeshos/go-spacemesh/log"
 )
 
@@ -21,20 +25,36 @@ type DebugService struct {
 	conState conservativeState
 	identity networkIdentity
 	oracle   oracle
+	peers    peerScores
+}
+
+// DebugOpt is an option for DebugService.
+type DebugOpt func(*DebugService)
+
+// WithPeerScores enables reading the scores of the peers that the node fetches data from.
+func WithPeerScores(peers peerScores) DebugOpt {
+	return func(d *DebugService) {
+		d.peers = peers
+	}
 }
 
 // RegisterService registers this service with a grpc server instance.
 func (d DebugService) RegisterService(server *Server) {
 	pb.RegisterDebugServiceServer(server.GrpcServer, d)
+	extpb.RegisterDebugServiceServer(server.GrpcServer, d)
 }
 
 // NewDebugService creates a new grpc service using config data.
-func NewDebugService(conState conservativeState, host networkIdentity, oracle oracle) *DebugService {
-	return &DebugService{
+func NewDebugService(conState conservativeState, host networkIdentity, oracle oracle, opts ...DebugOpt) *DebugService {
+	d := &DebugService{
 		conState: conState,
 		identity: host,
 		oracle:   oracle,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 // Accounts returns current counter and balance for all accounts.
@@ -84,6 +104,38 @@ func (d DebugService) ActiveSet(ctx context.Context, req *pb.ActiveSetRequest) (
 	return resp, nil
 }
 
+// PeerScores returns the scores of the peers that the node fetches data from, sorted from the best.
+func (d DebugService) PeerScores(context.Context, *extpb.PeerScoresRequest) (*extpb.PeerScoresResponse, error) {
+	if d.peers == nil {
+		return nil, status.Error(codes.Unavailable, "peer scores are not available")
+	}
+	resp := &extpb.PeerScoresResponse{}
+	for _, score := range d.peers.PeerScores() {
+		resp.Peers = append(resp.Peers, castPeerScore(&score))
+	}
+	return resp, nil
+}
+
+func castPeerScore(score *fetch.PeerScore) *extpb.PeerScore {
+	casted := &extpb.PeerScore{
+		Id:          score.Peer.String(),
+		Score:       score.Score,
+		Latency:     durationpb.New(score.Latency),
+		FailureRate: score.FailureRate,
+		InvalidRate: score.InvalidRate,
+		Requests:    score.Requests,
+		Failures:    score.Failures,
+		Invalid:     score.Invalid,
+	}
+	if !score.BackoffUntil.IsZero() {
+		casted.BackoffUntil = timestamppb.New(score.BackoffUntil)
+	}
+	if !score.BannedUntil.IsZero() {
+		casted.BannedUntil = timestamppb.New(score.BannedUntil)
+	}
+	return casted
+}
+
 // ProposalsStream streams all proposals confirmed by hare.
 func (d DebugService) ProposalsStream(_ *emptypb.Empty, stream pb.DebugService_ProposalsStreamServer) error {
 	sub := events.SubcribeProposals()
@@ -2,14 +2,25 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
+	"strconv"
 
 	"github.com/libp2p/go-libp2p/core/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
+	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/log"
 )
 
 const subscriptionChanBufSize = 1 << 16
 
+// cursorKey is the response header key with the cursor after which the journaled stream starts.
+// Client that didn't receive any event can resume the stream from it.
+const cursorKey = "cursor"
+
 // Errors for cases with a full event buffer.
 var (
 	errTxBufferFull          = "tx buffer is full"
@@ -19,6 +30,7 @@ var (
 	errActivationsBufferFull = "activations buffer is full"
 	errStatusBufferFull      = "status buffer is full"
 	errErrorsBufferFull      = "errors buffer is full"
+	errAppEventsBufferFull   = "app events buffer is full"
 )
 
 func consumeEvents[T any](ctx context.Context, subscription event.Subscription) (out <-chan T, bufFull <-chan struct{}) {
@@ -54,3 +66,29 @@ func closeSubscription(accountSubscription event.Subscription) {
 		log.With().Panic("Failed to close account subscription: " + err.Error())
 	}
 }
+
+// subscribeJournal subscribes to the events journal and sends the header with the cursor
+// after which the stream starts. If from is not nil, events after that cursor are replayed first.
+// Returns the cursor after which the stream starts.
+func subscribeJournal(
+	stream grpc.ServerStream,
+	from *uint64,
+	matcher func(*events.Record) bool,
+) (*events.BufferedSubscription[events.Record], uint64, error) {
+	var opts []events.SubOpt
+	if from != nil {
+		opts = append(opts, events.WithReplay(*from))
+	}
+	sub, start, err := events.SubscribeJournal(matcher, opts...)
+	switch {
+	case errors.Is(err, events.ErrCursorPruned), errors.Is(err, events.ErrCursorAhead):
+		return nil, 0, status.Error(codes.OutOfRange, err.Error())
+	case err != nil:
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	if err := stream.SendHeader(metadata.Pairs(cursorKey, strconv.FormatUint(start, 10))); err != nil {
+		sub.Close()
+		return nil, 0, status.Errorf(codes.Unavailable, "can't send header")
+	}
+	return sub, start, nil
+}
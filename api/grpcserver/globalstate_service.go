@@ -2,20 +2,28 @@ package grpcserver
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/transactions"
 )
 
 // GlobalStateService exposes global state data, output from the STF.
 type GlobalStateService struct {
+	db       sql.Executor
 	mesh     meshAPI
 	conState conservativeState
 }
@@ -23,11 +31,13 @@ type GlobalStateService struct {
 // RegisterService registers this service with a grpc server instance.
 func (s GlobalStateService) RegisterService(server *Server) {
 	pb.RegisterGlobalStateServiceServer(server.GrpcServer, s)
+	extpb.RegisterGlobalStateServiceServer(server.GrpcServer, s)
 }
 
 // NewGlobalStateService creates a new grpc service using config data.
-func NewGlobalStateService(msh meshAPI, conState conservativeState) *GlobalStateService {
+func NewGlobalStateService(db sql.Executor, msh meshAPI, conState conservativeState) *GlobalStateService {
 	return &GlobalStateService{
+		db:       db,
 		mesh:     msh,
 		conState: conState,
 	}
@@ -98,6 +108,51 @@ func (s GlobalStateService) Account(_ context.Context, in *pb.AccountRequest) (*
 	return &pb.AccountResponse{AccountWrapper: acct}, nil
 }
 
+// AccountProof returns the state of the account at the layer and the proof that it is
+// committed in the global state hash of that layer.
+func (s GlobalStateService) AccountProof(_ context.Context, in *extpb.AccountProofRequest) (*extpb.AccountProofResponse, error) {
+	log.Info("GRPC GlobalStateService.AccountProof")
+
+	if in.AccountId == nil {
+		return nil, status.Error(codes.InvalidArgument, "`AccountId` must be provided")
+	}
+	if in.Layer == nil {
+		return nil, status.Error(codes.InvalidArgument, "`Layer` must be provided")
+	}
+	address, err := types.StringToAddress(in.AccountId.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address %s: %v", in.AccountId.Address, err)
+	}
+	layer := types.LayerID(in.Layer.Number)
+	proof, err := s.conState.GetAccountProof(address, layer)
+	switch {
+	case errors.Is(err, sql.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "account %s not found at layer %d", address, layer)
+	case err != nil:
+		log.With().Error("unable to prove account state", address, layer, log.Err(err))
+		return nil, status.Error(codes.Internal, "error proving account state")
+	}
+	account := &extpb.ProvenAccount{
+		AccountId: &pb.AccountId{Address: proof.Account.Address.String()},
+		Layer:     &pb.LayerNumber{Number: proof.Account.Layer.Uint32()},
+		Counter:   proof.Account.NextNonce,
+		Balance:   &pb.Amount{Value: proof.Account.Balance},
+		State:     proof.Account.State,
+	}
+	if proof.Account.TemplateAddress != nil {
+		account.Template = &pb.AccountId{Address: proof.Account.TemplateAddress.String()}
+	}
+	resp := &extpb.AccountProofResponse{
+		Layer:   &pb.LayerNumber{Number: proof.Layer.Uint32()},
+		Root:    proof.Root.Bytes(),
+		Account: account,
+	}
+	for _, sibling := range proof.Proof {
+		resp.Proof = append(resp.Proof, sibling.Bytes())
+	}
+	return resp, nil
+}
+
 // AccountDataQuery returns historical account data such as rewards and receipts.
 func (s GlobalStateService) AccountDataQuery(_ context.Context, in *pb.AccountDataQueryRequest) (*pb.AccountDataQueryResponse, error) {
 	log.Info("GRPC GlobalStateService.AccountDataQuery")
@@ -113,8 +168,7 @@ func (s GlobalStateService) AccountDataQuery(_ context.Context, in *pb.AccountDa
 	}
 
 	// Read the filter flags
-	// filterTxReceipt := in.Filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_TRANSACTION_RECEIPT) != 0
-	_ = in.Filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_TRANSACTION_RECEIPT) != 0
+	filterTxReceipt := in.Filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_TRANSACTION_RECEIPT) != 0
 	filterReward := in.Filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_REWARD) != 0
 	filterAccount := in.Filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_ACCOUNT) != 0
 
@@ -124,9 +178,27 @@ func (s GlobalStateService) AccountDataQuery(_ context.Context, in *pb.AccountDa
 	}
 	res := &pb.AccountDataQueryResponse{}
 
-	// TODO: Implement this. The node does not implement tx receipts yet.
-	// See https://github.com/spacemeshos/go-spacemesh/issues/2072
-	// if filterTxReceipt {}
+	if filterTxReceipt {
+		var ierr error
+		err := transactions.IterateResults(s.db, transactions.ResultsFilter{Address: &addr}, func(rst *types.TransactionWithResult) bool {
+			var receipt *pb.TransactionReceipt
+			receipt, ierr = castReceipt(rst)
+			if ierr != nil {
+				return false
+			}
+			res.AccountItem = append(res.AccountItem, &pb.AccountData{Datum: &pb.AccountData_Receipt{
+				Receipt: receipt,
+			}})
+			return true
+		})
+		if err == nil {
+			err = ierr
+		}
+		if err != nil {
+			log.With().Error("unable to load transaction receipts", addr, log.Err(err))
+			return nil, status.Errorf(codes.Internal, "error getting transaction receipts")
+		}
+	}
 
 	if filterReward {
 		dbRewards, err := s.mesh.GetRewards(addr)
@@ -199,57 +271,71 @@ func (s GlobalStateService) SmesherDataQuery(_ context.Context, in *pb.SmesherDa
 // AccountDataStream exposes a stream of account-related data.
 func (s GlobalStateService) AccountDataStream(in *pb.AccountDataStreamRequest, stream pb.GlobalStateService_AccountDataStreamServer) error {
 	log.Info("GRPC GlobalStateService.AccountDataStream")
+	return s.streamAccountData(in.Filter, nil, stream, func(datum *pb.AccountData, _ uint64) error {
+		return stream.Send(&pb.AccountDataStreamResponse{Datum: datum})
+	})
+}
 
-	if in.Filter == nil {
+// AccountDataStreamWithCursor exposes a stream of account-related data with the journal cursor of every datum.
+func (s GlobalStateService) AccountDataStreamWithCursor(
+	in *extpb.AccountDataStreamWithCursorRequest,
+	stream extpb.GlobalStateService_AccountDataStreamWithCursorServer,
+) error {
+	log.Info("GRPC GlobalStateService.AccountDataStreamWithCursor")
+	return s.streamAccountData(in.Filter, in.FromCursor, stream, func(datum *pb.AccountData, cursor uint64) error {
+		return stream.Send(&extpb.AccountDataStreamWithCursorResponse{Datum: datum, Cursor: cursor})
+	})
+}
+
+func (s GlobalStateService) streamAccountData(
+	filter *pb.AccountDataFilter,
+	from *uint64,
+	stream grpc.ServerStream,
+	send func(*pb.AccountData, uint64) error,
+) error {
+	if filter == nil {
 		return status.Errorf(codes.InvalidArgument, "`Filter` must be provided")
 	}
-	if in.Filter.AccountId == nil {
+	if filter.AccountId == nil {
 		return status.Errorf(codes.InvalidArgument, "`Filter.AccountId` must be provided")
 	}
-	if in.Filter.AccountDataFlags == uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_UNSPECIFIED) {
+	if filter.AccountDataFlags == uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_UNSPECIFIED) {
 		return status.Errorf(codes.InvalidArgument, "`Filter.AccountDataFlags` must set at least one bitfield")
 	}
-	addr, err := types.StringToAddress(in.Filter.AccountId.Address)
+	addr, err := types.StringToAddress(filter.AccountId.Address)
 	if err != nil {
-		return fmt.Errorf("failed to parse in.Filter.AccountId.Address `%s`: %w", in.Filter.AccountId.Address, err)
+		return fmt.Errorf("failed to parse in.Filter.AccountId.Address `%s`: %w", filter.AccountId.Address, err)
 	}
 
-	filterAccount := in.Filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_ACCOUNT) != 0
-	filterReward := in.Filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_REWARD) != 0
-
-	// Subscribe to the various streams
-	var (
-		accountCh      <-chan events.Account
-		rewardsCh      <-chan events.Reward
-		receiptsCh     <-chan any
-		accountBufFull <-chan struct{}
-		rewardsBufFull <-chan struct{}
-	)
-	if filterAccount {
-		if accountSubscription := events.SubscribeAccount(); accountSubscription != nil {
-			accountCh, accountBufFull = consumeEvents[events.Account](stream.Context(), accountSubscription)
-		}
-	}
-	if filterReward {
-		if rewardsSubscription := events.SubscribeRewards(); rewardsSubscription != nil {
-			rewardsCh, rewardsBufFull = consumeEvents[events.Reward](stream.Context(), rewardsSubscription)
+	filterAccount := filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_ACCOUNT) != 0
+	filterReward := filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_REWARD) != 0
+	filterTxReceipt := filter.AccountDataFlags&uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_TRANSACTION_RECEIPT) != 0
+
+	sub, _, err := subscribeJournal(stream, from, func(rec *events.Record) bool {
+		switch {
+		case rec.Account != nil:
+			return filterAccount && rec.Account.Address == addr
+		case rec.Reward != nil:
+			return filterReward && rec.Reward.Coinbase == addr
+		case rec.Result != nil:
+			return filterTxReceipt && resultsMatcher{Address: &addr}.match(rec.Result)
 		}
-	}
-	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return false
+	})
+	if err != nil {
 		return err
 	}
+	defer sub.Close()
 
 	for {
 		select {
-		case <-accountBufFull:
-			log.Info("account buffer is full, shutting down")
+		case <-sub.Full():
+			log.Info("account data buffer is full, shutting down")
 			return status.Error(codes.Canceled, errAccountBufferFull)
-		case <-rewardsBufFull:
-			log.Info("rewards buffer is full, shutting down")
-			return status.Error(codes.Canceled, errRewardsBufferFull)
-		case updatedAccountEvent := <-accountCh:
-			// Apply address filter
-			if updatedAccountEvent.Address == addr {
+		case rec := <-sub.Out():
+			var datum *pb.AccountData
+			switch {
+			case rec.Account != nil:
 				// The Reporter service just sends us the account address. We are responsible
 				// for looking up the other required data here. Get the account balance and
 				// nonce.
@@ -258,18 +344,12 @@ func (s GlobalStateService) AccountDataStream(in *pb.AccountDataStreamRequest, s
 					log.With().Error("unable to fetch projected account state", log.Err(err))
 					return status.Errorf(codes.Internal, "error fetching projected account data")
 				}
-				resp := &pb.AccountDataStreamResponse{Datum: &pb.AccountData{Datum: &pb.AccountData_AccountWrapper{
+				datum = &pb.AccountData{Datum: &pb.AccountData_AccountWrapper{
 					AccountWrapper: acct,
-				}}}
-				if err := stream.Send(resp); err != nil {
-					return fmt.Errorf("send to stream: %w", err)
-				}
-			}
-
-		case reward := <-rewardsCh:
-			// Apply address filter
-			if reward.Coinbase == addr {
-				resp := &pb.AccountDataStreamResponse{Datum: &pb.AccountData{Datum: &pb.AccountData_Reward{
+				}}
+			case rec.Reward != nil:
+				reward := rec.Reward
+				datum = &pb.AccountData{Datum: &pb.AccountData_Reward{
 					Reward: &pb.Reward{
 						Layer:       &pb.LayerNumber{Number: reward.Layer.Uint32()},
 						Total:       &pb.Amount{Value: reward.Total},
@@ -279,30 +359,18 @@ func (s GlobalStateService) AccountDataStream(in *pb.AccountDataStreamRequest, s
 						// LayerComputed: 0,
 						Coinbase: &pb.AccountId{Address: addr.String()},
 					},
-				}}}
-				if err := stream.Send(resp); err != nil {
-					return fmt.Errorf("send to stream: %w", err)
+				}}
+			case rec.Result != nil:
+				receipt, err := castReceipt(rec.Result)
+				if err != nil {
+					return status.Error(codes.Internal, err.Error())
 				}
+				datum = &pb.AccountData{Datum: &pb.AccountData_Receipt{
+					Receipt: receipt,
+				}}
 			}
-
-		case receiptEvent := <-receiptsCh:
-			receipt := receiptEvent.(events.TxReceipt)
-			// Apply address filter
-			if receipt.Address == addr {
-				resp := &pb.AccountDataStreamResponse{Datum: &pb.AccountData{Datum: &pb.AccountData_Receipt{
-					Receipt: &pb.TransactionReceipt{
-						Id: &pb.TransactionId{Id: receipt.ID.Bytes()},
-						// Result:      receipt.Result,
-						GasUsed: receipt.GasUsed,
-						Fee:     &pb.Amount{Value: receipt.Fee},
-						Layer:   &pb.LayerNumber{Number: receipt.Layer.Uint32()},
-						Index:   receipt.Index,
-						// SvmData: nil,
-					},
-				}}}
-				if err := stream.Send(resp); err != nil {
-					return fmt.Errorf("send to stream: %w", err)
-				}
+			if err := send(datum, rec.Cursor); err != nil {
+				return fmt.Errorf("send to stream: %w", err)
 			}
 
 		case <-stream.Context().Done():
@@ -321,14 +389,40 @@ func (s GlobalStateService) SmesherRewardStream(in *pb.SmesherRewardStreamReques
 	return status.Errorf(codes.Unimplemented, "DEPRECATED")
 }
 
-// AppEventStream exposes a stream of emitted app events.
-func (s GlobalStateService) AppEventStream(*pb.AppEventStreamRequest, pb.GlobalStateService_AppEventStreamServer) error {
+// AppEventStream exposes a stream of events emitted by the account templates.
+// Each event is sent as a json object in the message of the app event.
+func (s GlobalStateService) AppEventStream(_ *pb.AppEventStreamRequest, stream pb.GlobalStateService_AppEventStreamServer) error {
 	log.Info("GRPC GlobalStateService.AppEventStream")
 
-	// TODO: implement me! We don't currently have any app events
-	// See https://github.com/spacemeshos/go-spacemesh/issues/2074
-
-	return status.Errorf(codes.Unimplemented, "this endpoint has not yet been implemented")
+	sub, err := events.SubscribeMatched(func(rst *types.TransactionWithResult) bool {
+		return len(rst.Events) > 0
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer sub.Close()
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return status.Errorf(codes.Unavailable, "can't send header")
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-sub.Full():
+			log.Info("app events buffer is full, shutting down")
+			return status.Error(codes.Canceled, errAppEventsBufferFull)
+		case rst := <-sub.Out():
+			for i := range rst.Events {
+				event, err := castAppEvent(rst.ID, &rst.Events[i])
+				if err != nil {
+					return status.Error(codes.Internal, err.Error())
+				}
+				if err := stream.Send(&pb.AppEventStreamResponse{Event: event}); err != nil {
+					return fmt.Errorf("send to stream: %w", err)
+				}
+			}
+		}
+	}
 }
 
 // GlobalStateStream exposes a stream of global data data items: rewards, receipts, account info, global state hash.
@@ -439,3 +533,58 @@ func (s GlobalStateService) GlobalStateStream(in *pb.GlobalStateStreamRequest, s
 		// See https://github.com/spacemeshos/go-spacemesh/issues/2075
 	}
 }
+
+// castReceipt converts the result to the receipt. Events emitted while executing the transaction
+// are scale encoded into svm data.
+func castReceipt(rst *types.TransactionWithResult) (*pb.TransactionReceipt, error) {
+	receipt := &pb.TransactionReceipt{
+		Id:      &pb.TransactionId{Id: rst.ID.Bytes()},
+		Result:  pb.TransactionReceipt_TRANSACTION_RESULT_EXECUTED,
+		GasUsed: rst.Gas,
+		Fee:     &pb.Amount{Value: rst.Fee},
+		Layer:   &pb.LayerNumber{Number: rst.Layer.Uint32()},
+	}
+	if rst.Status == types.TransactionFailure {
+		receipt.Result = pb.TransactionReceipt_TRANSACTION_RESULT_RUNTIME_EXCEPTION
+	}
+	if len(rst.Events) > 0 {
+		data, err := codec.EncodeSlice(rst.Events)
+		if err != nil {
+			return nil, err
+		}
+		receipt.SvmData = data
+	}
+	return receipt, nil
+}
+
+type appEvent struct {
+	Type        string `json:"type"`
+	Template    string `json:"template"`
+	Principal   string `json:"principal"`
+	Account     string `json:"account,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	Amount      uint64 `json:"amount,omitempty"`
+}
+
+func castAppEvent(tid types.TransactionID, event *types.TransactionEvent) (*pb.AppEvent, error) {
+	encoded := appEvent{
+		Type:      event.Type.String(),
+		Template:  event.Template.String(),
+		Principal: event.Principal.String(),
+		Amount:    event.Amount,
+	}
+	if event.Account != (types.Address{}) {
+		encoded.Account = event.Account.String()
+	}
+	if event.Destination != (types.Address{}) {
+		encoded.Destination = event.Destination.String()
+	}
+	msg, err := json.Marshal(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("marshal app event: %w", err)
+	}
+	return &pb.AppEvent{
+		TransactionId: &pb.TransactionId{Id: tid.Bytes()},
+		Message:       string(msg),
+	}, nil
+}
@@ -0,0 +1,232 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/fixture"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/transactions"
+)
+
+func spendEvent(principal, destination types.Address, amount uint64) types.TransactionEvent {
+	return types.TransactionEvent{
+		Type:        types.TransactionEventSpend,
+		Template:    types.Address{1},
+		Principal:   principal,
+		Destination: destination,
+		Amount:      amount,
+	}
+}
+
+func TestGlobalStateService_Receipts(t *testing.T) {
+	logtest.SetupGlobal(t)
+	db := sql.InMemory()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	gen := fixture.NewTransactionResultGenerator().WithAddresses(2)
+	tx := gen.Next()
+	tx.Addresses = []types.Address{addr1}
+	tx.Events = []types.TransactionEvent{spendEvent(addr1, addr2, 100)}
+	require.NoError(t, transactions.Add(db, &tx.Transaction, time.Time{}))
+	require.NoError(t, db.WithTx(ctx, func(dtx *sql.Tx) error {
+		if err := transactions.AddResult(dtx, tx.ID, &tx.TransactionResult); err != nil {
+			return err
+		}
+		return transactions.AddEvents(dtx, tx.ID, tx.Events)
+	}))
+
+	events.InitializeReporter()
+	t.Cleanup(events.CloseEventReporter)
+
+	svc := NewGlobalStateService(db, meshAPIMock, conStateAPI)
+	t.Cleanup(launchServer(t, cfg, svc))
+	c := pb.NewGlobalStateServiceClient(dialGrpc(ctx, t, cfg.PublicListener))
+
+	requireReceipt := func(t *testing.T, expected *types.TransactionWithResult, receipt *pb.TransactionReceipt) {
+		t.Helper()
+		require.Equal(t, expected.ID.Bytes(), receipt.Id.Id)
+		require.Equal(t, expected.Gas, receipt.GasUsed)
+		require.Equal(t, expected.Fee, receipt.Fee.Value)
+		require.Equal(t, expected.Layer.Uint32(), receipt.Layer.Number)
+		if len(expected.Events) == 0 {
+			require.Empty(t, receipt.SvmData)
+			return
+		}
+		decoded, err := codec.DecodeSlice[types.TransactionEvent](receipt.SvmData)
+		require.NoError(t, err)
+		require.Equal(t, expected.Events, decoded)
+	}
+
+	t.Run("query", func(t *testing.T) {
+		res, err := c.AccountDataQuery(ctx, &pb.AccountDataQueryRequest{
+			Filter: &pb.AccountDataFilter{
+				AccountId:        &pb.AccountId{Address: addr1.String()},
+				AccountDataFlags: uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_TRANSACTION_RECEIPT),
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, res.AccountItem, 1)
+		requireReceipt(t, tx, res.AccountItem[0].GetReceipt())
+
+		res, err = c.AccountDataQuery(ctx, &pb.AccountDataQueryRequest{
+			Filter: &pb.AccountDataFilter{
+				AccountId:        &pb.AccountId{Address: addr2.String()},
+				AccountDataFlags: uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_TRANSACTION_RECEIPT),
+			},
+		})
+		require.NoError(t, err)
+		require.Empty(t, res.AccountItem)
+	})
+	t.Run("stream", func(t *testing.T) {
+		stream, err := c.AccountDataStream(ctx, &pb.AccountDataStreamRequest{
+			Filter: &pb.AccountDataFilter{
+				AccountId:        &pb.AccountId{Address: addr1.String()},
+				AccountDataFlags: uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_TRANSACTION_RECEIPT),
+			},
+		})
+		require.NoError(t, err)
+		_, err = stream.Header()
+		require.NoError(t, err)
+
+		other := gen.Next()
+		other.Addresses = []types.Address{addr2}
+		matched := gen.Next()
+		matched.Addresses = []types.Address{addr2, addr1}
+		matched.Status = types.TransactionFailure
+		events.ReportResult(*other)
+		events.ReportResult(*matched)
+
+		received, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, pb.TransactionReceipt_TRANSACTION_RESULT_RUNTIME_EXCEPTION, received.Datum.GetReceipt().Result)
+		requireReceipt(t, matched, received.Datum.GetReceipt())
+	})
+}
+
+func TestGlobalStateService_AppEventStream(t *testing.T) {
+	logtest.SetupGlobal(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	events.InitializeReporter()
+	t.Cleanup(events.CloseEventReporter)
+
+	svc := NewGlobalStateService(sql.InMemory(), meshAPIMock, conStateAPI)
+	t.Cleanup(launchServer(t, cfg, svc))
+	c := pb.NewGlobalStateServiceClient(dialGrpc(ctx, t, cfg.PublicListener))
+
+	stream, err := c.AppEventStream(ctx, &pb.AppEventStreamRequest{})
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	gen := fixture.NewTransactionResultGenerator()
+	empty := gen.Next()
+	tx := gen.Next()
+	tx.Events = []types.TransactionEvent{
+		{Type: types.TransactionEventSpawn, Template: types.Address{1}, Principal: addr1, Account: addr1},
+		spendEvent(addr1, addr2, 100),
+	}
+	events.ReportResult(*empty)
+	events.ReportResult(*tx)
+
+	for _, expected := range []appEvent{
+		{Type: "spawn", Template: types.Address{1}.String(), Principal: addr1.String(), Account: addr1.String()},
+		{Type: "spend", Template: types.Address{1}.String(), Principal: addr1.String(), Destination: addr2.String(), Amount: 100},
+	} {
+		received, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, tx.ID.Bytes(), received.Event.TransactionId.Id)
+		var decoded appEvent
+		require.NoError(t, json.Unmarshal([]byte(received.Event.Message), &decoded))
+		require.Equal(t, expected, decoded)
+	}
+}
+
+func TestGlobalStateService_AccountDataStreamWithCursor(t *testing.T) {
+	logtest.SetupGlobal(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	events.InitializeReporter()
+	t.Cleanup(events.CloseEventReporter)
+	require.NoError(t, events.EnableJournal(sql.InMemory(), 0))
+
+	svc := NewGlobalStateService(sql.InMemory(), meshAPIMock, conStateAPI)
+	t.Cleanup(launchServer(t, cfg, svc))
+	c := extpb.NewGlobalStateServiceClient(dialGrpc(ctx, t, cfg.PublicListener))
+
+	events.ReportRewardReceived(events.Reward{Layer: types.LayerID(10), Total: 1, Coinbase: addr1})
+	events.ReportRewardReceived(events.Reward{Layer: types.LayerID(10), Total: 2, Coinbase: addr2})
+	events.ReportRewardReceived(events.Reward{Layer: types.LayerID(11), Total: 3, Coinbase: addr1})
+
+	filter := &pb.AccountDataFilter{
+		AccountId:        &pb.AccountId{Address: addr1.String()},
+		AccountDataFlags: uint32(pb.AccountDataFlag_ACCOUNT_DATA_FLAG_REWARD),
+	}
+	stream, err := c.AccountDataStreamWithCursor(ctx, &extpb.AccountDataStreamWithCursorRequest{
+		Filter:     filter,
+		FromCursor: proto.Uint64(0),
+	})
+	require.NoError(t, err)
+	header, err := stream.Header()
+	require.NoError(t, err)
+	require.Equal(t, []string{"0"}, header.Get(cursorKey))
+
+	// replayed from the journal
+	for _, expected := range []struct{ total, cursor uint64 }{{1, 1}, {3, 3}} {
+		received, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, expected.total, received.Datum.GetReward().Total.Value)
+		require.Equal(t, expected.cursor, received.Cursor)
+	}
+	// live
+	events.ReportRewardReceived(events.Reward{Layer: types.LayerID(12), Total: 4, Coinbase: addr1})
+	received, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), received.Datum.GetReward().Total.Value)
+	require.Equal(t, uint64(4), received.Cursor)
+
+	t.Run("resume", func(t *testing.T) {
+		stream, err := c.AccountDataStreamWithCursor(ctx, &extpb.AccountDataStreamWithCursorRequest{
+			Filter:     filter,
+			FromCursor: proto.Uint64(3),
+		})
+		require.NoError(t, err)
+		received, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), received.Datum.GetReward().Total.Value)
+		require.Equal(t, uint64(4), received.Cursor)
+	})
+	t.Run("live only", func(t *testing.T) {
+		stream, err := c.AccountDataStreamWithCursor(ctx, &extpb.AccountDataStreamWithCursorRequest{Filter: filter})
+		require.NoError(t, err)
+		header, err := stream.Header()
+		require.NoError(t, err)
+		require.Equal(t, []string{"4"}, header.Get(cursorKey))
+	})
+	t.Run("ahead", func(t *testing.T) {
+		stream, err := c.AccountDataStreamWithCursor(ctx, &extpb.AccountDataStreamWithCursorRequest{
+			Filter:     filter,
+			FromCursor: proto.Uint64(100),
+		})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+}
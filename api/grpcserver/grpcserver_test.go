@@ -34,12 +34,16 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/fetch"
 	vm "github.com/spacemeshos/go-spacemesh/genvm"
+	"github.com/spacemeshos/go-spacemesh/genvm/core"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk/wallet"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	pubsubmocks "github.com/spacemeshos/go-spacemesh/p2p/pubsub/mocks"
@@ -325,6 +329,37 @@ func (t *ConStateAPIMock) GetProjection(types.Address) (uint64, uint64) {
 	return accountCounter + 1, accountBalance + 1
 }
 
+func (t *ConStateAPIMock) GetAccountProof(addr types.Address, layer types.LayerID) (*trie.AccountProof, error) {
+	balance, ok := t.balances[addr]
+	if !ok {
+		return nil, sql.ErrNotFound
+	}
+	return &trie.AccountProof{
+		Layer: layer,
+		Root:  stateRoot,
+		Account: types.Account{
+			Layer:     layer,
+			Address:   addr,
+			Balance:   balance.Uint64(),
+			NextNonce: t.nonces[addr],
+		},
+	}, nil
+}
+
+func (t *ConStateAPIMock) Simulate(raw []byte, _ bool) (*types.TransactionWithResult, error) {
+	tx := types.NewRawTx(raw)
+	stx, ok := t.returnTx[tx.ID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown tx", core.ErrMalformed)
+	}
+	rst := &types.TransactionWithResult{Transaction: *stx}
+	rst.Status = types.TransactionSuccess
+	rst.Gas = stx.MaxGas
+	rst.Fee = stx.MaxGas * stx.GasPrice
+	rst.Addresses = []types.Address{stx.Principal}
+	return rst, nil
+}
+
 func (t *ConStateAPIMock) GetAllAccounts() (res []*types.Account, err error) {
 	for address, balance := range t.balances {
 		res = append(res, &types.Account{
@@ -430,6 +465,10 @@ func (s *SmeshingAPIMock) UpdatePoETServers(context.Context, []string) error {
 	return s.UpdatePoETErr
 }
 
+func (*SmeshingAPIMock) PoetOutcomes() []activation.PoetOutcome {
+	return nil
+}
+
 func (*SmeshingAPIMock) Smeshing() bool {
 	return false
 }
@@ -630,13 +669,14 @@ func TestNodeService(t *testing.T) {
 
 func TestGlobalStateService(t *testing.T) {
 	logtest.SetupGlobal(t)
-	svc := NewGlobalStateService(meshAPIMock, conStateAPI)
+	svc := NewGlobalStateService(sql.InMemory(), meshAPIMock, conStateAPI)
 	t.Cleanup(launchServer(t, cfg, svc))
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 	conn := dialGrpc(ctx, t, cfg.PublicListener)
 	c := pb.NewGlobalStateServiceClient(conn)
+	ext := extpb.NewGlobalStateServiceClient(conn)
 
 	// Construct an array of test cases to test each endpoint in turn
 	testCases := []struct {
@@ -662,6 +702,30 @@ func TestGlobalStateService(t *testing.T) {
 			require.Equal(t, uint64(accountBalance+1), res.AccountWrapper.StateProjected.Balance.Value)
 			require.Equal(t, uint64(accountCounter+1), res.AccountWrapper.StateProjected.Counter)
 		}},
+		{"AccountProof", func(t *testing.T) {
+			logtest.SetupGlobal(t)
+			res, err := ext.AccountProof(context.Background(), &extpb.AccountProofRequest{
+				AccountId: &pb.AccountId{Address: addr1.String()},
+				Layer:     &pb.LayerNumber{Number: layerVerified.Uint32()},
+			})
+			require.NoError(t, err)
+			require.Equal(t, layerVerified.Uint32(), res.Layer.Number)
+			require.Equal(t, stateRoot.Bytes(), res.Root)
+			require.Equal(t, addr1.String(), res.Account.AccountId.Address)
+			require.Equal(t, uint64(accountBalance), res.Account.Balance.Value)
+			require.Equal(t, uint64(accountCounter), res.Account.Counter)
+
+			_, err = ext.AccountProof(context.Background(), &extpb.AccountProofRequest{
+				AccountId: &pb.AccountId{Address: types.GenerateAddress([]byte{1, 2, 3}).String()},
+				Layer:     &pb.LayerNumber{Number: layerVerified.Uint32()},
+			})
+			require.Equal(t, codes.NotFound, status.Code(err))
+
+			_, err = ext.AccountProof(context.Background(), &extpb.AccountProofRequest{
+				AccountId: &pb.AccountId{Address: addr1.String()},
+			})
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		}},
 		{"AccountDataQuery_MissingFilter", func(t *testing.T) {
 			logtest.SetupGlobal(t)
 			_, err := c.AccountDataQuery(context.Background(), &pb.AccountDataQueryRequest{})
@@ -739,15 +803,6 @@ func TestGlobalStateService(t *testing.T) {
 			checkAccountDataQueryItemReward(t, res.AccountItem[0].Datum)
 			checkAccountDataQueryItemAccount(t, res.AccountItem[1].Datum)
 		}},
-		{"AppEventStream", func(t *testing.T) {
-			logtest.SetupGlobal(t)
-			stream, err := c.AppEventStream(context.Background(), &pb.AppEventStreamRequest{})
-			// We expect to be able to open the stream but for it to fail upon the first request
-			require.NoError(t, err)
-			_, err = stream.Recv()
-			statusCode := status.Code(err)
-			require.Equal(t, codes.Unimplemented, statusCode)
-		}},
 		{name: "AccountDataStream", run: func(t *testing.T) {
 			logtest.SetupGlobal(t)
 			// common testing framework
@@ -1691,6 +1746,36 @@ func TestTransactionService_SubmitNoConcurrency(t *testing.T) {
 	}
 }
 
+func TestTransactionService_Simulate(t *testing.T) {
+	logtest.SetupGlobal(t)
+	ctrl := gomock.NewController(t)
+	svc := NewTransactionService(sql.InMemory(), pubsubmocks.NewMockPublisher(ctrl), meshAPIMock, conStateAPI,
+		NewMocksyncer(ctrl), NewMocktxValidator(ctrl))
+
+	t.Cleanup(launchServer(t, cfg, svc))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c := extpb.NewTransactionServiceClient(dialGrpc(ctx, t, cfg.PublicListener))
+
+	_, err := c.SimulateTransaction(ctx, &extpb.SimulateTransactionRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	res, err := c.SimulateTransaction(ctx, &extpb.SimulateTransactionRequest{
+		Transaction: globalTx.Raw,
+		WithPending: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.TransactionResult_SUCCESS, res.Result.Status)
+	require.Equal(t, globalTx.ID.Bytes(), res.Result.Tx.Id)
+	require.Equal(t, globalTx.MaxGas, res.Result.GasConsumed)
+	require.Equal(t, globalTx.MaxGas*globalTx.GasPrice, res.Result.Fee)
+	require.Equal(t, []string{globalTx.Principal.String()}, res.Result.TouchedAddresses)
+
+	_, err = c.SimulateTransaction(ctx, &extpb.SimulateTransactionRequest{Transaction: []byte{1, 2, 3}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestTransactionService(t *testing.T) {
 	logtest.SetupGlobal(t)
 
@@ -2110,7 +2195,7 @@ func TestAccountDataStream_comprehensive(t *testing.T) {
 	events.InitializeReporter()
 	t.Cleanup(events.CloseEventReporter)
 
-	svc := NewGlobalStateService(meshAPIMock, conStateAPI)
+	svc := NewGlobalStateService(sql.InMemory(), meshAPIMock, conStateAPI)
 	t.Cleanup(launchServer(t, cfg, svc))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -2169,7 +2254,7 @@ func TestGlobalStateStream_comprehensive(t *testing.T) {
 	events.InitializeReporter()
 	t.Cleanup(events.CloseEventReporter)
 
-	svc := NewGlobalStateService(meshAPIMock, conStateAPI)
+	svc := NewGlobalStateService(sql.InMemory(), meshAPIMock, conStateAPI)
 	t.Cleanup(launchServer(t, cfg, svc))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -2456,13 +2541,15 @@ func TestDebugService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	identity := NewMocknetworkIdentity(ctrl)
 	mOracle := NewMockoracle(ctrl)
-	svc := NewDebugService(conStateAPI, identity, mOracle)
+	mPeers := NewMockpeerScores(ctrl)
+	svc := NewDebugService(conStateAPI, identity, mOracle, WithPeerScores(mPeers))
 	t.Cleanup(launchServer(t, cfg, svc))
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 	conn := dialGrpc(ctx, t, cfg.PublicListener)
 	c := pb.NewDebugServiceClient(conn)
+	ext := extpb.NewDebugServiceClient(conn)
 
 	t.Run("Accounts", func(t *testing.T) {
 		res, err := c.Accounts(context.Background(), &empty.Empty{})
@@ -2502,6 +2589,28 @@ func TestDebugService(t *testing.T) {
 		}
 		require.ElementsMatch(t, activeSet, ids)
 	})
+	t.Run("PeerScores", func(t *testing.T) {
+		banned := time.Now().Add(time.Hour)
+		scores := []fetch.PeerScore{
+			{Peer: "best", Score: 0.9, Latency: time.Second, Requests: 10},
+			{Peer: "worst", Score: 0.1, Requests: 10, Invalid: 9, InvalidRate: 0.9, BannedUntil: banned},
+		}
+		mPeers.EXPECT().PeerScores().Return(scores)
+		res, err := ext.PeerScores(context.Background(), &extpb.PeerScoresRequest{})
+		require.NoError(t, err)
+		require.Len(t, res.Peers, 2)
+		require.Equal(t, scores[0].Peer.String(), res.Peers[0].Id)
+		require.Equal(t, 0.9, res.Peers[0].Score)
+		require.Equal(t, time.Second, res.Peers[0].Latency.AsDuration())
+		require.Nil(t, res.Peers[0].BannedUntil)
+		require.Equal(t, scores[1].Peer.String(), res.Peers[1].Id)
+		require.Equal(t, uint64(9), res.Peers[1].Invalid)
+		require.True(t, banned.Equal(res.Peers[1].BannedUntil.AsTime()))
+		require.Nil(t, res.Peers[1].BackoffUntil)
+
+		_, err = NewDebugService(conStateAPI, identity, mOracle).PeerScores(context.Background(), &extpb.PeerScoresRequest{})
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
 	t.Run("ProposalsStream", func(t *testing.T) {
 		events.InitializeReporter()
 		t.Cleanup(events.CloseEventReporter)
@@ -2533,7 +2642,7 @@ func TestEventsReceived(t *testing.T) {
 	t.Cleanup(events.CloseEventReporter)
 
 	txService := NewTransactionService(sql.InMemory(), nil, meshAPIMock, conStateAPI, nil, nil)
-	gsService := NewGlobalStateService(meshAPIMock, conStateAPI)
+	gsService := NewGlobalStateService(sql.InMemory(), meshAPIMock, conStateAPI)
 	t.Cleanup(launchServer(t, cfg, txService, gsService))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -2612,7 +2721,7 @@ func TestTransactionsRewards(t *testing.T) {
 	events.InitializeReporter()
 	t.Cleanup(events.CloseEventReporter)
 
-	t.Cleanup(launchServer(t, cfg, NewGlobalStateService(meshAPIMock, conStateAPI)))
+	t.Cleanup(launchServer(t, cfg, NewGlobalStateService(sql.InMemory(), meshAPIMock, conStateAPI)))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	t.Cleanup(cancel)
@@ -2679,7 +2788,7 @@ func TestVMAccountUpdates(t *testing.T) {
 	require.NoError(t, err)
 	t.Cleanup(func() { db.Close() })
 	svm := vm.New(db, vm.WithLogger(logtest.New(t)))
-	t.Cleanup(launchServer(t, cfg, NewGlobalStateService(nil, txs.NewConservativeState(svm, db))))
+	t.Cleanup(launchServer(t, cfg, NewGlobalStateService(db, nil, txs.NewConservativeState(svm, db))))
 
 	keys := make([]*signing.EdSigner, 10)
 	accounts := make([]types.Account, len(keys))
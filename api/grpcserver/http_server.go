@@ -18,12 +18,37 @@ import (
 type JSONHTTPServer struct {
 	mu       sync.RWMutex
 	listener string
+	auth     *Auth
+	limiter  *Limiter
 	server   *http.Server
 }
 
+// JSONHTTPOpt is an option for the json http server.
+type JSONHTTPOpt func(*JSONHTTPServer)
+
+// WithAuth enables TLS and authentication for the json http server,
+// the same way as for the grpc listener with the same auth.
+func WithAuth(auth *Auth) JSONHTTPOpt {
+	return func(s *JSONHTTPServer) {
+		s.auth = auth
+	}
+}
+
+// WithLimiter applies the limits to the json http server. Limiter can be shared with
+// the grpc listener so that clients have the same budget for both.
+func WithLimiter(limiter *Limiter) JSONHTTPOpt {
+	return func(s *JSONHTTPServer) {
+		s.limiter = limiter
+	}
+}
+
 // NewJSONHTTPServer creates a new json http server.
-func NewJSONHTTPServer(listener string) *JSONHTTPServer {
-	return &JSONHTTPServer{listener: listener}
+func NewJSONHTTPServer(listener string, opts ...JSONHTTPOpt) *JSONHTTPServer {
+	s := &JSONHTTPServer{listener: listener}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Shutdown stops the server.
@@ -104,13 +129,20 @@ func (s *JSONHTTPServer) startInternal(
 	}
 
 	log.With().Info("starting grpc gateway server", log.String("address", s.listener))
-	s.setServer(&http.Server{
-		Addr:    s.listener,
-		Handler: mux,
-	})
+	server := &http.Server{
+		Addr:      s.listener,
+		Handler:   s.limiter.Handler(s.auth.Handler(mux)),
+		TLSConfig: s.auth.TLSConfig(),
+	}
+	s.setServer(server)
 
 	// This will block
-	log.Error("error from grpc http listener: %v", s.getServer().ListenAndServe())
+	if server.TLSConfig != nil {
+		// certificates are already in the tls config
+		log.Error("error from grpc http listener: %v", server.ListenAndServeTLS("", ""))
+	} else {
+		log.Error("error from grpc http listener: %v", server.ListenAndServe())
+	}
 }
 
 func (s *JSONHTTPServer) getServer() *http.Server {
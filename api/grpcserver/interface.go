@@ -6,7 +6,10 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/activation"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/fetch"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
@@ -25,6 +28,8 @@ type conservativeState interface {
 	GetBalance(types.Address) (uint64, error)
 	GetNonce(types.Address) (types.Nonce, error)
 	GetProjection(types.Address) (uint64, uint64)
+	GetAccountProof(types.Address, types.LayerID) (*trie.AccountProof, error)
+	Simulate([]byte, bool) (*types.TransactionWithResult, error)
 	GetMeshTransaction(types.TransactionID) (*types.MeshTransaction, error)
 	GetMeshTransactions([]types.TransactionID) ([]*types.MeshTransaction, map[types.TransactionID]struct{})
 	GetTransactionsByAddress(types.LayerID, types.LayerID, types.Address) ([]*types.MeshTransaction, error)
@@ -46,6 +51,18 @@ type atxProvider interface {
 	GetFullAtx(id types.ATXID) (*types.VerifiedActivationTx, error)
 }
 
+// epochATXs is an API to get the ATXs and their weight in an epoch.
+type epochATXs interface {
+	GetEpochAtx(types.EpochID, types.NodeID) (*types.ActivationTxHeader, error)
+	GetEpochWeight(types.EpochID) (uint64, []types.ATXID, error)
+}
+
+// mempoolPolicy is an API to read and update the policy of the mempool.
+type mempoolPolicy interface {
+	MinGasPrice() uint64
+	SetMinGasPrice(uint64)
+}
+
 type postSetupProvider interface {
 	Status() *activation.PostSetupStatus
 	Providers() ([]activation.PostSetupProvider, error)
@@ -53,6 +70,30 @@ type postSetupProvider interface {
 	Config() activation.PostConfig
 }
 
+// peerScores is an API to get the scores of the peers used to fetch data.
+type peerScores interface {
+	PeerScores() []fetch.PeerScore
+}
+
+// accessList is an API to update the access list of the p2p connection gater.
+type accessList interface {
+	Add(p2p.AccessEntry) error
+	Remove(p2p.AccessKind, string) error
+	Entries() []p2p.AccessEntry
+}
+
+// databaseBackup is an API to make an online backup of the node database.
+type databaseBackup interface {
+	Backup(ctx context.Context, path string) error
+}
+
+// databaseMigrations is an API to inspect and roll back migrations of the node database.
+type databaseMigrations interface {
+	Status() ([]sql.MigrationStatus, error)
+	DryRun() ([]sql.MigrationStatus, error)
+	Rollback() (*sql.MigrationStatus, error)
+}
+
 // peerCounter is an api to get amount of connected peers.
 type peerCounter interface {
 	PeerCount() uint64
@@ -0,0 +1,325 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	budgetLimit      = "budget"
+	methodLimit      = "method"
+	streamsLimit     = "streams"
+	concurrencyLimit = "concurrency"
+
+	// clients that didn't make calls for that long are forgotten.
+	idleClient = 10 * time.Minute
+)
+
+// LimitsConfig configures rate and concurrency limits for the listener.
+// Clients are identified by ip address, zero values disable the corresponding limit.
+type LimitsConfig struct {
+	// ClientRate is the budget that is replenished for a client every second.
+	ClientRate float64 `mapstructure:"client-rate"`
+	// ClientBurst is the maximal budget that a client can accumulate.
+	ClientBurst int `mapstructure:"client-burst"`
+	// ClientStreams is the maximal number of streams that a client can keep open concurrently.
+	ClientStreams int `mapstructure:"client-streams"`
+	// MaxConcurrent is the maximal number of unary calls that are served concurrently for all clients.
+	MaxConcurrent int `mapstructure:"max-concurrent"`
+	// Methods are limits for particular methods. Limits are applied to the corresponding
+	// json gateway endpoints as well.
+	// It is a list rather than a map keyed by the method, as viper lowercases map keys
+	// and splits them on dots.
+	Methods []MethodLimits `mapstructure:"methods"`
+}
+
+// MethodLimits are limits for a single grpc method.
+type MethodLimits struct {
+	// Method is the full grpc method name, for example /spacemesh.v1.MeshService/LayersQuery.
+	// It is case insensitive.
+	Method string `mapstructure:"method"`
+	// Cost of a call charged from the client budget. Defaults to 1.
+	Cost int `mapstructure:"cost"`
+	// Rate is the number of calls per second allowed for a client, Burst is the maximal number
+	// of calls allowed at once.
+	Rate  float64 `mapstructure:"rate"`
+	Burst int     `mapstructure:"burst"`
+}
+
+// DefaultPublicLimits returns limits for the public listener. Queries that read
+// the whole history from the database are more expensive than the rest.
+func DefaultPublicLimits() LimitsConfig {
+	return LimitsConfig{
+		ClientRate:    100,
+		ClientBurst:   200,
+		ClientStreams: 20,
+		MaxConcurrent: 100,
+		Methods: []MethodLimits{
+			{Method: "/spacemesh.v1.MeshService/LayersQuery", Cost: 20},
+			{Method: "/spacemesh.v1.MeshService/AccountMeshDataQuery", Cost: 20},
+			{Method: "/spacemesh.v1.GlobalStateService/AccountDataQuery", Cost: 10},
+			{Method: "/spacemesh.v1.GlobalStateService/SmesherDataQuery", Cost: 10},
+			{Method: "/spacemesh.v1.TransactionService/TransactionsState", Cost: 5},
+			{Method: "/spacemesh.v1.TransactionService/SubmitTransaction", Cost: 5},
+		},
+	}
+}
+
+func (m MethodLimits) cost() int {
+	if m.Cost > 0 {
+		return m.Cost
+	}
+	return 1
+}
+
+// Validate checks that every call can be served within the limits.
+func (c LimitsConfig) Validate() error {
+	methods := map[string]struct{}{}
+	for _, limits := range c.Methods {
+		if len(limits.Method) == 0 {
+			return fmt.Errorf("method limits without a method")
+		}
+		if _, exists := methods[strings.ToLower(limits.Method)]; exists {
+			return fmt.Errorf("limits for %s are configured more than once", limits.Method)
+		}
+		methods[strings.ToLower(limits.Method)] = struct{}{}
+		if c.ClientRate > 0 && limits.cost() > c.ClientBurst {
+			return fmt.Errorf("cost %d of %s exceeds client burst %d", limits.cost(), limits.Method, c.ClientBurst)
+		}
+		if limits.Rate > 0 && limits.Burst < 1 {
+			return fmt.Errorf("burst for %s must be at least 1", limits.Method)
+		}
+	}
+	return nil
+}
+
+// gatewayPath returns the path of the json gateway endpoint for the grpc method.
+// Endpoints follow the same convention in all services, /spacemesh.v1.MeshService/LayersQuery
+// is served on /v1/mesh/layersquery.
+func gatewayPath(method string) string {
+	parts := strings.Split(strings.TrimPrefix(strings.ToLower(method), "/"), "/")
+	if len(parts) != 2 {
+		return ""
+	}
+	pkg := strings.LastIndex(parts[0], ".")
+	if pkg < 0 {
+		return ""
+	}
+	version := parts[0][strings.LastIndex(parts[0][:pkg], ".")+1 : pkg]
+	service := strings.TrimSuffix(parts[0][pkg+1:], "service")
+	return fmt.Sprintf("/%s/%s/%s", version, service, parts[1])
+}
+
+type clientLimits struct {
+	budget   *rate.Limiter
+	methods  map[string]*rate.Limiter
+	streams  int
+	lastSeen time.Time
+}
+
+// Limiter enforces rate limits, stream caps and request cost budgets configured for the listener.
+type Limiter struct {
+	cfg LimitsConfig
+	now func() time.Time
+	// methods are limits keyed by the lowercased grpc method.
+	methods map[string]MethodLimits
+	// paths maps json gateway endpoints to grpc methods.
+	paths map[string]string
+
+	mu         sync.Mutex
+	clients    map[string]*clientLimits
+	concurrent int
+	lastSweep  time.Time
+}
+
+// NewLimiter creates a limiter for the listener.
+func NewLimiter(cfg LimitsConfig) (*Limiter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	l := &Limiter{
+		cfg:     cfg,
+		now:     time.Now,
+		methods: map[string]MethodLimits{},
+		paths:   map[string]string{},
+		clients: map[string]*clientLimits{},
+	}
+	for _, limits := range cfg.Methods {
+		method := strings.ToLower(limits.Method)
+		l.methods[method] = limits
+		if path := gatewayPath(method); len(path) > 0 {
+			l.paths[path] = method
+		}
+	}
+	return l, nil
+}
+
+// ServerOptions returns grpc options with interceptors that reject calls over the limits
+// with ResourceExhausted.
+func (l *Limiter) ServerOptions() []grpc.ServerOption {
+	if l == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(l.unaryInterceptor),
+		grpc.ChainStreamInterceptor(l.streamInterceptor),
+	}
+}
+
+// Handler applies the limits to the json gateway requests and rejects them with 429.
+func (l *Limiter) Handler(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			client = r.RemoteAddr
+		}
+		method := l.paths[r.URL.Path]
+		if method == "" {
+			method = r.URL.Path
+		}
+		release, err := l.acquire(client, method, false)
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), http.StatusTooManyRequests)
+			return
+		}
+		defer release()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func clientFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+func (l *Limiter) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	release, err := l.acquire(clientFromContext(ctx), info.FullMethod, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
+func (l *Limiter) streamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	release, err := l.acquire(clientFromContext(stream.Context()), info.FullMethod, true)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return handler(srv, stream)
+}
+
+func (l *Limiter) client(id string, now time.Time) *clientLimits {
+	if now.Sub(l.lastSweep) > idleClient {
+		for key, client := range l.clients {
+			if client.streams == 0 && now.Sub(client.lastSeen) > idleClient {
+				delete(l.clients, key)
+				trackedClients.Dec()
+			}
+		}
+		l.lastSweep = now
+	}
+	client, exists := l.clients[id]
+	if !exists {
+		client = &clientLimits{methods: map[string]*rate.Limiter{}}
+		if l.cfg.ClientRate > 0 {
+			client.budget = rate.NewLimiter(rate.Limit(l.cfg.ClientRate), l.cfg.ClientBurst)
+		}
+		l.clients[id] = client
+		trackedClients.Inc()
+	}
+	client.lastSeen = now
+	return client
+}
+
+// acquire checks the limits for the call and returns a function that must be called
+// once the call is served.
+func (l *Limiter) acquire(id, method string, stream bool) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	method = strings.ToLower(method)
+	client := l.client(id, now)
+	if stream && l.cfg.ClientStreams > 0 && client.streams >= l.cfg.ClientStreams {
+		return nil, limited(streamsLimit, "too many open streams")
+	}
+	if !stream && l.cfg.MaxConcurrent > 0 && l.concurrent >= l.cfg.MaxConcurrent {
+		return nil, limited(concurrencyLimit, "too many concurrent requests")
+	}
+	limits, exists := l.methods[method]
+	var limiter *rate.Limiter
+	if exists && limits.Rate > 0 {
+		limiter = client.methods[method]
+		if limiter == nil {
+			limiter = rate.NewLimiter(rate.Limit(limits.Rate), limits.Burst)
+			client.methods[method] = limiter
+		}
+	}
+	var reservation *rate.Reservation
+	if limiter != nil {
+		if reservation = limiter.ReserveN(now, 1); reservation.DelayFrom(now) > 0 {
+			reservation.CancelAt(now)
+			return nil, limited(methodLimit, fmt.Sprintf("rate limit for %s exceeded", limits.Method))
+		}
+	}
+	if client.budget != nil && !client.budget.AllowN(now, limits.cost()) {
+		if reservation != nil {
+			reservation.CancelAt(now)
+		}
+		return nil, limited(budgetLimit, "request budget exceeded")
+	}
+	if stream {
+		client.streams++
+		openStreams.Inc()
+		return func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			client.streams--
+			openStreams.Dec()
+		}, nil
+	}
+	l.concurrent++
+	concurrentCalls.Inc()
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.concurrent--
+		concurrentCalls.Dec()
+	}, nil
+}
+
+func limited(reason, msg string) error {
+	limitedCalls.WithLabelValues(reason).Inc()
+	return status.Error(codes.ResourceExhausted, msg)
+}
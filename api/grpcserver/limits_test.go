@@ -0,0 +1,276 @@
+package grpcserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testMethod   = "/spacemesh.v1.MeshService/LayersQuery"
+	testClient   = "10.0.0.1"
+	otherClient  = "10.0.0.2"
+	cheapMethod  = "/spacemesh.v1.NodeService/Echo"
+	streamMethod = "/spacemesh.v1.MeshService/LayerStream"
+)
+
+func newTestLimiter(tb testing.TB, cfg LimitsConfig) (*Limiter, *time.Time) {
+	tb.Helper()
+	l, err := NewLimiter(cfg)
+	require.NoError(tb, err)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func requireLimited(tb testing.TB, l *Limiter, id, method string, stream bool) {
+	tb.Helper()
+	release, err := l.acquire(id, method, stream)
+	require.Nil(tb, release)
+	require.Equal(tb, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestGatewayPath(t *testing.T) {
+	require.Equal(t, "/v1/mesh/layersquery", gatewayPath("/spacemesh.v1.MeshService/LayersQuery"))
+	require.Equal(t, "/v1/globalstate/accountdataquery", gatewayPath("/spacemesh.v1.GlobalStateService/AccountDataQuery"))
+	require.Equal(t, "/v2alpha1/node/echo", gatewayPath("/spacemesh.v2alpha1.NodeService/Echo"))
+	require.Empty(t, gatewayPath("LayersQuery"))
+	require.Empty(t, gatewayPath("/MeshService/LayersQuery"))
+}
+
+func TestLimitsConfig_Validate(t *testing.T) {
+	require.NoError(t, DefaultPublicLimits().Validate())
+	require.NoError(t, LimitsConfig{}.Validate())
+
+	cfg := LimitsConfig{ClientRate: 1, ClientBurst: 5, Methods: []MethodLimits{{Method: testMethod, Cost: 10}}}
+	require.ErrorContains(t, cfg.Validate(), "exceeds client burst")
+	cfg = LimitsConfig{Methods: []MethodLimits{{Method: testMethod, Rate: 1}}}
+	require.ErrorContains(t, cfg.Validate(), "burst")
+	_, err := NewLimiter(cfg)
+	require.Error(t, err)
+	cfg = LimitsConfig{Methods: []MethodLimits{{Cost: 2}}}
+	require.ErrorContains(t, cfg.Validate(), "without a method")
+	cfg = LimitsConfig{Methods: []MethodLimits{{Method: testMethod}, {Method: strings.ToLower(testMethod)}}}
+	require.ErrorContains(t, cfg.Validate(), "more than once")
+}
+
+func TestLimitsConfig_Viper(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("json")
+	require.NoError(t, v.ReadConfig(strings.NewReader(`{
+		"api": {
+			"grpc-public-limits": {
+				"client-rate": 10,
+				"client-burst": 20,
+				"methods": [
+					{"method": "/spacemesh.v1.MeshService/LayersQuery", "cost": 15},
+					{"method": "/spacemesh.v1.NodeService/Echo", "rate": 1, "burst": 1}
+				]
+			}
+		}
+	}`)))
+	conf := struct {
+		API Config `mapstructure:"api"`
+	}{API: DefaultConfig()}
+	require.NoError(t, v.Unmarshal(&conf, func(cfg *mapstructure.DecoderConfig) {
+		cfg.ZeroFields = true
+	}))
+	require.Equal(t, []MethodLimits{
+		{Method: testMethod, Cost: 15},
+		{Method: cheapMethod, Rate: 1, Burst: 1},
+	}, conf.API.PublicLimits.Methods)
+
+	l, _ := newTestLimiter(t, conf.API.PublicLimits)
+	release, err := l.acquire(testClient, testMethod, false)
+	require.NoError(t, err)
+	release()
+	requireLimited(t, l, testClient, testMethod, false)
+
+	release, err = l.acquire(otherClient, cheapMethod, false)
+	require.NoError(t, err)
+	release()
+	requireLimited(t, l, otherClient, cheapMethod, false)
+}
+
+func TestLimiter_CaseInsensitive(t *testing.T) {
+	l, _ := newTestLimiter(t, LimitsConfig{
+		Methods: []MethodLimits{{Method: strings.ToLower(testMethod), Rate: 1, Burst: 1}},
+	})
+	release, err := l.acquire(testClient, testMethod, false)
+	require.NoError(t, err)
+	release()
+	requireLimited(t, l, testClient, testMethod, false)
+	require.Equal(t, strings.ToLower(testMethod), l.paths["/v1/mesh/layersquery"])
+}
+
+func TestLimiter_Nil(t *testing.T) {
+	var l *Limiter
+	require.Empty(t, l.ServerOptions())
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	require.NotNil(t, l.Handler(handler))
+}
+
+func TestLimiter_Budget(t *testing.T) {
+	l, now := newTestLimiter(t, LimitsConfig{
+		ClientRate:  10,
+		ClientBurst: 20,
+		Methods:     []MethodLimits{{Method: testMethod, Cost: 15}},
+	})
+	release, err := l.acquire(testClient, testMethod, false)
+	require.NoError(t, err)
+	release()
+	requireLimited(t, l, testClient, testMethod, false)
+
+	// remaining budget is enough for the cheap calls
+	for i := 0; i < 5; i++ {
+		release, err := l.acquire(testClient, cheapMethod, false)
+		require.NoError(t, err)
+		release()
+	}
+	requireLimited(t, l, testClient, cheapMethod, false)
+
+	// other clients have their own budget
+	release, err = l.acquire(otherClient, testMethod, false)
+	require.NoError(t, err)
+	release()
+
+	*now = now.Add(2 * time.Second)
+	release, err = l.acquire(testClient, testMethod, false)
+	require.NoError(t, err)
+	release()
+}
+
+func TestLimiter_MethodRate(t *testing.T) {
+	l, now := newTestLimiter(t, LimitsConfig{
+		Methods: []MethodLimits{{Method: testMethod, Rate: 1, Burst: 2}},
+	})
+	for i := 0; i < 2; i++ {
+		release, err := l.acquire(testClient, testMethod, false)
+		require.NoError(t, err)
+		release()
+	}
+	requireLimited(t, l, testClient, testMethod, false)
+
+	release, err := l.acquire(testClient, cheapMethod, false)
+	require.NoError(t, err)
+	release()
+
+	*now = now.Add(time.Second)
+	release, err = l.acquire(testClient, testMethod, false)
+	require.NoError(t, err)
+	release()
+	requireLimited(t, l, testClient, testMethod, false)
+}
+
+func TestLimiter_RejectedCallIsNotCharged(t *testing.T) {
+	l, _ := newTestLimiter(t, LimitsConfig{
+		ClientRate:  1,
+		ClientBurst: 3,
+		Methods:     []MethodLimits{{Method: testMethod, Rate: 1, Burst: 1}},
+	})
+	release, err := l.acquire(testClient, testMethod, false)
+	require.NoError(t, err)
+	release()
+	requireLimited(t, l, testClient, testMethod, false)
+	for i := 0; i < 2; i++ {
+		release, err := l.acquire(testClient, cheapMethod, false)
+		require.NoError(t, err)
+		release()
+	}
+}
+
+func TestLimiter_Streams(t *testing.T) {
+	l, _ := newTestLimiter(t, LimitsConfig{ClientStreams: 2, MaxConcurrent: 1})
+	var releases []func()
+	for i := 0; i < 2; i++ {
+		release, err := l.acquire(testClient, streamMethod, true)
+		require.NoError(t, err)
+		releases = append(releases, release)
+	}
+	requireLimited(t, l, testClient, streamMethod, true)
+
+	// streams are not counted as concurrent unary calls
+	release, err := l.acquire(testClient, cheapMethod, false)
+	require.NoError(t, err)
+	release()
+
+	release, err = l.acquire(otherClient, streamMethod, true)
+	require.NoError(t, err)
+	release()
+
+	releases[0]()
+	release, err = l.acquire(testClient, streamMethod, true)
+	require.NoError(t, err)
+	release()
+	releases[1]()
+}
+
+func TestLimiter_Concurrency(t *testing.T) {
+	l, _ := newTestLimiter(t, LimitsConfig{MaxConcurrent: 2})
+	first, err := l.acquire(testClient, cheapMethod, false)
+	require.NoError(t, err)
+	second, err := l.acquire(otherClient, cheapMethod, false)
+	require.NoError(t, err)
+	requireLimited(t, l, testClient, cheapMethod, false)
+	first()
+	release, err := l.acquire(testClient, cheapMethod, false)
+	require.NoError(t, err)
+	release()
+	second()
+}
+
+func TestLimiter_IdleClients(t *testing.T) {
+	l, now := newTestLimiter(t, LimitsConfig{ClientRate: 1, ClientBurst: 1})
+	release, err := l.acquire(testClient, cheapMethod, false)
+	require.NoError(t, err)
+	release()
+	stream, err := l.acquire(otherClient, streamMethod, true)
+	require.NoError(t, err)
+	require.Len(t, l.clients, 2)
+
+	*now = now.Add(2 * idleClient)
+	release, err = l.acquire(testClient, cheapMethod, false)
+	require.NoError(t, err)
+	release()
+	// client with an open stream is not forgotten
+	require.Len(t, l.clients, 2)
+
+	stream()
+	*now = now.Add(2 * idleClient)
+	release, err = l.acquire(testClient, cheapMethod, false)
+	require.NoError(t, err)
+	release()
+	require.Len(t, l.clients, 1)
+}
+
+func TestLimiter_Handler(t *testing.T) {
+	l, _ := newTestLimiter(t, LimitsConfig{
+		ClientRate:  1,
+		ClientBurst: 10,
+		Methods:     []MethodLimits{{Method: testMethod, Cost: 10}},
+	})
+	served := 0
+	handler := l.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+	}))
+	serve := func(path, remote string) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.RemoteAddr = remote
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	require.Equal(t, http.StatusOK, serve("/v1/mesh/layersquery", testClient+":1000"))
+	// clients are identified by ip, port is ignored
+	require.Equal(t, http.StatusTooManyRequests, serve("/v1/node/echo", testClient+":1001"))
+	require.Equal(t, http.StatusOK, serve("/v1/node/echo", otherClient+":1000"))
+	require.Equal(t, 2, served)
+}
@@ -6,9 +6,11 @@ import (
 	"time"
 
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/log"
@@ -29,6 +31,7 @@ type MeshService struct {
 // RegisterService registers this service with a grpc server instance.
 func (s MeshService) RegisterService(server *Server) {
 	pb.RegisterMeshServiceServer(server.GrpcServer, s)
+	extpb.RegisterMeshServiceServer(server.GrpcServer, s)
 }
 
 // NewMeshService creates a new service using config data.
@@ -517,32 +520,41 @@ func (s MeshService) AccountMeshDataStream(in *pb.AccountMeshDataStreamRequest,
 // LayerStream exposes a stream of all mesh data per layer.
 func (s MeshService) LayerStream(_ *pb.LayerStreamRequest, stream pb.MeshService_LayerStreamServer) error {
 	log.Info("GRPC MeshService.LayerStream")
+	return s.streamLayers(stream, nil, func(layer *pb.Layer, _ uint64) error {
+		return stream.Send(&pb.LayerStreamResponse{Layer: layer})
+	})
+}
 
-	var (
-		layerCh       <-chan events.LayerUpdate
-		layersBufFull <-chan struct{}
-	)
+// LayerStreamWithCursor exposes a stream of all mesh data per layer with the journal cursor of every layer.
+func (s MeshService) LayerStreamWithCursor(in *extpb.LayerStreamWithCursorRequest, stream extpb.MeshService_LayerStreamWithCursorServer) error {
+	log.Info("GRPC MeshService.LayerStreamWithCursor")
+	return s.streamLayers(stream, in.FromCursor, func(layer *pb.Layer, cursor uint64) error {
+		return stream.Send(&extpb.LayerStreamWithCursorResponse{Layer: layer, Cursor: cursor})
+	})
+}
 
-	if layersSubscription := events.SubscribeLayers(); layersSubscription != nil {
-		layerCh, layersBufFull = consumeEvents[events.LayerUpdate](stream.Context(), layersSubscription)
+func (s MeshService) streamLayers(stream grpc.ServerStream, from *uint64, send func(*pb.Layer, uint64) error) error {
+	sub, _, err := subscribeJournal(stream, from, func(rec *events.Record) bool {
+		return rec.Layer != nil
+	})
+	if err != nil {
+		return err
 	}
+	defer sub.Close()
 
 	for {
 		select {
-		case <-layersBufFull:
+		case <-sub.Full():
 			log.Info("layer buffer is full, shutting down")
-			return status.Error(codes.Canceled, errAccountBufferFull)
-		case layer, ok := <-layerCh:
-			if !ok {
-				log.Info("LayerStream closed, shutting down")
-				return nil
-			}
+			return status.Error(codes.Canceled, errLayerBufferFull)
+		case rec := <-sub.Out():
+			layer := rec.Layer
 			pbLayer, err := s.readLayer(stream.Context(), layer.LayerID, convertLayerStatus(layer.Status))
 			if err != nil {
 				return fmt.Errorf("read layer: %w", err)
 			}
 
-			if err := stream.Send(&pb.LayerStreamResponse{Layer: pbLayer}); err != nil {
+			if err := send(pbLayer, rec.Cursor); err != nil {
 				return fmt.Errorf("send to stream: %w", err)
 			}
 		case <-stream.Context().Done():
@@ -0,0 +1,34 @@
+package grpcserver
+
+import (
+	"github.com/spacemeshos/go-spacemesh/metrics"
+)
+
+const subsystem = "api"
+
+var (
+	limitedCalls = metrics.NewCounter(
+		"limited_calls",
+		subsystem,
+		"number of calls rejected by the limits",
+		[]string{"reason"},
+	)
+	concurrentCalls = metrics.NewGauge(
+		"concurrent_calls",
+		subsystem,
+		"number of unary calls that are served concurrently",
+		nil,
+	).WithLabelValues()
+	openStreams = metrics.NewGauge(
+		"open_streams",
+		subsystem,
+		"number of open streams",
+		nil,
+	).WithLabelValues()
+	trackedClients = metrics.NewGauge(
+		"tracked_clients",
+		subsystem,
+		"number of clients tracked by the limits",
+		nil,
+	).WithLabelValues()
+)
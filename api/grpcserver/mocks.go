@@ -12,7 +12,10 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	activation "github.com/spacemeshos/go-spacemesh/activation"
 	types "github.com/spacemeshos/go-spacemesh/common/types"
+	fetch "github.com/spacemeshos/go-spacemesh/fetch"
+	trie "github.com/spacemeshos/go-spacemesh/genvm/trie"
 	p2p "github.com/spacemeshos/go-spacemesh/p2p"
+	sql "github.com/spacemeshos/go-spacemesh/sql"
 	system "github.com/spacemeshos/go-spacemesh/system"
 )
 
@@ -76,6 +79,21 @@ func (m *MockconservativeState) EXPECT() *MockconservativeStateMockRecorder {
 	return m.recorder
 }
 
+// GetAccountProof mocks base method.
+func (m *MockconservativeState) GetAccountProof(arg0 types.Address, arg1 types.LayerID) (*trie.AccountProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountProof", arg0, arg1)
+	ret0, _ := ret[0].(*trie.AccountProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountProof indicates an expected call of GetAccountProof.
+func (mr *MockconservativeStateMockRecorder) GetAccountProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountProof", reflect.TypeOf((*MockconservativeState)(nil).GetAccountProof), arg0, arg1)
+}
+
 // GetAllAccounts mocks base method.
 func (m *MockconservativeState) GetAllAccounts() ([]*types.Account, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +229,21 @@ func (mr *MockconservativeStateMockRecorder) GetTransactionsByAddress(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsByAddress", reflect.TypeOf((*MockconservativeState)(nil).GetTransactionsByAddress), arg0, arg1, arg2)
 }
 
+// Simulate mocks base method.
+func (m *MockconservativeState) Simulate(arg0 []byte, arg1 bool) (*types.TransactionWithResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Simulate", arg0, arg1)
+	ret0, _ := ret[0].(*types.TransactionWithResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Simulate indicates an expected call of Simulate.
+func (mr *MockconservativeStateMockRecorder) Simulate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Simulate", reflect.TypeOf((*MockconservativeState)(nil).Simulate), arg0, arg1)
+}
+
 // Validation mocks base method.
 func (m *MockconservativeState) Validation(raw types.RawTx) system.ValidationRequest {
 	m.ctrl.T.Helper()
@@ -337,6 +370,109 @@ func (mr *MockatxProviderMockRecorder) GetFullAtx(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullAtx", reflect.TypeOf((*MockatxProvider)(nil).GetFullAtx), id)
 }
 
+// MockepochATXs is a mock of epochATXs interface.
+type MockepochATXs struct {
+	ctrl     *gomock.Controller
+	recorder *MockepochATXsMockRecorder
+}
+
+// MockepochATXsMockRecorder is the mock recorder for MockepochATXs.
+type MockepochATXsMockRecorder struct {
+	mock *MockepochATXs
+}
+
+// NewMockepochATXs creates a new mock instance.
+func NewMockepochATXs(ctrl *gomock.Controller) *MockepochATXs {
+	mock := &MockepochATXs{ctrl: ctrl}
+	mock.recorder = &MockepochATXsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockepochATXs) EXPECT() *MockepochATXsMockRecorder {
+	return m.recorder
+}
+
+// GetEpochAtx mocks base method.
+func (m *MockepochATXs) GetEpochAtx(arg0 types.EpochID, arg1 types.NodeID) (*types.ActivationTxHeader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpochAtx", arg0, arg1)
+	ret0, _ := ret[0].(*types.ActivationTxHeader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpochAtx indicates an expected call of GetEpochAtx.
+func (mr *MockepochATXsMockRecorder) GetEpochAtx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpochAtx", reflect.TypeOf((*MockepochATXs)(nil).GetEpochAtx), arg0, arg1)
+}
+
+// GetEpochWeight mocks base method.
+func (m *MockepochATXs) GetEpochWeight(arg0 types.EpochID) (uint64, []types.ATXID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpochWeight", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].([]types.ATXID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEpochWeight indicates an expected call of GetEpochWeight.
+func (mr *MockepochATXsMockRecorder) GetEpochWeight(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpochWeight", reflect.TypeOf((*MockepochATXs)(nil).GetEpochWeight), arg0)
+}
+
+// MockmempoolPolicy is a mock of mempoolPolicy interface.
+type MockmempoolPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockmempoolPolicyMockRecorder
+}
+
+// MockmempoolPolicyMockRecorder is the mock recorder for MockmempoolPolicy.
+type MockmempoolPolicyMockRecorder struct {
+	mock *MockmempoolPolicy
+}
+
+// NewMockmempoolPolicy creates a new mock instance.
+func NewMockmempoolPolicy(ctrl *gomock.Controller) *MockmempoolPolicy {
+	mock := &MockmempoolPolicy{ctrl: ctrl}
+	mock.recorder = &MockmempoolPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmempoolPolicy) EXPECT() *MockmempoolPolicyMockRecorder {
+	return m.recorder
+}
+
+// MinGasPrice mocks base method.
+func (m *MockmempoolPolicy) MinGasPrice() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinGasPrice")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// MinGasPrice indicates an expected call of MinGasPrice.
+func (mr *MockmempoolPolicyMockRecorder) MinGasPrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinGasPrice", reflect.TypeOf((*MockmempoolPolicy)(nil).MinGasPrice))
+}
+
+// SetMinGasPrice mocks base method.
+func (m *MockmempoolPolicy) SetMinGasPrice(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMinGasPrice", arg0)
+}
+
+// SetMinGasPrice indicates an expected call of SetMinGasPrice.
+func (mr *MockmempoolPolicyMockRecorder) SetMinGasPrice(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinGasPrice", reflect.TypeOf((*MockmempoolPolicy)(nil).SetMinGasPrice), arg0)
+}
+
 // MockpostSetupProvider is a mock of postSetupProvider interface.
 type MockpostSetupProvider struct {
 	ctrl     *gomock.Controller
@@ -418,6 +554,213 @@ func (mr *MockpostSetupProviderMockRecorder) Status() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockpostSetupProvider)(nil).Status))
 }
 
+// MockpeerScores is a mock of peerScores interface.
+type MockpeerScores struct {
+	ctrl     *gomock.Controller
+	recorder *MockpeerScoresMockRecorder
+}
+
+// MockpeerScoresMockRecorder is the mock recorder for MockpeerScores.
+type MockpeerScoresMockRecorder struct {
+	mock *MockpeerScores
+}
+
+// NewMockpeerScores creates a new mock instance.
+func NewMockpeerScores(ctrl *gomock.Controller) *MockpeerScores {
+	mock := &MockpeerScores{ctrl: ctrl}
+	mock.recorder = &MockpeerScoresMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpeerScores) EXPECT() *MockpeerScoresMockRecorder {
+	return m.recorder
+}
+
+// PeerScores mocks base method.
+func (m *MockpeerScores) PeerScores() []fetch.PeerScore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerScores")
+	ret0, _ := ret[0].([]fetch.PeerScore)
+	return ret0
+}
+
+// PeerScores indicates an expected call of PeerScores.
+func (mr *MockpeerScoresMockRecorder) PeerScores() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerScores", reflect.TypeOf((*MockpeerScores)(nil).PeerScores))
+}
+
+// MockaccessList is a mock of accessList interface.
+type MockaccessList struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccessListMockRecorder
+}
+
+// MockaccessListMockRecorder is the mock recorder for MockaccessList.
+type MockaccessListMockRecorder struct {
+	mock *MockaccessList
+}
+
+// NewMockaccessList creates a new mock instance.
+func NewMockaccessList(ctrl *gomock.Controller) *MockaccessList {
+	mock := &MockaccessList{ctrl: ctrl}
+	mock.recorder = &MockaccessListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccessList) EXPECT() *MockaccessListMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockaccessList) Add(arg0 p2p.AccessEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockaccessListMockRecorder) Add(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockaccessList)(nil).Add), arg0)
+}
+
+// Entries mocks base method.
+func (m *MockaccessList) Entries() []p2p.AccessEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Entries")
+	ret0, _ := ret[0].([]p2p.AccessEntry)
+	return ret0
+}
+
+// Entries indicates an expected call of Entries.
+func (mr *MockaccessListMockRecorder) Entries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockaccessList)(nil).Entries))
+}
+
+// Remove mocks base method.
+func (m *MockaccessList) Remove(arg0 p2p.AccessKind, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockaccessListMockRecorder) Remove(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockaccessList)(nil).Remove), arg0, arg1)
+}
+
+// MockdatabaseBackup is a mock of databaseBackup interface.
+type MockdatabaseBackup struct {
+	ctrl     *gomock.Controller
+	recorder *MockdatabaseBackupMockRecorder
+}
+
+// MockdatabaseBackupMockRecorder is the mock recorder for MockdatabaseBackup.
+type MockdatabaseBackupMockRecorder struct {
+	mock *MockdatabaseBackup
+}
+
+// NewMockdatabaseBackup creates a new mock instance.
+func NewMockdatabaseBackup(ctrl *gomock.Controller) *MockdatabaseBackup {
+	mock := &MockdatabaseBackup{ctrl: ctrl}
+	mock.recorder = &MockdatabaseBackupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdatabaseBackup) EXPECT() *MockdatabaseBackupMockRecorder {
+	return m.recorder
+}
+
+// Backup mocks base method.
+func (m *MockdatabaseBackup) Backup(ctx context.Context, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", ctx, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockdatabaseBackupMockRecorder) Backup(ctx, path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockdatabaseBackup)(nil).Backup), ctx, path)
+}
+
+// MockdatabaseMigrations is a mock of databaseMigrations interface.
+type MockdatabaseMigrations struct {
+	ctrl     *gomock.Controller
+	recorder *MockdatabaseMigrationsMockRecorder
+}
+
+// MockdatabaseMigrationsMockRecorder is the mock recorder for MockdatabaseMigrations.
+type MockdatabaseMigrationsMockRecorder struct {
+	mock *MockdatabaseMigrations
+}
+
+// NewMockdatabaseMigrations creates a new mock instance.
+func NewMockdatabaseMigrations(ctrl *gomock.Controller) *MockdatabaseMigrations {
+	mock := &MockdatabaseMigrations{ctrl: ctrl}
+	mock.recorder = &MockdatabaseMigrationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdatabaseMigrations) EXPECT() *MockdatabaseMigrationsMockRecorder {
+	return m.recorder
+}
+
+// DryRun mocks base method.
+func (m *MockdatabaseMigrations) DryRun() ([]sql.MigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRun")
+	ret0, _ := ret[0].([]sql.MigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DryRun indicates an expected call of DryRun.
+func (mr *MockdatabaseMigrationsMockRecorder) DryRun() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRun", reflect.TypeOf((*MockdatabaseMigrations)(nil).DryRun))
+}
+
+// Rollback mocks base method.
+func (m *MockdatabaseMigrations) Rollback() (*sql.MigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback")
+	ret0, _ := ret[0].(*sql.MigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockdatabaseMigrationsMockRecorder) Rollback() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockdatabaseMigrations)(nil).Rollback))
+}
+
+// Status mocks base method.
+func (m *MockdatabaseMigrations) Status() ([]sql.MigrationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].([]sql.MigrationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockdatabaseMigrationsMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockdatabaseMigrations)(nil).Status))
+}
+
 // MockpeerCounter is a mock of peerCounter interface.
 type MockpeerCounter struct {
 	ctrl     *gomock.Controller
@@ -2,47 +2,137 @@ package grpcserver
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
+	"github.com/spacemeshos/economics/rewards"
 	"github.com/spacemeshos/post/config"
 	"google.golang.org/genproto/googleapis/rpc/code"
 	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/proposals/util"
+	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
-// SmesherService exposes endpoints to manage smeshing.
-type SmesherService struct {
+// SmesherIDHeader is the grpc metadata key that selects the identity for SmesherService requests
+// when the node smeshes with multiple identities. The value is the hex encoded id of the smesher.
+// Requests without the header are served by the primary identity of the node.
+const SmesherIDHeader = "x-smesher-id"
+
+type smesherIdentity struct {
 	postSetupProvider postSetupProvider
 	smeshingProvider  activation.SmeshingProvider
+}
+
+// SmesherService exposes endpoints to manage smeshing.
+type SmesherService struct {
+	primary    smesherIdentity
+	identities map[types.NodeID]smesherIdentity
 
 	streamInterval time.Duration
 	postOpts       activation.PostSetupOpts
+	mempool        mempoolPolicy
+
+	// atxs, clock and the layer params are used to estimate rewards.
+	atxs           epochATXs
+	clock          genesisTimeAPI
+	layerSize      uint32
+	layersPerEpoch uint32
+}
+
+// SmesherOpt is an option for SmesherService.
+type SmesherOpt func(*SmesherService)
+
+// WithSmesherIdentity adds an identity that can be managed by the SmesherService.
+func WithSmesherIdentity(post postSetupProvider, smeshing activation.SmeshingProvider) SmesherOpt {
+	return func(s *SmesherService) {
+		s.identities[smeshing.SmesherID()] = smesherIdentity{post, smeshing}
+	}
+}
+
+// WithMempoolPolicy enables reading and updating the minimal gas price of the mempool.
+func WithMempoolPolicy(policy mempoolPolicy) SmesherOpt {
+	return func(s *SmesherService) {
+		s.mempool = policy
+	}
+}
+
+// WithRewardsEstimation enables estimation of the smeshing rewards of the identities.
+func WithRewardsEstimation(atxs epochATXs, clock genesisTimeAPI, layerSize, layersPerEpoch uint32) SmesherOpt {
+	return func(s *SmesherService) {
+		s.atxs = atxs
+		s.clock = clock
+		s.layerSize = layerSize
+		s.layersPerEpoch = layersPerEpoch
+	}
 }
 
 // RegisterService registers this service with a grpc server instance.
 func (s SmesherService) RegisterService(server *Server) {
 	pb.RegisterSmesherServiceServer(server.GrpcServer, s)
+	extpb.RegisterSmesherServiceServer(server.GrpcServer, s)
 }
 
 // NewSmesherService creates a new grpc service using config data.
-func NewSmesherService(post postSetupProvider, smeshing activation.SmeshingProvider, streamInterval time.Duration, postOpts activation.PostSetupOpts) *SmesherService {
-	return &SmesherService{post, smeshing, streamInterval, postOpts}
+func NewSmesherService(post postSetupProvider, smeshing activation.SmeshingProvider, streamInterval time.Duration, postOpts activation.PostSetupOpts, opts ...SmesherOpt) *SmesherService {
+	s := &SmesherService{
+		primary:        smesherIdentity{post, smeshing},
+		identities:     map[types.NodeID]smesherIdentity{},
+		streamInterval: streamInterval,
+		postOpts:       postOpts,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// identity returns the identity selected by the SmesherIDHeader of the request.
+func (s SmesherService) identity(ctx context.Context) (smesherIdentity, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return s.primary, nil
+	}
+	values := md.Get(SmesherIDHeader)
+	if len(values) == 0 {
+		return s.primary, nil
+	}
+	data, err := hex.DecodeString(values[0])
+	if err != nil || len(data) != len(types.NodeID{}) {
+		return smesherIdentity{}, status.Errorf(codes.InvalidArgument, "invalid %s: %s", SmesherIDHeader, values[0])
+	}
+	id := types.BytesToNodeID(data)
+	if s.primary.smeshingProvider != nil && id == s.primary.smeshingProvider.SmesherID() {
+		return s.primary, nil
+	}
+	ident, ok := s.identities[id]
+	if !ok {
+		return smesherIdentity{}, status.Errorf(codes.NotFound, "unknown smesher %s", id.ShortString())
+	}
+	return ident, nil
 }
 
 // IsSmeshing reports whether the node is smeshing.
-func (s SmesherService) IsSmeshing(context.Context, *empty.Empty) (*pb.IsSmeshingResponse, error) {
+func (s SmesherService) IsSmeshing(ctx context.Context, _ *empty.Empty) (*pb.IsSmeshingResponse, error) {
 	log.Info("GRPC SmesherService.IsSmeshing")
 
-	return &pb.IsSmeshingResponse{IsSmeshing: s.smeshingProvider.Smeshing()}, nil
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.IsSmeshingResponse{IsSmeshing: ident.smeshingProvider.Smeshing()}, nil
 }
 
 // StartSmeshing requests that the node begin smeshing.
@@ -81,7 +171,11 @@ func (s SmesherService) StartSmeshing(ctx context.Context, in *pb.StartSmeshingR
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse in.Coinbase.Address `%s`: %w", in.Coinbase.Address, err)
 	}
-	if err := s.smeshingProvider.StartSmeshing(coinbaseAddr, opts); err != nil {
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ident.smeshingProvider.StartSmeshing(coinbaseAddr, opts); err != nil {
 		err := fmt.Sprintf("failed to start smeshing: %v", err)
 		log.Error(err)
 		return nil, status.Error(codes.Internal, err)
@@ -96,9 +190,13 @@ func (s SmesherService) StartSmeshing(ctx context.Context, in *pb.StartSmeshingR
 func (s SmesherService) StopSmeshing(ctx context.Context, in *pb.StopSmeshingRequest) (*pb.StopSmeshingResponse, error) {
 	log.Info("GRPC SmesherService.StopSmeshing")
 
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
 	errchan := make(chan error, 1)
 	go func() {
-		errchan <- s.smeshingProvider.StopSmeshing(in.DeleteFiles)
+		errchan <- ident.smeshingProvider.StopSmeshing(in.DeleteFiles)
 	}()
 	select {
 	case <-ctx.Done():
@@ -116,24 +214,32 @@ func (s SmesherService) StopSmeshing(ctx context.Context, in *pb.StopSmeshingReq
 }
 
 // SmesherID returns the smesher ID of this node.
-func (s SmesherService) SmesherID(context.Context, *empty.Empty) (*pb.SmesherIDResponse, error) {
+func (s SmesherService) SmesherID(ctx context.Context, _ *empty.Empty) (*pb.SmesherIDResponse, error) {
 	log.Info("GRPC SmesherService.SmesherID")
 
-	nodeID := s.smeshingProvider.SmesherID()
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodeID := ident.smeshingProvider.SmesherID()
 	addr := types.GenerateAddress(nodeID[:])
 	return &pb.SmesherIDResponse{AccountId: &pb.AccountId{Address: addr.String()}}, nil
 }
 
 // Coinbase returns the current coinbase setting of this node.
-func (s SmesherService) Coinbase(context.Context, *empty.Empty) (*pb.CoinbaseResponse, error) {
+func (s SmesherService) Coinbase(ctx context.Context, _ *empty.Empty) (*pb.CoinbaseResponse, error) {
 	log.Info("GRPC SmesherService.Coinbase")
 
-	addr := s.smeshingProvider.Coinbase()
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addr := ident.smeshingProvider.Coinbase()
 	return &pb.CoinbaseResponse{AccountId: &pb.AccountId{Address: addr.String()}}, nil
 }
 
 // SetCoinbase sets the current coinbase setting of this node.
-func (s SmesherService) SetCoinbase(_ context.Context, in *pb.SetCoinbaseRequest) (*pb.SetCoinbaseResponse, error) {
+func (s SmesherService) SetCoinbase(ctx context.Context, in *pb.SetCoinbaseRequest) (*pb.SetCoinbaseResponse, error) {
 	log.Info("GRPC SmesherService.SetCoinbase")
 
 	if in.Id == nil {
@@ -144,7 +250,11 @@ func (s SmesherService) SetCoinbase(_ context.Context, in *pb.SetCoinbaseRequest
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse in.Id.Address `%s`: %w", in.Id.Address, err)
 	}
-	s.smeshingProvider.SetCoinbase(addr)
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ident.smeshingProvider.SetCoinbase(addr)
 
 	return &pb.SetCoinbaseResponse{
 		Status: &rpcstatus.Status{Code: int32(code.Code_OK)},
@@ -154,26 +264,175 @@ func (s SmesherService) SetCoinbase(_ context.Context, in *pb.SetCoinbaseRequest
 // MinGas returns the current mingas setting of this node.
 func (s SmesherService) MinGas(context.Context, *empty.Empty) (*pb.MinGasResponse, error) {
 	log.Info("GRPC SmesherService.MinGas")
-	return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+
+	if s.mempool == nil {
+		return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+	}
+	return &pb.MinGasResponse{Mingas: &pb.SimpleInt{Value: s.mempool.MinGasPrice()}}, nil
 }
 
 // SetMinGas sets the mingas setting of this node.
-func (s SmesherService) SetMinGas(context.Context, *pb.SetMinGasRequest) (*pb.SetMinGasResponse, error) {
+func (s SmesherService) SetMinGas(_ context.Context, in *pb.SetMinGasRequest) (*pb.SetMinGasResponse, error) {
 	log.Info("GRPC SmesherService.SetMinGas")
-	return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+
+	if s.mempool == nil {
+		return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+	}
+	if in.Mingas == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "`Mingas` must be provided")
+	}
+	s.mempool.SetMinGasPrice(in.Mingas.Value)
+	log.With().Info("updated mempool min gas price", log.Uint64("min_gas_price", in.Mingas.Value))
+
+	return &pb.SetMinGasResponse{
+		Status: &rpcstatus.Status{Code: int32(code.Code_OK)},
+	}, nil
 }
 
 // EstimatedRewards returns estimated smeshing rewards over the next epoch.
-func (s SmesherService) EstimatedRewards(context.Context, *pb.EstimatedRewardsRequest) (*pb.EstimatedRewardsResponse, error) {
+func (s SmesherService) EstimatedRewards(ctx context.Context, _ *pb.EstimatedRewardsRequest) (*pb.EstimatedRewardsResponse, error) {
 	log.Info("GRPC SmesherService.EstimatedRewards")
-	return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+
+	estimate, err := s.rewardsEstimate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.EstimatedRewardsResponse{
+		Amount:   &pb.Amount{Value: estimate.Total},
+		NumUnits: estimate.NumUnits,
+	}, nil
+}
+
+// EstimatedRewardsBreakdown returns estimated smeshing rewards over the next epoch with the expected
+// number of eligibilities and the expected reward of every layer.
+func (s SmesherService) EstimatedRewardsBreakdown(ctx context.Context, _ *extpb.EstimatedRewardsBreakdownRequest) (*extpb.EstimatedRewardsBreakdownResponse, error) {
+	log.Info("GRPC SmesherService.EstimatedRewardsBreakdown")
+
+	estimate, err := s.rewardsEstimate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &extpb.EstimatedRewardsBreakdownResponse{
+		Epoch:         &pb.EpochNumber{Number: estimate.Epoch.Uint32()},
+		NumUnits:      estimate.NumUnits,
+		Eligibilities: estimate.Eligibilities,
+		Amount:        &pb.Amount{Value: estimate.Total},
+	}
+	for _, layer := range estimate.Layers {
+		resp.Layers = append(resp.Layers, &extpb.LayerReward{
+			Layer:  &pb.LayerNumber{Number: layer.Layer.Uint32()},
+			Amount: &pb.Amount{Value: layer.Amount},
+		})
+	}
+	return resp, nil
+}
+
+func (s SmesherService) rewardsEstimate(ctx context.Context) (*RewardsEstimate, error) {
+	if s.atxs == nil || s.clock == nil {
+		return nil, status.Errorf(codes.Unimplemented, "rewards estimation is not enabled")
+	}
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := s.estimateRewards(ident.smeshingProvider.SmesherID())
+	switch {
+	case errors.Is(err, sql.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "smesher has no activation for the current or next epoch")
+	case err != nil:
+		log.With().Error("failed to estimate rewards", log.Err(err))
+		return nil, status.Errorf(codes.Internal, "failed to estimate rewards: %v", err)
+	}
+	log.With().Info("estimated rewards",
+		estimate.Epoch,
+		log.Uint32("eligibilities", estimate.Eligibilities),
+		log.Uint64("total", estimate.Total),
+	)
+	return estimate, nil
+}
+
+// RewardsEstimate is the estimate of the rewards of an identity over an epoch.
+type RewardsEstimate struct {
+	Epoch types.EpochID
+	// NumUnits is the number of space units in the ATX of the identity.
+	NumUnits uint32
+	// Eligibilities is the expected number of eligibilities to publish a proposal in the epoch.
+	Eligibilities uint32
+	// Total is the expected amount of subsidy in smidge for the whole epoch. Fees are not included.
+	Total uint64
+	// Layers is the expected amount of subsidy in smidge for every layer of the epoch.
+	// Amounts are rounded down, so their sum can be slightly less than Total.
+	Layers []LayerRewardEstimate
+}
+
+// LayerRewardEstimate is the expected subsidy of an identity in a layer.
+type LayerRewardEstimate struct {
+	Layer  types.LayerID
+	Amount uint64
+}
+
+// estimateRewards estimates the rewards of the identity over the next epoch. If the identity hasn't
+// published an ATX targeting the next epoch yet, the ATX targeting the current epoch is used.
+func (s SmesherService) estimateRewards(id types.NodeID) (*RewardsEstimate, error) {
+	current := s.clock.CurrentLayer().GetEpoch()
+	target := current + 1
+	hdr, err := s.atxs.GetEpochAtx(current, id)
+	if errors.Is(err, sql.ErrNotFound) && current > 0 {
+		target = current
+		hdr, err = s.atxs.GetEpochAtx(current-1, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	totalWeight, _, err := s.atxs.GetEpochWeight(target)
+	if err != nil {
+		return nil, fmt.Errorf("epoch weight %v: %w", target, err)
+	}
+	eligibilities, err := util.GetNumEligibleSlots(hdr.GetWeight(), totalWeight, s.layerSize, s.layersPerEpoch)
+	if err != nil {
+		return nil, fmt.Errorf("eligible slots: %w", err)
+	}
+
+	// the subsidy of a layer is distributed between the layer's eligibilities, which are
+	// layerSize on average.
+	share := func(subsidy uint64) (uint64, error) {
+		rst := new(big.Int).SetUint64(subsidy)
+		rst.Mul(rst, new(big.Int).SetUint64(uint64(eligibilities))).
+			Quo(rst, new(big.Int).SetUint64(uint64(s.layerSize)*uint64(s.layersPerEpoch)))
+		if !rst.IsUint64() {
+			return 0, fmt.Errorf("estimated reward %v overflows uint64", rst)
+		}
+		return rst.Uint64(), nil
+	}
+	total, err := share(epochSubsidy(target))
+	if err != nil {
+		return nil, err
+	}
+	estimate := &RewardsEstimate{
+		Epoch:         target,
+		NumUnits:      hdr.NumUnits,
+		Eligibilities: eligibilities,
+		Total:         total,
+	}
+	for lid := target.FirstLayer(); lid.Before((target + 1).FirstLayer()); lid = lid.Add(1) {
+		amount, err := share(layerSubsidy(lid))
+		if err != nil {
+			return nil, err
+		}
+		estimate.Layers = append(estimate.Layers, LayerRewardEstimate{Layer: lid, Amount: amount})
+	}
+	return estimate, nil
 }
 
 // PostSetupStatus returns post data status.
-func (s SmesherService) PostSetupStatus(context.Context, *empty.Empty) (*pb.PostSetupStatusResponse, error) {
+func (s SmesherService) PostSetupStatus(ctx context.Context, _ *empty.Empty) (*pb.PostSetupStatusResponse, error) {
 	log.Info("GRPC SmesherService.PostSetupStatus")
 
-	status := s.postSetupProvider.Status()
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := ident.postSetupProvider.Status()
 	return &pb.PostSetupStatusResponse{Status: statusToPbStatus(status)}, nil
 }
 
@@ -181,13 +440,17 @@ func (s SmesherService) PostSetupStatus(context.Context, *empty.Empty) (*pb.Post
 func (s SmesherService) PostSetupStatusStream(_ *empty.Empty, stream pb.SmesherService_PostSetupStatusStreamServer) error {
 	log.Info("GRPC SmesherService.PostSetupStatusStream")
 
+	ident, err := s.identity(stream.Context())
+	if err != nil {
+		return err
+	}
 	timer := time.NewTicker(s.streamInterval)
 	defer timer.Stop()
 
 	for {
 		select {
 		case <-timer.C:
-			status := s.postSetupProvider.Status()
+			status := ident.postSetupProvider.Status()
 			if err := stream.Send(&pb.PostSetupStatusStreamResponse{Status: statusToPbStatus(status)}); err != nil {
 				return fmt.Errorf("send to stream: %w", err)
 			}
@@ -201,7 +464,11 @@ func (s SmesherService) PostSetupStatusStream(_ *empty.Empty, stream pb.SmesherS
 func (s SmesherService) PostSetupProviders(ctx context.Context, in *pb.PostSetupProvidersRequest) (*pb.PostSetupProvidersResponse, error) {
 	log.Info("GRPC SmesherService.PostSetupProviders")
 
-	providers, err := s.postSetupProvider.Providers()
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	providers, err := ident.postSetupProvider.Providers()
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get OpenCL providers: %v", err)
 	}
@@ -212,7 +479,7 @@ func (s SmesherService) PostSetupProviders(ctx context.Context, in *pb.PostSetup
 		var hashesPerSec int
 		if in.Benchmark {
 			var err error
-			hashesPerSec, err = s.postSetupProvider.Benchmark(p)
+			hashesPerSec, err = ident.postSetupProvider.Benchmark(p)
 			if err != nil {
 				log.Error("failed to benchmark provider: %v", err)
 				return nil, status.Error(codes.Internal, "failed to benchmark provider")
@@ -231,10 +498,14 @@ func (s SmesherService) PostSetupProviders(ctx context.Context, in *pb.PostSetup
 }
 
 // PostConfig returns the Post protocol config.
-func (s SmesherService) PostConfig(context.Context, *empty.Empty) (*pb.PostConfigResponse, error) {
+func (s SmesherService) PostConfig(ctx context.Context, _ *empty.Empty) (*pb.PostConfigResponse, error) {
 	log.Info("GRPC SmesherService.PostConfig")
 
-	cfg := s.postSetupProvider.Config()
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg := ident.postSetupProvider.Config()
 
 	return &pb.PostConfigResponse{
 		BitsPerLabel:  config.BitsPerLabel,
@@ -267,7 +538,11 @@ func statusToPbStatus(status *activation.PostSetupStatus) *pb.PostSetupStatus {
 
 // UpdatePoetServers update server that is used for generating PoETs.
 func (s SmesherService) UpdatePoetServers(ctx context.Context, req *pb.UpdatePoetServersRequest) (*pb.UpdatePoetServersResponse, error) {
-	err := s.smeshingProvider.UpdatePoETServers(ctx, req.Urls)
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = ident.smeshingProvider.UpdatePoETServers(ctx, req.Urls)
 	if err == nil {
 		return &pb.UpdatePoetServersResponse{
 			Status: &rpcstatus.Status{Code: int32(code.Code_OK)},
@@ -279,3 +554,65 @@ func (s SmesherService) UpdatePoetServers(ctx context.Context, req *pb.UpdatePoe
 	}
 	return nil, status.Errorf(codes.Internal, "failed to update poet server")
 }
+
+// PoetOutcomes returns the outcome of the latest challenge for every PoET service
+// of the identity selected by the SmesherIDHeader.
+func (s SmesherService) PoetOutcomes(ctx context.Context, _ *extpb.PoetOutcomesRequest) (*extpb.PoetOutcomesResponse, error) {
+	log.Info("GRPC SmesherService.PoetOutcomes")
+
+	ident, err := s.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &extpb.PoetOutcomesResponse{}
+	for _, outcome := range ident.smeshingProvider.PoetOutcomes() {
+		casted := &extpb.PoetOutcome{
+			ServiceId: outcome.ServiceID.ServiceID,
+			Round:     outcome.Round,
+			Status:    poetOutcomeStatuses[outcome.Status],
+			LeafCount: outcome.LeafCount,
+		}
+		if outcome.Err != nil {
+			casted.Error = outcome.Err.Error()
+		}
+		resp.Outcomes = append(resp.Outcomes, casted)
+	}
+	return resp, nil
+}
+
+var poetOutcomeStatuses = map[activation.PoetOutcomeStatus]extpb.PoetOutcomeStatus{
+	activation.PoetSubmitted:     extpb.PoetOutcomeStatus_POET_OUTCOME_STATUS_SUBMITTED,
+	activation.PoetSubmitFailed:  extpb.PoetOutcomeStatus_POET_OUTCOME_STATUS_SUBMIT_FAILED,
+	activation.PoetProofFailed:   extpb.PoetOutcomeStatus_POET_OUTCOME_STATUS_PROOF_FAILED,
+	activation.PoetProofInvalid:  extpb.PoetOutcomeStatus_POET_OUTCOME_STATUS_PROOF_INVALID,
+	activation.PoetNotMember:     extpb.PoetOutcomeStatus_POET_OUTCOME_STATUS_NOT_MEMBER,
+	activation.PoetProofReceived: extpb.PoetOutcomeStatus_POET_OUTCOME_STATUS_RECEIVED,
+	activation.PoetProofSelected: extpb.PoetOutcomeStatus_POET_OUTCOME_STATUS_SELECTED,
+}
+
+// epochSubsidy returns the subsidy issued over all layers of the epoch. Only layers after
+// the effective genesis are rewarded.
+func epochSubsidy(epoch types.EpochID) uint64 {
+	var (
+		genesis = types.GetEffectiveGenesis()
+		first   = epoch.FirstLayer()
+		last    = (epoch + 1).FirstLayer().Sub(1)
+	)
+	if !last.After(genesis) {
+		return 0
+	}
+	if !first.After(genesis) {
+		first = genesis.Add(1)
+	}
+	return rewards.TotalAccumulatedSubsidyAtLayer(last.Difference(genesis)) -
+		rewards.TotalAccumulatedSubsidyAtLayer(first.Difference(genesis)-1)
+}
+
+// layerSubsidy returns the subsidy issued in the layer, as it is computed by the vm.
+func layerSubsidy(lid types.LayerID) uint64 {
+	genesis := types.GetEffectiveGenesis()
+	if !lid.After(genesis) {
+		return 0
+	}
+	return rewards.TotalSubsidyAtLayer(lid.Difference(genesis))
+}
@@ -2,19 +2,28 @@ package grpcserver_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
+	"github.com/spacemeshos/economics/rewards"
 	"github.com/spacemeshos/post/config"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
 	"github.com/spacemeshos/go-spacemesh/api/grpcserver"
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/txs"
 )
 
 func TestPostConfig(t *testing.T) {
@@ -114,3 +123,179 @@ func TestSmesherService_PostSetupProviders(t *testing.T) {
 	require.EqualValues(t, providers[1].ID, resp.Providers[1].Id)
 	require.Equal(t, uint64(100_000), resp.Providers[1].Performance)
 }
+
+func TestSmesherService_MultipleIdentities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	primaryPost := activation.NewMockpostSetupProvider(ctrl)
+	primary := activation.NewMockSmeshingProvider(ctrl)
+	secondaryPost := activation.NewMockpostSetupProvider(ctrl)
+	secondary := activation.NewMockSmeshingProvider(ctrl)
+
+	primaryID := types.RandomNodeID()
+	secondaryID := types.RandomNodeID()
+	primary.EXPECT().SmesherID().Return(primaryID).AnyTimes()
+	secondary.EXPECT().SmesherID().Return(secondaryID).AnyTimes()
+
+	svc := grpcserver.NewSmesherService(primaryPost, primary, time.Second, activation.DefaultPostSetupOpts(),
+		grpcserver.WithSmesherIdentity(secondaryPost, secondary),
+	)
+	withID := func(id string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcserver.SmesherIDHeader, id))
+	}
+
+	resp, err := svc.SmesherID(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, types.GenerateAddress(primaryID.Bytes()).String(), resp.AccountId.Address)
+
+	resp, err = svc.SmesherID(withID(primaryID.String()), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, types.GenerateAddress(primaryID.Bytes()).String(), resp.AccountId.Address)
+
+	resp, err = svc.SmesherID(withID(secondaryID.String()), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, types.GenerateAddress(secondaryID.Bytes()).String(), resp.AccountId.Address)
+
+	primary.EXPECT().Smeshing().Return(false)
+	secondary.EXPECT().Smeshing().Return(true)
+	smeshing, err := svc.IsSmeshing(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.False(t, smeshing.IsSmeshing)
+	smeshing, err = svc.IsSmeshing(withID(secondaryID.String()), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.True(t, smeshing.IsSmeshing)
+
+	secondaryPost.EXPECT().Status().Return(&activation.PostSetupStatus{NumLabelsWritten: 7})
+	status, err := svc.PostSetupStatus(withID(secondaryID.String()), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.EqualValues(t, 7, status.Status.NumLabelsWritten)
+
+	_, err = svc.SmesherID(withID(types.RandomNodeID().String()), &emptypb.Empty{})
+	require.Equal(t, codes.NotFound, grpcstatus.Code(err))
+	_, err = svc.SmesherID(withID("not hex"), &emptypb.Empty{})
+	require.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+}
+
+func TestSmesherService_EstimatedRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	postSetupProvider := activation.NewMockpostSetupProvider(ctrl)
+	smeshingProvider := activation.NewMockSmeshingProvider(ctrl)
+	atxs := grpcserver.NewMockepochATXs(ctrl)
+	clock := grpcserver.NewMockgenesisTimeAPI(ctrl)
+
+	const layerSize = 50
+	layersPerEpoch := types.GetLayersPerEpoch()
+	nodeID := types.RandomNodeID()
+	smeshingProvider.EXPECT().SmesherID().Return(nodeID).AnyTimes()
+
+	svc := grpcserver.NewSmesherService(postSetupProvider, smeshingProvider, time.Second, activation.DefaultPostSetupOpts())
+	_, err := svc.EstimatedRewards(context.Background(), &pb.EstimatedRewardsRequest{})
+	require.Equal(t, codes.Unimplemented, grpcstatus.Code(err))
+
+	svc = grpcserver.NewSmesherService(postSetupProvider, smeshingProvider, time.Second, activation.DefaultPostSetupOpts(),
+		grpcserver.WithRewardsEstimation(atxs, clock, layerSize, layersPerEpoch),
+	)
+	current := types.EpochID(5)
+	clock.EXPECT().CurrentLayer().Return(current.FirstLayer().Add(1)).AnyTimes()
+	hdr := &types.ActivationTxHeader{NumUnits: 3, EffectiveNumUnits: 3, TickCount: 10}
+	// the identity has a quarter of the total weight
+	expected := func(epoch types.EpochID) uint64 {
+		genesis := types.GetEffectiveGenesis()
+		subsidy := rewards.TotalAccumulatedSubsidyAtLayer((epoch + 1).FirstLayer().Sub(1).Difference(genesis)) -
+			rewards.TotalAccumulatedSubsidyAtLayer(epoch.FirstLayer().Difference(genesis)-1)
+		eligibilities := uint64(layerSize*layersPerEpoch) / 4
+		return subsidy * eligibilities / uint64(layerSize*layersPerEpoch)
+	}
+
+	t.Run("next epoch", func(t *testing.T) {
+		atxs.EXPECT().GetEpochAtx(current, nodeID).Return(hdr, nil)
+		atxs.EXPECT().GetEpochWeight(current+1).Return(4*hdr.GetWeight(), nil, nil)
+		resp, err := svc.EstimatedRewards(context.Background(), &pb.EstimatedRewardsRequest{})
+		require.NoError(t, err)
+		require.Equal(t, hdr.NumUnits, resp.NumUnits)
+		require.Equal(t, expected(current+1), resp.Amount.Value)
+	})
+	t.Run("breakdown", func(t *testing.T) {
+		atxs.EXPECT().GetEpochAtx(current, nodeID).Return(hdr, nil)
+		atxs.EXPECT().GetEpochWeight(current+1).Return(4*hdr.GetWeight(), nil, nil)
+		resp, err := svc.EstimatedRewardsBreakdown(context.Background(), &extpb.EstimatedRewardsBreakdownRequest{})
+		require.NoError(t, err)
+		require.EqualValues(t, current+1, resp.Epoch.Number)
+		require.Equal(t, hdr.NumUnits, resp.NumUnits)
+		require.EqualValues(t, layerSize*layersPerEpoch/4, resp.Eligibilities)
+		require.Equal(t, expected(current+1), resp.Amount.Value)
+		require.Len(t, resp.Layers, int(layersPerEpoch))
+		var sum uint64
+		for i, layer := range resp.Layers {
+			lid := (current + 1).FirstLayer().Add(uint32(i))
+			require.Equal(t, lid.Uint32(), layer.Layer.Number)
+			subsidy := rewards.TotalSubsidyAtLayer(lid.Difference(types.GetEffectiveGenesis()))
+			require.Equal(t, subsidy*uint64(resp.Eligibilities)/uint64(layerSize*layersPerEpoch), layer.Amount.Value)
+			sum += layer.Amount.Value
+		}
+		require.InDelta(t, resp.Amount.Value, sum, float64(layersPerEpoch))
+	})
+	t.Run("current epoch", func(t *testing.T) {
+		atxs.EXPECT().GetEpochAtx(current, nodeID).Return(nil, fmt.Errorf("no atx: %w", sql.ErrNotFound))
+		atxs.EXPECT().GetEpochAtx(current-1, nodeID).Return(hdr, nil)
+		atxs.EXPECT().GetEpochWeight(current).Return(4*hdr.GetWeight(), nil, nil)
+		resp, err := svc.EstimatedRewards(context.Background(), &pb.EstimatedRewardsRequest{})
+		require.NoError(t, err)
+		require.Equal(t, expected(current), resp.Amount.Value)
+	})
+	t.Run("no activation", func(t *testing.T) {
+		atxs.EXPECT().GetEpochAtx(gomock.Any(), nodeID).Return(nil, sql.ErrNotFound).Times(2)
+		_, err := svc.EstimatedRewards(context.Background(), &pb.EstimatedRewardsRequest{})
+		require.Equal(t, codes.NotFound, grpcstatus.Code(err))
+	})
+}
+
+func TestSmesherService_MinGas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	postSetupProvider := activation.NewMockpostSetupProvider(ctrl)
+	smeshingProvider := activation.NewMockSmeshingProvider(ctrl)
+	policy := txs.NewMempoolPolicy(txs.DefaultMempoolConfig())
+
+	svc := grpcserver.NewSmesherService(postSetupProvider, smeshingProvider, time.Second, activation.DefaultPostSetupOpts())
+	_, err := svc.MinGas(context.Background(), &emptypb.Empty{})
+	require.Equal(t, codes.Unimplemented, grpcstatus.Code(err))
+
+	svc = grpcserver.NewSmesherService(postSetupProvider, smeshingProvider, time.Second, activation.DefaultPostSetupOpts(),
+		grpcserver.WithMempoolPolicy(policy),
+	)
+	resp, err := svc.MinGas(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, txs.DefaultMempoolConfig().MinGasPrice, resp.Mingas.Value)
+
+	_, err = svc.SetMinGas(context.Background(), &pb.SetMinGasRequest{})
+	require.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+	_, err = svc.SetMinGas(context.Background(), &pb.SetMinGasRequest{Mingas: &pb.SimpleInt{Value: 7}})
+	require.NoError(t, err)
+	require.EqualValues(t, 7, policy.MinGasPrice())
+	resp, err = svc.MinGas(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.EqualValues(t, 7, resp.Mingas.Value)
+}
+
+func TestSmesherService_PoetOutcomes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	postSetupProvider := activation.NewMockpostSetupProvider(ctrl)
+	smeshingProvider := activation.NewMockSmeshingProvider(ctrl)
+	svc := grpcserver.NewSmesherService(postSetupProvider, smeshingProvider, time.Second, activation.DefaultPostSetupOpts())
+
+	outcomes := []activation.PoetOutcome{
+		{ServiceID: types.PoetServiceID{ServiceID: []byte("poet0")}, Round: "1", Status: activation.PoetProofSelected, LeafCount: 10},
+		{ServiceID: types.PoetServiceID{ServiceID: []byte("poet1")}, Status: activation.PoetSubmitFailed, Err: errors.New("test")},
+	}
+	smeshingProvider.EXPECT().PoetOutcomes().Return(outcomes)
+	resp, err := svc.PoetOutcomes(context.Background(), &extpb.PoetOutcomesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Outcomes, 2)
+	require.Equal(t, []byte("poet0"), resp.Outcomes[0].ServiceId)
+	require.Equal(t, "1", resp.Outcomes[0].Round)
+	require.Equal(t, extpb.PoetOutcomeStatus_POET_OUTCOME_STATUS_SELECTED, resp.Outcomes[0].Status)
+	require.EqualValues(t, 10, resp.Outcomes[0].LeafCount)
+	require.Empty(t, resp.Outcomes[0].Error)
+	require.Equal(t, []byte("poet1"), resp.Outcomes[1].ServiceId)
+	require.Equal(t, extpb.PoetOutcomeStatus_POET_OUTCOME_STATUS_SUBMIT_FAILED, resp.Outcomes[1].Status)
+	require.Equal(t, "test", resp.Outcomes[1].Error)
+}
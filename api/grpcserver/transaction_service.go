@@ -10,10 +10,12 @@ import (
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"google.golang.org/genproto/googleapis/rpc/code"
 	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/genvm/core"
@@ -36,6 +38,7 @@ type TransactionService struct {
 // RegisterService registers this service with a grpc server instance.
 func (s TransactionService) RegisterService(server *Server) {
 	pb.RegisterTransactionServiceServer(server.GrpcServer, s)
+	extpb.RegisterTransactionServiceServer(server.GrpcServer, s)
 }
 
 // NewTransactionService creates a new grpc service using config data.
@@ -78,6 +81,26 @@ func (s TransactionService) ParseTransaction(ctx context.Context, in *pb.ParseTr
 	return &pb.ParseTransactionResponse{Tx: castTransaction(&tx)}, nil
 }
 
+// SimulateTransaction executes the transaction on top of the latest applied state, optionally after
+// pending transactions of the principal, and returns the result without persisting anything.
+func (s TransactionService) SimulateTransaction(_ context.Context, in *extpb.SimulateTransactionRequest) (*extpb.SimulateTransactionResponse, error) {
+	if len(in.Transaction) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty transaction")
+	}
+	rst, err := s.conState.Simulate(in.Transaction, in.WithPending)
+	switch {
+	case errors.Is(err, core.ErrNotSpawned):
+		return nil, status.Error(codes.NotFound, "account is not spawned")
+	case errors.Is(err, core.ErrMalformed), errors.Is(err, core.ErrTxLimit):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, core.ErrIneffective):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &extpb.SimulateTransactionResponse{Result: castResult(rst)}, nil
+}
+
 // SubmitTransaction allows a new tx to be submitted.
 func (s TransactionService) SubmitTransaction(ctx context.Context, in *pb.SubmitTransactionRequest) (*pb.SubmitTransactionResponse, error) {
 	if len(in.Transaction) == 0 {
@@ -299,9 +322,37 @@ func (s TransactionService) TransactionsStateStream(in *pb.TransactionsStateStre
 
 // StreamResults allows to query historical results and subscribe to live data using the same filter.
 func (s TransactionService) StreamResults(in *pb.TransactionResultsRequest, stream pb.TransactionService_StreamResultsServer) error {
+	return s.streamResults(in, nil, stream, func(rst *pb.TransactionResult, _ uint64) error {
+		return stream.Send(rst)
+	})
+}
+
+// StreamResultsWithCursor is StreamResults with the journal cursor of every result.
+func (s TransactionService) StreamResultsWithCursor(
+	in *extpb.StreamResultsWithCursorRequest,
+	stream extpb.TransactionService_StreamResultsWithCursorServer,
+) error {
+	if in.Filter == nil {
+		return status.Error(codes.InvalidArgument, "`Filter` must be provided")
+	}
+	if in.FromCursor != nil && !in.Filter.Watch {
+		return status.Error(codes.InvalidArgument, "`FromCursor` can be used only with watch")
+	}
+	return s.streamResults(in.Filter, in.FromCursor, stream, func(rst *pb.TransactionResult, cursor uint64) error {
+		return stream.Send(&extpb.StreamResultsWithCursorResponse{Result: rst, Cursor: cursor})
+	})
+}
+
+func (s TransactionService) streamResults(
+	in *pb.TransactionResultsRequest,
+	from *uint64,
+	stream grpc.ServerStream,
+	send func(*pb.TransactionResult, uint64) error,
+) error {
 	var (
 		filter    transactions.ResultsFilter
-		sub       *events.BufferedSubscription[types.TransactionWithResult]
+		sub       *events.BufferedSubscription[events.Record]
+		start     uint64
 		err       error
 		persisted types.LayerID
 	)
@@ -330,13 +381,16 @@ func (s TransactionService) StreamResults(in *pb.TransactionResultsRequest, stre
 	}
 
 	if in.Watch {
-		sub, err = events.SubscribeMatched(resultsMatcher(filter).match)
+		sub, start, err = subscribeJournal(stream, from, func(rec *events.Record) bool {
+			return rec.Result != nil && resultsMatcher(filter).match(rec.Result)
+		})
 		if err != nil {
-			return status.Error(codes.Internal, err.Error())
+			return err
 		}
 		defer sub.Close()
-		if err := stream.SendHeader(metadata.MD{}); err != nil {
-			return status.Errorf(codes.Unavailable, "can't send header")
+		if from != nil {
+			// missed results are replayed from the journal instead of the database
+			return watchResults(stream, sub, persisted, send)
 		}
 	}
 
@@ -345,7 +399,8 @@ func (s TransactionService) StreamResults(in *pb.TransactionResultsRequest, stre
 		if rst.Layer.After(persisted) {
 			persisted = rst.Layer
 		}
-		ierr = stream.Send(castResult(rst))
+		// results from the database precede the results watched after the start cursor
+		ierr = send(castResult(rst), start)
 		return ierr == nil
 	})
 	if err == nil {
@@ -360,17 +415,27 @@ func (s TransactionService) StreamResults(in *pb.TransactionResultsRequest, stre
 	if sub == nil {
 		return nil
 	}
+	return watchResults(stream, sub, persisted, send)
+}
+
+// watchResults sends results from the subscription that are after the persisted layer.
+func watchResults(
+	stream grpc.ServerStream,
+	sub *events.BufferedSubscription[events.Record],
+	persisted types.LayerID,
+	send func(*pb.TransactionResult, uint64) error,
+) error {
 	for {
 		select {
 		case <-stream.Context().Done():
 			return nil
 		case <-sub.Full():
 			return status.Error(codes.Canceled, "buffer overflow")
-		case rst := <-sub.Out():
-			if !rst.Layer.After(persisted) {
+		case rec := <-sub.Out():
+			if !rec.Result.Layer.After(persisted) {
 				break
 			}
-			if err := stream.Send(castResult(&rst)); err != nil {
+			if err := send(castResult(rec.Result), rec.Cursor); err != nil {
 				if errors.Is(err, io.EOF) {
 					return nil
 				}
@@ -15,7 +15,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
+	extpb "github.com/spacemeshos/go-spacemesh/api/proto/spacemesh/ext/v1"
 	"github.com/spacemeshos/go-spacemesh/common/fixture"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
@@ -131,6 +133,51 @@ func TestTransactionService_StreamResults(t *testing.T) {
 			})
 		}
 	})
+	t.Run("WithCursor", func(t *testing.T) {
+		events.InitializeReporter()
+		t.Cleanup(events.CloseEventReporter)
+		require.NoError(t, events.EnableJournal(sql.InMemory(), 0))
+		client := extpb.NewTransactionServiceClient(conn)
+
+		stream, err := client.StreamResultsWithCursor(ctx, &extpb.StreamResultsWithCursorRequest{
+			Filter: &pb.TransactionResultsRequest{Watch: true},
+		})
+		require.NoError(t, err)
+		// results from the database have the cursor after which the stream starts
+		for range txs {
+			received, err := stream.Recv()
+			require.NoError(t, err)
+			require.Zero(t, received.Cursor)
+		}
+		live := fixture.NewTransactionResultGenerator().WithLayers(100, 1).Next()
+		events.ReportResult(*live)
+		received, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, live.ID[:], received.Result.Tx.Id)
+		require.Equal(t, uint64(1), received.Cursor)
+
+		t.Run("resume", func(t *testing.T) {
+			stream, err := client.StreamResultsWithCursor(ctx, &extpb.StreamResultsWithCursorRequest{
+				Filter:     &pb.TransactionResultsRequest{Watch: true},
+				FromCursor: proto.Uint64(0),
+			})
+			require.NoError(t, err)
+			// replayed from the journal instead of the database
+			received, err := stream.Recv()
+			require.NoError(t, err)
+			require.Equal(t, live.ID[:], received.Result.Tx.Id)
+			require.Equal(t, uint64(1), received.Cursor)
+		})
+		t.Run("without watch", func(t *testing.T) {
+			stream, err := client.StreamResultsWithCursor(ctx, &extpb.StreamResultsWithCursorRequest{
+				Filter:     &pb.TransactionResultsRequest{},
+				FromCursor: proto.Uint64(0),
+			})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
 }
 
 func BenchmarkStreamResults(b *testing.B) {
@@ -805,7 +805,11 @@ func (pd *ProtocolDriver) sendProposal(ctx context.Context, epoch types.EpochID,
 	}
 
 	logger := pd.logger.WithContext(ctx).WithFields(epoch)
-	vrfSig := buildSignedProposal(ctx, pd.logger, pd.vrfSigner, epoch, nonce)
+	vrfSig, err := buildSignedProposal(ctx, pd.logger, pd.vrfSigner, epoch, nonce)
+	if err != nil {
+		logger.With().Error("failed to sign beacon proposal", log.Err(err))
+		return
+	}
 	proposal := ProposalFromVrf(vrfSig)
 	m := ProposalMessage{
 		EpochID:      epoch,
@@ -956,7 +960,10 @@ func (pd *ProtocolDriver) sendFirstRoundVote(ctx context.Context, epoch types.Ep
 	if err != nil {
 		pd.logger.With().Fatal("failed to serialize message for signing", log.Err(err))
 	}
-	sig := pd.edSigner.Sign(signing.BEACON, encoded)
+	sig, err := pd.edSigner.TrySign(signing.BEACON, encoded)
+	if err != nil {
+		return fmt.Errorf("sign first round vote: %w", err)
+	}
 
 	m := FirstVotingMessage{
 		FirstVotingMessageBody: mb,
@@ -1003,7 +1010,10 @@ func (pd *ProtocolDriver) sendFollowingVote(ctx context.Context, epoch types.Epo
 	if err != nil {
 		pd.logger.With().Fatal("failed to serialize message for signing", log.Err(err))
 	}
-	sig := pd.edSigner.Sign(signing.BEACON, encoded)
+	sig, err := pd.edSigner.TrySign(signing.BEACON, encoded)
+	if err != nil {
+		return fmt.Errorf("sign following vote: %w", err)
+	}
 
 	m := FollowingVotingMessage{
 		FollowingVotingMessageBody: mb,
@@ -1106,16 +1116,19 @@ func atxThreshold(kappa int, q *big.Rat, numATXs int) *big.Int {
 	return threshold
 }
 
-func buildSignedProposal(ctx context.Context, logger log.Log, signer vrfSigner, epoch types.EpochID, nonce types.VRFPostIndex) types.VrfSignature {
+func buildSignedProposal(ctx context.Context, logger log.Log, signer vrfSigner, epoch types.EpochID, nonce types.VRFPostIndex) (types.VrfSignature, error) {
 	p := buildProposal(logger, epoch, nonce)
-	vrfSig := signer.Sign(p)
+	vrfSig, err := signer.TrySign(p)
+	if err != nil {
+		return types.EmptyVrfSignature, err
+	}
 	proposal := ProposalFromVrf(vrfSig)
 	logger.WithContext(ctx).With().Debug("calculated beacon proposal",
 		epoch,
 		nonce,
 		log.String("proposal", hex.EncodeToString(proposal[:])),
 	)
-	return vrfSig
+	return vrfSig, nil
 }
 
 func buildProposal(logger log.Log, epoch types.EpochID, nonce types.VRFPostIndex) []byte {
@@ -67,7 +67,8 @@ func setMinerFirstRoundVotes(t *testing.T, pd *ProtocolDriver, epoch types.Epoch
 }
 
 func createProposal(t *testing.T, vrfSigner *signing.VRFSigner, epoch types.EpochID, corruptSignature bool) *ProposalMessage {
-	sig := buildSignedProposal(context.Background(), logtest.New(t), vrfSigner, epoch, types.VRFPostIndex(rand.Uint64()))
+	sig, err := buildSignedProposal(context.Background(), logtest.New(t), vrfSigner, epoch, types.VRFPostIndex(rand.Uint64()))
+	require.NoError(t, err)
 	msg := &ProposalMessage{
 		NodeID:       vrfSigner.NodeID(),
 		EpochID:      epoch,
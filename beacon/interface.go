@@ -32,7 +32,7 @@ type layerClock interface {
 }
 
 type vrfSigner interface {
-	Sign(msg []byte) types.VrfSignature
+	TrySign(msg []byte) (types.VrfSignature, error)
 	NodeID() types.NodeID
 	LittleEndian() bool
 }
@@ -282,18 +282,19 @@ func (mr *MockvrfSignerMockRecorder) NodeID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeID", reflect.TypeOf((*MockvrfSigner)(nil).NodeID))
 }
 
-// Sign mocks base method.
-func (m *MockvrfSigner) Sign(msg []byte) types.VrfSignature {
+// TrySign mocks base method.
+func (m *MockvrfSigner) TrySign(msg []byte) (types.VrfSignature, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Sign", msg)
+	ret := m.ctrl.Call(m, "TrySign", msg)
 	ret0, _ := ret[0].(types.VrfSignature)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Sign indicates an expected call of Sign.
-func (mr *MockvrfSignerMockRecorder) Sign(msg interface{}) *gomock.Call {
+// TrySign indicates an expected call of TrySign.
+func (mr *MockvrfSignerMockRecorder) TrySign(msg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockvrfSigner)(nil).Sign), msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrySign", reflect.TypeOf((*MockvrfSigner)(nil).TrySign), msg)
 }
 
 // MockvrfVerifier is a mock of vrfVerifier interface.
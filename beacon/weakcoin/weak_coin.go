@@ -253,7 +253,11 @@ func (wc *WeakCoin) prepareProposal(epoch types.EpochID, nonce types.VRFPostInde
 	var smallest *types.VrfSignature
 	for unit := uint32(0); unit < minerAllowance; unit++ {
 		proposal := wc.encodeProposal(epoch, nonce, round, unit)
-		signature := wc.signer.Sign(proposal)
+		signature, err := wc.signer.TrySign(proposal)
+		if err != nil {
+			wc.logger.With().Error("failed to sign weak coin proposal", epoch, round, log.Err(err))
+			return nil, types.EmptyVrfSignature
+		}
 		if wc.aboveThreshold(signature) {
 			continue
 		}
@@ -106,8 +106,7 @@ type Certifier struct {
 
 	db           *datastore.CachedDB
 	oracle       hare.Rolacle
-	nodeID       types.NodeID
-	signer       *signing.EdSigner
+	signers      []*signing.EdSigner
 	nonceFetcher nonceFetcher
 	edVerifier   *signing.EdVerifier
 	publisher    pubsub.Publisher
@@ -126,7 +125,6 @@ type Certifier struct {
 func NewCertifier(
 	db *datastore.CachedDB,
 	o hare.Rolacle,
-	n types.NodeID,
 	s *signing.EdSigner,
 	v *signing.EdVerifier,
 	p pubsub.Publisher,
@@ -141,8 +139,7 @@ func NewCertifier(
 		ctx:         context.Background(),
 		db:          db,
 		oracle:      o,
-		nodeID:      n,
-		signer:      s,
+		signers:     []*signing.EdSigner{s},
 		edVerifier:  v,
 		publisher:   p,
 		layerClock:  lc,
@@ -241,13 +238,41 @@ func (c *Certifier) RegisterForCert(ctx context.Context, lid types.LayerID, bid
 	return c.tryGenCert(ctx, logger, lid, bid)
 }
 
+// Register adds an identity that certifies hare outputs if eligible.
+func (c *Certifier) Register(sig *signing.EdSigner) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, existing := range c.signers {
+		if existing.NodeID() == sig.NodeID() {
+			return
+		}
+	}
+	c.signers = append(c.signers, sig)
+}
+
+func (c *Certifier) getSigners() []*signing.EdSigner {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.signers
+}
+
 // CertifyIfEligible signs the hare output, along with its role proof as a certifier, and gossip the CertifyMessage
-// if the node is eligible to be a certifier.
+// for every registered identity that is eligible to be a certifier.
 func (c *Certifier) CertifyIfEligible(ctx context.Context, logger log.Log, lid types.LayerID, bid types.BlockID) error {
 	if _, err := c.beacon.GetBeacon(lid.GetEpoch()); err != nil {
 		return errBeaconNotAvailable
 	}
-	nonce, err := c.nonceFetcher.VRFNonce(c.nodeID, lid.GetEpoch())
+	var rst error
+	for _, sig := range c.getSigners() {
+		if err := c.certifyIfEligible(ctx, logger.WithFields(sig.NodeID()), sig, lid, bid); err != nil && rst == nil {
+			rst = err
+		}
+	}
+	return rst
+}
+
+func (c *Certifier) certifyIfEligible(ctx context.Context, logger log.Log, sig *signing.EdSigner, lid types.LayerID, bid types.BlockID) error {
+	nonce, err := c.nonceFetcher.VRFNonce(sig.NodeID(), lid.GetEpoch())
 	if err != nil { // never submitted an atx, not eligible
 		if errors.Is(err, sql.ErrNotFound) {
 			return nil
@@ -256,13 +281,17 @@ func (c *Certifier) CertifyIfEligible(ctx context.Context, logger log.Log, lid t
 	}
 
 	// check if the node is eligible to certify the hare output
-	proof, err := c.oracle.Proof(ctx, nonce, lid, eligibility.CertifyRound)
+	vrfSigner, err := sig.VRFSigner()
+	if err != nil {
+		return fmt.Errorf("vrf signer: %w", err)
+	}
+	proof, err := c.oracle.Proof(ctx, vrfSigner, nonce, lid, eligibility.CertifyRound)
 	if err != nil {
 		logger.With().Error("failed to get eligibility proof to certify", log.Err(err))
 		return err
 	}
 
-	eligibilityCount, err := c.oracle.CalcEligibility(ctx, lid, eligibility.CertifyRound, c.cfg.CommitteeSize, c.nodeID, nonce, proof)
+	eligibilityCount, err := c.oracle.CalcEligibility(ctx, lid, eligibility.CertifyRound, c.cfg.CommitteeSize, sig.NodeID(), nonce, proof)
 	if err != nil {
 		logger.With().Error("failed to check eligibility to certify", log.Err(err))
 		return err
@@ -278,9 +307,13 @@ func (c *Certifier) CertifyIfEligible(ctx context.Context, logger log.Log, lid t
 			EligibilityCnt: eligibilityCount,
 			Proof:          proof,
 		},
-		SmesherID: c.nodeID,
+		SmesherID: sig.NodeID(),
+	}
+	msg.Signature, err = sig.TrySign(signing.HARE, msg.Bytes())
+	if err != nil {
+		logger.With().Error("failed to sign certify message", log.Err(err))
+		return err
 	}
-	msg.Signature = c.signer.Sign(signing.HARE, msg.Bytes())
 	data, err := codec.Encode(&msg)
 	if err != nil {
 		logger.With().Panic("failed to serialize certify message", log.Err(err))
@@ -56,7 +56,7 @@ func newTestCertifier(t *testing.T) *testCertifier {
 	mb := smocks.NewMockBeaconGetter(ctrl)
 	mtortoise := smocks.NewMockTortoise(ctrl)
 	mNonceFetcher := mocks.NewMocknonceFetcher(ctrl)
-	c := NewCertifier(db, mo, nid, signer, edVerifier, mp, mc, mb, mtortoise,
+	c := NewCertifier(db, mo, signer, edVerifier, mp, mc, mb, mtortoise,
 		WithCertifierLogger(logtest.New(t)),
 		withNonceFetcher(mNonceFetcher),
 	)
@@ -611,8 +611,8 @@ func Test_CertifyIfEligible(t *testing.T) {
 
 	nonce := types.VRFPostIndex(rand.Uint64())
 	tc.mNonceFetcher.EXPECT().VRFNonce(gomock.Any(), b.LayerIndex.GetEpoch()).Return(nonce, nil)
-	tc.mOracle.EXPECT().Proof(gomock.Any(), nonce, b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
-	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nodeID, nonce, proof).Return(defaultCnt, nil)
+	tc.mOracle.EXPECT().Proof(gomock.Any(), gomock.Any(), nonce, b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
+	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nid, nonce, proof).Return(defaultCnt, nil)
 	tc.mPub.EXPECT().Publish(gomock.Any(), pubsub.BlockCertify, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, got []byte) error {
 			var msg types.CertifyMessage
@@ -629,6 +629,34 @@ func Test_CertifyIfEligible(t *testing.T) {
 	require.NoError(t, tc.CertifyIfEligible(context.Background(), tc.logger, b.LayerIndex, b.ID()))
 }
 
+func Test_CertifyIfEligible_MultipleIdentities(t *testing.T) {
+	tc := newTestCertifier(t)
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	tc.Register(signer)
+	tc.Register(signer) // registering twice is a no-op
+
+	b := generateBlock(t, tc.db)
+	tc.mb.EXPECT().GetBeacon(b.LayerIndex.GetEpoch()).Return(types.RandomBeacon(), nil)
+	proof := types.RandomVrfSignature()
+	nonce := types.VRFPostIndex(rand.Uint64())
+	for _, id := range []types.NodeID{tc.nid, signer.NodeID()} {
+		tc.mNonceFetcher.EXPECT().VRFNonce(id, b.LayerIndex.GetEpoch()).Return(nonce, nil)
+		tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, id, nonce, proof).Return(defaultCnt, nil)
+	}
+	tc.mOracle.EXPECT().Proof(gomock.Any(), gomock.Any(), nonce, b.LayerIndex, eligibility.CertifyRound).Return(proof, nil).Times(2)
+	var certifiers []types.NodeID
+	tc.mPub.EXPECT().Publish(gomock.Any(), pubsub.BlockCertify, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, got []byte) error {
+			var msg types.CertifyMessage
+			require.NoError(t, codec.Decode(got, &msg))
+			certifiers = append(certifiers, msg.SmesherID)
+			return nil
+		}).Times(2)
+	require.NoError(t, tc.CertifyIfEligible(context.Background(), tc.logger, b.LayerIndex, b.ID()))
+	require.ElementsMatch(t, []types.NodeID{tc.nid, signer.NodeID()}, certifiers)
+}
+
 func Test_CertifyIfEligible_NotEligible(t *testing.T) {
 	tc := newTestCertifier(t)
 	b := generateBlock(t, tc.db)
@@ -636,8 +664,8 @@ func Test_CertifyIfEligible_NotEligible(t *testing.T) {
 	proof := types.RandomVrfSignature()
 	nonce := types.VRFPostIndex(rand.Uint64())
 	tc.mNonceFetcher.EXPECT().VRFNonce(gomock.Any(), b.LayerIndex.GetEpoch()).Return(nonce, nil)
-	tc.mOracle.EXPECT().Proof(gomock.Any(), nonce, b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
-	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nodeID, nonce, proof).Return(uint16(0), nil)
+	tc.mOracle.EXPECT().Proof(gomock.Any(), gomock.Any(), nonce, b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
+	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nid, nonce, proof).Return(uint16(0), nil)
 	require.NoError(t, tc.CertifyIfEligible(context.Background(), tc.logger, b.LayerIndex, b.ID()))
 }
 
@@ -649,8 +677,8 @@ func Test_CertifyIfEligible_EligibilityErr(t *testing.T) {
 	proof := types.RandomVrfSignature()
 	nonce := types.VRFPostIndex(rand.Uint64())
 	tc.mNonceFetcher.EXPECT().VRFNonce(gomock.Any(), b.LayerIndex.GetEpoch()).Return(nonce, nil)
-	tc.mOracle.EXPECT().Proof(gomock.Any(), nonce, b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
-	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nodeID, nonce, proof).Return(uint16(0), errUnknown)
+	tc.mOracle.EXPECT().Proof(gomock.Any(), gomock.Any(), nonce, b.LayerIndex, eligibility.CertifyRound).Return(proof, nil)
+	tc.mOracle.EXPECT().CalcEligibility(gomock.Any(), b.LayerIndex, eligibility.CertifyRound, tc.cfg.CommitteeSize, tc.nid, nonce, proof).Return(uint16(0), errUnknown)
 	require.ErrorIs(t, tc.CertifyIfEligible(context.Background(), tc.logger, b.LayerIndex, b.ID()), errUnknown)
 }
 
@@ -661,7 +689,7 @@ func Test_CertifyIfEligible_ProofErr(t *testing.T) {
 	errUnknown := errors.New("unknown")
 	nonce := types.VRFPostIndex(rand.Uint64())
 	tc.mNonceFetcher.EXPECT().VRFNonce(gomock.Any(), b.LayerIndex.GetEpoch()).Return(nonce, nil)
-	tc.mOracle.EXPECT().Proof(gomock.Any(), nonce, b.LayerIndex, eligibility.CertifyRound).Return(types.EmptyVrfSignature, errUnknown)
+	tc.mOracle.EXPECT().Proof(gomock.Any(), gomock.Any(), nonce, b.LayerIndex, eligibility.CertifyRound).Return(types.EmptyVrfSignature, errUnknown)
 	require.ErrorIs(t, tc.CertifyIfEligible(context.Background(), tc.logger, b.LayerIndex, b.ID()), errUnknown)
 }
 
@@ -43,6 +43,15 @@ var (
 	updateOkCount      = updateCount.WithLabelValues(success)
 	updateFailureCount = updateCount.WithLabelValues(failure)
 
+	rejectedCount = metrics.NewCounter(
+		"rejected",
+		namespace,
+		"number of updates rejected because of missing signatures",
+		[]string{"reason"},
+	)
+	rejectedUnsignedCount    = rejectedCount.WithLabelValues("unsigned")
+	rejectedUnderSignedCount = rejectedCount.WithLabelValues("under_signed")
+
 	queryDuration = metrics.NewHistogramWithBuckets(
 		"query_duration",
 		namespace,
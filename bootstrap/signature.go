@@ -0,0 +1,127 @@
+package bootstrap
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+var (
+	ErrUnsigned            = errors.New("update is not signed")
+	ErrNotEnoughSignatures = errors.New("not enough valid signatures")
+)
+
+// SigningBytes returns the bytes that are signed by the trusted keys.
+// It is the json encoding of the update without signatures.
+func (u *Update) SigningBytes() ([]byte, error) {
+	data, err := json.Marshal(&Update{Version: u.Version, Data: u.Data})
+	if err != nil {
+		return nil, fmt.Errorf("marshal update: %w", err)
+	}
+	return data, nil
+}
+
+// Sign adds the signature of the signer to the update, replacing the previous
+// signature made with the same key.
+func (u *Update) Sign(signer *signing.EdSigner) error {
+	msg, err := u.SigningBytes()
+	if err != nil {
+		return err
+	}
+	sig, err := signer.TrySign(signing.BOOTSTRAP, msg)
+	if err != nil {
+		return err
+	}
+	signature := Signature{
+		PublicKey: hex.EncodeToString(signer.PublicKey().Bytes()),
+		Signature: hex.EncodeToString(sig[:]),
+	}
+	for i := range u.Signatures {
+		if u.Signatures[i].PublicKey == signature.PublicKey {
+			u.Signatures[i] = signature
+			return nil
+		}
+	}
+	u.Signatures = append(u.Signatures, signature)
+	return nil
+}
+
+func decodeKey(key string) (types.NodeID, error) {
+	var id types.NodeID
+	decoded, err := hex.DecodeString(key)
+	if err != nil {
+		return id, fmt.Errorf("decode key %s: %w", key, err)
+	}
+	if len(decoded) != len(id) {
+		return id, fmt.Errorf("invalid key size %d/%d", len(decoded), len(id))
+	}
+	return types.BytesToNodeID(decoded), nil
+}
+
+func trustedKeys(cfg Config) (map[types.NodeID]struct{}, error) {
+	keys := make(map[types.NodeID]struct{}, len(cfg.TrustedKeys))
+	for _, key := range cfg.TrustedKeys {
+		id, err := decodeKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("trusted key: %w", err)
+		}
+		keys[id] = struct{}{}
+	}
+	return keys, nil
+}
+
+// verifySignatures checks that the update is signed by at least cfg.Threshold of the trusted keys.
+// Updates are not required to be signed if no trusted keys are configured.
+func verifySignatures(cfg Config, update *Update) error {
+	keys, err := trustedKeys(cfg)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if len(update.Signatures) == 0 {
+		rejectedUnsignedCount.Inc()
+		return ErrUnsigned
+	}
+	msg, err := update.SigningBytes()
+	if err != nil {
+		return err
+	}
+	verifier, err := signing.NewEdVerifier()
+	if err != nil {
+		return err
+	}
+	valid := map[types.NodeID]struct{}{}
+	for _, signature := range update.Signatures {
+		id, err := decodeKey(signature.PublicKey)
+		if err != nil {
+			continue
+		}
+		if _, exists := keys[id]; !exists {
+			continue
+		}
+		decoded, err := hex.DecodeString(signature.Signature)
+		if err != nil || len(decoded) != types.EdSignatureSize {
+			continue
+		}
+		var sig types.EdSignature
+		copy(sig[:], decoded)
+		if verifier.Verify(signing.BOOTSTRAP, id, msg, sig) {
+			valid[id] = struct{}{}
+		}
+	}
+	threshold := cfg.Threshold
+	if threshold < 1 {
+		threshold = 1
+	}
+	if len(valid) < threshold {
+		rejectedUnderSignedCount.Inc()
+		return fmt.Errorf("%w: %d/%d", ErrNotEnoughSignatures, len(valid), threshold)
+	}
+	return nil
+}
@@ -8,6 +8,13 @@ import (
 type Update struct {
 	Version string    `json:"version"`
 	Data    InnerData `json:"data"`
+	// Signatures are ed25519 signatures over Update.SigningBytes().
+	Signatures []Signature `json:"signatures,omitempty"`
+}
+
+type Signature struct {
+	PublicKey string `json:"key"`
+	Signature string `json:"signature"`
 }
 
 type InnerData struct {
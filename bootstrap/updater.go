@@ -6,7 +6,8 @@
 //
 // The updater periodically checks for the latest update from a URL provided
 // by the spacemesh administrator, verifies the data, persists on disk and
-// notifies subscribers of a new update.
+// notifies subscribers of a new update. If trusted keys are configured, the
+// update must carry ed25519 signatures from at least the threshold of them.
 //
 // Subscribers register by calling `Subscribe()` to receive a channel for
 // the latest update.
@@ -53,6 +54,10 @@ var (
 type Config struct {
 	URL     string `mapstructure:"bootstrap-url"`
 	Version string `mapstructure:"bootstrap-version"`
+	// TrustedKeys are hex encoded ed25519 public keys that sign updates.
+	// If set, updates without signatures of at least Threshold keys are rejected.
+	TrustedKeys []string `mapstructure:"bootstrap-trusted-keys"`
+	Threshold   int      `mapstructure:"bootstrap-threshold"`
 
 	DataDir   string
 	Interval  time.Duration
@@ -66,9 +71,22 @@ func DefaultConfig() Config {
 		DataDir:   os.TempDir(),
 		Interval:  30 * time.Second,
 		NumToKeep: 10,
+		Threshold: 1,
 	}
 }
 
+// Validate checks that the trusted keys are valid and the threshold can be met.
+func (c Config) Validate() error {
+	keys, err := trustedKeys(c)
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 && (c.Threshold < 1 || c.Threshold > len(keys)) {
+		return fmt.Errorf("threshold %d must be between 1 and the number of trusted keys %d", c.Threshold, len(keys))
+	}
+	return nil
+}
+
 type Updater struct {
 	cfg    Config
 	logger log.Log
@@ -297,6 +315,9 @@ func validate(cfg Config, source string, data []byte, lastUpdateId int64) (*Veri
 	if err := json.Unmarshal(data, update); err != nil {
 		return nil, fmt.Errorf("unmarshal %s: %w", source, err)
 	}
+	if err := verifySignatures(cfg, update); err != nil {
+		return nil, fmt.Errorf("verify %s: %w", source, err)
+	}
 
 	verified, err := validateData(cfg, update, lastUpdateId)
 	if err != nil {
@@ -2,10 +2,13 @@ package bootstrap_test
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +18,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/bootstrap"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/signing"
 )
 
 const (
@@ -446,3 +450,133 @@ func TestNoNewUpdate(t *testing.T) {
 	require.Empty(t, ch)
 	require.True(t, cached)
 }
+
+func signedUpdate(tb testing.TB, data string, signers ...*signing.EdSigner) string {
+	tb.Helper()
+	var update bootstrap.Update
+	require.NoError(tb, json.Unmarshal([]byte(data), &update))
+	for _, signer := range signers {
+		require.NoError(tb, update.Sign(signer))
+	}
+	signed, err := json.Marshal(update)
+	require.NoError(tb, err)
+	return string(signed)
+}
+
+func TestSignedUpdates(t *testing.T) {
+	signers := make([]*signing.EdSigner, 4)
+	for i := range signers {
+		signer, err := signing.NewEdSigner()
+		require.NoError(t, err)
+		signers[i] = signer
+	}
+	trusted := make([]string, 0, 3)
+	for _, signer := range signers[:3] {
+		trusted = append(trusted, hex.EncodeToString(signer.PublicKey().Bytes()))
+	}
+	tampered := strings.Replace(signedUpdate(t, update1, signers[0], signers[1]), "6fe7c971", "00000000", 1)
+
+	tcs := []struct {
+		desc   string
+		update string
+		err    error
+	}{
+		{
+			desc:   "unsigned",
+			update: update1,
+			err:    bootstrap.ErrUnsigned,
+		},
+		{
+			desc:   "under signed",
+			update: signedUpdate(t, update1, signers[0]),
+			err:    bootstrap.ErrNotEnoughSignatures,
+		},
+		{
+			desc:   "signed by the same key",
+			update: signedUpdate(t, update1, signers[0], signers[0]),
+			err:    bootstrap.ErrNotEnoughSignatures,
+		},
+		{
+			desc:   "untrusted key",
+			update: signedUpdate(t, update1, signers[0], signers[3]),
+			err:    bootstrap.ErrNotEnoughSignatures,
+		},
+		{
+			desc:   "tampered",
+			update: tampered,
+			err:    bootstrap.ErrNotEnoughSignatures,
+		},
+		{
+			desc:   "threshold",
+			update: signedUpdate(t, update1, signers[0], signers[2]),
+		},
+		{
+			desc:   "all keys",
+			update: signedUpdate(t, update1, signers...),
+		},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tc.update))
+			}))
+			defer ts.Close()
+
+			cfg := bootstrap.DefaultConfig()
+			cfg.URL = ts.URL
+			cfg.TrustedKeys = trusted
+			cfg.Threshold = 2
+			require.NoError(t, cfg.Validate())
+			fs := afero.NewMemMapFs()
+			updater := bootstrap.New(
+				bootstrap.WithConfig(cfg),
+				bootstrap.WithLogger(logtest.New(t)),
+				bootstrap.WithFilesystem(fs),
+				bootstrap.WithHttpClient(ts.Client()),
+			)
+			ch := updater.Subscribe()
+			err := updater.DoIt(context.Background())
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				require.Empty(t, ch)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, ch, 1)
+			checkUpdate1(t, <-ch)
+
+			// persisted update is verified on load too
+			updater = bootstrap.New(
+				bootstrap.WithConfig(cfg),
+				bootstrap.WithLogger(logtest.New(t)),
+				bootstrap.WithFilesystem(fs),
+			)
+			ch = updater.Subscribe()
+			require.NoError(t, updater.Load(context.Background()))
+			require.Len(t, ch, 1)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	key := hex.EncodeToString(signer.PublicKey().Bytes())
+
+	cfg := bootstrap.DefaultConfig()
+	require.NoError(t, cfg.Validate())
+	cfg.TrustedKeys = []string{key}
+	require.NoError(t, cfg.Validate())
+	cfg.Threshold = 2
+	require.Error(t, cfg.Validate())
+	cfg.Threshold = 0
+	require.Error(t, cfg.Validate())
+	cfg.Threshold = 1
+	cfg.TrustedKeys = []string{key[2:]}
+	require.Error(t, cfg.Validate())
+	cfg.TrustedKeys = []string{"zz"}
+	require.Error(t, cfg.Validate())
+}
@@ -0,0 +1,296 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/recovery"
+)
+
+const recoveryFile = "recovery"
+
+// ErrUnsupportedVersion is returned when the checkpoint file was created with an unknown schema.
+var ErrUnsupportedVersion = errors.New("checkpoint: unsupported version")
+
+// ErrPruned is returned when the state for the checkpoint snapshot was pruned.
+var ErrPruned = errors.New("checkpoint: state pruned")
+
+// Config is the node configuration for recovering from a checkpoint.
+type Config struct {
+	// Uri of the checkpoint file. Either a local path or an http(s) url.
+	Uri string `mapstructure:"recover-from"`
+}
+
+// DefaultConfig returns the default recovery config. Recovery is disabled by default.
+func DefaultConfig() Config {
+	return Config{}
+}
+
+// RecoverConfig is the configuration for Recover.
+type RecoverConfig struct {
+	DataDir string
+	DbFile  string
+	Uri     string
+}
+
+// DbPath returns the path of the state database.
+func (c *RecoverConfig) DbPath() string {
+	return filepath.Join(c.DataDir, c.DbFile)
+}
+
+// RecoveryFilename returns the path where a checkpoint file is copied before recovery.
+func RecoveryFilename(dataDir string) string {
+	return filepath.Join(dataDir, checkpointDir, recoveryFile)
+}
+
+// CopyToLocal reads the checkpoint from the uri, validates it and persists it
+// in the data directory. Returns the path to the local copy.
+func CopyToLocal(ctx context.Context, fs afero.Fs, dataDir, uri string) (string, error) {
+	data, err := read(ctx, fs, uri)
+	if err != nil {
+		return "", err
+	}
+	if _, err = parse(data); err != nil {
+		return "", err
+	}
+	rf, err := NewRecoveryFile(fs, RecoveryFilename(dataDir))
+	if err != nil {
+		return "", fmt.Errorf("new recovery file: %w", err)
+	}
+	if _, err = rf.fwriter.Write(data); err != nil {
+		return "", fmt.Errorf("write recovery file: %w", err)
+	}
+	if err = rf.save(fs); err != nil {
+		return "", err
+	}
+	return rf.path, nil
+}
+
+// Recover wipes the state database and seeds it with the data from the checkpoint file.
+// The node is restored from the restore layer in the checkpoint: all layers before it
+// are treated as genesis.
+//
+// If the database was already recovered from the same checkpoint, it is left as is.
+// A remote checkpoint isn't downloaded again if the database was recovered from the same uri.
+func Recover(ctx context.Context, logger log.Log, fs afero.Fs, cfg *RecoverConfig) (types.LayerID, error) {
+	dbPath := cfg.DbPath()
+	if remote(cfg.Uri) {
+		if _, err := os.Stat(dbPath); err == nil {
+			id, uri, restore, err := recoveredFrom(dbPath)
+			if err != nil {
+				return 0, err
+			}
+			if uri == cfg.Uri {
+				logger.With().Info("state already recovered from checkpoint",
+					log.String("checkpoint", id),
+					log.Stringer("restore", restore),
+					log.String("uri", uri),
+				)
+				return restore, nil
+			}
+		}
+	}
+	data, err := read(ctx, fs, cfg.Uri)
+	if err != nil {
+		return 0, err
+	}
+	checkpoint, err := parse(data)
+	if err != nil {
+		return 0, err
+	}
+	restore := types.LayerID(checkpoint.Data.Restore)
+	logger = logger.WithFields(
+		log.String("checkpoint", checkpoint.Data.CheckpointId),
+		log.Stringer("restore", restore),
+	)
+
+	if _, err = os.Stat(dbPath); err == nil {
+		id, _, _, err := recoveredFrom(dbPath)
+		if err != nil {
+			return 0, err
+		}
+		if id == checkpoint.Data.CheckpointId {
+			logger.With().Info("state already recovered from checkpoint")
+			return restore, nil
+		}
+		logger.With().Info("wiping state database before recovery", log.String("path", dbPath))
+		if err = wipe(dbPath); err != nil {
+			return 0, err
+		}
+	} else if !os.IsNotExist(err) {
+		return 0, fmt.Errorf("stat db %s: %w", dbPath, err)
+	}
+
+	if err = os.MkdirAll(cfg.DataDir, 0o700); err != nil {
+		return 0, fmt.Errorf("create data dir %s: %w", cfg.DataDir, err)
+	}
+	db, err := sql.Open("file:" + dbPath)
+	if err != nil {
+		return 0, fmt.Errorf("open db %s: %w", dbPath, err)
+	}
+	defer db.Close()
+	if err = db.WithTx(ctx, func(tx *sql.Tx) error {
+		return seed(tx, checkpoint, cfg.Uri)
+	}); err != nil {
+		return 0, fmt.Errorf("seed checkpoint data: %w", err)
+	}
+	logger.With().Info("recovered state from checkpoint",
+		log.Int("atxs", len(checkpoint.Data.Atxs)),
+		log.Int("accounts", len(checkpoint.Data.Accounts)),
+	)
+	return restore, nil
+}
+
+func seed(tx *sql.Tx, checkpoint *Checkpoint, uri string) error {
+	restore := types.LayerID(checkpoint.Data.Restore)
+	for _, atx := range checkpoint.Data.Atxs {
+		catx := atxs.CheckpointAtx{
+			ID:             types.ATXID(types.BytesToHash(atx.ID)),
+			Epoch:          types.EpochID(atx.Epoch),
+			CommitmentATX:  types.ATXID(types.BytesToHash(atx.CommitmentAtx)),
+			VRFNonce:       types.VRFPostIndex(atx.VrfNonce),
+			NumUnits:       atx.NumUnits,
+			BaseTickHeight: atx.BaseTickHeight,
+			TickCount:      atx.TickCount,
+			SmesherID:      types.BytesToNodeID(atx.PublicKey),
+			Sequence:       atx.Sequence,
+		}
+		copy(catx.Coinbase[:], atx.Coinbase)
+		if err := atxs.AddCheckpointed(tx, &catx); err != nil {
+			return err
+		}
+	}
+	for _, acct := range checkpoint.Data.Accounts {
+		account := &types.Account{
+			Layer:     restore.Sub(1),
+			NextNonce: acct.Nonce,
+			Balance:   acct.Balance,
+		}
+		copy(account.Address[:], acct.Address)
+		if len(acct.Template) > 0 {
+			account.TemplateAddress = &types.Address{}
+			copy(account.TemplateAddress[:], acct.Template)
+			account.State = acct.State
+		}
+		if err := accounts.Update(tx, account); err != nil {
+			return err
+		}
+		if err := trie.UpdateAccount(tx, account); err != nil {
+			return err
+		}
+	}
+	if err := layers.SetProcessed(tx, restore.Sub(1)); err != nil {
+		return err
+	}
+	if err := layers.SetApplied(tx, restore.Sub(1), types.EmptyBlockID); err != nil {
+		return err
+	}
+	return recovery.SetCheckpoint(tx, checkpoint.Data.CheckpointId, uri, restore)
+}
+
+// recoveredFrom returns the id, the uri and the restore layer of the latest checkpoint
+// the database was recovered from. They are empty if the database wasn't recovered.
+func recoveredFrom(dbPath string) (string, string, types.LayerID, error) {
+	db, err := sql.Open("file:" + dbPath)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("open db %s: %w", dbPath, err)
+	}
+	defer db.Close()
+	id, restore, err := recovery.CheckpointInfo(db)
+	if errors.Is(err, sql.ErrNotFound) {
+		return "", "", 0, nil
+	} else if err != nil {
+		return "", "", 0, err
+	}
+	uri, err := recovery.CheckpointUri(db)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return id, uri, restore, nil
+}
+
+// remote returns true if the checkpoint at the uri is downloaded over http.
+func remote(uri string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme == "http" || parsed.Scheme == "https"
+}
+
+func wipe(dbPath string) error {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", dbPath+suffix, err)
+		}
+	}
+	return nil
+}
+
+func parse(data []byte) (*Checkpoint, error) {
+	if err := ValidateSchema(data); err != nil {
+		return nil, err
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("unmarshal checkpoint: %w", err)
+	}
+	if checkpoint.Version != SchemaVersion {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, checkpoint.Version)
+	}
+	return &checkpoint, nil
+}
+
+func read(ctx context.Context, fs afero.Fs, uri string) ([]byte, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parse recovery uri %s: %w", uri, err)
+	}
+	switch parsed.Scheme {
+	case "http", "https":
+		return query(ctx, parsed)
+	case "file":
+		uri = parsed.Path
+	}
+	data, err := afero.ReadFile(fs, uri)
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint file %s: %w", uri, err)
+	}
+	return data, nil
+}
+
+func query(ctx context.Context, resource *url.URL) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resource.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create http request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http get recovery file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http get recovery file: %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read http body: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,219 @@
+package checkpoint_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/checkpoint"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/recovery"
+)
+
+func generate(t *testing.T, fs afero.Fs, dir string) string {
+	t.Helper()
+	db := sql.InMemory()
+	createMesh(t, db, allAtxs, allAccounts)
+	r := checkpoint.NewRunner(db,
+		checkpoint.WithFilesystem(fs),
+		checkpoint.WithLogger(logtest.New(t)),
+		checkpoint.WithDataDir(dir),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	fname, err := r.Generate(ctx, types.LayerID(5), types.LayerID(7))
+	require.NoError(t, err)
+	return fname
+}
+
+func verifyRecovered(t *testing.T, cfg *checkpoint.RecoverConfig) {
+	t.Helper()
+	db, err := sql.Open("file:" + cfg.DbPath())
+	require.NoError(t, err)
+	defer db.Close()
+
+	expected := expectedCheckpoint(t)
+	id, restore, err := recovery.CheckpointInfo(db)
+	require.NoError(t, err)
+	require.Equal(t, expected.Data.CheckpointId, id)
+	require.Equal(t, types.LayerID(expected.Data.Restore), restore)
+
+	for _, catx := range expected.Data.Atxs {
+		got, err := atxs.Get(db, types.ATXID(types.BytesToHash(catx.ID)))
+		require.NoError(t, err)
+		require.Equal(t, catx.Epoch, got.PublishEpoch.Uint32())
+		require.Equal(t, catx.CommitmentAtx, got.CommitmentATX.Bytes())
+		require.Equal(t, catx.VrfNonce, uint64(*got.VRFNonce))
+		require.Equal(t, catx.NumUnits, got.EffectiveNumUnits())
+		require.Equal(t, catx.BaseTickHeight, got.BaseTickHeight())
+		require.Equal(t, catx.TickCount, got.TickCount())
+		require.Equal(t, catx.PublicKey, got.SmesherID.Bytes())
+		require.Equal(t, catx.Sequence, got.Sequence)
+		require.Equal(t, catx.Coinbase, got.Coinbase.Bytes())
+	}
+	for _, acct := range expected.Data.Accounts {
+		var addr types.Address
+		copy(addr[:], acct.Address)
+		got, err := accounts.Latest(db, addr)
+		require.NoError(t, err)
+		require.Equal(t, acct.Balance, got.Balance)
+		require.Equal(t, acct.Nonce, got.NextNonce)
+		require.Equal(t, acct.Template, got.TemplateAddress.Bytes())
+		require.Equal(t, acct.State, got.State)
+		require.Equal(t, restore.Sub(1), got.Layer)
+		proof, err := trie.ProveAccount(db, addr, restore.Sub(1))
+		require.NoError(t, err)
+		require.True(t, proof.Verify(proof.Root))
+	}
+	processed, err := layers.GetProcessed(db)
+	require.NoError(t, err)
+	require.Equal(t, restore.Sub(1), processed)
+	applied, err := layers.GetLastApplied(db)
+	require.NoError(t, err)
+	require.Equal(t, restore.Sub(1), applied)
+}
+
+func TestRecover(t *testing.T) {
+	fs := afero.NewOsFs()
+	fname := generate(t, fs, t.TempDir())
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, fname)
+	}))
+	defer ts.Close()
+
+	tcs := []struct {
+		desc string
+		uri  string
+	}{
+		{desc: "local path", uri: fname},
+		{desc: "file scheme", uri: "file://" + fname},
+		{desc: "http", uri: ts.URL},
+	}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &checkpoint.RecoverConfig{
+				DataDir: t.TempDir(),
+				DbFile:  "state.sql",
+				Uri:     tc.uri,
+			}
+			restore, err := checkpoint.Recover(context.Background(), logtest.New(t), fs, cfg)
+			require.NoError(t, err)
+			require.Equal(t, types.LayerID(7), restore)
+			verifyRecovered(t, cfg)
+		})
+	}
+}
+
+func TestRecover_WipesExistingState(t *testing.T) {
+	fs := afero.NewOsFs()
+	cfg := &checkpoint.RecoverConfig{
+		DataDir: t.TempDir(),
+		DbFile:  "state.sql",
+		Uri:     generate(t, fs, t.TempDir()),
+	}
+	db, err := sql.Open("file:" + cfg.DbPath())
+	require.NoError(t, err)
+	stale := types.Address{9, 9, 9}
+	require.NoError(t, accounts.Update(db, &types.Account{Address: stale, Layer: types.LayerID(100)}))
+	require.NoError(t, db.Close())
+
+	_, err = checkpoint.Recover(context.Background(), logtest.New(t), fs, cfg)
+	require.NoError(t, err)
+	verifyRecovered(t, cfg)
+
+	db, err = sql.Open("file:" + cfg.DbPath())
+	require.NoError(t, err)
+	has, err := accounts.Has(db, stale)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	// recovering from the same checkpoint again doesn't touch the state
+	require.NoError(t, accounts.Update(db, &types.Account{Address: stale, Layer: types.LayerID(100)}))
+	require.NoError(t, db.Close())
+	_, err = checkpoint.Recover(context.Background(), logtest.New(t), fs, cfg)
+	require.NoError(t, err)
+	db, err = sql.Open("file:" + cfg.DbPath())
+	require.NoError(t, err)
+	defer db.Close()
+	has, err = accounts.Has(db, stale)
+	require.NoError(t, err)
+	require.True(t, has)
+}
+
+func TestRecover_RemoteNotDownloadedAgain(t *testing.T) {
+	fs := afero.NewOsFs()
+	fname := generate(t, fs, t.TempDir())
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.ServeFile(w, r, fname)
+	}))
+	defer ts.Close()
+	cfg := &checkpoint.RecoverConfig{
+		DataDir: t.TempDir(),
+		DbFile:  "state.sql",
+		Uri:     ts.URL,
+	}
+	restore, err := checkpoint.Recover(context.Background(), logtest.New(t), fs, cfg)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID(7), restore)
+	require.EqualValues(t, 1, requests.Load())
+
+	restore, err = checkpoint.Recover(context.Background(), logtest.New(t), fs, cfg)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID(7), restore)
+	require.EqualValues(t, 1, requests.Load())
+	verifyRecovered(t, cfg)
+
+	db, err := sql.Open("file:" + cfg.DbPath())
+	require.NoError(t, err)
+	defer db.Close()
+	uri, err := recovery.CheckpointUri(db)
+	require.NoError(t, err)
+	require.Equal(t, ts.URL, uri)
+}
+
+func TestRecover_InvalidData(t *testing.T) {
+	fs := afero.NewOsFs()
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "invalid")
+	require.NoError(t, afero.WriteFile(fs, fname, []byte(`{"version":"https://spacemesh.io/checkpoint.schema.json.1.0"}`), 0o600))
+	cfg := &checkpoint.RecoverConfig{
+		DataDir: dir,
+		DbFile:  "state.sql",
+		Uri:     fname,
+	}
+	_, err := checkpoint.Recover(context.Background(), logtest.New(t), fs, cfg)
+	require.Error(t, err)
+	require.NoFileExists(t, cfg.DbPath())
+
+	_, err = checkpoint.CopyToLocal(context.Background(), fs, dir, fname)
+	require.Error(t, err)
+}
+
+func TestCopyToLocal(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	src := generate(t, fs, "/src")
+	dst, err := checkpoint.CopyToLocal(context.Background(), fs, "/dst", src)
+	require.NoError(t, err)
+	require.Equal(t, checkpoint.RecoveryFilename("/dst"), dst)
+	expected, err := afero.ReadFile(fs, src)
+	require.NoError(t, err)
+	got, err := afero.ReadFile(fs, dst)
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/accounts"
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/pruning"
 )
 
 const (
@@ -77,6 +78,14 @@ func checkpointDB(ctx context.Context, db *sql.Database, snapshot, restore types
 	}
 	defer tx.Release()
 
+	pruned, err := pruning.Layer(tx)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot < pruned {
+		return nil, fmt.Errorf("%w: snapshot %s, pruned before %s", ErrPruned, snapshot, pruned)
+	}
+
 	atxSnapshot, err := atxs.LatestN(tx, 2)
 	if err != nil {
 		return nil, fmt.Errorf("atxs snapshot: %w", err)
@@ -150,6 +150,10 @@ func EnsureCLIFlags(cmd *cobra.Command, appCFG *config.Config) error {
 			ff = reflect.TypeOf(appCFG.Bootstrap)
 			elem = reflect.ValueOf(&appCFG.Bootstrap).Elem()
 			assignFields(ff, elem, name)
+
+			ff = reflect.TypeOf(appCFG.Recovery)
+			elem = reflect.ValueOf(&appCFG.Recovery).Elem()
+			assignFields(ff, elem, name)
 		}
 	})
 	return nil
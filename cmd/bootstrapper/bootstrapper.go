@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/url"
 	"os"
 	"os/signal"
@@ -12,7 +11,6 @@ import (
 	"syscall"
 	"time"
 
-	"cloud.google.com/go/storage"
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -42,6 +40,10 @@ var (
 	genFallback       bool
 	dataDir           string
 	logLevel          string
+	signingKeys       []string
+	signPath          string
+	s3Endpoint        string
+	s3Region          string
 )
 
 func init() {
@@ -52,8 +54,12 @@ func init() {
 	// options specific to one-time execution
 	cmd.PersistentFlags().BoolVar(&genBeacon, "beacon", false, "generate beacon")
 	cmd.PersistentFlags().BoolVar(&genActiveSet, "actives", false, "generate active set")
-	cmd.PersistentFlags().StringVar(&out, "out", "gs://my-bucket/spacemesh-fallback", "gs URI for upload")
+	cmd.PersistentFlags().StringVar(&out, "out", "gs://my-bucket/spacemesh-fallback",
+		"URI for upload: gs://bucket/path, s3://bucket/path or file:///path")
 	cmd.PersistentFlags().StringVar(&creds, "creds", "", "path to gcloud credential file")
+	cmd.PersistentFlags().StringVar(&s3Endpoint, "s3-endpoint", "https://s3.amazonaws.com",
+		"endpoint of the s3-compatible object store. credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	cmd.PersistentFlags().StringVar(&s3Region, "s3-region", "us-east-1", "region of the s3-compatible object store")
 
 	// for systests only
 	cmd.PersistentFlags().BoolVar(&serveUpdate, "serve-update",
@@ -65,6 +71,12 @@ func init() {
 	cmd.PersistentFlags().BoolVar(&genFallback, "fallback", false,
 		"in addition to bootstrap data, also generate fallback data")
 
+	// signing
+	cmd.PersistentFlags().StringSliceVar(&signingKeys, "signing-keys", nil,
+		"files with hex encoded ed25519 private keys to sign the update with")
+	cmd.PersistentFlags().StringVar(&signPath, "sign", "",
+		"instead of generating an update, add signatures to the existing update file")
+
 	// admin
 	cmd.PersistentFlags().StringVar(&dataDir, "data-dir", os.TempDir(), "directory to persist update data")
 	cmd.PersistentFlags().StringVar(&logLevel, "level", "info", "logging level")
@@ -74,6 +86,16 @@ var cmd = &cobra.Command{
 	Use:   "bootstrapper",
 	Short: "generate bootstrapping data",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		signers, err := LoadSigners(afero.NewOsFs(), signingKeys)
+		if err != nil {
+			return err
+		}
+		if len(signPath) > 0 {
+			if len(signers) == 0 {
+				return fmt.Errorf("no keys specified via --signing-keys")
+			}
+			return SignFile(afero.NewOsFs(), signPath, signers)
+		}
 		if len(args) == 0 {
 			return fmt.Errorf("epoch not specfiied")
 		}
@@ -92,6 +114,7 @@ var cmd = &cobra.Command{
 			bitcoinEndpoint,
 			spacemeshEndpoint,
 			WithLogger(logger.WithName("generator")),
+			WithSigners(signers...),
 		)
 
 		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
@@ -115,37 +138,30 @@ var cmd = &cobra.Command{
 		if genActiveSet && len(spacemeshEndpoint) == 0 {
 			return fmt.Errorf("missing spacemesh endpoint for active set generation")
 		}
-		bucket, path, err := parseToGsBucket(out)
+		store, err := NewStorage(out, afero.NewOsFs(), S3Config{
+			Endpoint:  s3Endpoint,
+			Region:    s3Region,
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		})
 		if err != nil {
 			return fmt.Errorf("parse output uri %v: %w", out, err)
 		}
 		if err = g.Generate(ctx, types.EpochID(targetEpoch), genBeacon, genActiveSet); err != nil {
 			return err
 		}
-		return upload(ctx, bucket, path)
+		return upload(ctx, store)
 	},
 }
 
-func upload(ctx context.Context, bucket, path string) error {
+func upload(ctx context.Context, store Storage) error {
 	filename := PersistedFilename()
-	r, err := os.Open(filename)
+	data, err := os.ReadFile(filename)
 	if err != nil {
-		return fmt.Errorf("open generated file %v: %w", filename, err)
-	}
-	defer r.Close()
-	if err = os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", creds); err != nil {
-		return fmt.Errorf("set env for credential: %w", err)
-	}
-	client, err := storage.NewClient(ctx)
-	if err != nil {
-		return fmt.Errorf("create gs client: %w", err)
-	}
-	w := client.Bucket(bucket).Object(path).NewWriter(ctx)
-	if _, err = io.Copy(w, r); err != nil {
-		return fmt.Errorf("copy to gs object (%v): %w", out, err)
+		return fmt.Errorf("read generated file %v: %w", filename, err)
 	}
-	if err = w.Close(); err != nil {
-		return fmt.Errorf("complete upload (%v): %w", out, err)
+	if err = store.Upload(ctx, data); err != nil {
+		return fmt.Errorf("upload %v: %w", out, err)
 	}
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/bootstrap"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
 )
 
 const (
@@ -40,6 +41,7 @@ type Generator struct {
 	client      *http.Client
 	btcEndpoint string
 	smEndpoint  string
+	signers     []*signing.EdSigner
 }
 
 type Opt func(*Generator)
@@ -62,6 +64,13 @@ func WithHttpClient(c *http.Client) Opt {
 	}
 }
 
+// WithSigners signs generated updates with the keys.
+func WithSigners(signers ...*signing.EdSigner) Opt {
+	return func(g *Generator) {
+		g.signers = signers
+	}
+}
+
 func NewGenerator(btcEndpoint string, smEndpoint string, opts ...Opt) *Generator {
 	g := &Generator{
 		logger:      log.NewNop(),
@@ -222,6 +231,9 @@ func (g *Generator) GenUpdate(epoch types.EpochID, beacon types.Beacon, activeSe
 		UpdateId: time.Now().Unix(),
 		Epoch:    edata,
 	}
+	if err := signUpdate(&update, g.signers); err != nil {
+		return err
+	}
 	data, err := json.Marshal(update)
 	if err != nil {
 		return fmt.Errorf("marshal data %v: %w", string(data), err)
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/spacemeshos/go-spacemesh/bootstrap"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+// LoadSigners loads ed25519 keys from the files. Key files have the same format
+// as the identity file of the node: hex encoded private key.
+func LoadSigners(fs afero.Fs, filenames []string) ([]*signing.EdSigner, error) {
+	signers := make([]*signing.EdSigner, 0, len(filenames))
+	for _, filename := range filenames {
+		data, err := afero.ReadFile(fs, filename)
+		if err != nil {
+			return nil, fmt.Errorf("read signing key %v: %w", filename, err)
+		}
+		key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("decode signing key %v: %w", filename, err)
+		}
+		if len(key) != signing.PrivateKeySize {
+			return nil, fmt.Errorf("invalid signing key size %d/%d", len(key), signing.PrivateKeySize)
+		}
+		signer, err := signing.NewEdSigner(signing.WithPrivateKey(key))
+		if err != nil {
+			return nil, fmt.Errorf("signing key %v: %w", filename, err)
+		}
+		signers = append(signers, signer)
+	}
+	return signers, nil
+}
+
+func signUpdate(update *bootstrap.Update, signers []*signing.EdSigner) error {
+	for _, signer := range signers {
+		if err := update.Sign(signer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SignFile adds signatures to the existing update file. Updates signed by m-of-n
+// keys are produced by passing the file to the holders of the keys in turn.
+func SignFile(fs afero.Fs, filename string, signers []*signing.EdSigner) error {
+	data, err := afero.ReadFile(fs, filename)
+	if err != nil {
+		return fmt.Errorf("read update %v: %w", filename, err)
+	}
+	if err = bootstrap.ValidateSchema(data); err != nil {
+		return fmt.Errorf("invalid update %v: %w", filename, err)
+	}
+	var update bootstrap.Update
+	if err = json.Unmarshal(data, &update); err != nil {
+		return fmt.Errorf("unmarshal update %v: %w", filename, err)
+	}
+	if err = signUpdate(&update, signers); err != nil {
+		return err
+	}
+	data, err = json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("marshal update: %w", err)
+	}
+	if err = afero.WriteFile(fs, filename, data, 0o600); err != nil {
+		return fmt.Errorf("persist signed update %v: %w", filename, err)
+	}
+	return nil
+}
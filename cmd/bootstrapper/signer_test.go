@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/bootstrap"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+func writeKey(tb testing.TB, fs afero.Fs, filename string) *signing.EdSigner {
+	tb.Helper()
+	signer, err := signing.NewEdSigner()
+	require.NoError(tb, err)
+	require.NoError(tb, afero.WriteFile(fs, filename, []byte(hex.EncodeToString(signer.PrivateKey())), 0o600))
+	return signer
+}
+
+func TestLoadSigners(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	signer := writeKey(t, fs, "key1")
+	signers, err := LoadSigners(fs, []string{"key1"})
+	require.NoError(t, err)
+	require.Len(t, signers, 1)
+	require.Equal(t, signer.PublicKey(), signers[0].PublicKey())
+
+	_, err = LoadSigners(fs, []string{"missing"})
+	require.Error(t, err)
+	require.NoError(t, afero.WriteFile(fs, "short", []byte("abcd"), 0o600))
+	_, err = LoadSigners(fs, []string{"short"})
+	require.Error(t, err)
+}
+
+func TestSignFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	first := writeKey(t, fs, "key1")
+	second := writeKey(t, fs, "key2")
+	signers, err := LoadSigners(fs, []string{"key1"})
+	require.NoError(t, err)
+
+	g := NewGenerator("", "",
+		WithLogger(logtest.New(t)),
+		WithFilesystem(fs),
+		WithSigners(signers...),
+	)
+	epoch := types.EpochID(3)
+	require.NoError(t, g.GenUpdate(epoch, types.RandomBeacon(), nil))
+
+	verify := func() (int, error) {
+		data, err := afero.ReadFile(fs, PersistedFilename())
+		require.NoError(t, err)
+		var update bootstrap.Update
+		require.NoError(t, json.Unmarshal(data, &update))
+		return len(update.Signatures), bootstrap.ValidateSchema(data)
+	}
+	n, err := verify()
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	signers, err = LoadSigners(fs, []string{"key2", "key1"})
+	require.NoError(t, err)
+	require.NoError(t, SignFile(fs, PersistedFilename(), signers))
+	n, err = verify()
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	// node accepts the update signed by both keys
+	cfg := bootstrap.DefaultConfig()
+	cfg.Version = SchemaVersion
+	cfg.TrustedKeys = []string{
+		hex.EncodeToString(first.PublicKey().Bytes()),
+		hex.EncodeToString(second.PublicKey().Bytes()),
+	}
+	cfg.Threshold = 2
+	data, err := afero.ReadFile(fs, PersistedFilename())
+	require.NoError(t, err)
+	persisted := bootstrap.PersistFilename(filepath.Join(cfg.DataDir, bootstrap.DirName), time.Now().Unix())
+	require.NoError(t, afero.WriteFile(fs, persisted, data, 0o400))
+	updater := bootstrap.New(
+		bootstrap.WithConfig(cfg),
+		bootstrap.WithLogger(logtest.New(t)),
+		bootstrap.WithFilesystem(fs),
+	)
+	ch := updater.Subscribe()
+	require.NoError(t, updater.Load(context.Background()))
+	require.Len(t, ch, 1)
+	require.Equal(t, epoch, (<-ch).Data.Epoch)
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/spf13/afero"
+)
+
+const (
+	schemeFile = "file"
+	schemeGS   = "gs"
+	schemeS3   = "s3"
+
+	s3Service   = "s3"
+	amzDate     = "20060102T150405Z"
+	amzDay      = "20060102"
+	sigV4Scheme = "AWS4-HMAC-SHA256"
+)
+
+// Storage is a destination for the generated update.
+type Storage interface {
+	Upload(ctx context.Context, data []byte) error
+}
+
+// S3Config configures access to the S3-compatible object store.
+type S3Config struct {
+	// Endpoint of the object store. Objects are addressed in path-style: <endpoint>/<bucket>/<key>.
+	Endpoint  string
+	Region    string
+	AccessKey string
+	SecretKey string
+}
+
+// NewStorage returns the storage for the uri. The backend is selected by the scheme:
+// gs://bucket/path, s3://bucket/path or file:///path. Uri without a scheme is a local path.
+func NewStorage(uri string, fs afero.Fs, s3cfg S3Config) (Storage, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	switch parsed.Scheme {
+	case "", schemeFile:
+		path := parsed.Path
+		if parsed.Scheme == "" {
+			path = uri
+		}
+		if len(path) == 0 {
+			return nil, fmt.Errorf("path %s must not be empty", uri)
+		}
+		return &fsStorage{fs: fs, path: path}, nil
+	case schemeGS:
+		bucket, path, err := parseToGsBucket(uri)
+		if err != nil {
+			return nil, err
+		}
+		return &gsStorage{bucket: bucket, path: path}, nil
+	case schemeS3:
+		bucket, key, err := parseBucket(parsed)
+		if err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return nil, fmt.Errorf("path %s must have object key", uri)
+		}
+		if len(s3cfg.AccessKey) == 0 || len(s3cfg.SecretKey) == 0 {
+			return nil, errors.New("missing s3 credentials")
+		}
+		return &s3Storage{cfg: s3cfg, client: &http.Client{}, bucket: bucket, key: key}, nil
+	default:
+		return nil, fmt.Errorf("scheme not supported %v", parsed.Scheme)
+	}
+}
+
+func parseBucket(parsed *url.URL) (bucket, path string, err error) {
+	if parsed.Host == "" {
+		return "", "", fmt.Errorf("path %s must have bucket", parsed)
+	}
+	// remove leading "/" in URL path
+	return parsed.Host, strings.TrimPrefix(parsed.Path, "/"), nil
+}
+
+type fsStorage struct {
+	fs   afero.Fs
+	path string
+}
+
+func (s *fsStorage) Upload(_ context.Context, data []byte) error {
+	if err := s.fs.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("create dir for %v: %w", s.path, err)
+	}
+	// write to a temporary file first so that the update is never served partially written
+	tmp := s.path + ".tmp"
+	if err := afero.WriteFile(s.fs, tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write %v: %w", tmp, err)
+	}
+	if err := s.fs.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("rename %v: %w", tmp, err)
+	}
+	return nil
+}
+
+type gsStorage struct {
+	bucket, path string
+}
+
+func (s *gsStorage) Upload(ctx context.Context, data []byte) error {
+	if err := os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", creds); err != nil {
+		return fmt.Errorf("set env for credential: %w", err)
+	}
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("create gs client: %w", err)
+	}
+	defer client.Close()
+	w := client.Bucket(s.bucket).Object(s.path).NewWriter(ctx)
+	if _, err = io.Copy(w, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("copy to gs object (%v/%v): %w", s.bucket, s.path, err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("complete upload (%v/%v): %w", s.bucket, s.path, err)
+	}
+	return nil
+}
+
+type s3Storage struct {
+	cfg         S3Config
+	client      *http.Client
+	bucket, key string
+}
+
+func (s *s3Storage) Upload(ctx context.Context, data []byte) error {
+	endpoint, err := url.Parse(s.cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("parse s3 endpoint: %w", err)
+	}
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/" + s.bucket + "/" + s.key
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint.String(), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("create http request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	signV4(req, data, s.cfg, time.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("put s3 object (%v/%v): %w", s.bucket, s.key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("put s3 object (%v/%v): %s: %s", s.bucket, s.key, resp.Status, body)
+	}
+	return nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// signV4 signs the request with AWS signature version 4. All headers set on the request are signed.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html.
+func signV4(req *http.Request, payload []byte, cfg S3Config, now time.Time) {
+	now = now.UTC()
+	payloadHash := sha256.Sum256(payload)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	req.Header.Set("X-Amz-Date", now.Format(amzDate))
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := strings.Join([]string{now.Format(amzDay), cfg.Region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		sigV4Scheme,
+		now.Format(amzDate),
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+cfg.SecretKey), now.Format(amzDay))
+	key = hmacSHA256(key, cfg.Region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Scheme, cfg.AccessKey, scope, signedHeaders, signature))
+}
@@ -0,0 +1,72 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// backupCommand returns the command to make a backup of the node database.
+// Config of the node is loaded from the flags of the root command.
+func backupCommand(root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "backup <file>",
+		Short: "Write a consistent backup of the node database to the file",
+		Long: `Write a consistent backup of the node database to the file.
+The backup can be made while the node is running, it is verified before it is written to the file.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			conf, err := loadConfig(root)
+			if err != nil {
+				return fmt.Errorf("failed to initialize config: %w", err)
+			}
+			path := filepath.Join(conf.DataDir(), dbFile)
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("database %s: %w", path, err)
+			}
+			// migrations are applied by the node
+			db, err := sql.Open("file:"+path, sql.WithConnections(1), sql.WithMigrations(nil))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			if err := db.Backup(context.Background(), args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.OutOrStdout(), "database %s is written to %s\n", path, args[0])
+			return nil
+		},
+	}
+}
+
+// restoreCommand returns the command to restore the node database from a backup.
+// Config of the node is loaded from the flags of the root command.
+func restoreCommand(root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore the node database from the backup on the next start of the node",
+		Long: `Verify the backup and stage it in the data directory of the node.
+The database is replaced with the backup when the node starts next time.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			conf, err := loadConfig(root)
+			if err != nil {
+				return fmt.Errorf("failed to initialize config: %w", err)
+			}
+			if err := os.MkdirAll(conf.DataDir(), 0o700); err != nil {
+				return fmt.Errorf("create data directory: %w", err)
+			}
+			path := filepath.Join(conf.DataDir(), dbFile)
+			if err := sql.StageRestore(args[0], path); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.OutOrStdout(), "database %s will be restored from %s on the next start\n", path, args[0])
+			return nil
+		},
+	}
+}
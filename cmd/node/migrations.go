@@ -0,0 +1,149 @@
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// migrationsCommand returns the command to inspect and roll back migrations of the node database.
+// Config of the node is loaded from the flags of the root command.
+func migrationsCommand(root *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrations",
+		Short: "Inspect and roll back migrations of the node database",
+		Long: `Inspect and roll back migrations of the node database.
+The node must be stopped before migrations are rolled back.`,
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Print the status of the migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return withMigrations(root, func(db *sql.Database, migrations sql.MigrationList) error {
+				statuses, err := migrations.Status(db)
+				if err != nil {
+					return err
+				}
+				printMigrations(c.OutOrStdout(), statuses)
+				return nil
+			})
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "dry-run",
+		Short: "Apply pending migrations without committing them",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return withMigrations(root, func(db *sql.Database, migrations sql.MigrationList) error {
+				pending, err := migrations.DryRun(db)
+				if err != nil {
+					return err
+				}
+				if len(pending) == 0 {
+					fmt.Fprintln(c.OutOrStdout(), "no pending migrations")
+					return nil
+				}
+				printMigrations(c.OutOrStdout(), pending)
+				return nil
+			})
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "rollback",
+		Short: "Roll back the last applied migration",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, _ []string) error {
+			return withMigrations(root, func(db *sql.Database, migrations sql.MigrationList) error {
+				reverted, err := migrations.Rollback(db)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(c.OutOrStdout(), "migration %04d %s is rolled back\n", reverted.Version, reverted.Name)
+				return nil
+			})
+		},
+	})
+	return cmd
+}
+
+func withMigrations(root *cobra.Command, fn func(*sql.Database, sql.MigrationList) error) error {
+	conf, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("failed to initialize config: %w", err)
+	}
+	path := filepath.Join(conf.DataDir(), dbFile)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("database %s: %w", path, err)
+	}
+	migrations, err := sql.StateMigrations()
+	if err != nil {
+		return err
+	}
+	// pending migrations are applied by the node
+	db, err := sql.Open("file:"+path, sql.WithConnections(1), sql.WithMigrations(nil))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return fn(db, migrations)
+}
+
+func printMigrations(w io.Writer, statuses []sql.MigrationStatus) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATE\tAPPLIED")
+	for _, status := range statuses {
+		applied := "-"
+		if !status.AppliedAt.IsZero() {
+			applied = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, status.State, applied)
+	}
+	tw.Flush()
+}
+
+// nodeMigrations serves migrations of the database of the running node. The node is stopped
+// after a migration is rolled back, as it can't run with an older schema.
+type nodeMigrations struct {
+	db         *sql.Database
+	rolledBack chan<- struct{}
+}
+
+func (m *nodeMigrations) Status() ([]sql.MigrationStatus, error) {
+	migrations, err := sql.StateMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return migrations.Status(m.db)
+}
+
+func (m *nodeMigrations) DryRun() ([]sql.MigrationStatus, error) {
+	migrations, err := sql.StateMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return migrations.DryRun(m.db)
+}
+
+func (m *nodeMigrations) Rollback() (*sql.MigrationStatus, error) {
+	migrations, err := sql.StateMigrations()
+	if err != nil {
+		return nil, err
+	}
+	reverted, err := migrations.Rollback(m.db)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case m.rolledBack <- struct{}{}:
+	default:
+	}
+	return reverted, nil
+}
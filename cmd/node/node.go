@@ -2,6 +2,7 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
@@ -14,12 +15,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/gofrs/flock"
 	grpc_logsettable "github.com/grpc-ecosystem/go-grpc-middleware/logging/settable"
 	grpczap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	grpctags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
 	"github.com/mitchellh/mapstructure"
+	"github.com/natefinch/atomic"
 	"github.com/pyroscope-io/pyroscope/pkg/agent/profiler"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -42,6 +46,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/fetch"
 	vm "github.com/spacemeshos/go-spacemesh/genvm"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
 	"github.com/spacemeshos/go-spacemesh/hare"
 	"github.com/spacemeshos/go-spacemesh/hare/eligibility"
 	"github.com/spacemeshos/go-spacemesh/layerpatrol"
@@ -53,10 +58,12 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/proposals"
+	"github.com/spacemeshos/go-spacemesh/prune"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	dbmetrics "github.com/spacemeshos/go-spacemesh/sql/metrics"
+	"github.com/spacemeshos/go-spacemesh/sql/recovery"
 	"github.com/spacemeshos/go-spacemesh/syncer"
 	"github.com/spacemeshos/go-spacemesh/system"
 	"github.com/spacemeshos/go-spacemesh/timesync"
@@ -69,8 +76,19 @@ import (
 const (
 	edKeyFileName   = "key.bin"
 	genesisFileName = "genesis.json"
+	eventsOutboxDir = "events"
+	dbFile          = "state.sql"
+	protectionFile  = "slashing_protection.sql"
 )
 
+// errRecoveryRestart is returned by App.Start when the node is requested to recover
+// from a checkpoint and needs to restart its services.
+var errRecoveryRestart = errors.New("restarting to recover from checkpoint")
+
+// errRecoveryAborted is returned when the services didn't stop in time before recovery.
+// The checkpoint is kept in the data directory, recovery can be retried with --recover-from.
+var errRecoveryAborted = errors.New("recovery from checkpoint aborted: app failed to clean up in time")
+
 // Logger names.
 const (
 	ClockLogger            = "clock"
@@ -101,6 +119,9 @@ const (
 	ExecutorLogger         = "executor"
 	MalfeasanceLogger      = "malfeasance"
 	BootstrapLogger        = "bootstrap"
+	CheckpointLogger       = "checkpoint"
+	PruneLogger            = "prune"
+	EventsLogger           = "events"
 )
 
 func GetCommand() *cobra.Command {
@@ -116,16 +137,20 @@ func GetCommand() *cobra.Command {
 			if conf.LOGGING.Encoder == config.JSONLogEncoder {
 				log.JSONLog(true)
 			}
-			app := New(
-				WithConfig(conf),
-				// NOTE(dshulyak) this needs to be max level so that child logger can can be current level or below.
-				// otherwise it will fail later when child logger will try to increase level.
-				WithLog(log.RegisterHooks(
-					log.NewWithLevel("", zap.NewAtomicLevelAt(zapcore.DebugLevel)),
-					events.EventHook())),
-			)
 
 			run := func(ctx context.Context) error {
+				// background tasks started with the context, e.g. metrics pushers, stop
+				// with the app and are started again after a restart
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				app := New(
+					WithConfig(conf),
+					// NOTE(dshulyak) this needs to be max level so that child logger can can be current level or below.
+					// otherwise it will fail later when child logger will try to increase level.
+					WithLog(log.RegisterHooks(
+						log.NewWithLevel("", zap.NewAtomicLevelAt(zapcore.DebugLevel)),
+						events.EventHook())),
+				)
 				if err = app.Initialize(); err != nil {
 					return err
 				}
@@ -145,13 +170,25 @@ func GetCommand() *cobra.Command {
 				case <-done:
 				case <-cleanupCtx.Done():
 					log.With().Error("app failed to clean up in time")
+					if errors.Is(err, errRecoveryRestart) {
+						// the database may still be open, it must not be wiped by recovery
+						return fmt.Errorf("%w: checkpoint %s", errRecoveryAborted, conf.Recovery.Uri)
+					}
 				}
 				return err
 			}
 			// os.Interrupt for all systems, especially windows, syscall.SIGTERM is mainly for docker.
 			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 			defer cancel()
-			if err = run(ctx); err != nil {
+			for {
+				err = run(ctx)
+				if !errors.Is(err, errRecoveryRestart) {
+					break
+				}
+				log.With().Info("restarting node to recover from checkpoint", log.String("uri", conf.Recovery.Uri))
+				resetGlobalState(conf)
+			}
+			if err != nil {
 				log.With().Fatal(err.Error())
 			}
 		},
@@ -172,6 +209,10 @@ func GetCommand() *cobra.Command {
 		},
 	}
 	c.AddCommand(&versionCmd)
+	c.AddCommand(protectionCommand(c))
+	c.AddCommand(backupCommand(c))
+	c.AddCommand(restoreCommand(c))
+	c.AddCommand(migrationsCommand(c))
 
 	return c
 }
@@ -254,15 +295,40 @@ func WithConfig(conf *config.Config) Option {
 	}
 }
 
+// WithClock sets the source of time for the layer clock of the App.
+func WithClock(c clock.Clock) Option {
+	return func(app *App) {
+		app.timeSource = c
+	}
+}
+
+// WithHost sets the p2p host of the App instead of creating one from the config.
+// The host is stopped when the App is stopped.
+func WithHost(host *p2p.Host) Option {
+	return func(app *App) {
+		app.host = host
+	}
+}
+
+// WithPoetClients sets the clients of PoET services instead of creating them
+// from the addresses in the config.
+func WithPoetClients(clients ...activation.PoetProvingServiceClient) Option {
+	return func(app *App) {
+		app.poetClients = clients
+	}
+}
+
 // New creates an instance of the spacemesh app.
 func New(opts ...Option) *App {
 	defaultConfig := config.DefaultConfig()
 	app := &App{
-		Config:  &defaultConfig,
-		log:     appLog,
-		loggers: make(map[string]*zap.AtomicLevel),
-		started: make(chan struct{}),
-		eg:      &errgroup.Group{},
+		Config:     &defaultConfig,
+		log:        appLog,
+		loggers:    make(map[string]*zap.AtomicLevel),
+		started:    make(chan struct{}),
+		recover:    make(chan struct{}, 1),
+		rolledBack: make(chan struct{}, 1),
+		eg:         &errgroup.Group{},
 	}
 	for _, opt := range opts {
 		opt(app)
@@ -280,12 +346,15 @@ type App struct {
 	Config             *config.Config
 	db                 *sql.Database
 	dbMetrics          *dbmetrics.DBMetricsCollector
+	eventsPublisher    *events.Publisher
+	remoteSigner       *signing.RemoteSigner
+	protection         *signing.Protection
 	grpcPublicService  *grpcserver.Server
 	grpcPrivateService *grpcserver.Server
 	jsonAPIService     *grpcserver.JSONHTTPServer
 	syncer             *syncer.Syncer
 	proposalListener   *proposals.Handler
-	proposalBuilder    *miner.ProposalBuilder
+	smeshers           []*smesher
 	mesh               *mesh.Mesh
 	cachedDB           *datastore.CachedDB
 	clock              *timesync.NodeClock
@@ -293,8 +362,6 @@ type App struct {
 	hOracle            *eligibility.Oracle
 	blockGen           *blocks.Generator
 	certifier          *blocks.Certifier
-	postSetupMgr       *activation.PostSetupManager
-	atxBuilder         *activation.Builder
 	atxHandler         *activation.Handler
 	txHandler          *txs.TxHandler
 	validator          *activation.Validator
@@ -307,12 +374,18 @@ type App struct {
 	ptimesync          *peersync.Sync
 	tortoise           *tortoise.Tortoise
 	updater            *bootstrap.Updater
-
-	host *p2p.Host
-
-	loggers map[string]*zap.AtomicLevel
-	started chan struct{} // this channel is closed once the app has finished starting
-	eg      *errgroup.Group
+	pruner             *prune.Pruner
+
+	host        *p2p.Host
+	timeSource  clock.Clock
+	poetClients []activation.PoetProvingServiceClient
+
+	loggers    map[string]*zap.AtomicLevel
+	started    chan struct{} // this channel is closed once the app has finished starting
+	recover    chan struct{} // receives a signal when the app needs to restart to recover from a checkpoint
+	rolledBack chan struct{} // receives a signal when a migration of the database is rolled back over the api
+	recovered  bool          // true if the state was recovered from a checkpoint
+	eg         *errgroup.Group
 }
 
 func (app *App) Started() chan struct{} {
@@ -372,6 +445,9 @@ func (app *App) Initialize() (err error) {
 
 		return errors.New("incompatible tortoise hare params")
 	}
+	if err := app.Config.Pruning.Validate(app.Config.Tortoise.Hdist, app.Config.Tortoise.Zdist); err != nil {
+		return err
+	}
 
 	// override default config in timesync since timesync is using TimeConfigValues
 	timeCfg.TimeConfigValues = app.Config.TIME
@@ -383,6 +459,15 @@ func (app *App) Initialize() (err error) {
 	return nil
 }
 
+// resetGlobalState resets the package level state initialized by the app, so that the app
+// starts from a clean state when it is restarted in the same process.
+func resetGlobalState(conf *config.Config) {
+	events.DisableJournal()
+	events.CloseEventReporter()
+	// resets the effective genesis that is moved forward when the node is recovered
+	types.SetLayersPerEpoch(conf.LayersPerEpoch)
+}
+
 // setupLogging configured the app logging system.
 func (app *App) setupLogging() {
 	log.Info("%s", app.getAppInfo())
@@ -457,19 +542,33 @@ func (app *App) SetLogLevel(name, loglevel string) error {
 	return nil
 }
 
+// smesher groups the services that run separately for every identity of the node.
+type smesher struct {
+	signer          *signing.EdSigner
+	opts            activation.PostSetupOpts
+	postSetupMgr    *activation.PostSetupManager
+	atxBuilder      *activation.Builder
+	proposalBuilder *miner.ProposalBuilder
+}
+
 func (app *App) initServices(
 	ctx context.Context,
-	sgn *signing.EdSigner,
+	signers []*signing.EdSigner,
 	poetClients []activation.PoetProvingServiceClient,
 	vrfSigner *signing.VRFSigner,
 	clock *timesync.NodeClock,
 ) error {
+	sgn := signers[0]
 	nodeID := sgn.NodeID()
 	layerSize := app.Config.LayerAvgSize
 	layersPerEpoch := types.GetLayersPerEpoch()
 	lg := app.log.Named(nodeID.ShortString()).WithFields(nodeID)
 
-	poetDb := activation.NewPoetDb(app.db, app.addLogger(PoetDbLogger, lg))
+	poetKeys, err := app.Config.POET.ServiceKeys()
+	if err != nil {
+		return fmt.Errorf("parse poet service keys: %w", err)
+	}
+	poetDb := activation.NewPoetDb(app.db, app.addLogger(PoetDbLogger, lg), activation.WithPoetServiceKeys(poetKeys))
 	validator := activation.NewValidator(poetDb, app.Config.POST)
 	app.validator = validator
 
@@ -483,11 +582,12 @@ func (app *App) initServices(
 		txs.WithCSConfig(txs.CSConfig{
 			BlockGasLimit:     app.Config.BlockGasLimit,
 			NumTXsPerProposal: app.Config.TxsPerProposal,
+			Mempool:           app.Config.Mempool,
 		}),
 		txs.WithLogger(app.addLogger(ConStateLogger, lg)))
 
 	genesisAccts := app.Config.Genesis.ToAccounts()
-	if len(genesisAccts) > 0 {
+	if len(genesisAccts) > 0 && !app.recovered {
 		exists, err := state.AccountExists(genesisAccts[0].Address)
 		if err != nil {
 			return fmt.Errorf("failed to check genesis account %v: %w", genesisAccts[0].Address, err)
@@ -504,7 +604,6 @@ func (app *App) initServices(
 		return errors.New("invalid golden atx id")
 	}
 
-	var err error
 	app.edVerifier, err = signing.NewEdVerifier(signing.WithVerifierPrefix(app.Config.Genesis.GenesisID().Bytes()))
 	if err != nil {
 		return fmt.Errorf("failed to create signature verifier: %w", err)
@@ -550,6 +649,10 @@ func (app *App) initServices(
 		GracePeriod: app.Config.POET.GracePeriod,
 	}
 	fetcherWrapped := &layerFetcher{}
+	var atxHandlerOpts []activation.HandlerOption
+	if gater := app.host.Gater(); gater != nil {
+		atxHandlerOpts = append(atxHandlerOpts, activation.WithPeerDenier(gater, app.Config.FETCH.BanDuration))
+	}
 	atxHandler := activation.NewHandler(
 		app.cachedDB,
 		app.edVerifier,
@@ -563,6 +666,7 @@ func (app *App) initServices(
 		[]activation.AtxReceiver{trtl, beaconProtocol},
 		app.addLogger(ATXHandlerLogger, lg),
 		poetCfg,
+		atxHandlerOpts...,
 	)
 
 	// we can't have an epoch offset which is greater/equal than the number of layers in an epoch
@@ -592,17 +696,20 @@ func (app *App) initServices(
 		app.addLogger(TxHandlerLogger, lg),
 	)
 
-	app.hOracle = eligibility.New(beaconProtocol, app.cachedDB, vrfVerifier, vrfSigner, app.Config.LayersPerEpoch, app.Config.HareEligibility, app.addLogger(HareOracleLogger, lg))
+	app.hOracle = eligibility.New(beaconProtocol, app.cachedDB, vrfVerifier, app.Config.LayersPerEpoch, app.Config.HareEligibility, app.addLogger(HareOracleLogger, lg))
 	// TODO: genesisMinerWeight is set to app.Config.SpaceToCommit, because PoET ticks are currently hardcoded to 1
 
 	app.Config.Bootstrap.DataDir = app.Config.DataDir()
 	app.Config.Bootstrap.Interval = app.Config.LayerDuration / 5
+	if err := app.Config.Bootstrap.Validate(); err != nil {
+		return fmt.Errorf("bootstrap config: %w", err)
+	}
 	app.updater = bootstrap.New(
 		bootstrap.WithConfig(app.Config.Bootstrap),
 		bootstrap.WithLogger(app.addLogger(BootstrapLogger, lg)),
 	)
 
-	app.certifier = blocks.NewCertifier(app.cachedDB, app.hOracle, nodeID, sgn, app.edVerifier, app.host, clock, beaconProtocol, trtl,
+	app.certifier = blocks.NewCertifier(app.cachedDB, app.hOracle, sgn, app.edVerifier, app.host, clock, beaconProtocol, trtl,
 		blocks.WithCertContext(ctx),
 		blocks.WithCertConfig(blocks.CertConfig{
 			CommitteeSize:    app.Config.HARE.N,
@@ -667,47 +774,6 @@ func (app *App) initServices(
 		app.addLogger(HareLogger, lg),
 	)
 
-	proposalBuilder := miner.NewProposalBuilder(
-		ctx,
-		clock,
-		sgn,
-		vrfSigner,
-		app.cachedDB,
-		app.host,
-		trtl,
-		beaconProtocol,
-		newSyncer,
-		app.conState,
-		miner.WithNodeID(nodeID),
-		miner.WithLayerSize(layerSize),
-		miner.WithLayerPerEpoch(layersPerEpoch),
-		miner.WithHdist(app.Config.Tortoise.Hdist),
-		miner.WithLogger(app.addLogger(ProposalBuilderLogger, lg)),
-	)
-
-	postSetupMgr, err := activation.NewPostSetupManager(
-		nodeID,
-		app.Config.POST,
-		app.addLogger(PostLogger, lg),
-		app.cachedDB, goldenATXID,
-		app.Config.SMESHING.ProvingOpts,
-	)
-	if err != nil {
-		app.log.Panic("failed to create post setup manager: %v", err)
-	}
-
-	nipostBuilder := activation.NewNIPostBuilder(
-		nodeID,
-		postSetupMgr,
-		poetClients,
-		poetDb,
-		app.Config.SMESHING.Opts.DataDir,
-		app.addLogger(NipostBuilderLogger, lg),
-		sgn,
-		poetCfg,
-		clock,
-	)
-
 	var coinbaseAddr types.Address
 	if app.Config.SMESHING.Start {
 		coinbaseAddr, err = types.StringToAddress(app.Config.SMESHING.CoinbaseAccount)
@@ -724,22 +790,85 @@ func (app *App) initServices(
 		GoldenATXID:     goldenATXID,
 		LayersPerEpoch:  layersPerEpoch,
 	}
-	atxBuilder := activation.NewBuilder(
-		builderConfig,
-		nodeID,
-		sgn,
-		app.cachedDB,
-		atxHandler,
-		app.host,
-		nipostBuilder,
-		postSetupMgr,
-		clock,
-		newSyncer,
-		app.addLogger("atxBuilder", lg),
-		activation.WithContext(ctx),
-		activation.WithPoetConfig(poetCfg),
-		activation.WithPoetRetryInterval(app.Config.HARE.WakeupDelta),
-	)
+	smeshers := make([]*smesher, 0, len(signers))
+	for i, sig := range signers {
+		id := sig.NodeID()
+		slg := lg
+		if i > 0 {
+			slg = app.log.Named(id.ShortString()).WithFields(id)
+			app.hare.Register(sig)
+			app.certifier.Register(sig)
+		}
+		sVrfSigner, err := sig.VRFSigner()
+		if err != nil {
+			return fmt.Errorf("could not create vrf signer for %s: %w", id.ShortString(), err)
+		}
+		proposalBuilder := miner.NewProposalBuilder(
+			ctx,
+			clock,
+			sig,
+			sVrfSigner,
+			app.cachedDB,
+			app.host,
+			trtl,
+			beaconProtocol,
+			newSyncer,
+			app.conState,
+			miner.WithNodeID(id),
+			miner.WithLayerSize(layerSize),
+			miner.WithLayerPerEpoch(layersPerEpoch),
+			miner.WithHdist(app.Config.Tortoise.Hdist),
+			miner.WithLogger(app.addLogger(ProposalBuilderLogger, slg)),
+		)
+
+		postSetupMgr, err := activation.NewPostSetupManager(
+			id,
+			app.Config.POST,
+			app.addLogger(PostLogger, slg),
+			app.cachedDB, goldenATXID,
+			app.Config.SMESHING.ProvingOpts,
+		)
+		if err != nil {
+			app.log.Panic("failed to create post setup manager: %v", err)
+		}
+
+		opts := app.smeshingOpts(i)
+		nipostBuilder := activation.NewNIPostBuilder(
+			id,
+			postSetupMgr,
+			poetClients,
+			poetDb,
+			opts.DataDir,
+			app.addLogger(NipostBuilderLogger, slg),
+			sig,
+			poetCfg,
+			clock,
+		)
+
+		atxBuilder := activation.NewBuilder(
+			builderConfig,
+			id,
+			sig,
+			app.cachedDB,
+			atxHandler,
+			app.host,
+			nipostBuilder,
+			postSetupMgr,
+			clock,
+			newSyncer,
+			app.addLogger("atxBuilder", slg),
+			activation.WithContext(ctx),
+			activation.WithPoetConfig(poetCfg),
+			activation.WithPoetRetryInterval(app.Config.HARE.WakeupDelta),
+		)
+		smeshers = append(smeshers, &smesher{
+			signer:          sig,
+			opts:            opts,
+			postSetupMgr:    postSetupMgr,
+			atxBuilder:      atxBuilder,
+			proposalBuilder: proposalBuilder,
+		})
+	}
 
 	malfeasanceHandler := malfeasance.NewHandler(
 		app.cachedDB,
@@ -774,25 +903,31 @@ func (app *App) initServices(
 	app.host.Register(pubsub.BlockCertify, pubsub.ChainGossipHandler(syncHandler, app.certifier.HandleCertifyMessage))
 	app.host.Register(pubsub.MalfeasanceProof, pubsub.ChainGossipHandler(atxSyncHandler, malfeasanceHandler.HandleMalfeasanceProof))
 
-	app.proposalBuilder = proposalBuilder
+	app.smeshers = smeshers
 	app.proposalListener = proposalListener
 	app.mesh = msh
 	app.syncer = newSyncer
 	app.clock = clock
 	app.svm = state
-	app.atxBuilder = atxBuilder
-	app.postSetupMgr = postSetupMgr
 	app.atxHandler = atxHandler
 	app.fetcher = fetcher
 	app.beaconProtocol = beaconProtocol
 	app.tortoise = trtl
+	if app.Config.Pruning.Enabled {
+		app.pruner = prune.New(app.db, trtl,
+			prune.WithConfig(app.Config.Pruning),
+			prune.WithLogger(app.addLogger(PruneLogger, lg)),
+		)
+	}
 	if !app.Config.TIME.Peersync.Disable {
-		app.ptimesync = peersync.New(
-			app.host,
-			app.host,
+		opts := []peersync.Option{
 			peersync.WithLog(app.addLogger(TimeSyncLogger, lg)),
 			peersync.WithConfig(app.Config.TIME.Peersync),
-		)
+		}
+		if app.timeSource != nil {
+			opts = append(opts, peersync.WithTime(app.timeSource))
+		}
+		app.ptimesync = peersync.New(app.host, app.host, opts...)
 	}
 
 	return nil
@@ -837,8 +972,10 @@ func (app *App) startServices(ctx context.Context, appErr chan error) error {
 	if err := app.hare.Start(ctx); err != nil {
 		return fmt.Errorf("cannot start hare: %w", err)
 	}
-	if err := app.proposalBuilder.Start(ctx); err != nil {
-		return fmt.Errorf("cannot start block producer: %w", err)
+	for _, s := range app.smeshers {
+		if err := s.proposalBuilder.Start(ctx); err != nil {
+			return fmt.Errorf("cannot start block producer: %w", err)
+		}
 	}
 
 	if app.Config.SMESHING.Start {
@@ -846,8 +983,10 @@ func (app *App) startServices(ctx context.Context, appErr chan error) error {
 		if err != nil {
 			app.log.Panic("failed to parse CoinbaseAccount address on start `%s`: %v", app.Config.SMESHING.CoinbaseAccount, err)
 		}
-		if err := app.atxBuilder.StartSmeshing(coinbaseAddr, app.Config.SMESHING.Opts); err != nil {
-			log.Panic("failed to start smeshing: %v", err)
+		for _, s := range app.smeshers {
+			if err := s.atxBuilder.StartSmeshing(coinbaseAddr, s.opts); err != nil {
+				log.Panic("failed to start smeshing for %s: %v", s.signer.NodeID().ShortString(), err)
+			}
 		}
 	} else {
 		log.Info("smeshing not started, waiting to be triggered via smesher api")
@@ -857,6 +996,12 @@ func (app *App) startServices(ctx context.Context, appErr chan error) error {
 		app.ptimesync.Start()
 	}
 
+	if app.pruner != nil {
+		app.eg.Go(func() error {
+			return app.pruner.Run(ctx)
+		})
+	}
+
 	if app.updater != nil {
 		app.listenToUpdates(ctx, appErr)
 	}
@@ -868,25 +1013,70 @@ func (app *App) newCheckpointRunnerFunc() grpcserver.CheckpointRunnerFunc {
 		return checkpoint.NewRunner(
 			app.db,
 			checkpoint.WithDataDir(app.Config.DataDir()),
-			checkpoint.WithLogger(app.log.WithName("checkpoint")),
+			checkpoint.WithLogger(app.log.WithName(CheckpointLogger)),
 		)
 	}
 }
 
+// recoverFromCheckpoint copies the checkpoint to the data directory and signals
+// the app to restart its services and recover from it.
+func (app *App) recoverFromCheckpoint(ctx context.Context, uri string) error {
+	fname, err := checkpoint.CopyToLocal(ctx, afero.NewOsFs(), app.Config.DataDir(), uri)
+	if err != nil {
+		return err
+	}
+	app.log.With().Info("checkpoint is ready for recovery", log.String("uri", uri), log.String("path", fname))
+	app.Config.Recovery.Uri = fname
+	select {
+	case app.recover <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// recoverState recovers the state database from the configured checkpoint.
+func (app *App) recoverState(ctx context.Context, lg log.Log) error {
+	_, err := checkpoint.Recover(ctx, app.addLogger(CheckpointLogger, lg), afero.NewOsFs(), &checkpoint.RecoverConfig{
+		DataDir: app.Config.DataDir(),
+		DbFile:  dbFile,
+		Uri:     app.Config.Recovery.Uri,
+	})
+	if err != nil {
+		return fmt.Errorf("recover from checkpoint %s: %w", app.Config.Recovery.Uri, err)
+	}
+	return nil
+}
+
 func (app *App) initService(ctx context.Context, svc grpcserver.Service) (grpcserver.ServiceAPI, error) {
 	switch svc {
 	case grpcserver.Debug:
-		return grpcserver.NewDebugService(app.conState, app.host, app.hOracle), nil
+		return grpcserver.NewDebugService(app.conState, app.host, app.hOracle, grpcserver.WithPeerScores(app.fetcher)), nil
 	case grpcserver.GlobalState:
-		return grpcserver.NewGlobalStateService(app.mesh, app.conState), nil
+		return grpcserver.NewGlobalStateService(app.db, app.mesh, app.conState), nil
 	case grpcserver.Mesh:
 		return grpcserver.NewMeshService(app.mesh, app.conState, app.clock, app.Config.LayersPerEpoch, app.Config.Genesis.GenesisID(), app.Config.LayerDuration, app.Config.LayerAvgSize, uint32(app.Config.TxsPerProposal)), nil
 	case grpcserver.Node:
 		return grpcserver.NewNodeService(ctx, app.host, app.mesh, app.clock, app.syncer, cmd.Version, cmd.Commit), nil
 	case grpcserver.Admin:
-		return grpcserver.NewAdminService(app.newCheckpointRunnerFunc()), nil
+		var opts []grpcserver.AdminOpt
+		if gater := app.host.Gater(); gater != nil {
+			opts = append(opts, grpcserver.WithAccessList(gater))
+		}
+		opts = append(opts,
+			grpcserver.WithDatabaseBackup(app.db),
+			grpcserver.WithDatabaseMigrations(&nodeMigrations{db: app.db, rolledBack: app.rolledBack}),
+		)
+		return grpcserver.NewAdminService(app.newCheckpointRunnerFunc(), app.recoverFromCheckpoint, opts...), nil
 	case grpcserver.Smesher:
-		return grpcserver.NewSmesherService(app.postSetupMgr, app.atxBuilder, app.Config.API.SmesherStreamInterval, app.Config.SMESHING.Opts), nil
+		opts := []grpcserver.SmesherOpt{
+			grpcserver.WithRewardsEstimation(app.cachedDB, app.clock, app.Config.LayerAvgSize, app.Config.LayersPerEpoch),
+			grpcserver.WithMempoolPolicy(app.conState.MempoolPolicy()),
+		}
+		for _, s := range app.smeshers[1:] {
+			opts = append(opts, grpcserver.WithSmesherIdentity(s.postSetupMgr, s.atxBuilder))
+		}
+		primary := app.smeshers[0]
+		return grpcserver.NewSmesherService(primary.postSetupMgr, primary.atxBuilder, app.Config.API.SmesherStreamInterval, app.Config.SMESHING.Opts, opts...), nil
 	case grpcserver.Transaction:
 		return grpcserver.NewTransactionService(app.db, app.host, app.mesh, app.conState, app.syncer, app.txHandler), nil
 	case grpcserver.Activation:
@@ -895,13 +1085,22 @@ func (app *App) initService(ctx context.Context, svc grpcserver.Service) (grpcse
 	return nil, fmt.Errorf("unknown service %s", svc)
 }
 
-func (app *App) newGrpc(logger *zap.Logger, endpoint string) *grpcserver.Server {
-	return grpcserver.New(endpoint,
+func (app *App) newGrpc(
+	logger *zap.Logger,
+	endpoint string,
+	limiter *grpcserver.Limiter,
+	auth *grpcserver.Auth,
+) *grpcserver.Server {
+	opts := []grpc.ServerOption{
 		grpc.ChainStreamInterceptor(grpctags.StreamServerInterceptor(), grpczap.StreamServerInterceptor(logger)),
 		grpc.ChainUnaryInterceptor(grpctags.UnaryServerInterceptor(), grpczap.UnaryServerInterceptor(logger)),
 		grpc.MaxSendMsgSize(app.Config.API.GrpcSendMsgSize),
 		grpc.MaxRecvMsgSize(app.Config.API.GrpcRecvMsgSize),
-	)
+	}
+	// limits and auth interceptors are chained after logging so that rejected calls are logged
+	opts = append(opts, limiter.ServerOptions()...)
+	opts = append(opts, auth.ServerOptions()...)
+	return grpcserver.New(endpoint, opts...)
 }
 
 func (app *App) startAPIServices(ctx context.Context) error {
@@ -911,11 +1110,27 @@ func (app *App) startAPIServices(ctx context.Context) error {
 		unique = map[grpcserver.Service]struct{}{}
 		public []grpcserver.ServiceAPI
 	)
+	publicAuth, err := grpcserver.NewAuth(app.Config.API.PublicAuth)
+	if err != nil {
+		return fmt.Errorf("public api auth: %w", err)
+	}
+	privateAuth, err := grpcserver.NewAuth(app.Config.API.PrivateAuth)
+	if err != nil {
+		return fmt.Errorf("private api auth: %w", err)
+	}
+	publicLimiter, err := grpcserver.NewLimiter(app.Config.API.PublicLimits)
+	if err != nil {
+		return fmt.Errorf("public api limits: %w", err)
+	}
+	privateLimiter, err := grpcserver.NewLimiter(app.Config.API.PrivateLimits)
+	if err != nil {
+		return fmt.Errorf("private api limits: %w", err)
+	}
 	if len(app.Config.API.PublicServices) > 0 {
-		app.grpcPublicService = app.newGrpc(logger, app.Config.API.PublicListener)
+		app.grpcPublicService = app.newGrpc(logger, app.Config.API.PublicListener, publicLimiter, publicAuth)
 	}
 	if len(app.Config.API.PrivateServices) > 0 {
-		app.grpcPrivateService = app.newGrpc(logger, app.Config.API.PrivateListener)
+		app.grpcPrivateService = app.newGrpc(logger, app.Config.API.PrivateListener, privateLimiter, privateAuth)
 	}
 	for _, svc := range app.Config.API.PublicServices {
 		if _, exists := unique[svc]; exists {
@@ -944,7 +1159,10 @@ func (app *App) startAPIServices(ctx context.Context) error {
 		if len(public) == 0 {
 			return fmt.Errorf("can't start json server without public services")
 		}
-		app.jsonAPIService = grpcserver.NewJSONHTTPServer(app.Config.API.JSONListener)
+		app.jsonAPIService = grpcserver.NewJSONHTTPServer(app.Config.API.JSONListener,
+			grpcserver.WithAuth(publicAuth),
+			grpcserver.WithLimiter(publicLimiter),
+		)
 		app.jsonAPIService.StartService(ctx, public...)
 	}
 	if app.grpcPublicService != nil {
@@ -978,8 +1196,8 @@ func (app *App) stopServices(ctx context.Context) {
 		app.updater.Close()
 	}
 
-	if app.proposalBuilder != nil {
-		app.proposalBuilder.Close()
+	for _, s := range app.smeshers {
+		s.proposalBuilder.Close()
 	}
 
 	if app.clock != nil {
@@ -990,8 +1208,8 @@ func (app *App) stopServices(ctx context.Context) {
 		app.beaconProtocol.Close()
 	}
 
-	if app.atxBuilder != nil {
-		_ = app.atxBuilder.StopSmeshing(false)
+	for _, s := range app.smeshers {
+		_ = s.atxBuilder.StopSmeshing(false)
 	}
 
 	if app.hare != nil {
@@ -1025,6 +1243,7 @@ func (app *App) stopServices(ctx context.Context) {
 		}
 	}
 	if app.db != nil {
+		events.DisableJournal()
 		if err := app.db.Close(); err != nil {
 			app.log.With().Warning("db exited with error", log.Err(err))
 		}
@@ -1032,13 +1251,132 @@ func (app *App) stopServices(ctx context.Context) {
 	if app.dbMetrics != nil {
 		app.dbMetrics.Close()
 	}
+	if app.eventsPublisher != nil {
+		app.eventsPublisher.Stop()
+	}
+	if app.remoteSigner != nil {
+		if err := app.remoteSigner.Close(); err != nil {
+			app.log.With().Warning("failed to close remote signer connection", log.Err(err))
+		}
+	}
+	if app.protection != nil {
+		if err := app.protection.Close(); err != nil {
+			app.log.With().Warning("slashing protection db exited with error", log.Err(err))
+		}
+	}
 
 	events.CloseEventReporter()
 }
 
+// smeshingOpts returns the PoST setup options of the i-th identity of the node.
+func (app *App) smeshingOpts(i int) activation.PostSetupOpts {
+	opts := app.Config.SMESHING.Opts
+	if i > 0 {
+		opts.DataDir = app.Config.SMESHING.Identities[i-1]
+	}
+	return opts
+}
+
+// LoadOrCreateEdSigners loads or creates the ed identities of the node: the primary identity
+// and one identity for every additional PoST data directory.
+//
+// If the remote signer is configured, the primary identity signs with it.
+// All identities record signed messages in the slashing protection database.
+func (app *App) LoadOrCreateEdSigners(ctx context.Context) ([]*signing.EdSigner, error) {
+	if err := app.openProtection(); err != nil {
+		return nil, err
+	}
+	passphrase, err := app.keyPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]*signing.EdSigner, 0, 1+len(app.Config.SMESHING.Identities))
+	unique := map[types.NodeID]string{}
+	for i := 0; i <= len(app.Config.SMESHING.Identities); i++ {
+		dir := app.smeshingOpts(i).DataDir
+		var signer *signing.EdSigner
+		if i == 0 && app.Config.SMESHING.RemoteSigner != "" {
+			dir = app.Config.SMESHING.RemoteSigner
+			signer, err = app.connectRemoteSigner(ctx)
+		} else {
+			signer, err = app.loadOrCreateEdSigner(dir, passphrase)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("identity in %s: %w", dir, err)
+		}
+		if other, exists := unique[signer.NodeID()]; exists {
+			return nil, fmt.Errorf("identity in %s is a duplicate of identity in %s", dir, other)
+		}
+		unique[signer.NodeID()] = dir
+		signers = append(signers, signer)
+	}
+	return signers, nil
+}
+
 // LoadOrCreateEdSigner either loads a previously created ed identity for the node or creates a new one if not exists.
 func (app *App) LoadOrCreateEdSigner() (*signing.EdSigner, error) {
-	filename := filepath.Join(app.Config.SMESHING.Opts.DataDir, edKeyFileName)
+	passphrase, err := app.keyPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	return app.loadOrCreateEdSigner(app.Config.SMESHING.Opts.DataDir, passphrase)
+}
+
+// openProtection opens the slashing protection database in the data directory.
+func (app *App) openProtection() error {
+	if app.protection != nil {
+		return nil
+	}
+	if err := os.MkdirAll(app.Config.DataDir(), 0o700); err != nil {
+		return fmt.Errorf("create data directory: %w", err)
+	}
+	protection, err := signing.OpenProtection("file:" + filepath.Join(app.Config.DataDir(), protectionFile))
+	if err != nil {
+		return err
+	}
+	app.protection = protection
+	return nil
+}
+
+// keyPassphrase reads the passphrase of the identity keys. It is nil if keys are not encrypted.
+func (app *App) keyPassphrase() ([]byte, error) {
+	if app.Config.SMESHING.KeyPassphraseFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(app.Config.SMESHING.KeyPassphraseFile)
+	if err != nil {
+		return nil, fmt.Errorf("read key passphrase: %w", err)
+	}
+	passphrase := bytes.TrimRight(data, "\r\n")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("key passphrase file %s is empty", app.Config.SMESHING.KeyPassphraseFile)
+	}
+	return passphrase, nil
+}
+
+func (app *App) connectRemoteSigner(ctx context.Context) (*signing.EdSigner, error) {
+	ctx, cancel := context.WithTimeout(ctx, app.Config.SMESHING.RemoteSignerTimeout)
+	defer cancel()
+	remote, err := signing.NewRemoteSigner(ctx, app.Config.SMESHING.RemoteSigner, app.Config.SMESHING.RemoteSignerTimeout)
+	if err != nil {
+		return nil, err
+	}
+	edSgn, err := signing.NewEdSigner(
+		signing.WithSigner(remote),
+		signing.WithPrefix(app.Config.Genesis.GenesisID().Bytes()),
+		signing.WithProtection(app.protection),
+	)
+	if err != nil {
+		remote.Close()
+		return nil, err
+	}
+	app.remoteSigner = remote
+	log.With().Info("connected to remote signer", edSgn.PublicKey())
+	return edSgn, nil
+}
+
+func (app *App) loadOrCreateEdSigner(dir string, passphrase []byte) (*signing.EdSigner, error) {
+	filename := filepath.Join(dir, edKeyFileName)
 	log.Info("Looking for identity file at `%v`", filename)
 
 	data, err := os.ReadFile(filename)
@@ -1051,6 +1389,7 @@ func (app *App) LoadOrCreateEdSigner() (*signing.EdSigner, error) {
 
 		edSgn, err := signing.NewEdSigner(
 			signing.WithPrefix(app.Config.Genesis.GenesisID().Bytes()),
+			signing.WithProtection(app.protection),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create identity: %w", err)
@@ -1058,36 +1397,53 @@ func (app *App) LoadOrCreateEdSigner() (*signing.EdSigner, error) {
 		if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
 			return nil, fmt.Errorf("failed to create directory for identity file: %w", err)
 		}
-
-		err = os.WriteFile(filename, []byte(hex.EncodeToString(edSgn.PrivateKey())), 0o600)
-		if err != nil {
+		if err := writeKeyFile(filename, edSgn.PrivateKey(), passphrase); err != nil {
 			return nil, fmt.Errorf("failed to write identity file: %w", err)
 		}
 
 		log.With().Info("created new identity", edSgn.PublicKey())
 		return edSgn, nil
 	}
-	dst := make([]byte, signing.PrivateKeySize)
-	n, err := hex.Decode(dst, data)
+	key, err := signing.ParseKey(data, passphrase)
 	if err != nil {
-		return nil, fmt.Errorf("decoding private key: %w", err)
-	}
-	if n != signing.PrivateKeySize {
-		return nil, fmt.Errorf("invalid key size %d/%d", n, signing.PrivateKeySize)
+		return nil, err
 	}
 	edSgn, err := signing.NewEdSigner(
-		signing.WithPrivateKey(dst),
+		signing.WithPrivateKey(key),
 		signing.WithPrefix(app.Config.Genesis.GenesisID().Bytes()),
+		signing.WithProtection(app.protection),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct identity from data file: %w", err)
 	}
+	if passphrase != nil && !signing.IsEncryptedKey(data) {
+		if err := writeKeyFile(filename, key, passphrase); err != nil {
+			return nil, fmt.Errorf("failed to encrypt identity file: %w", err)
+		}
+		log.With().Info("encrypted identity file", log.String("path", filename))
+	}
 
 	log.Info("Loaded existing identity; public key: %v", edSgn.PublicKey())
 
 	return edSgn, nil
 }
 
+// writeKeyFile atomically writes the key to the file. The key is encrypted if passphrase is not nil,
+// otherwise it is hex encoded.
+func writeKeyFile(filename string, key signing.PrivateKey, passphrase []byte) error {
+	data := []byte(hex.EncodeToString(key))
+	if passphrase != nil {
+		var err error
+		if data, err = signing.EncryptKey(key, passphrase); err != nil {
+			return err
+		}
+	}
+	if err := atomic.WriteFile(filename, bytes.NewReader(data)); err != nil {
+		return err
+	}
+	return os.Chmod(filename, 0o600)
+}
+
 func (app *App) startSyncer(ctx context.Context) {
 	app.syncer.Start(ctx)
 }
@@ -1097,7 +1453,7 @@ func (app *App) setupDBs(ctx context.Context, lg log.Log, dbPath string) error {
 		return fmt.Errorf("failed to create %s: %w", dbPath, err)
 	}
 
-	sqlDB, err := sql.Open("file:"+filepath.Join(dbPath, "state.sql"),
+	sqlDB, err := sql.Open("file:"+filepath.Join(dbPath, dbFile),
 		sql.WithConnections(app.Config.DatabaseConnections),
 		sql.WithLatencyMetering(app.Config.DatabaseLatencyMetering),
 	)
@@ -1105,6 +1461,18 @@ func (app *App) setupDBs(ctx context.Context, lg log.Log, dbPath string) error {
 		return fmt.Errorf("open sqlite db %w", err)
 	}
 	app.db = sqlDB
+	rebuilt, err := trie.RebuildIfEmpty(ctx, sqlDB)
+	if err != nil {
+		return fmt.Errorf("rebuild accounts trie: %w", err)
+	}
+	if rebuilt {
+		lg.Info("rebuilt accounts trie")
+	}
+	if app.Config.EventsJournalSize > 0 {
+		if err := events.EnableJournal(sqlDB, app.Config.EventsJournalSize); err != nil {
+			return fmt.Errorf("enable events journal: %w", err)
+		}
+	}
 
 	if app.Config.CollectMetrics {
 		app.dbMetrics = dbmetrics.NewDBMetricsCollector(ctx, sqlDB, app.addLogger(StateDbLogger, lg), 5*time.Minute)
@@ -1148,12 +1516,24 @@ func (app *App) Start(ctx context.Context) error {
 		})
 	}
 
+	/* Create or load miner identity */
+
+	signers, err := app.LoadOrCreateEdSigners(ctx)
+	if err != nil {
+		return fmt.Errorf("could not retrieve identity: %w", err)
+	}
+	edSgn := signers[0]
+
 	if app.Config.ProfilerURL != "" {
 		p, err := profiler.Start(profiler.Config{
 			ApplicationName: app.Config.ProfilerName,
 			// app.Config.ProfilerURL should be the pyroscope server address
 			// TODO: AuthToken? no need right now since server isn't public
 			ServerAddress: app.Config.ProfilerURL,
+			Tags: map[string]string{
+				"node_id":    edSgn.NodeID().String(),
+				"genesis_id": app.Config.Genesis.GenesisID().ShortString(),
+			},
 			// by default all profilers are enabled,
 		})
 		if err != nil {
@@ -1162,20 +1542,16 @@ func (app *App) Start(ctx context.Context) error {
 		defer p.Stop()
 	}
 
-	/* Create or load miner identity */
-
-	edSgn, err := app.LoadOrCreateEdSigner()
-	if err != nil {
-		return fmt.Errorf("could not retrieve identity: %w", err)
-	}
-
-	poetClients := make([]activation.PoetProvingServiceClient, 0, len(app.Config.PoETServers))
-	for _, address := range app.Config.PoETServers {
-		client, err := activation.NewHTTPPoetClient(address, app.Config.POET)
-		if err != nil {
-			return fmt.Errorf("cannot create poet client: %w", err)
+	poetClients := app.poetClients
+	if poetClients == nil {
+		poetClients = make([]activation.PoetProvingServiceClient, 0, len(app.Config.PoETServers))
+		for _, address := range app.Config.PoETServers {
+			client, err := activation.NewHTTPPoetClient(address, app.Config.POET)
+			if err != nil {
+				return fmt.Errorf("cannot create poet client: %w", err)
+			}
+			poetClients = append(poetClients, client)
 		}
-		poetClients = append(poetClients, client)
 	}
 
 	app.nodeID = edSgn.NodeID()
@@ -1188,28 +1564,49 @@ func (app *App) Start(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("cannot parse genesis time %s: %w", app.Config.Genesis.GenesisTime, err)
 	}
-	clock, err := timesync.NewClock(
+	clockOpts := []timesync.OptionFunc{
 		timesync.WithLayerDuration(app.Config.LayerDuration),
-		timesync.WithTickInterval(1*time.Second),
+		timesync.WithTickInterval(1 * time.Second),
 		timesync.WithGenesisTime(gTime),
 		timesync.WithLogger(app.addLogger(ClockLogger, lg)),
-	)
+	}
+	if app.timeSource != nil {
+		clockOpts = append(clockOpts, timesync.WithClock(app.timeSource))
+	}
+	clock, err := timesync.NewClock(clockOpts...)
 	if err != nil {
 		return fmt.Errorf("cannot create clock: %w", err)
 	}
 
-	lg.Info("initializing p2p services")
+	if app.host == nil {
+		lg.Info("initializing p2p services")
 
-	cfg := app.Config.P2P
-	cfg.DataDir = filepath.Join(app.Config.DataDir(), "p2p")
-	p2plog := app.addLogger(P2PLogger, lg)
-	// if addLogger won't add a level we will use a default 0 (info).
-	cfg.LogLevel = app.getLevel(P2PLogger)
-	app.host, err = p2p.New(ctx, p2plog, cfg, app.Config.Genesis.GenesisID(),
-		p2p.WithNodeReporter(events.ReportNodeStatusUpdate),
-	)
+		cfg := app.Config.P2P
+		cfg.DataDir = filepath.Join(app.Config.DataDir(), "p2p")
+		p2plog := app.addLogger(P2PLogger, lg)
+		// if addLogger won't add a level we will use a default 0 (info).
+		cfg.LogLevel = app.getLevel(P2PLogger)
+		app.host, err = p2p.New(ctx, p2plog, cfg, app.Config.Genesis.GenesisID(),
+			p2p.WithNodeReporter(events.ReportNodeStatusUpdate),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to initialize p2p host: %w", err)
+		}
+	}
+
+	// backup staged by `node restore` replaces the database before it is opened
+	restored, err := sql.ApplyRestore(filepath.Join(app.Config.DataDir(), dbFile))
 	if err != nil {
-		return fmt.Errorf("failed to initialize p2p host: %w", err)
+		return fmt.Errorf("restore database from backup: %w", err)
+	}
+	if restored {
+		lg.Info("restored database from backup")
+	}
+
+	if len(app.Config.Recovery.Uri) > 0 {
+		if err = app.recoverState(ctx, lg); err != nil {
+			return err
+		}
 	}
 
 	dbStorepath := app.Config.DataDir()
@@ -1223,9 +1620,22 @@ func (app *App) Start(ctx context.Context) error {
 	}
 
 	types.SetLayersPerEpoch(app.Config.LayersPerEpoch)
+	if _, restore, err := recovery.CheckpointInfo(app.db); err == nil {
+		// the mesh before the restore layer is not available, it is treated as genesis
+		if restore.Sub(1).After(types.GetEffectiveGenesis()) {
+			types.SetEffectiveGenesis(restore.Sub(1).Uint32())
+		}
+		app.recovered = true
+		lg.With().Info("node state recovered from checkpoint",
+			log.Stringer("restore", restore),
+			log.Stringer("effective_genesis", types.GetEffectiveGenesis()),
+		)
+	} else if !errors.Is(err, sql.ErrNotFound) {
+		return fmt.Errorf("load checkpoint info: %w", err)
+	}
 	err = app.initServices(
 		ctx,
-		edSgn,
+		signers,
 		poetClients,
 		vrfSigner,
 		clock,
@@ -1239,8 +1649,44 @@ func (app *App) Start(ctx context.Context) error {
 	}
 
 	if app.Config.MetricsPush != "" {
-		metrics.StartPushingMetrics(app.Config.MetricsPush, app.Config.MetricsPushPeriod,
-			app.host.ID().String(), app.Config.Genesis.GenesisID().ShortString())
+		if err := metrics.StartPushingMetrics(ctx, app.Config.MetricsPush, app.Config.MetricsPushPeriod,
+			app.host.ID().String(), app.Config.Genesis.GenesisID().ShortString()); err != nil {
+			return fmt.Errorf("start pushing metrics: %w", err)
+		}
+	}
+
+	if app.Config.ProfilesPush != "" {
+		cpuDuration := app.Config.ProfilesCPUDuration
+		if app.Config.ProfilerURL != "" {
+			// pyroscope profiles cpu continuously, a second cpu profile can't be started
+			app.log.Info("cpu profile is not pushed while profiler-url is set")
+			cpuDuration = 0
+		}
+		if err := metrics.StartPushingProfiles(ctx, metrics.ProfilesConfig{
+			URL:         app.Config.ProfilesPush,
+			Period:      app.Config.ProfilesPushPeriod,
+			CPUDuration: cpuDuration,
+			Name:        app.Config.ProfilerName,
+			NodeID:      edSgn.NodeID().String(),
+			GenesisID:   app.Config.Genesis.GenesisID().ShortString(),
+		}); err != nil {
+			return fmt.Errorf("start pushing profiles: %w", err)
+		}
+	}
+
+	if app.Config.PublishEventsURL != "" {
+		publisher, err := events.NewPublisher(app.Config.PublishEventsURL,
+			filepath.Join(app.Config.DataDir(), eventsOutboxDir),
+			events.WithPublisherConfig(app.Config.Events),
+			events.WithPublisherLogger(app.addLogger(EventsLogger, lg)),
+		)
+		if err != nil {
+			return fmt.Errorf("create events publisher: %w", err)
+		}
+		if err := publisher.Start(ctx); err != nil {
+			return fmt.Errorf("start events publisher: %w", err)
+		}
+		app.eventsPublisher = publisher
 	}
 
 	if err := app.startServices(ctx, appErr); err != nil {
@@ -1276,6 +1722,11 @@ func (app *App) Start(ctx context.Context) error {
 		return nil
 	case err = <-appErr:
 		return err
+	case <-app.recover:
+		return errRecoveryRestart
+	case <-app.rolledBack:
+		app.log.Info("stopping the node after a migration of the database is rolled back")
+		return nil
 	}
 }
 
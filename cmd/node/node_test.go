@@ -40,6 +40,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/api/grpcserver"
 	"github.com/spacemeshos/go-spacemesh/beacon"
 	"github.com/spacemeshos/go-spacemesh/cmd"
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/common/util"
 	"github.com/spacemeshos/go-spacemesh/config"
@@ -115,6 +116,41 @@ func TestSpacemeshApp_getEdIdentity(t *testing.T) {
 	})
 }
 
+func TestSpacemeshApp_EncryptedEdSigner(t *testing.T) {
+	tempdir := t.TempDir()
+	app := New(WithLog(logtest.New(t)))
+	app.Config.SMESHING.Opts.DataDir = tempdir
+	keyfile := filepath.Join(tempdir, edKeyFileName)
+
+	plain, err := app.LoadOrCreateEdSigner()
+	require.NoError(t, err)
+	data, err := os.ReadFile(keyfile)
+	require.NoError(t, err)
+	require.False(t, signing.IsEncryptedKey(data))
+
+	// plain key is encrypted when passphrase is configured
+	app.Config.SMESHING.KeyPassphraseFile = filepath.Join(tempdir, "passphrase")
+	require.NoError(t, os.WriteFile(app.Config.SMESHING.KeyPassphraseFile, []byte("secret\n"), 0o600))
+	encrypted, err := app.LoadOrCreateEdSigner()
+	require.NoError(t, err)
+	require.Equal(t, plain.PublicKey(), encrypted.PublicKey())
+	data, err = os.ReadFile(keyfile)
+	require.NoError(t, err)
+	require.True(t, signing.IsEncryptedKey(data))
+
+	loaded, err := app.LoadOrCreateEdSigner()
+	require.NoError(t, err)
+	require.Equal(t, plain.PublicKey(), loaded.PublicKey())
+
+	require.NoError(t, os.WriteFile(app.Config.SMESHING.KeyPassphraseFile, []byte("other"), 0o600))
+	_, err = app.LoadOrCreateEdSigner()
+	require.ErrorIs(t, err, signing.ErrInvalidPassphrase)
+
+	app.Config.SMESHING.KeyPassphraseFile = ""
+	_, err = app.LoadOrCreateEdSigner()
+	require.ErrorContains(t, err, "passphrase is required")
+}
+
 func testLoadOrCreateEdSigner(t *testing.T, data []byte, expect string) {
 	tempdir := t.TempDir()
 	app := New(WithLog(logtest.New(t)))
@@ -131,6 +167,70 @@ func testLoadOrCreateEdSigner(t *testing.T, data []byte, expect string) {
 	}
 }
 
+func TestSpacemeshApp_LoadOrCreateEdSigners(t *testing.T) {
+	app := New(WithLog(logtest.New(t)))
+	app.Config.DataDirParent = t.TempDir()
+	app.Config.SMESHING.Opts.DataDir = t.TempDir()
+	app.Config.SMESHING.Identities = []string{t.TempDir(), t.TempDir()}
+
+	signers, err := app.LoadOrCreateEdSigners(context.Background())
+	require.NoError(t, err)
+	require.Len(t, signers, 3)
+	primary, err := app.LoadOrCreateEdSigner()
+	require.NoError(t, err)
+	require.Equal(t, primary.NodeID(), signers[0].NodeID())
+	require.NotEqual(t, signers[0].NodeID(), signers[1].NodeID())
+	require.NotEqual(t, signers[1].NodeID(), signers[2].NodeID())
+
+	loaded, err := app.LoadOrCreateEdSigners(context.Background())
+	require.NoError(t, err)
+	for i := range signers {
+		require.Equal(t, signers[i].NodeID(), loaded[i].NodeID())
+	}
+
+	// the same key can't be used by two identities
+	data, err := os.ReadFile(filepath.Join(app.Config.SMESHING.Identities[0], edKeyFileName))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(app.Config.SMESHING.Identities[1], edKeyFileName), data, 0o600))
+	_, err = app.LoadOrCreateEdSigners(context.Background())
+	require.ErrorContains(t, err, "duplicate")
+}
+
+func TestSpacemeshApp_SlashingProtection(t *testing.T) {
+	newApp := func(dataDir, postDir string) *App {
+		app := New(WithLog(logtest.New(t)))
+		app.Config.DataDirParent = dataDir
+		app.Config.SMESHING.Opts.DataDir = postDir
+		t.Cleanup(func() { app.protection.Close() })
+		return app
+	}
+	postDir := t.TempDir()
+	app := newApp(t.TempDir(), postDir)
+	signers, err := app.LoadOrCreateEdSigners(context.Background())
+	require.NoError(t, err)
+
+	encode := func(hash types.Hash32) []byte {
+		data, err := codec.Encode(&types.BallotMetadata{Layer: types.LayerID(10), MsgHash: hash})
+		require.NoError(t, err)
+		return data
+	}
+	_, err = signers[0].TrySign(signing.BALLOT, encode(types.Hash32{1}))
+	require.NoError(t, err)
+	_, err = signers[0].TrySign(signing.BALLOT, encode(types.Hash32{2}))
+	require.ErrorIs(t, err, signing.ErrConflictingMessage)
+
+	var exported bytes.Buffer
+	require.NoError(t, app.protection.Export(&exported, app.Config.Genesis.GenesisID()))
+
+	// the same identity on another machine
+	other := newApp(t.TempDir(), postDir)
+	signers, err = other.LoadOrCreateEdSigners(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, other.protection.Import(&exported, other.Config.Genesis.GenesisID()))
+	_, err = signers[0].TrySign(signing.BALLOT, encode(types.Hash32{2}))
+	require.ErrorIs(t, err, signing.ErrConflictingMessage)
+}
+
 func newLogger(buf *bytes.Buffer) log.Log {
 	lvl := zap.NewAtomicLevelAt(zapcore.InfoLevel)
 	syncer := zapcore.AddSync(buf)
@@ -967,7 +1067,7 @@ func initSingleInstance(lg log.Log, cfg config.Config, i int, genesisTime string
 
 	smApp.nodeID = edSgn.NodeID()
 	types.SetLayersPerEpoch(smApp.Config.LayersPerEpoch)
-	err = smApp.initServices(context.Background(), edSgn, []activation.PoetProvingServiceClient{poetClient}, vrfSigner, clock)
+	err = smApp.initServices(context.Background(), []*signing.EdSigner{edSgn}, []activation.PoetProvingServiceClient{poetClient}, vrfSigner, clock)
 	if err != nil {
 		return nil, err
 	}
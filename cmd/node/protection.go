@@ -0,0 +1,71 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/natefinch/atomic"
+	"github.com/spf13/cobra"
+
+	"github.com/spacemeshos/go-spacemesh/config"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+// protectionCommand returns the command to move the slashing protection records of the node
+// between machines. Config of the node is loaded from the flags of the root command.
+func protectionCommand(root *cobra.Command) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "slashing-protection",
+		Short: "Export or import messages recorded in the slashing protection database",
+	}
+	c.AddCommand(&cobra.Command{
+		Use:   "export <file>",
+		Short: "Export messages signed by the identities of the node to the file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return withProtection(root, func(conf *config.Config, protection *signing.Protection) error {
+				var buf bytes.Buffer
+				if err := protection.Export(&buf, conf.Genesis.GenesisID()); err != nil {
+					return err
+				}
+				return atomic.WriteFile(args[0], &buf)
+			})
+		},
+	})
+	c.AddCommand(&cobra.Command{
+		Use:   "import <file>",
+		Short: "Import messages signed by the identities of the node from the file",
+		Long: `Import messages signed by the identities of the node from the file exported on another machine.
+Nothing is imported if any of the messages conflicts with a message recorded by this node.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return withProtection(root, func(conf *config.Config, protection *signing.Protection) error {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				return protection.Import(f, conf.Genesis.GenesisID())
+			})
+		},
+	})
+	return c
+}
+
+func withProtection(root *cobra.Command, exec func(*config.Config, *signing.Protection) error) error {
+	conf, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("failed to initialize config: %w", err)
+	}
+	if err := os.MkdirAll(conf.DataDir(), 0o700); err != nil {
+		return fmt.Errorf("create data directory: %w", err)
+	}
+	protection, err := signing.OpenProtection("file:" + filepath.Join(conf.DataDir(), protectionFile))
+	if err != nil {
+		return err
+	}
+	defer protection.Close()
+	return exec(conf, protection)
+}
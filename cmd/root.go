@@ -61,6 +61,12 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.ProfilerURL, "send profiler data to certain url, if no url no profiling will be sent, format: http://<IP>:<PORT>")
 	cmd.PersistentFlags().StringVar(&cfg.ProfilerName, "profiler-name",
 		cfg.ProfilerName, "the name to use when sending profiles")
+	cmd.PersistentFlags().StringVar(&cfg.ProfilesPush, "profiles-push",
+		cfg.ProfilesPush, "upload cpu, heap and goroutine profiles to url. profiles are not uploaded if url is empty")
+	cmd.PersistentFlags().DurationVar(&cfg.ProfilesPushPeriod, "profiles-push-period",
+		cfg.ProfilesPushPeriod, "interval between profile uploads")
+	cmd.PersistentFlags().DurationVar(&cfg.ProfilesCPUDuration, "profiles-cpu-duration",
+		cfg.ProfilesCPUDuration, "duration of the uploaded cpu profiles. cpu profiles are not uploaded if zero or if profiler-url is set")
 
 	cmd.PersistentFlags().IntVar(&cfg.SyncRequestTimeout, "sync-request-timeout",
 		cfg.SyncRequestTimeout, "the timeout in ms for direct requests in the sync")
@@ -82,6 +88,10 @@ func AddCommands(cmd *cobra.Command) {
 
 	cmd.PersistentFlags().StringVar(&cfg.P2P.Listen, "listen",
 		cfg.P2P.Listen, "address for listening")
+	cmd.PersistentFlags().StringVar(&cfg.P2P.ListenQUIC, "listen-quic",
+		cfg.P2P.ListenQUIC, "address for listening with quic transport")
+	cmd.PersistentFlags().StringSliceVar(&cfg.P2P.Transports, "transports",
+		cfg.P2P.Transports, "enabled transports in the order of preference (tcp, quic)")
 	cmd.PersistentFlags().BoolVar(&cfg.P2P.Flood, "flood",
 		cfg.P2P.Flood, "flood created messages to all peers")
 	cmd.PersistentFlags().BoolVar(&cfg.P2P.DisableNatPort, "disable-natport",
@@ -130,6 +140,30 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.API.GrpcSendMsgSize, "GRPC api send message size")
 	cmd.PersistentFlags().StringVar(&cfg.API.JSONListener, "grpc-json-listener",
 		cfg.API.JSONListener, "Socket for the grpc gateway for the list of services in grpc-public-services. If left empty - grpc gateway won't be enabled.")
+	cmd.PersistentFlags().StringVar(&cfg.API.PublicAuth.TLSCert, "grpc-public-tls-cert",
+		cfg.API.PublicAuth.TLSCert, "PEM encoded TLS certificate for grpc-public-listener and grpc-json-listener.")
+	cmd.PersistentFlags().StringVar(&cfg.API.PublicAuth.TLSKey, "grpc-public-tls-key",
+		cfg.API.PublicAuth.TLSKey, "PEM encoded TLS key for grpc-public-listener and grpc-json-listener.")
+	cmd.PersistentFlags().StringVar(&cfg.API.PublicAuth.TLSClientCA, "grpc-public-tls-client-ca",
+		cfg.API.PublicAuth.TLSClientCA, "PEM encoded CA certificates. If set, clients of grpc-public-listener must present a certificate signed by them.")
+	cmd.PersistentFlags().StringSliceVar(&cfg.API.PublicAuth.Tokens, "grpc-public-tokens",
+		cfg.API.PublicAuth.Tokens, "Bearer tokens accepted by grpc-public-listener. If set, calls without a token are rejected.")
+	cmd.PersistentFlags().StringVar(&cfg.API.PrivateAuth.TLSCert, "grpc-private-tls-cert",
+		cfg.API.PrivateAuth.TLSCert, "PEM encoded TLS certificate for grpc-private-listener.")
+	cmd.PersistentFlags().StringVar(&cfg.API.PrivateAuth.TLSKey, "grpc-private-tls-key",
+		cfg.API.PrivateAuth.TLSKey, "PEM encoded TLS key for grpc-private-listener.")
+	cmd.PersistentFlags().StringVar(&cfg.API.PrivateAuth.TLSClientCA, "grpc-private-tls-client-ca",
+		cfg.API.PrivateAuth.TLSClientCA, "PEM encoded CA certificates. If set, clients of grpc-private-listener must present a certificate signed by them.")
+	cmd.PersistentFlags().StringSliceVar(&cfg.API.PrivateAuth.Tokens, "grpc-private-tokens",
+		cfg.API.PrivateAuth.Tokens, "Bearer tokens accepted by grpc-private-listener. If set, calls without a token are rejected.")
+	cmd.PersistentFlags().Float64Var(&cfg.API.PublicLimits.ClientRate, "grpc-public-client-rate",
+		cfg.API.PublicLimits.ClientRate, "Request cost budget replenished every second for a client of the public api. Zero disables the budget.")
+	cmd.PersistentFlags().IntVar(&cfg.API.PublicLimits.ClientBurst, "grpc-public-client-burst",
+		cfg.API.PublicLimits.ClientBurst, "Maximal request cost budget of a client of the public api.")
+	cmd.PersistentFlags().IntVar(&cfg.API.PublicLimits.ClientStreams, "grpc-public-client-streams",
+		cfg.API.PublicLimits.ClientStreams, "Maximal number of concurrent streams of a client of the public api. Zero is unlimited.")
+	cmd.PersistentFlags().IntVar(&cfg.API.PublicLimits.MaxConcurrent, "grpc-public-max-concurrent",
+		cfg.API.PublicLimits.MaxConcurrent, "Maximal number of requests to the public api served concurrently. Zero is unlimited.")
 	/**======================== Hare Flags ========================== **/
 
 	// N determines the size of the hare committee
@@ -188,6 +222,8 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.Tortoise.MaxExceptions, "number of exceptions tolerated for a base ballot")
 	cmd.PersistentFlags().Uint32Var(&cfg.Tortoise.BadBeaconVoteDelayLayers, "tortoise-delay-layers",
 		cfg.Tortoise.BadBeaconVoteDelayLayers, "number of layers to ignore a ballot with a different beacon")
+	cmd.PersistentFlags().Uint32Var(&cfg.Tortoise.SnapshotInterval, "tortoise-snapshot-interval",
+		cfg.Tortoise.SnapshotInterval, "number of layers between persisted snapshots of the tortoise state (0 disables snapshots)")
 
 	// TODO(moshababo): add usage desc
 	cmd.PersistentFlags().Uint64Var(&cfg.POST.LabelsPerUnit, "post-labels-per-unit",
@@ -225,6 +261,14 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.SMESHING.Opts.ProviderID, "")
 	cmd.PersistentFlags().BoolVar(&cfg.SMESHING.Opts.Throttle, "smeshing-opts-throttle",
 		cfg.SMESHING.Opts.Throttle, "")
+	cmd.PersistentFlags().StringSliceVar(&cfg.SMESHING.Identities, "smeshing-identities",
+		cfg.SMESHING.Identities, "additional PoST data directories, each is used by a separate smeshing identity")
+	cmd.PersistentFlags().StringVar(&cfg.SMESHING.KeyPassphraseFile, "smeshing-key-passphrase-file",
+		cfg.SMESHING.KeyPassphraseFile, "file with the passphrase to encrypt identity keys. existing plain keys are encrypted on startup")
+	cmd.PersistentFlags().StringVar(&cfg.SMESHING.RemoteSigner, "smeshing-remote-signer",
+		cfg.SMESHING.RemoteSigner, "unix socket of the remote signer that holds the key of the primary identity, e.g. unix:///run/signer.sock")
+	cmd.PersistentFlags().DurationVar(&cfg.SMESHING.RemoteSignerTimeout, "smeshing-remote-signer-timeout",
+		cfg.SMESHING.RemoteSignerTimeout, "timeout of the requests to the remote signer")
 
 	/**======================== Consensus Flags ========================== **/
 
@@ -239,12 +283,60 @@ func AddCommands(cmd *cobra.Command) {
 		cfg.POET.CycleGap, "cycle gap of poet server")
 	cmd.PersistentFlags().DurationVar(&cfg.POET.GracePeriod, "grace-period",
 		cfg.POET.GracePeriod, "propagation time for ATXs in the network")
+	cmd.PersistentFlags().StringSliceVar(&cfg.POET.ServicePubkeys, "poet-service-pubkeys",
+		cfg.POET.ServicePubkeys, "hex encoded public keys of trusted poet services. challenges are submitted only to them and proofs must be signed by them. any service is trusted if empty")
 
 	/**======================== bootstrap data updater Flags ========================== **/
 	cmd.PersistentFlags().StringVar(&cfg.Bootstrap.URL, "bootstrap-url",
 		cfg.Bootstrap.URL, "the url to query bootstrap data update")
 	cmd.PersistentFlags().StringVar(&cfg.Bootstrap.Version, "bootstrap-version",
 		cfg.Bootstrap.Version, "the update version of the bootstrap data")
+	cmd.PersistentFlags().StringSliceVar(&cfg.Bootstrap.TrustedKeys, "bootstrap-trusted-keys",
+		cfg.Bootstrap.TrustedKeys, "hex encoded ed25519 public keys trusted to sign bootstrap data. if set, unsigned data is rejected")
+	cmd.PersistentFlags().IntVar(&cfg.Bootstrap.Threshold, "bootstrap-threshold",
+		cfg.Bootstrap.Threshold, "the number of trusted keys that must sign bootstrap data")
+
+	/**======================== checkpoint Flags ========================== **/
+	cmd.PersistentFlags().StringVar(&cfg.Recovery.Uri, "recover-from",
+		cfg.Recovery.Uri, "recover the node from a checkpoint file. either a local path or an http(s) url")
+
+	/**======================== mempool Flags ========================== **/
+	cmd.PersistentFlags().Uint64Var(&cfg.Mempool.MinGasPrice, "mempool-min-gas-price",
+		cfg.Mempool.MinGasPrice, "minimal gas price of transactions accepted into the mempool")
+	cmd.PersistentFlags().IntVar(&cfg.Mempool.MaxTXsPerPrincipal, "mempool-max-txs-per-principal",
+		cfg.Mempool.MaxTXsPerPrincipal, "max number of pending transactions of a single principal in the mempool")
+	cmd.PersistentFlags().IntVar(&cfg.Mempool.MaxSize, "mempool-max-size",
+		cfg.Mempool.MaxSize, "max number of transactions in the mempool, transactions with the lowest fee are evicted first. 0 means no limit")
+	cmd.PersistentFlags().DurationVar(&cfg.Mempool.TTL, "mempool-ttl",
+		cfg.Mempool.TTL, "time a transaction can stay in the mempool without being included. 0 means no limit")
+
+	/**======================== pruning Flags ========================== **/
+	cmd.PersistentFlags().BoolVar(&cfg.Pruning.Enabled, "pruning-enabled",
+		cfg.Pruning.Enabled, "delete proposals, ballot bodies, certificates and accounts history older than the retention window")
+	cmd.PersistentFlags().Uint32Var(&cfg.Pruning.Retention, "pruning-retention",
+		cfg.Pruning.Retention, "number of layers before the latest verified layer whose data is kept. must be at least tortoise hdist + zdist")
+	cmd.PersistentFlags().DurationVar(&cfg.Pruning.Interval, "pruning-interval",
+		cfg.Pruning.Interval, "interval between pruning runs")
+	cmd.PersistentFlags().Uint32Var(&cfg.Pruning.BatchLayers, "pruning-batch-layers",
+		cfg.Pruning.BatchLayers, "max number of layers pruned in a single database transaction")
+
+	/**======================== events publisher Flags ========================== **/
+	cmd.PersistentFlags().StringVar(&cfg.PublishEventsURL, "events-url",
+		cfg.PublishEventsURL, "webhook url where node events are posted in json batches. empty url disables publishing")
+	cmd.PersistentFlags().Uint64Var(&cfg.EventsJournalSize, "events-journal-size",
+		cfg.EventsJournalSize, "number of latest events persisted so that api streams can be resumed with from_cursor. 0 disables the journal")
+	cmd.PersistentFlags().IntVar(&cfg.Events.BatchSize, "events-batch-size",
+		cfg.Events.BatchSize, "max number of events posted in a single request")
+	cmd.PersistentFlags().DurationVar(&cfg.Events.FlushInterval, "events-flush-interval",
+		cfg.Events.FlushInterval, "max time an incomplete batch waits before it is posted")
+	cmd.PersistentFlags().DurationVar(&cfg.Events.RetryInterval, "events-retry-interval",
+		cfg.Events.RetryInterval, "initial delay before failed batch is posted again, doubled after every failure")
+	cmd.PersistentFlags().DurationVar(&cfg.Events.MaxRetryInterval, "events-max-retry-interval",
+		cfg.Events.MaxRetryInterval, "max delay before failed batch is posted again")
+	cmd.PersistentFlags().DurationVar(&cfg.Events.RequestTimeout, "events-request-timeout",
+		cfg.Events.RequestTimeout, "timeout for a single request to the webhook")
+	cmd.PersistentFlags().IntVar(&cfg.Events.OutboxSize, "events-outbox-size",
+		cfg.Events.OutboxSize, "max number of undelivered batches kept on disk. new events are dropped once it is full")
 
 	// Bind Flags to config
 	err := viper.BindPFlags(cmd.PersistentFlags())
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/natefinch/atomic"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+var (
+	listen         string
+	keyFile        string
+	passphraseFile string
+	protectionFile string
+	logLevel       string
+)
+
+func init() {
+	cmd.PersistentFlags().StringVar(&keyFile, "key", "key.bin",
+		"identity file of the node: hex encoded or encrypted private key")
+	cmd.PersistentFlags().StringVar(&passphraseFile, "passphrase-file", "",
+		"file with the passphrase of the encrypted key")
+	cmd.Flags().StringVar(&listen, "listen", "unix:///tmp/spacemesh-signer.sock",
+		"unix socket to listen on: unix:///path/to/socket. only the owner of the signer can connect to it")
+	cmd.Flags().StringVar(&protectionFile, "protection", "slashing_protection.sql",
+		"slashing protection database with the messages signed by the identity")
+	cmd.Flags().StringVar(&logLevel, "level", "info", "logging level")
+	cmd.AddCommand(encryptCmd)
+}
+
+var cmd = &cobra.Command{
+	Use:   "signer",
+	Short: "sign messages of the smeshing identity on behalf of the node",
+	Long: `signer holds the private key of the smeshing identity and signs messages for the node
+started with --smeshing-remote-signer. It records signed ATXs, ballots and hare messages in the
+slashing protection database, and refuses to sign a message that conflicts with a recorded one.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lvl, err := zap.ParseAtomicLevel(strings.ToLower(logLevel))
+		if err != nil {
+			return err
+		}
+		logger := log.NewWithLevel("signer", lvl)
+		key, err := loadKey()
+		if err != nil {
+			return err
+		}
+		path, err := parseListen(listen)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove stale socket %s: %w", path, err)
+		}
+		lis, err := net.Listen("unix", path)
+		if err != nil {
+			return fmt.Errorf("listen on %s: %w", listen, err)
+		}
+		// the service doesn't authenticate clients, the socket must be accessible only by the node
+		if err := os.Chmod(path, 0o600); err != nil {
+			lis.Close()
+			return fmt.Errorf("restrict access to socket %s: %w", path, err)
+		}
+		protection, err := signing.OpenProtection("file:" + protectionFile)
+		if err != nil {
+			return err
+		}
+		defer protection.Close()
+		server := signing.NewSignerServer(signing.NewSignerService(key, protection, logger))
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		go func() {
+			<-ctx.Done()
+			server.GracefulStop()
+		}()
+		logger.With().Info("serving signer",
+			log.String("address", listen),
+			signing.NewPublicKey(signing.Public(key)),
+		)
+		return server.Serve(lis)
+	},
+}
+
+var encryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "encrypt the plain hex identity file with the passphrase",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passphrase, err := readPassphrase()
+		if err != nil {
+			return err
+		}
+		if passphrase == nil {
+			return errors.New("--passphrase-file is required")
+		}
+		key, err := loadKey()
+		if err != nil {
+			return err
+		}
+		data, err := signing.EncryptKey(key, passphrase)
+		if err != nil {
+			return err
+		}
+		return atomic.WriteFile(keyFile, bytes.NewReader(data))
+	},
+}
+
+func readPassphrase() ([]byte, error) {
+	if passphraseFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(passphraseFile)
+	if err != nil {
+		return nil, fmt.Errorf("read passphrase: %w", err)
+	}
+	return bytes.TrimRight(data, "\r\n"), nil
+}
+
+func loadKey() (signing.PrivateKey, error) {
+	passphrase, err := readPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read key %s: %w", keyFile, err)
+	}
+	key, err := signing.ParseKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", keyFile, err)
+	}
+	return key, nil
+}
+
+// parseListen returns the path of the socket. Only unix sockets are supported, as the
+// connection to the signer is neither encrypted nor authenticated.
+func parseListen(address string) (string, error) {
+	path := strings.TrimPrefix(address, "unix://")
+	if !strings.HasPrefix(address, "unix://") || path == "" {
+		return "", fmt.Errorf("address %s must be a unix socket: unix:///path/to/socket", address)
+	}
+	return path, nil
+}
+
+func main() {
+	if err := cmd.Execute(); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -27,6 +27,12 @@ func SetLayersPerEpoch(layers uint32) {
 	atomic.StoreUint32(&effectiveGenesis, layers*2-1)
 }
 
+// SetEffectiveGenesis overwrites the effective genesis layer. It is used when the node
+// is recovered from a checkpoint and the mesh before the restore layer is not available.
+func SetEffectiveGenesis(eg uint32) {
+	atomic.StoreUint32(&effectiveGenesis, eg)
+}
+
 // GetLayersPerEpoch returns number of layers per epoch.
 func GetLayersPerEpoch() uint32 {
 	return atomic.LoadUint32(&layersPerEpoch)
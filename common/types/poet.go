@@ -123,6 +123,15 @@ func (proofMessage *PoetProofMessage) Ref() (PoetProofRef, error) {
 	return (PoetProofRef)(h), nil
 }
 
+// SignedBytes returns the data that is signed by the PoET service: the root of the proof,
+// the service ID and the round ID.
+func (proofMessage *PoetProofMessage) SignedBytes() []byte {
+	msg := make([]byte, 0, len(proofMessage.Root)+len(proofMessage.PoetServiceID)+len(proofMessage.RoundID))
+	msg = append(msg, proofMessage.Root...)
+	msg = append(msg, proofMessage.PoetServiceID...)
+	return append(msg, proofMessage.RoundID...)
+}
+
 type RoundEnd time.Time
 
 func (re RoundEnd) Equal(other RoundEnd) bool {
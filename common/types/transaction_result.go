@@ -60,8 +60,64 @@ func (h *TransactionResult) MarshalLogObject(encoder log.ObjectEncoder) error {
 	return nil
 }
 
+// TransactionEventType is a type of the event emitted by the account template.
+type TransactionEventType uint8
+
+const (
+	// TransactionEventSpawn is emitted when an account is spawned.
+	TransactionEventSpawn TransactionEventType = iota + 1
+	// TransactionEventSpend is emitted when coins are transferred from the principal account.
+	TransactionEventSpend
+	// TransactionEventDrain is emitted when coins are transferred from the vault on behalf of the principal.
+	TransactionEventDrain
+)
+
+// String implements human readable representation of the event type.
+func (t TransactionEventType) String() string {
+	switch t {
+	case TransactionEventSpawn:
+		return "spawn"
+	case TransactionEventSpend:
+		return "spend"
+	case TransactionEventDrain:
+		return "drain"
+	}
+	return "unknown"
+}
+
+// TransactionEvent is emitted by the account template while executing the transaction.
+// Events are recorded only for successfully applied transactions.
+type TransactionEvent struct {
+	Type TransactionEventType
+	// Template of the account that emitted the event, or of the spawned account for spawn events.
+	Template  Address
+	Principal Address
+	// Account is the spawned account for spawn events and the vault for drain events.
+	Account Address
+	// Destination and Amount are set for events that transfer coins.
+	Destination Address
+	Amount      uint64
+}
+
+// MarshalLogObject implements encoding for the tx event.
+func (e *TransactionEvent) MarshalLogObject(encoder log.ObjectEncoder) error {
+	encoder.AddString("type", e.Type.String())
+	encoder.AddString("template", e.Template.String())
+	encoder.AddString("principal", e.Principal.String())
+	if e.Type == TransactionEventSpawn || e.Type == TransactionEventDrain {
+		encoder.AddString("account", e.Account.String())
+	}
+	if e.Type == TransactionEventSpend || e.Type == TransactionEventDrain {
+		encoder.AddString("destination", e.Destination.String())
+		encoder.AddUint64("amount", e.Amount)
+	}
+	return nil
+}
+
 // TransactionWithResult is a transaction with attached result.
 type TransactionWithResult struct {
 	Transaction
 	TransactionResult
+	// Events emitted by the account templates, they are stored separately from the result.
+	Events []TransactionEvent `scale:"max=16"`
 }
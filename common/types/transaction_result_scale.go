@@ -119,6 +119,100 @@ func (t *TransactionResult) DecodeScale(dec *scale.Decoder) (total int, err erro
 	return total, nil
 }
 
+func (t *TransactionEvent) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeCompact8(enc, uint8(t.Type))
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeByteArray(enc, t.Template[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeByteArray(enc, t.Principal[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeByteArray(enc, t.Account[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeByteArray(enc, t.Destination[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeCompact64(enc, uint64(t.Amount))
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *TransactionEvent) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		field, n, err := scale.DecodeCompact8(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Type = TransactionEventType(field)
+	}
+	{
+		n, err := scale.DecodeByteArray(dec, t.Template[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.DecodeByteArray(dec, t.Principal[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.DecodeByteArray(dec, t.Account[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.DecodeByteArray(dec, t.Destination[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		field, n, err := scale.DecodeCompact64(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Amount = uint64(field)
+	}
+	return total, nil
+}
+
 func (t *TransactionWithResult) EncodeScale(enc *scale.Encoder) (total int, err error) {
 	{
 		n, err := t.Transaction.EncodeScale(enc)
@@ -134,6 +228,13 @@ func (t *TransactionWithResult) EncodeScale(enc *scale.Encoder) (total int, err
 		}
 		total += n
 	}
+	{
+		n, err := scale.EncodeStructSliceWithLimit(enc, t.Events, 16)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
 	return total, nil
 }
 
@@ -152,5 +253,13 @@ func (t *TransactionWithResult) DecodeScale(dec *scale.Decoder) (total int, err
 		}
 		total += n
 	}
+	{
+		field, n, err := scale.DecodeStructSliceWithLimit[TransactionEvent](dec, 16)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Events = field
+	}
 	return total, nil
 }
@@ -14,14 +14,18 @@ import (
 	"github.com/spacemeshos/go-spacemesh/api/grpcserver"
 	"github.com/spacemeshos/go-spacemesh/beacon"
 	"github.com/spacemeshos/go-spacemesh/bootstrap"
+	"github.com/spacemeshos/go-spacemesh/checkpoint"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/fetch"
 	vm "github.com/spacemeshos/go-spacemesh/genvm"
 	hareConfig "github.com/spacemeshos/go-spacemesh/hare/config"
 	eligConfig "github.com/spacemeshos/go-spacemesh/hare/eligibility/config"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/prune"
 	timeConfig "github.com/spacemeshos/go-spacemesh/timesync/config"
 	"github.com/spacemeshos/go-spacemesh/tortoise"
+	"github.com/spacemeshos/go-spacemesh/txs"
 )
 
 const (
@@ -42,22 +46,26 @@ func init() {
 // Config defines the top level configuration for a spacemesh node.
 type Config struct {
 	BaseConfig      `mapstructure:"main"`
-	Address         *types.Config         `mapstructure:"address"`
-	Genesis         *GenesisConfig        `mapstructure:"genesis"`
-	Tortoise        tortoise.Config       `mapstructure:"tortoise"`
-	P2P             p2p.Config            `mapstructure:"p2p"`
-	API             grpcserver.Config     `mapstructure:"api"`
-	HARE            hareConfig.Config     `mapstructure:"hare"`
-	HareEligibility eligConfig.Config     `mapstructure:"hare-eligibility"`
-	Beacon          beacon.Config         `mapstructure:"beacon"`
-	TIME            timeConfig.TimeConfig `mapstructure:"time"`
-	VM              vm.Config             `mapstructure:"vm"`
-	POST            activation.PostConfig `mapstructure:"post"`
-	POET            activation.PoetConfig `mapstructure:"poet"`
-	SMESHING        SmeshingConfig        `mapstructure:"smeshing"`
-	LOGGING         LoggerConfig          `mapstructure:"logging"`
-	FETCH           fetch.Config          `mapstructure:"fetch"`
-	Bootstrap       bootstrap.Config      `mapstructure:"bootstrap"`
+	Address         *types.Config          `mapstructure:"address"`
+	Genesis         *GenesisConfig         `mapstructure:"genesis"`
+	Tortoise        tortoise.Config        `mapstructure:"tortoise"`
+	P2P             p2p.Config             `mapstructure:"p2p"`
+	API             grpcserver.Config      `mapstructure:"api"`
+	HARE            hareConfig.Config      `mapstructure:"hare"`
+	HareEligibility eligConfig.Config      `mapstructure:"hare-eligibility"`
+	Beacon          beacon.Config          `mapstructure:"beacon"`
+	TIME            timeConfig.TimeConfig  `mapstructure:"time"`
+	VM              vm.Config              `mapstructure:"vm"`
+	POST            activation.PostConfig  `mapstructure:"post"`
+	POET            activation.PoetConfig  `mapstructure:"poet"`
+	SMESHING        SmeshingConfig         `mapstructure:"smeshing"`
+	LOGGING         LoggerConfig           `mapstructure:"logging"`
+	FETCH           fetch.Config           `mapstructure:"fetch"`
+	Bootstrap       bootstrap.Config       `mapstructure:"bootstrap"`
+	Recovery        checkpoint.Config      `mapstructure:"recovery"`
+	Mempool         txs.MempoolConfig      `mapstructure:"mempool"`
+	Pruning         prune.Config           `mapstructure:"pruning"`
+	Events          events.PublisherConfig `mapstructure:"events"`
 }
 
 // DataDir returns the absolute path to use for the node's data. This is the tilde-expanded path given in the config
@@ -82,6 +90,15 @@ type BaseConfig struct {
 	ProfilerName string `mapstructure:"profiler-name"`
 	ProfilerURL  string `mapstructure:"profiler-url"`
 
+	// ProfilesPush is the url where cpu, heap and goroutine profiles are uploaded.
+	// Empty url disables uploads.
+	ProfilesPush string `mapstructure:"profiles-push"`
+	// ProfilesPushPeriod is the interval between profile uploads.
+	ProfilesPushPeriod time.Duration `mapstructure:"profiles-push-period"`
+	// ProfilesCPUDuration is the duration of every uploaded cpu profile. CPU profiles are not uploaded
+	// if it is zero or if ProfilerURL is set, as pyroscope already profiles cpu.
+	ProfilesCPUDuration time.Duration `mapstructure:"profiles-cpu-duration"`
+
 	OracleServer        string `mapstructure:"oracle_server"`
 	OracleServerWorldID int    `mapstructure:"oracle_server_worldid"`
 
@@ -97,7 +114,11 @@ type BaseConfig struct {
 
 	SyncInterval int `mapstructure:"sync-interval"` // sync interval in seconds
 
+	// PublishEventsURL is the webhook url where node events are posted. Empty url disables publishing.
 	PublishEventsURL string `mapstructure:"events-url"`
+	// EventsJournalSize is the number of latest events persisted for resumable api streams.
+	// Zero disables the journal.
+	EventsJournalSize uint64 `mapstructure:"events-journal-size"`
 
 	TxsPerProposal int    `mapstructure:"txs-per-proposal"`
 	BlockGasLimit  uint64 `mapstructure:"block-gas-limit"`
@@ -116,6 +137,17 @@ type SmeshingConfig struct {
 	CoinbaseAccount string                     `mapstructure:"smeshing-coinbase"`
 	Opts            activation.PostSetupOpts   `mapstructure:"smeshing-opts"`
 	ProvingOpts     activation.PostProvingOpts `mapstructure:"smeshing-proving-opts"`
+	// Identities is a list of additional PoST data directories. The node smeshes with a separate
+	// identity for every directory, the key of the identity is stored in the directory.
+	Identities []string `mapstructure:"smeshing-identities"`
+	// KeyPassphraseFile is the file with the passphrase of the identity keys. If it is set, keys are
+	// stored encrypted and existing plain hex keys are encrypted on startup.
+	KeyPassphraseFile string `mapstructure:"smeshing-key-passphrase-file"`
+	// RemoteSigner is the unix socket of the signer process that holds the key of the primary identity.
+	// The key is not loaded by the node if it is set.
+	RemoteSigner string `mapstructure:"smeshing-remote-signer"`
+	// RemoteSignerTimeout is the timeout of the requests to the remote signer.
+	RemoteSignerTimeout time.Duration `mapstructure:"smeshing-remote-signer-timeout"`
 }
 
 // DefaultConfig returns the default configuration for a spacemesh node.
@@ -138,6 +170,10 @@ func DefaultConfig() Config {
 		FETCH:           fetch.DefaultConfig(),
 		LOGGING:         defaultLoggingConfig(),
 		Bootstrap:       bootstrap.DefaultConfig(),
+		Recovery:        checkpoint.DefaultConfig(),
+		Mempool:         txs.DefaultMempoolConfig(),
+		Pruning:         prune.DefaultConfig(),
+		Events:          events.DefaultPublisherConfig(),
 	}
 }
 
@@ -162,6 +198,8 @@ func defaultBaseConfig() BaseConfig {
 		MetricsPushPeriod:   60,
 		ProfilerURL:         "",
 		ProfilerName:        "gp-spacemesh",
+		ProfilesPushPeriod:  time.Minute,
+		ProfilesCPUDuration: 10 * time.Second,
 		OracleServer:        "http://localhost:3030",
 		OracleServerWorldID: 0,
 		LayerDuration:       30 * time.Second,
@@ -174,6 +212,7 @@ func defaultBaseConfig() BaseConfig {
 		OptFilterThreshold:  90,
 		TickSize:            100,
 		DatabaseConnections: 16,
+		EventsJournalSize:   100_000,
 	}
 }
 
@@ -184,6 +223,8 @@ func DefaultSmeshingConfig() SmeshingConfig {
 		CoinbaseAccount: "",
 		Opts:            activation.DefaultPostSetupOpts(),
 		ProvingOpts:     activation.DefaultPostProvingOpts(),
+
+		RemoteSignerTimeout: 10 * time.Second,
 	}
 }
 
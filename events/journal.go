@@ -0,0 +1,378 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/event"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/journal"
+)
+
+const (
+	// number of events loaded from the database at once during replay.
+	replayBatch = 100
+	// journal is pruned once per pruneInterval events.
+	pruneInterval = 1000
+)
+
+var (
+	// ErrCursorPruned is returned if events after the cursor are no longer in the journal.
+	ErrCursorPruned = errors.New("events after the cursor are not in the journal")
+	// ErrCursorAhead is returned if the cursor is greater than the cursor of the latest event.
+	ErrCursorAhead = errors.New("cursor is ahead of the journal")
+)
+
+// Record is an event from the journal. Exactly one of the event fields is set.
+//
+// Cursor is monotonic across all journaled events. Client that received
+// events up to some cursor can subscribe with WithReplay to receive events that it missed.
+type Record struct {
+	Cursor  uint64                       `json:"-"`
+	Layer   *LayerUpdate                 `json:",omitempty"`
+	Result  *types.TransactionWithResult `json:",omitempty"`
+	Account *Account                     `json:",omitempty"`
+	Reward  *Reward                      `json:",omitempty"`
+}
+
+// eventsJournal assigns cursors to the events and persists them in the database
+// if it was enabled with EnableJournal.
+//
+// Events are persisted in batches by a background goroutine, so that reporting an event
+// doesn't wait for the database. Events that are not persisted yet are kept in pending
+// and replayed from memory.
+type eventsJournal struct {
+	emitter event.Emitter
+
+	mu      sync.Mutex
+	db      *sql.Database
+	size    uint64
+	last    uint64
+	pending []Record
+	wake    chan struct{}
+	stop    func()
+
+	// flushMu serializes writes of the pending events.
+	flushMu sync.Mutex
+}
+
+// EnableJournal persists journaled events in the database, keeping up to size latest events.
+// Zero size disables pruning. It is a noop if reporter is not initialized.
+func EnableJournal(db *sql.Database, size uint64) error {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter == nil {
+		return nil
+	}
+	last, err := journal.Last(db)
+	if err != nil {
+		return err
+	}
+	j := reporter.journal
+	j.disable()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.db = db
+	j.size = size
+	if last > j.last {
+		j.last = last
+	}
+	j.wake = make(chan struct{}, 1)
+	j.stop = func() {
+		cancel()
+		<-done
+	}
+	go func() {
+		defer close(done)
+		j.run(ctx, j.wake)
+	}()
+	return nil
+}
+
+// DisableJournal stops persisting events in the database after persisting pending events.
+// Cursors are still assigned to the live events.
+func DisableJournal() {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter == nil {
+		return
+	}
+	reporter.journal.disable()
+}
+
+func (j *eventsJournal) disable() {
+	j.mu.Lock()
+	stop := j.stop
+	j.stop = nil
+	j.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.db = nil
+	j.pending = nil
+}
+
+func (j *eventsJournal) append(rec Record) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	rec.Cursor = j.last + 1
+	j.last = rec.Cursor
+	if j.db != nil {
+		j.pending = append(j.pending, rec)
+		select {
+		case j.wake <- struct{}{}:
+		default:
+		}
+	}
+	if err := j.emitter.Emit(rec); err != nil {
+		log.With().Error("failed to emit journaled event", log.Uint64("cursor", rec.Cursor), log.Err(err))
+	}
+}
+
+func (j *eventsJournal) run(ctx context.Context, wake <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			j.flush()
+			return
+		case <-wake:
+			j.flush()
+		}
+	}
+}
+
+// flush persists pending events in a single transaction.
+func (j *eventsJournal) flush() {
+	j.flushMu.Lock()
+	defer j.flushMu.Unlock()
+	j.mu.Lock()
+	db, size, batch := j.db, j.size, j.pending
+	j.mu.Unlock()
+	if db == nil || len(batch) == 0 {
+		return
+	}
+	if err := persist(db, size, batch); err != nil {
+		log.With().Error("failed to persist events",
+			log.Uint64("from", batch[0].Cursor),
+			log.Uint64("to", batch[len(batch)-1].Cursor),
+			log.Err(err),
+		)
+	}
+	// events are removed only after they are persisted, so that replay can read them
+	// either from the database or from memory
+	j.mu.Lock()
+	j.pending = j.pending[len(batch):]
+	j.mu.Unlock()
+}
+
+func persist(db *sql.Database, size uint64, batch []Record) error {
+	return db.WithTx(context.Background(), func(tx *sql.Tx) error {
+		for i := range batch {
+			data, err := json.Marshal(&batch[i])
+			if err != nil {
+				return fmt.Errorf("encode event: %w", err)
+			}
+			if err := journal.Add(tx, batch[i].Cursor, data); err != nil {
+				return err
+			}
+		}
+		// pruned at the last multiple of pruneInterval in the batch
+		first, last := batch[0].Cursor, batch[len(batch)-1].Cursor
+		at := last / pruneInterval * pruneInterval
+		if size > 0 && at >= first && at > size {
+			return journal.Prune(tx, at-size)
+		}
+		return nil
+	})
+}
+
+// WithReplay replays journaled events with cursor greater than from before live events.
+func WithReplay(from uint64) SubOpt {
+	return func(conf *subconf) {
+		conf.replay = &from
+	}
+}
+
+// SubscribeJournal subscribes to journaled events, filtered with matcher.
+// If WithReplay is set the subscription starts with persisted events after the cursor,
+// otherwise it receives only events reported after subscription.
+//
+// Returns the cursor after which the subscription starts.
+func SubscribeJournal(matcher func(*Record) bool, opts ...SubOpt) (*BufferedSubscription[Record], uint64, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter == nil {
+		return nil, 0, ErrNotInitialized
+	}
+	conf := newSubconf(opts...)
+	j := reporter.journal
+
+	j.mu.Lock()
+	from := j.last
+	db := j.db
+	replay := conf.replay != nil && *conf.replay < from
+	var pending []Record
+	if conf.replay != nil {
+		if *conf.replay > from {
+			j.mu.Unlock()
+			return nil, 0, fmt.Errorf("%w: %d > %d", ErrCursorAhead, *conf.replay, from)
+		}
+		if replay {
+			pending = append(pending, j.pending...)
+			if err := checkReplay(db, pending, *conf.replay); err != nil {
+				j.mu.Unlock()
+				return nil, 0, err
+			}
+		}
+	}
+	// subscribing under journal lock guarantees that live events follow
+	// the events that will be replayed without gaps or duplicates
+	sub, err := reporter.bus.Subscribe(new(Record))
+	j.mu.Unlock()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bs := &BufferedSubscription[Record]{
+		cancel: cancel,
+		result: make(chan Record, conf.buffer),
+		full:   make(chan struct{}),
+	}
+	if !replay {
+		go bs.run(ctx, sub, matcher)
+		return bs, from, nil
+	}
+	var once sync.Once
+	closeFull := func() {
+		once.Do(func() { close(bs.full) })
+	}
+	live := make(chan Record, conf.buffer)
+	go drainJournal(ctx, sub, live, closeFull)
+	go replayJournal(ctx, bs, db, pending, *conf.replay, from, matcher, live, closeFull)
+	return bs, *conf.replay, nil
+}
+
+func checkReplay(db *sql.Database, pending []Record, from uint64) error {
+	if db == nil {
+		return fmt.Errorf("%w: journal is not persisted", ErrCursorPruned)
+	}
+	first, err := journal.First(db)
+	if err != nil {
+		return err
+	}
+	if first == 0 && len(pending) > 0 {
+		first = pending[0].Cursor
+	}
+	if first == 0 || from+1 < first {
+		return fmt.Errorf("%w: oldest event %d", ErrCursorPruned, first)
+	}
+	return nil
+}
+
+// drainJournal buffers live events while persisted events are replayed.
+func drainJournal(ctx context.Context, s Subscription, live chan<- Record, closeFull func()) {
+	defer s.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt := <-s.Out():
+			select {
+			case live <- evt.(Record):
+			default:
+				closeFull()
+				return
+			}
+		}
+	}
+}
+
+func replayJournal(
+	ctx context.Context,
+	sub *BufferedSubscription[Record],
+	db *sql.Database,
+	pending []Record,
+	from, to uint64,
+	matcher func(*Record) bool,
+	live <-chan Record,
+	closeFull func(),
+) {
+	send := func(rec Record) bool {
+		if matcher != nil && !matcher(&rec) {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case sub.result <- rec:
+			return true
+		}
+	}
+	// events after the first pending one are replayed from memory
+	persisted := to
+	if len(pending) > 0 {
+		persisted = pending[0].Cursor - 1
+	}
+	for from < persisted {
+		var (
+			batch  []Record
+			derr   error
+			cursor = from
+		)
+		err := journal.Iterate(db, from, persisted, replayBatch, func(c uint64, data []byte) bool {
+			rec := Record{Cursor: c}
+			if derr = json.Unmarshal(data, &rec); derr != nil {
+				return false
+			}
+			batch = append(batch, rec)
+			cursor = c
+			return true
+		})
+		if err == nil {
+			err = derr
+		}
+		if err != nil {
+			log.With().Error("failed to replay events journal", log.Uint64("cursor", from), log.Err(err))
+			closeFull()
+			return
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, rec := range batch {
+			if !send(rec) {
+				return
+			}
+		}
+		from = cursor
+	}
+	for _, rec := range pending {
+		if rec.Cursor > from && !send(rec) {
+			return
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.full:
+			return
+		case rec := <-live:
+			if !send(rec) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/fixture"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/journal"
+)
+
+func receiveRecord(tb testing.TB, sub *BufferedSubscription[Record]) Record {
+	tb.Helper()
+	select {
+	case rec := <-sub.Out():
+		return rec
+	case <-sub.Full():
+		require.FailNow(tb, "subscription overflowed")
+	case <-time.After(time.Second):
+		require.FailNow(tb, "timeout")
+	}
+	return Record{}
+}
+
+func TestJournalReplay(t *testing.T) {
+	InitializeReporter()
+	t.Cleanup(CloseEventReporter)
+	db := sql.InMemory()
+	require.NoError(t, EnableJournal(db, 0))
+
+	result := fixture.NewTransactionResultGenerator().Next()
+	ReportLayerUpdate(LayerUpdate{LayerID: types.LayerID(10), Status: LayerStatusTypeApproved})
+	ReportResult(*result)
+	ReportAccountUpdate(types.Address{1})
+	ReportRewardReceived(Reward{Layer: types.LayerID(10), Total: 10, LayerReward: 5, Coinbase: types.Address{1}})
+
+	sub, from, err := SubscribeJournal(nil, WithReplay(1))
+	require.NoError(t, err)
+	t.Cleanup(sub.Close)
+	require.Equal(t, uint64(1), from)
+
+	rec := receiveRecord(t, sub)
+	require.Equal(t, uint64(2), rec.Cursor)
+	require.Equal(t, result, rec.Result)
+	rec = receiveRecord(t, sub)
+	require.Equal(t, uint64(3), rec.Cursor)
+	require.Equal(t, &Account{Address: types.Address{1}}, rec.Account)
+	rec = receiveRecord(t, sub)
+	require.Equal(t, uint64(4), rec.Cursor)
+	require.Equal(t, uint64(10), rec.Reward.Total)
+
+	// switches to live events after replay
+	ReportLayerUpdate(LayerUpdate{LayerID: types.LayerID(11), Status: LayerStatusTypeApproved})
+	rec = receiveRecord(t, sub)
+	require.Equal(t, uint64(5), rec.Cursor)
+	require.Equal(t, &LayerUpdate{LayerID: types.LayerID(11), Status: LayerStatusTypeApproved}, rec.Layer)
+
+	t.Run("matched", func(t *testing.T) {
+		sub, _, err := SubscribeJournal(func(rec *Record) bool {
+			return rec.Layer != nil
+		}, WithReplay(0))
+		require.NoError(t, err)
+		defer sub.Close()
+		require.Equal(t, uint64(1), receiveRecord(t, sub).Cursor)
+		require.Equal(t, uint64(5), receiveRecord(t, sub).Cursor)
+	})
+	t.Run("live", func(t *testing.T) {
+		sub, from, err := SubscribeJournal(nil)
+		require.NoError(t, err)
+		defer sub.Close()
+		require.Equal(t, uint64(5), from)
+		ReportAccountUpdate(types.Address{2})
+		require.Equal(t, uint64(6), receiveRecord(t, sub).Cursor)
+	})
+	t.Run("ahead", func(t *testing.T) {
+		_, _, err := SubscribeJournal(nil, WithReplay(100))
+		require.ErrorIs(t, err, ErrCursorAhead)
+	})
+	t.Run("restart", func(t *testing.T) {
+		CloseEventReporter()
+		InitializeReporter()
+		require.NoError(t, EnableJournal(db, 0))
+		sub, from, err := SubscribeJournal(nil)
+		require.NoError(t, err)
+		defer sub.Close()
+		require.Equal(t, uint64(6), from)
+	})
+}
+
+func TestJournalPruned(t *testing.T) {
+	InitializeReporter()
+	t.Cleanup(CloseEventReporter)
+
+	ReportAccountUpdate(types.Address{1})
+	_, _, err := SubscribeJournal(nil, WithReplay(0))
+	require.ErrorIs(t, err, ErrCursorPruned)
+
+	db := sql.InMemory()
+	require.NoError(t, EnableJournal(db, 10))
+	for i := 0; i < pruneInterval; i++ {
+		ReportAccountUpdate(types.Address{1})
+	}
+	reporter.journal.flush()
+	_, _, err = SubscribeJournal(nil, WithReplay(pruneInterval-20))
+	require.ErrorIs(t, err, ErrCursorPruned)
+	sub, _, err := SubscribeJournal(nil, WithReplay(pruneInterval-10))
+	require.NoError(t, err)
+	defer sub.Close()
+	require.Equal(t, uint64(pruneInterval-9), receiveRecord(t, sub).Cursor)
+}
+
+func TestJournalReplayPending(t *testing.T) {
+	InitializeReporter()
+	t.Cleanup(CloseEventReporter)
+	db := sql.InMemory()
+	require.NoError(t, EnableJournal(db, 0))
+
+	ReportAccountUpdate(types.Address{1})
+	reporter.journal.flush()
+	// blocks writes of the events reported after this point
+	reporter.journal.flushMu.Lock()
+	ReportAccountUpdate(types.Address{2})
+	ReportAccountUpdate(types.Address{3})
+	last, err := journal.Last(db)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), last)
+
+	sub, _, err := SubscribeJournal(nil, WithReplay(0))
+	require.NoError(t, err)
+	defer sub.Close()
+	reporter.journal.flushMu.Unlock()
+	for i := 1; i <= 3; i++ {
+		rec := receiveRecord(t, sub)
+		require.Equal(t, uint64(i), rec.Cursor)
+		require.Equal(t, types.Address{byte(i)}, rec.Account.Address)
+	}
+
+	DisableJournal()
+	last, err = journal.Last(db)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), last)
+}
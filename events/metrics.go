@@ -0,0 +1,60 @@
+package events
+
+import (
+	"github.com/spacemeshos/go-spacemesh/metrics"
+)
+
+const (
+	namespace = "events"
+
+	// publisher subscription overflowed, counted once per overflow.
+	dropOverflow = "overflow"
+	// events were dropped because in-memory buffer is full.
+	dropBuffer = "buffer"
+	// events were dropped because on-disk outbox is full.
+	dropOutbox = "outbox"
+)
+
+var (
+	publishedEvents = metrics.NewCounter(
+		"publisher_events",
+		namespace,
+		"number of events accepted by the publisher",
+		[]string{"type"},
+	)
+	droppedEvents = metrics.NewCounter(
+		"publisher_dropped",
+		namespace,
+		"number of events dropped by the publisher",
+		[]string{"reason"},
+	)
+	droppedOverflow = droppedEvents.WithLabelValues(dropOverflow)
+	droppedBuffer   = droppedEvents.WithLabelValues(dropBuffer)
+	droppedOutbox   = droppedEvents.WithLabelValues(dropOutbox)
+
+	deliveredBatches = metrics.NewCounter(
+		"publisher_delivered",
+		namespace,
+		"number of batches delivered to the webhook",
+		[]string{},
+	).WithLabelValues()
+	failedDeliveries = metrics.NewCounter(
+		"publisher_failures",
+		namespace,
+		"number of failed attempts to deliver a batch to the webhook",
+		[]string{},
+	).WithLabelValues()
+
+	bufferedEvents = metrics.NewGauge(
+		"publisher_buffered",
+		namespace,
+		"number of events buffered in memory",
+		[]string{},
+	).WithLabelValues()
+	outboxBatches = metrics.NewGauge(
+		"publisher_outbox",
+		namespace,
+		"number of batches waiting in the on-disk outbox",
+		[]string{},
+	).WithLabelValues()
+)
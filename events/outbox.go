@@ -0,0 +1,141 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const outboxExt = ".json"
+
+var errOutboxFull = errors.New("outbox is full")
+
+// outbox is a directory with batches that are waiting to be delivered.
+// every batch is stored in a separate file named after its sequence number,
+// file is removed only after the batch was delivered.
+type outbox struct {
+	dir   string
+	limit int
+
+	mu      sync.Mutex
+	next    uint64
+	pending []uint64
+}
+
+func openOutbox(dir string, limit int) (*outbox, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create outbox %s: %w", dir, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read outbox %s: %w", dir, err)
+	}
+	o := &outbox{dir: dir, limit: limit}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, outboxExt) {
+			// leftovers from interrupted writes
+			if strings.HasSuffix(name, ".tmp") {
+				_ = os.Remove(filepath.Join(dir, name))
+			}
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, outboxExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		o.pending = append(o.pending, seq)
+		if seq >= o.next {
+			o.next = seq + 1
+		}
+	}
+	sort.Slice(o.pending, func(i, j int) bool { return o.pending[i] < o.pending[j] })
+	outboxBatches.Set(float64(len(o.pending)))
+	return o, nil
+}
+
+func (o *outbox) path(seq uint64) string {
+	return filepath.Join(o.dir, fmt.Sprintf("%020d%s", seq, outboxExt))
+}
+
+// push persists batch at the end of the outbox.
+func (o *outbox) push(data []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.limit > 0 && len(o.pending) >= o.limit {
+		return errOutboxFull
+	}
+	seq := o.next
+	tmp := o.path(seq) + ".tmp"
+	if err := writeSynced(tmp, data); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, o.path(seq)); err != nil {
+		return fmt.Errorf("rename %s: %w", tmp, err)
+	}
+	o.next++
+	o.pending = append(o.pending, seq)
+	outboxBatches.Set(float64(len(o.pending)))
+	return nil
+}
+
+// peek returns the oldest batch in the outbox.
+func (o *outbox) peek() (uint64, []byte, bool, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.pending) == 0 {
+		return 0, nil, false, nil
+	}
+	seq := o.pending[0]
+	data, err := os.ReadFile(o.path(seq))
+	if err != nil {
+		return 0, nil, false, fmt.Errorf("read batch %d: %w", seq, err)
+	}
+	return seq, data, true, nil
+}
+
+// remove deletes delivered batch from the outbox.
+func (o *outbox) remove(seq uint64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for i, pending := range o.pending {
+		if pending != seq {
+			continue
+		}
+		if err := os.Remove(o.path(seq)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove batch %d: %w", seq, err)
+		}
+		o.pending = append(o.pending[:i], o.pending[i+1:]...)
+		outboxBatches.Set(float64(len(o.pending)))
+		return nil
+	}
+	return nil
+}
+
+func (o *outbox) size() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.pending)
+}
+
+func writeSynced(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("sync %s: %w", path, err)
+	}
+	return f.Close()
+}
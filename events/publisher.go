@@ -0,0 +1,430 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// buffered batches are kept in memory before new events are dropped.
+const bufferedBatches = 4
+
+// PublisherConfig is a configuration for the webhook publisher.
+type PublisherConfig struct {
+	// BatchSize is the max number of events posted in a single request.
+	BatchSize int `mapstructure:"events-batch-size"`
+	// FlushInterval is the max time event waits in memory before it is persisted to the outbox.
+	FlushInterval time.Duration `mapstructure:"events-flush-interval"`
+	// RetryInterval is the initial delay after failed delivery. It is doubled after every failure.
+	RetryInterval time.Duration `mapstructure:"events-retry-interval"`
+	// MaxRetryInterval is the upper bound for the delay after failed delivery.
+	MaxRetryInterval time.Duration `mapstructure:"events-max-retry-interval"`
+	// RequestTimeout is the timeout for a single request to the webhook.
+	RequestTimeout time.Duration `mapstructure:"events-request-timeout"`
+	// OutboxSize is the max number of undelivered batches persisted on disk.
+	// Once the outbox is full new events are dropped.
+	OutboxSize int `mapstructure:"events-outbox-size"`
+}
+
+// DefaultPublisherConfig returns default configuration for the publisher.
+func DefaultPublisherConfig() PublisherConfig {
+	return PublisherConfig{
+		BatchSize:        100,
+		FlushInterval:    time.Second,
+		RetryInterval:    time.Second,
+		MaxRetryInterval: time.Minute,
+		RequestTimeout:   10 * time.Second,
+		OutboxSize:       1000,
+	}
+}
+
+// PublisherOpt for configuring Publisher.
+type PublisherOpt func(*Publisher)
+
+// WithPublisherConfig changes publisher config.
+func WithPublisherConfig(cfg PublisherConfig) PublisherOpt {
+	return func(p *Publisher) {
+		p.cfg = cfg
+	}
+}
+
+// WithPublisherLogger changes publisher logger.
+func WithPublisherLogger(logger log.Log) PublisherOpt {
+	return func(p *Publisher) {
+		p.logger = logger
+	}
+}
+
+// WithHTTPClient changes http client that is used to post batches.
+func WithHTTPClient(client *http.Client) PublisherOpt {
+	return func(p *Publisher) {
+		p.client = client
+	}
+}
+
+// Envelope is a single event posted to the webhook.
+type Envelope struct {
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Publisher posts events from the reporter to the webhook.
+//
+// Events are collected in memory, persisted in batches to the on-disk outbox
+// and removed from it only after the webhook responded with 2xx status.
+// Delivery is at-least-once, receiver should be ready to get the same batch
+// more than once, e.g. if node was restarted before the response was received.
+type Publisher struct {
+	logger log.Log
+	cfg    PublisherConfig
+	url    string
+	client *http.Client
+	outbox *outbox
+
+	mu     sync.Mutex
+	buffer []Envelope
+
+	flush   chan struct{}
+	deliver chan struct{}
+
+	eg     errgroup.Group
+	cancel context.CancelFunc
+}
+
+// NewPublisher creates a publisher that posts events to url and keeps
+// undelivered batches in dir.
+func NewPublisher(url, dir string, opts ...PublisherOpt) (*Publisher, error) {
+	p := &Publisher{
+		logger:  log.NewNop(),
+		cfg:     DefaultPublisherConfig(),
+		url:     url,
+		flush:   make(chan struct{}, 1),
+		deliver: make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.cfg.BatchSize <= 0 {
+		return nil, fmt.Errorf("events batch size must be positive: %d", p.cfg.BatchSize)
+	}
+	if p.client == nil {
+		p.client = &http.Client{Timeout: p.cfg.RequestTimeout}
+	}
+	ob, err := openOutbox(dir, p.cfg.OutboxSize)
+	if err != nil {
+		return nil, err
+	}
+	p.outbox = ob
+	return p, nil
+}
+
+// Start subscribes to the reporter and publishes events in the background until Stop is called.
+func (p *Publisher) Start(ctx context.Context) error {
+	collectors := []func() (func(context.Context), error){
+		collector(p, "layer", func(layer *LayerUpdate) any {
+			return layerEvent{Layer: layer.LayerID.Uint32(), Status: layer.Status}
+		}),
+		collector(p, "transaction", castTransaction),
+		collector(p, "reward", func(reward *Reward) any {
+			return rewardEvent{
+				Layer:       reward.Layer.Uint32(),
+				Total:       reward.Total,
+				LayerReward: reward.LayerReward,
+				Coinbase:    reward.Coinbase.String(),
+			}
+		}),
+		collector(p, "activation", castActivation),
+		collector(p, "error", func(err *NodeError) any {
+			return errorEvent{Msg: err.Msg, Level: err.Level.String()}
+		}),
+		collector(p, "proposal", castProposal),
+	}
+	runs := make([]func(context.Context), 0, len(collectors))
+	for _, subscribe := range collectors {
+		run, err := subscribe()
+		if err != nil {
+			// loops exit immediately and close their subscriptions
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+			for _, run := range runs {
+				run(canceled)
+			}
+			return err
+		}
+		runs = append(runs, run)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+	for _, run := range append(runs, p.runFlush, p.runDelivery) {
+		run := run
+		p.eg.Go(func() error {
+			run(ctx)
+			return nil
+		})
+	}
+	return nil
+}
+
+// Stop stops the publisher and writes events that were not persisted yet to the outbox.
+func (p *Publisher) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	_ = p.eg.Wait()
+	p.persist(true)
+}
+
+// collector returns a function that subscribes to events of type T
+// and returns a loop that adds them to the publisher buffer.
+func collector[T any](p *Publisher, kind string, cast func(*T) any) func() (func(context.Context), error) {
+	return func() (func(context.Context), error) {
+		sub, err := Subscribe[T]()
+		if err != nil {
+			return nil, fmt.Errorf("subscribe to %s events: %w", kind, err)
+		}
+		return func(ctx context.Context) {
+			defer func() { sub.Close() }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-sub.Full():
+					p.logger.With().Warning("events subscription overflowed", log.String("type", kind))
+					droppedOverflow.Inc()
+					sub.Close()
+					if sub, err = Subscribe[T](); err != nil {
+						p.logger.With().Error("failed to resubscribe", log.String("type", kind), log.Err(err))
+						return
+					}
+				case evt := <-sub.Out():
+					p.add(kind, cast(&evt))
+				}
+			}
+		}, nil
+	}
+}
+
+func (p *Publisher) add(kind string, data any) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		p.logger.With().Error("failed to encode event", log.String("type", kind), log.Err(err))
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.buffer) >= bufferedBatches*p.cfg.BatchSize {
+		droppedBuffer.Inc()
+		return
+	}
+	p.buffer = append(p.buffer, Envelope{Type: kind, Timestamp: time.Now().UTC(), Data: raw})
+	publishedEvents.WithLabelValues(kind).Inc()
+	bufferedEvents.Set(float64(len(p.buffer)))
+	if len(p.buffer) >= p.cfg.BatchSize {
+		notify(p.flush)
+	}
+}
+
+func (p *Publisher) runFlush(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.persist(true)
+		case <-p.flush:
+			p.persist(false)
+		}
+	}
+}
+
+// persist writes buffered events to the outbox. Incomplete batch is written only if all is true.
+func (p *Publisher) persist(all bool) {
+	for {
+		p.mu.Lock()
+		n := len(p.buffer)
+		if n > p.cfg.BatchSize {
+			n = p.cfg.BatchSize
+		}
+		if n == 0 || (!all && n < p.cfg.BatchSize) {
+			p.mu.Unlock()
+			return
+		}
+		batch := p.buffer[:n:n]
+		p.buffer = p.buffer[n:]
+		bufferedEvents.Set(float64(len(p.buffer)))
+		p.mu.Unlock()
+
+		data, err := json.Marshal(batch)
+		if err != nil {
+			p.logger.With().Error("failed to encode batch", log.Err(err))
+			droppedOutbox.Add(float64(len(batch)))
+			continue
+		}
+		if err := p.outbox.push(data); err != nil {
+			if errors.Is(err, errOutboxFull) {
+				p.logger.With().Warning("events outbox is full", log.Int("events", len(batch)))
+			} else {
+				p.logger.With().Error("failed to persist batch", log.Int("events", len(batch)), log.Err(err))
+			}
+			droppedOutbox.Add(float64(len(batch)))
+			continue
+		}
+		notify(p.deliver)
+	}
+}
+
+func (p *Publisher) runDelivery(ctx context.Context) {
+	backoff := p.cfg.RetryInterval
+	for {
+		seq, data, ok, err := p.outbox.peek()
+		if err != nil {
+			p.logger.With().Error("failed to read batch from outbox", log.Err(err))
+		} else if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.deliver:
+				continue
+			}
+		} else if err = p.post(ctx, data); err == nil {
+			deliveredBatches.Inc()
+			backoff = p.cfg.RetryInterval
+			if err := p.outbox.remove(seq); err != nil {
+				p.logger.With().Error("failed to remove delivered batch", log.Err(err))
+			}
+			continue
+		} else if ctx.Err() == nil {
+			failedDeliveries.Inc()
+			p.logger.With().Debug("failed to deliver batch",
+				log.Uint64("seq", seq),
+				log.Duration("retry", backoff),
+				log.Err(err),
+			)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > p.cfg.MaxRetryInterval {
+			backoff = p.cfg.MaxRetryInterval
+		}
+	}
+}
+
+func (p *Publisher) post(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return nil
+}
+
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+type layerEvent struct {
+	Layer  uint32 `json:"layer"`
+	Status int    `json:"status"`
+}
+
+type transactionEvent struct {
+	ID        string `json:"id"`
+	Layer     uint32 `json:"layer"`
+	Valid     bool   `json:"valid"`
+	Principal string `json:"principal,omitempty"`
+	Nonce     uint64 `json:"nonce,omitempty"`
+	MaxGas    uint64 `json:"max_gas,omitempty"`
+	GasPrice  uint64 `json:"gas_price,omitempty"`
+}
+
+func castTransaction(tx *Transaction) any {
+	rst := transactionEvent{
+		ID:    tx.Transaction.ID.String(),
+		Layer: tx.LayerID.Uint32(),
+		Valid: tx.Valid,
+	}
+	if header := tx.Transaction.TxHeader; header != nil {
+		rst.Principal = header.Principal.String()
+		rst.Nonce = header.Nonce
+		rst.MaxGas = header.MaxGas
+		rst.GasPrice = header.GasPrice
+	}
+	return rst
+}
+
+type rewardEvent struct {
+	Layer       uint32 `json:"layer"`
+	Total       uint64 `json:"total"`
+	LayerReward uint64 `json:"layer_reward"`
+	Coinbase    string `json:"coinbase"`
+}
+
+type activationEvent struct {
+	ID           string `json:"id"`
+	Smesher      string `json:"smesher"`
+	PublishEpoch uint32 `json:"publish_epoch"`
+	Coinbase     string `json:"coinbase"`
+	NumUnits     uint32 `json:"num_units"`
+	Weight       uint64 `json:"weight"`
+}
+
+func castActivation(atx *ActivationTx) any {
+	return activationEvent{
+		ID:           atx.ID().String(),
+		Smesher:      atx.SmesherID.String(),
+		PublishEpoch: atx.PublishEpoch.Uint32(),
+		Coinbase:     atx.Coinbase.String(),
+		NumUnits:     atx.NumUnits,
+		Weight:       atx.GetWeight(),
+	}
+}
+
+type errorEvent struct {
+	Msg   string `json:"msg"`
+	Level string `json:"level"`
+}
+
+type proposalEvent struct {
+	ID      string `json:"id"`
+	Layer   uint32 `json:"layer"`
+	Smesher string `json:"smesher"`
+	Status  string `json:"status"`
+}
+
+func castProposal(proposal *EventProposal) any {
+	return proposalEvent{
+		ID:      proposal.Proposal.ID().String(),
+		Layer:   proposal.Proposal.Layer.Uint32(),
+		Smesher: proposal.Proposal.SmesherID.String(),
+		Status:  proposal.Status.String(),
+	}
+}
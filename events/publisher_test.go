@@ -0,0 +1,170 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+type webhook struct {
+	mu       sync.Mutex
+	failures int
+	batches  [][]Envelope
+}
+
+func (w *webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failures > 0 {
+		w.failures--
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var batch []Envelope
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.batches = append(w.batches, batch)
+}
+
+func (w *webhook) received() [][]Envelope {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([][]Envelope(nil), w.batches...)
+}
+
+func (w *webhook) fail(n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.failures = n
+}
+
+func testPublisherConfig() PublisherConfig {
+	return PublisherConfig{
+		BatchSize:        2,
+		FlushInterval:    time.Hour,
+		RetryInterval:    10 * time.Millisecond,
+		MaxRetryInterval: 10 * time.Millisecond,
+		RequestTimeout:   time.Second,
+		OutboxSize:       10,
+	}
+}
+
+func startPublisher(tb testing.TB, url, dir string, cfg PublisherConfig) *Publisher {
+	tb.Helper()
+	p, err := NewPublisher(url, dir,
+		WithPublisherConfig(cfg),
+		WithPublisherLogger(logtest.New(tb)),
+	)
+	require.NoError(tb, err)
+	require.NoError(tb, p.Start(context.Background()))
+	return p
+}
+
+func TestPublisherBatches(t *testing.T) {
+	InitializeReporter()
+	t.Cleanup(CloseEventReporter)
+
+	hook := &webhook{}
+	srv := httptest.NewServer(hook)
+	t.Cleanup(srv.Close)
+
+	p := startPublisher(t, srv.URL, t.TempDir(), testPublisherConfig())
+	t.Cleanup(p.Stop)
+
+	ReportLayerUpdate(LayerUpdate{LayerID: types.LayerID(10), Status: LayerStatusTypeApproved})
+	ReportRewardReceived(Reward{Layer: types.LayerID(10), Total: 100, LayerReward: 50, Coinbase: types.Address{1}})
+	ReportLayerUpdate(LayerUpdate{LayerID: types.LayerID(11), Status: LayerStatusTypeApproved})
+	ReportLayerUpdate(LayerUpdate{LayerID: types.LayerID(12), Status: LayerStatusTypeApproved})
+
+	require.Eventually(t, func() bool {
+		return len(hook.received()) == 2
+	}, time.Second, 10*time.Millisecond)
+	kinds := map[string]int{}
+	for _, batch := range hook.received() {
+		require.Len(t, batch, 2)
+		for _, envelope := range batch {
+			kinds[envelope.Type]++
+			if envelope.Type == "reward" {
+				var reward rewardEvent
+				require.NoError(t, json.Unmarshal(envelope.Data, &reward))
+				require.Equal(t, uint64(100), reward.Total)
+			}
+		}
+	}
+	require.Equal(t, map[string]int{"layer": 3, "reward": 1}, kinds)
+}
+
+func TestPublisherRetry(t *testing.T) {
+	InitializeReporter()
+	t.Cleanup(CloseEventReporter)
+
+	hook := &webhook{}
+	hook.fail(3)
+	srv := httptest.NewServer(hook)
+	t.Cleanup(srv.Close)
+
+	cfg := testPublisherConfig()
+	cfg.BatchSize = 1
+	p := startPublisher(t, srv.URL, t.TempDir(), cfg)
+	t.Cleanup(p.Stop)
+
+	ReportError(NodeError{Msg: "test"})
+	require.Eventually(t, func() bool {
+		return len(hook.received()) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, "error", hook.received()[0][0].Type)
+	require.Eventually(t, func() bool {
+		return p.outbox.size() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPublisherOutbox(t *testing.T) {
+	InitializeReporter()
+	t.Cleanup(CloseEventReporter)
+
+	hook := &webhook{}
+	hook.fail(1 << 20)
+	srv := httptest.NewServer(hook)
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	cfg := testPublisherConfig()
+	cfg.FlushInterval = 10 * time.Millisecond
+	cfg.OutboxSize = 1
+	p := startPublisher(t, srv.URL, dir, cfg)
+	ReportLayerUpdate(LayerUpdate{LayerID: types.LayerID(10)})
+	require.Eventually(t, func() bool {
+		return p.outbox.size() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// outbox is full, event is dropped
+	ReportLayerUpdate(LayerUpdate{LayerID: types.LayerID(11)})
+	p.Stop()
+	require.Empty(t, hook.received())
+
+	hook.fail(0)
+	restarted := startPublisher(t, srv.URL, dir, cfg)
+	t.Cleanup(restarted.Stop)
+	require.Eventually(t, func() bool {
+		return len(hook.received()) == 1
+	}, time.Second, 10*time.Millisecond)
+	batch := hook.received()[0]
+	require.Len(t, batch, 1)
+	var layer layerEvent
+	require.NoError(t, json.Unmarshal(batch[0].Data, &layer))
+	require.Equal(t, uint32(10), layer.Layer)
+	require.Eventually(t, func() bool {
+		return restarted.outbox.size() == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -100,6 +100,7 @@ func ReportRewardReceived(r Reward) {
 		} else {
 			log.Debug("reported reward: %v", r)
 		}
+		reporter.journal.append(Record{Reward: &r})
 	}
 }
 
@@ -115,6 +116,7 @@ func ReportLayerUpdate(layer LayerUpdate) {
 		} else {
 			log.With().Debug("reported new or updated layer", layer)
 		}
+		reporter.journal.append(Record{Layer: &layer})
 	}
 }
 
@@ -161,11 +163,15 @@ func ReportNodeStatusUpdate() {
 
 // ReportResult reports creation or receipt of a new tx receipt.
 func ReportResult(rst types.TransactionWithResult) {
+	mu.RLock()
+	defer mu.RUnlock()
+
 	if reporter != nil {
 		if err := reporter.resultsEmitter.Emit(rst); err != nil {
 			// TODO(nkryuchkov): consider returning an error and log outside the function
 			log.With().Error("Failed to emit tx results", rst.ID, log.Err(err))
 		}
+		reporter.journal.append(Record{Result: &rst})
 	}
 }
 
@@ -183,6 +189,7 @@ func ReportAccountUpdate(a types.Address) {
 		} else {
 			log.With().Debug("reported account update", a)
 		}
+		reporter.journal.append(Record{Account: &accountEvent})
 	}
 }
 
@@ -411,6 +418,7 @@ type EventReporter struct {
 	rewardEmitter      event.Emitter
 	resultsEmitter     event.Emitter
 	proposalsEmitter   event.Emitter
+	journal            *eventsJournal
 	stopChan           chan struct{}
 }
 
@@ -462,6 +470,11 @@ func newEventReporter() *EventReporter {
 		log.With().Panic("failed to to create proposal emitter", log.Err(err))
 	}
 
+	journalEmitter, err := bus.Emitter(new(Record))
+	if err != nil {
+		log.With().Panic("failed to create journal emitter", log.Err(err))
+	}
+
 	return &EventReporter{
 		bus:                bus,
 		transactionEmitter: transactionEmitter,
@@ -473,6 +486,7 @@ func newEventReporter() *EventReporter {
 		resultsEmitter:     resultsEmitter,
 		errorEmitter:       errorEmitter,
 		proposalsEmitter:   proposalsEmitter,
+		journal:            &eventsJournal{emitter: journalEmitter},
 		stopChan:           make(chan struct{}),
 	}
 }
@@ -482,6 +496,7 @@ func CloseEventReporter() {
 	mu.Lock()
 	defer mu.Unlock()
 	if reporter != nil {
+		reporter.journal.disable()
 		if err := reporter.transactionEmitter.Close(); err != nil {
 			log.With().Panic("failed to close transactionEmitter", log.Err(err))
 		}
@@ -509,6 +524,9 @@ func CloseEventReporter() {
 		if err := reporter.proposalsEmitter.Close(); err != nil {
 			log.With().Panic("failed to close propoposalsEmitter", log.Err(err))
 		}
+		if err := reporter.journal.emitter.Close(); err != nil {
+			log.With().Panic("failed to close journalEmitter", log.Err(err))
+		}
 
 		close(reporter.stopChan)
 		reporter = nil
@@ -2,8 +2,12 @@ package events
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrNotInitialized is returned on subscribe if the reporter is not initialized.
+var ErrNotInitialized = errors.New("event reporter is not initialized")
+
 func newSubconf(opts ...SubOpt) *subconf {
 	conf := &subconf{buffer: 1 << 10}
 	for _, opt := range opts {
@@ -14,6 +18,7 @@ func newSubconf(opts ...SubOpt) *subconf {
 
 type subconf struct {
 	buffer int
+	replay *uint64
 }
 
 // SubOpt for changing subscribe options.
@@ -27,6 +32,11 @@ func WithBuffer(n int) SubOpt {
 }
 
 func subscribe[T any](matcher func(*T) bool, opts ...SubOpt) (*BufferedSubscription[T], error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter == nil {
+		return nil, ErrNotInitialized
+	}
 	sub, err := reporter.bus.Subscribe(new(T))
 	if err != nil {
 		return nil, err
@@ -16,6 +16,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/p2p/server"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
@@ -56,6 +57,7 @@ type promise struct {
 type batchInfo struct {
 	RequestBatch
 	peer p2p.Peer
+	sent time.Time
 }
 
 // setID calculates the hash of all requests and sets it as this batches ID.
@@ -82,6 +84,14 @@ type Config struct {
 	BatchSize, QueueSize int
 	RequestTimeout       time.Duration // in seconds
 	MaxRetriesForRequest int
+
+	// BackoffBase is how long a peer is not selected for requests after a failure.
+	// It doubles on every consecutive failure up to BackoffMax.
+	BackoffBase, BackoffMax time.Duration
+	// BanThreshold is the number of responses that failed validation after which
+	// the peer is disconnected and not selected for requests for BanDuration.
+	BanThreshold int
+	BanDuration  time.Duration
 }
 
 // DefaultConfig is the default config for the fetch component.
@@ -93,6 +103,10 @@ func DefaultConfig() Config {
 		BatchSize:            20,
 		RequestTimeout:       time.Second * time.Duration(10),
 		MaxRetriesForRequest: 100,
+		BackoffBase:          time.Second,
+		BackoffMax:           time.Minute,
+		BanThreshold:         3,
+		BanDuration:          time.Hour,
 	}
 }
 
@@ -160,6 +174,7 @@ type Fetch struct {
 	mu           sync.Mutex
 	onlyOnce     sync.Once
 	hashToPeers  *HashPeersCache
+	scores       *peerScores
 
 	shutdownCtx context.Context
 	cancel      context.CancelFunc
@@ -184,6 +199,7 @@ func NewFetch(cdb *datastore.CachedDB, msh meshProvider, b system.BeaconGetter,
 		opt(f)
 	}
 
+	f.scores = newPeerScores(f.cfg, peerScoresSize)
 	f.batchTimeout = time.NewTicker(f.cfg.BatchTimeout)
 	srvOpts := []server.Opt{
 		server.WithTimeout(f.cfg.RequestTimeout),
@@ -320,6 +336,7 @@ func (f *Fetch) receiveResponse(data []byte) {
 			log.Stringer("batch_hash", response.ID))
 		return
 	}
+	f.scores.onSuccess(batch.peer, time.Since(batch.sent))
 
 	batchMap := batch.toMap()
 	// iterate all hash Responses
@@ -338,7 +355,9 @@ func (f *Fetch) receiveResponse(data []byte) {
 		rsp := resp
 		f.eg.Go(func() error {
 			// validation fetch data recursively. offload to another goroutine
-			f.hashValidationDone(rsp.Hash, req.validator(req.ctx, batch.peer, rsp.Data))
+			err := req.validator(req.ctx, batch.peer, rsp.Data)
+			f.peerValidationDone(req.ctx, batch.peer, req.hint, err)
+			f.hashValidationDone(rsp.Hash, err)
 			return nil
 		})
 		delete(batchMap, resp.Hash)
@@ -375,6 +394,28 @@ func (f *Fetch) hashValidationDone(hash types.Hash32, err error) {
 	delete(f.ongoing, hash)
 }
 
+// peerValidationDone updates the score of the peer with the result of validation of the data it served,
+// and disconnects the peer if it is banned.
+func (f *Fetch) peerValidationDone(ctx context.Context, peer p2p.Peer, hint datastore.Hint, err error) {
+	switch {
+	case err == nil:
+		f.scores.onValidation(peer, true)
+	case errors.Is(err, pubsub.ErrValidationReject):
+		if !f.scores.onValidation(peer, false) {
+			return
+		}
+		f.logger.WithContext(ctx).With().Warning("banning peer that served invalid data",
+			log.Stringer("peer", peer),
+			log.String("hint", string(hint)),
+			log.Duration("duration", f.cfg.BanDuration),
+			log.Err(err),
+		)
+		if err := f.host.ClosePeer(peer); err != nil {
+			f.logger.With().Warning("failed to disconnect banned peer", log.Stringer("peer", peer), log.Err(err))
+		}
+	}
+}
+
 func (f *Fetch) failAfterRetry(hash types.Hash32) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -449,7 +490,10 @@ func (f *Fetch) organizeRequests(requests []RequestMessage) map[p2p.Peer][][]Req
 	peers := f.host.GetPeers()
 
 	for _, req := range requests {
-		p, exists := f.hashToPeers.GetRandom(req.Hash, req.Hint, rng)
+		p, exists := f.scores.selectPeer(f.hashToPeers.GetPeers(req.Hash, req.Hint), rng)
+		if !exists {
+			p, exists = f.scores.selectPeer(peers, rng)
+		}
 		if !exists {
 			p = randomPeer(peers)
 		}
@@ -485,6 +529,7 @@ func (f *Fetch) organizeRequests(requests []RequestMessage) map[p2p.Peer][][]Req
 // sendBatch dispatches batched request messages to provided peer.
 func (f *Fetch) sendBatch(p p2p.Peer, batch *batchInfo) error {
 	f.mu.Lock()
+	batch.sent = time.Now()
 	f.batched[batch.ID] = batch
 	f.mu.Unlock()
 
@@ -496,6 +541,7 @@ func (f *Fetch) sendBatch(p p2p.Peer, batch *batchInfo) error {
 		f.logger.With().Warning("failed to send batch",
 			log.Stringer("batch_hash", batch.ID),
 			log.Err(err))
+		f.scores.onFailure(p)
 		f.handleHashError(batch.ID, err)
 	}
 
@@ -521,6 +567,7 @@ func (f *Fetch) sendBatch(p p2p.Peer, batch *batchInfo) error {
 			break
 		}
 
+		f.scores.onFailure(p)
 		retries++
 		if retries > f.cfg.MaxRetriesForPeer {
 			f.handleHashError(batch.ID, fmt.Errorf("batched request failed w retries: %w", err))
@@ -615,6 +662,12 @@ func (f *Fetch) RegisterPeerHashes(peer p2p.Peer, hashes []types.Hash32) {
 	f.hashToPeers.RegisterPeerHashes(peer, hashes)
 }
 
+// GetPeers returns connected peers that are not banned.
 func (f *Fetch) GetPeers() []p2p.Peer {
-	return f.host.GetPeers()
+	return f.scores.filterBanned(f.host.GetPeers())
+}
+
+// PeerScores returns the scores of the peers that fetch sent requests to, sorted from the best.
+func (f *Fetch) PeerScores() []PeerScore {
+	return f.scores.snapshot()
 }
@@ -3,6 +3,7 @@ package fetch
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/fetch/mocks"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
@@ -59,12 +61,16 @@ func createFetch(tb testing.TB) *testFetch {
 		mPoetH:     mocks.NewMockPoetValidator(ctrl),
 	}
 	cfg := Config{
-		time.Millisecond * time.Duration(2000), // make sure we never hit the batch timeout
-		3,
-		3,
-		1000,
-		time.Second * time.Duration(3),
-		3,
+		BatchTimeout:         time.Millisecond * time.Duration(2000), // make sure we never hit the batch timeout
+		MaxRetriesForPeer:    3,
+		BatchSize:            3,
+		QueueSize:            1000,
+		RequestTimeout:       time.Second * time.Duration(3),
+		MaxRetriesForRequest: 3,
+		BackoffBase:          time.Second,
+		BackoffMax:           time.Minute,
+		BanThreshold:         2,
+		BanDuration:          time.Hour,
 	}
 	lg := logtest.New(tb)
 	tf.Fetch = NewFetch(datastore.NewCachedDB(sql.InMemory(), lg), tf.mMesh, nil, nil,
@@ -287,3 +293,42 @@ func TestFetch_GetRandomPeer(t *testing.T) {
 	}
 	assert.False(t, allTheSame)
 }
+
+func TestFetch_BanPeerServingInvalidData(t *testing.T) {
+	f := createFetch(t)
+	f.cfg.MaxRetriesForRequest = 0
+	peer := p2p.Peer("buddy")
+	f.mh.EXPECT().GetPeers().Return([]p2p.Peer{peer}).AnyTimes()
+	f.mHashS.EXPECT().Request(gomock.Any(), peer, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ p2p.Peer, req []byte, okFunc func([]byte), _ func(error)) error {
+			var rb RequestBatch
+			require.NoError(t, codec.Decode(req, &rb))
+			resBatch := ResponseBatch{ID: rb.ID}
+			for _, r := range rb.Requests {
+				resBatch.Responses = append(resBatch.Responses, ResponseMessage{Hash: r.Hash, Data: []byte("a")})
+			}
+			bts, err := codec.Encode(&resBatch)
+			require.NoError(t, err)
+			okFunc(bts)
+			return nil
+		}).Times(2)
+	invalid := func(context.Context, p2p.Peer, []byte) error {
+		return fmt.Errorf("%w: invalid atx", pubsub.ErrValidationReject)
+	}
+
+	for i := 0; i < 2; i++ {
+		p, err := f.getHash(context.TODO(), types.RandomHash(), datastore.ATXDB, invalid)
+		require.NoError(t, err)
+		if i == 1 {
+			f.mh.EXPECT().ClosePeer(peer)
+		}
+		f.requestHashBatchFromPeers()
+		<-p.completed
+		require.ErrorIs(t, p.err, pubsub.ErrValidationReject)
+	}
+	require.Empty(t, f.GetPeers())
+	scores := f.PeerScores()
+	require.Len(t, scores, 1)
+	require.Equal(t, uint64(2), scores[0].Invalid)
+	require.False(t, scores[0].BannedUntil.IsZero())
+}
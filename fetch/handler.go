@@ -3,6 +3,7 @@ package fetch
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
@@ -14,6 +15,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
 	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/pruning"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
@@ -111,6 +113,10 @@ func (h *handler) handleLayerOpinionsReq(ctx context.Context, req []byte) ([]byt
 	if err := codec.Decode(req, &lid); err != nil {
 		return nil, err
 	}
+	if err := h.checkPruned(lid); err != nil {
+		h.logger.WithContext(ctx).With().Debug("refused layer opinions request", lid, log.Err(err))
+		return nil, err
+	}
 	lo.PrevAggHash, err = layers.GetAggregatedHash(h.cdb, lid.Sub(1))
 	if err != nil && !errors.Is(err, sql.ErrNotFound) {
 		h.logger.WithContext(ctx).With().Warning("failed to get prev agg hash", lid, log.Err(err))
@@ -144,6 +150,18 @@ func (h *handler) handleLayerOpinionsReq(ctx context.Context, req []byte) ([]byt
 	return out, nil
 }
 
+// checkPruned returns errPruned if the historical data of the layer was pruned.
+func (h *handler) checkPruned(lid types.LayerID) error {
+	pruned, err := pruning.Layer(h.cdb)
+	if err != nil {
+		return err
+	}
+	if lid.Before(pruned) {
+		return fmt.Errorf("%w: layer %s, pruned before %s", errPruned, lid, pruned)
+	}
+	return nil
+}
+
 func (h *handler) handleHashReq(ctx context.Context, data []byte) ([]byte, error) {
 	var requestBatch RequestBatch
 	if err := codec.Decode(data, &requestBatch); err != nil {
@@ -22,6 +22,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
 	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/pruning"
 	smocks "github.com/spacemeshos/go-spacemesh/system/mocks"
 )
 
@@ -169,6 +170,22 @@ func TestHandleLayerOpinionsReq(t *testing.T) {
 	}
 }
 
+func TestHandleLayerOpinionsReq_Pruned(t *testing.T) {
+	th := createTestHandler(t)
+	lid := types.LayerID(111)
+	createOpinions(t, th.cdb, lid, true)
+	require.NoError(t, pruning.SetLayer(th.cdb, lid.Add(1)))
+
+	lidBytes, err := codec.Encode(&lid)
+	require.NoError(t, err)
+	_, err = th.handleLayerOpinionsReq(context.Background(), lidBytes)
+	require.ErrorIs(t, err, errPruned)
+
+	require.NoError(t, pruning.SetLayer(th.cdb, lid))
+	_, err = th.handleLayerOpinionsReq(context.Background(), lidBytes)
+	require.NoError(t, err)
+}
+
 func TestHandleMeshHashReq(t *testing.T) {
 	tt := []struct {
 		name        string
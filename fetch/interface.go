@@ -48,5 +48,6 @@ type meshProvider interface {
 
 type host interface {
 	GetPeers() []p2p.Peer
+	ClosePeer(p2p.Peer) error
 	Close() error
 }
@@ -15,7 +15,10 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p"
 )
 
-var errBadRequest = errors.New("invalid request")
+var (
+	errBadRequest = errors.New("invalid request")
+	errPruned     = errors.New("data pruned")
+)
 
 // GetAtxs gets the data for given atx IDs and validates them. returns an error if at least one ATX cannot be fetched.
 func (f *Fetch) GetAtxs(ctx context.Context, ids []types.ATXID) error {
@@ -1,6 +1,8 @@
 package fetch
 
 import (
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/metrics"
 )
@@ -34,3 +36,26 @@ func logCacheHit(hint datastore.Hint) {
 func logCacheMiss(hint datastore.Hint) {
 	total.WithLabelValues(string(hint)).Inc()
 }
+
+const (
+	successEvent = "success"
+	failureEvent = "failure"
+	invalidEvent = "invalid"
+	banEvent     = "ban"
+)
+
+var (
+	peerEvents = metrics.NewCounter(
+		"peer_events",
+		subsystem,
+		"Results of the requests to peers that are used for scoring",
+		[]string{"kind"})
+
+	peerScoreHist = metrics.NewHistogramWithBuckets(
+		"peer_score",
+		subsystem,
+		"Scores of the peers after an update",
+		[]string{},
+		prometheus.LinearBuckets(0, 0.1, 11),
+	).WithLabelValues()
+)
@@ -397,6 +397,20 @@ func (mr *MockhostMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*Mockhost)(nil).Close))
 }
 
+// ClosePeer mocks base method.
+func (m *Mockhost) ClosePeer(arg0 p2p.Peer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePeer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClosePeer indicates an expected call of ClosePeer.
+func (mr *MockhostMockRecorder) ClosePeer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePeer", reflect.TypeOf((*Mockhost)(nil).ClosePeer), arg0)
+}
+
 // GetPeers mocks base method.
 func (m *Mockhost) GetPeers() []p2p.Peer {
 	m.ctrl.T.Helper()
@@ -0,0 +1,277 @@
+package fetch
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+)
+
+const (
+	// latencyAlpha is the weight of the latest sample in the moving averages of the peer stats.
+	latencyAlpha = 0.2
+	// peerScoresSize is the number of peers with stats. Stats of the least recently used peers
+	// are dropped, so that they don't accumulate for every peer the node was ever connected to.
+	peerScoresSize = 1000
+)
+
+// PeerScore is a snapshot of the stats that fetch collected about a peer.
+type PeerScore struct {
+	Peer p2p.Peer
+	// Score is in the range [0, 1], peers with higher score are preferred for requests.
+	Score float64
+	// Latency is the moving average of the time to receive a response from the peer.
+	Latency time.Duration
+	// FailureRate is the moving average of the requests that failed.
+	FailureRate float64
+	// InvalidRate is the moving average of the responses with the data that failed validation.
+	InvalidRate float64
+
+	Requests uint64
+	Failures uint64
+	Invalid  uint64
+
+	// BackoffUntil is set after a failed request. Peer is not selected until that time, unless no other
+	// peer is available.
+	BackoffUntil time.Time
+	// BannedUntil is set when the peer served too much invalid data. Peer is disconnected
+	// and is not selected until that time.
+	BannedUntil time.Time
+}
+
+type peerStats struct {
+	latency     float64 // seconds
+	failureRate float64
+	invalidRate float64
+
+	requests, failures, invalid uint64
+	// consecutive failures, used to compute the backoff.
+	consecutive int
+	// invalid data served since the last ban.
+	strikes int
+
+	backoffUntil time.Time
+	bannedUntil  time.Time
+}
+
+func (s *peerStats) score() float64 {
+	return (1 - s.failureRate) * (1 - s.invalidRate) / (1 + s.latency)
+}
+
+func ewma(avg, sample float64) float64 {
+	return (1-latencyAlpha)*avg + latencyAlpha*sample
+}
+
+// peerScores tracks latency, failures and invalid data per peer.
+type peerScores struct {
+	cfg Config
+	now func() time.Time
+
+	mu    sync.Mutex
+	stats *lru.Cache
+}
+
+func newPeerScores(cfg Config, size int) *peerScores {
+	stats, err := lru.New(size)
+	if err != nil {
+		log.Panic("could not initialize peer scores: %v", err)
+	}
+	return &peerScores{
+		cfg:   cfg,
+		now:   time.Now,
+		stats: stats,
+	}
+}
+
+// get returns the stats of the peer, the peer is marked as recently used.
+func (ps *peerScores) get(peer p2p.Peer) *peerStats {
+	item, exists := ps.stats.Get(peer)
+	if !exists {
+		stats := &peerStats{}
+		ps.stats.Add(peer, stats)
+		return stats
+	}
+	return item.(*peerStats)
+}
+
+// peek returns the stats of the peer, if there are any, without updating the recent usage.
+func (ps *peerScores) peek(peer p2p.Peer) (*peerStats, bool) {
+	item, exists := ps.stats.Peek(peer)
+	if !exists {
+		return nil, false
+	}
+	return item.(*peerStats), true
+}
+
+// onSuccess records a response received from the peer after the latency.
+func (ps *peerScores) onSuccess(peer p2p.Peer, latency time.Duration) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	stats := ps.get(peer)
+	stats.requests++
+	if stats.requests == 1 {
+		stats.latency = latency.Seconds()
+	} else {
+		stats.latency = ewma(stats.latency, latency.Seconds())
+	}
+	stats.failureRate = ewma(stats.failureRate, 0)
+	stats.consecutive = 0
+	stats.backoffUntil = time.Time{}
+	peerEvents.WithLabelValues(successEvent).Inc()
+	peerScoreHist.Observe(stats.score())
+}
+
+// onFailure records a failed request to the peer and backs off from it.
+func (ps *peerScores) onFailure(peer p2p.Peer) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	stats := ps.get(peer)
+	stats.requests++
+	stats.failures++
+	stats.failureRate = ewma(stats.failureRate, 1)
+	backoff := ps.cfg.BackoffBase << stats.consecutive
+	if backoff > ps.cfg.BackoffMax || backoff <= 0 {
+		backoff = ps.cfg.BackoffMax
+	} else {
+		stats.consecutive++
+	}
+	stats.backoffUntil = ps.now().Add(backoff)
+	peerEvents.WithLabelValues(failureEvent).Inc()
+	peerScoreHist.Observe(stats.score())
+}
+
+// onValidation records the result of validating the data served by the peer.
+// Returns true if the peer served too much invalid data and is banned.
+func (ps *peerScores) onValidation(peer p2p.Peer, valid bool) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	stats := ps.get(peer)
+	if valid {
+		stats.invalidRate = ewma(stats.invalidRate, 0)
+		return false
+	}
+	stats.invalid++
+	stats.strikes++
+	stats.invalidRate = ewma(stats.invalidRate, 1)
+	peerEvents.WithLabelValues(invalidEvent).Inc()
+	peerScoreHist.Observe(stats.score())
+	if stats.strikes < ps.cfg.BanThreshold {
+		return false
+	}
+	stats.strikes = 0
+	stats.bannedUntil = ps.now().Add(ps.cfg.BanDuration)
+	peerEvents.WithLabelValues(banEvent).Inc()
+	return true
+}
+
+func (ps *peerScores) banned(peer p2p.Peer) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	stats, exists := ps.peek(peer)
+	return exists && ps.now().Before(stats.bannedUntil)
+}
+
+// filterBanned returns peers that are not banned.
+func (ps *peerScores) filterBanned(peers []p2p.Peer) []p2p.Peer {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	now := ps.now()
+	rst := make([]p2p.Peer, 0, len(peers))
+	for _, peer := range peers {
+		if stats, exists := ps.peek(peer); exists && now.Before(stats.bannedUntil) {
+			continue
+		}
+		rst = append(rst, peer)
+	}
+	return rst
+}
+
+// selectPeer picks a peer at random, weighted by the score. Peers in backoff are selected
+// only if all candidates are in backoff, banned peers are never selected.
+func (ps *peerScores) selectPeer(candidates []p2p.Peer, rng *rand.Rand) (p2p.Peer, bool) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	now := ps.now()
+	var (
+		ready, backoff               []p2p.Peer
+		readyWeights, backoffWeights []float64
+	)
+	for _, peer := range candidates {
+		stats, exists := ps.peek(peer)
+		switch {
+		case !exists:
+			ready = append(ready, peer)
+			readyWeights = append(readyWeights, 1)
+		case now.Before(stats.bannedUntil):
+		case now.Before(stats.backoffUntil):
+			backoff = append(backoff, peer)
+			backoffWeights = append(backoffWeights, stats.score())
+		default:
+			ready = append(ready, peer)
+			readyWeights = append(readyWeights, stats.score())
+		}
+	}
+	if len(ready) > 0 {
+		return weightedPeer(ready, readyWeights, rng), true
+	}
+	if len(backoff) > 0 {
+		return weightedPeer(backoff, backoffWeights, rng), true
+	}
+	return p2p.NoPeer, false
+}
+
+func weightedPeer(peers []p2p.Peer, weights []float64, rng *rand.Rand) p2p.Peer {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return peers[rng.Intn(len(peers))]
+	}
+	x := rng.Float64() * total
+	for i, w := range weights {
+		x -= w
+		if x < 0 {
+			return peers[i]
+		}
+	}
+	return peers[len(peers)-1]
+}
+
+// snapshot returns scores of all known peers, sorted from the best.
+func (ps *peerScores) snapshot() []PeerScore {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	rst := make([]PeerScore, 0, ps.stats.Len())
+	for _, key := range ps.stats.Keys() {
+		peer := key.(p2p.Peer)
+		stats, exists := ps.peek(peer)
+		if !exists {
+			continue
+		}
+		rst = append(rst, PeerScore{
+			Peer:         peer,
+			Score:        stats.score(),
+			Latency:      time.Duration(stats.latency * float64(time.Second)),
+			FailureRate:  stats.failureRate,
+			InvalidRate:  stats.invalidRate,
+			Requests:     stats.requests,
+			Failures:     stats.failures,
+			Invalid:      stats.invalid,
+			BackoffUntil: stats.backoffUntil,
+			BannedUntil:  stats.bannedUntil,
+		})
+	}
+	sort.Slice(rst, func(i, j int) bool {
+		if rst[i].Score == rst[j].Score {
+			return rst[i].Peer < rst[j].Peer
+		}
+		return rst[i].Score > rst[j].Score
+	})
+	return rst
+}
@@ -0,0 +1,108 @@
+package fetch
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/p2p"
+)
+
+func TestPeerScores_Select(t *testing.T) {
+	ps := newPeerScores(DefaultConfig(), peerScoresSize)
+	fast, slow := p2p.Peer("fast"), p2p.Peer("slow")
+	for i := 0; i < 10; i++ {
+		ps.onSuccess(fast, 10*time.Millisecond)
+		ps.onSuccess(slow, 5*time.Second)
+	}
+	rng := rand.New(rand.NewSource(1))
+	counts := map[p2p.Peer]int{}
+	for i := 0; i < 1000; i++ {
+		peer, ok := ps.selectPeer([]p2p.Peer{fast, slow}, rng)
+		require.True(t, ok)
+		counts[peer]++
+	}
+	require.Greater(t, counts[fast], 3*counts[slow])
+	require.NotZero(t, counts[slow])
+
+	scores := ps.snapshot()
+	require.Len(t, scores, 2)
+	require.Equal(t, fast, scores[0].Peer)
+	require.Equal(t, uint64(10), scores[0].Requests)
+	require.Greater(t, scores[0].Score, scores[1].Score)
+}
+
+func TestPeerScores_Backoff(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BackoffBase = time.Second
+	cfg.BackoffMax = 3 * time.Second
+	ps := newPeerScores(cfg, peerScoresSize)
+	now := time.Now()
+	ps.now = func() time.Time { return now }
+
+	good, bad := p2p.Peer("good"), p2p.Peer("bad")
+	rng := rand.New(rand.NewSource(1))
+
+	ps.onFailure(bad)
+	require.Equal(t, now.Add(time.Second), ps.snapshot()[0].BackoffUntil)
+	for i := 0; i < 100; i++ {
+		peer, ok := ps.selectPeer([]p2p.Peer{good, bad}, rng)
+		require.True(t, ok)
+		require.Equal(t, good, peer)
+	}
+	// peer in backoff is selected if there are no other candidates
+	peer, ok := ps.selectPeer([]p2p.Peer{bad}, rng)
+	require.True(t, ok)
+	require.Equal(t, bad, peer)
+
+	ps.onFailure(bad)
+	require.Equal(t, now.Add(2*time.Second), ps.snapshot()[0].BackoffUntil)
+	ps.onFailure(bad)
+	require.Equal(t, now.Add(3*time.Second), ps.snapshot()[0].BackoffUntil)
+	ps.onFailure(bad)
+	require.Equal(t, now.Add(3*time.Second), ps.snapshot()[0].BackoffUntil)
+
+	ps.onSuccess(bad, time.Millisecond)
+	require.True(t, ps.snapshot()[0].BackoffUntil.IsZero())
+	require.Equal(t, uint64(4), ps.snapshot()[0].Failures)
+}
+
+func TestPeerScores_Ban(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BanThreshold = 2
+	cfg.BanDuration = time.Minute
+	ps := newPeerScores(cfg, peerScoresSize)
+	now := time.Now()
+	ps.now = func() time.Time { return now }
+
+	good, bad := p2p.Peer("good"), p2p.Peer("bad")
+	require.False(t, ps.onValidation(good, true))
+	require.False(t, ps.onValidation(bad, false))
+	require.False(t, ps.banned(bad))
+	require.True(t, ps.onValidation(bad, false))
+	require.True(t, ps.banned(bad))
+	require.Equal(t, []p2p.Peer{good}, ps.filterBanned([]p2p.Peer{good, bad}))
+
+	_, ok := ps.selectPeer([]p2p.Peer{bad}, rand.New(rand.NewSource(1)))
+	require.False(t, ok)
+
+	now = now.Add(time.Minute)
+	require.False(t, ps.banned(bad))
+	require.Equal(t, []p2p.Peer{good, bad}, ps.filterBanned([]p2p.Peer{good, bad}))
+}
+
+func TestPeerScores_Evict(t *testing.T) {
+	ps := newPeerScores(DefaultConfig(), 2)
+	first, second, third := p2p.Peer("first"), p2p.Peer("second"), p2p.Peer("third")
+	ps.onSuccess(first, time.Millisecond)
+	ps.onSuccess(second, time.Millisecond)
+	ps.onSuccess(first, time.Millisecond)
+	ps.onSuccess(third, time.Millisecond)
+
+	scores := ps.snapshot()
+	require.Len(t, scores, 2)
+	peers := []p2p.Peer{scores[0].Peer, scores[1].Peer}
+	require.ElementsMatch(t, []p2p.Peer{first, third}, peers)
+}
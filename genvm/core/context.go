@@ -41,6 +41,7 @@ type Context struct {
 
 	touched []Address
 	changed map[Address]*Account
+	events  []Event
 }
 
 // Principal returns address of the account that signed transaction.
@@ -93,6 +94,12 @@ func (c *Context) Spawn(args scale.Encodable) error {
 	account.State = buf.Bytes()
 	account.TemplateAddress = &c.Header.TemplateAddress
 	c.change(account)
+	c.Emit(Event{
+		Type:      EventSpawn,
+		Template:  c.Header.TemplateAddress,
+		Principal: c.Principal(),
+		Account:   account.Address,
+	})
 	return nil
 }
 
@@ -167,6 +174,11 @@ func (c *Context) Relay(remoteTemplate, address Address, call func(Host) error)
 	return nil
 }
 
+// Emit records the event that will be stored with the transaction result.
+func (c *Context) Emit(event Event) {
+	c.events = append(c.events, event)
+}
+
 // Consume gas from the account after validation passes.
 func (c *Context) Consume(gas uint64) (err error) {
 	amount := gas * c.Header.GasPrice
@@ -217,6 +229,11 @@ func (c *Context) Updated() []types.Address {
 	return rst
 }
 
+// Events emitted by the templates.
+func (c *Context) Events() []Event {
+	return c.events
+}
+
 func (c *Context) load(address types.Address) (*Account, error) {
 	if address == c.Principal() {
 		return &c.PrincipalAccount, nil
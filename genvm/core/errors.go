@@ -24,4 +24,7 @@ var (
 	ErrTemplateMismatch = errors.New("relay template mismatch")
 	// ErrTxLimit overflows max tx size.
 	ErrTxLimit = errors.New("overflows tx limit")
+	// ErrIneffective raised if transaction can't be included into the block,
+	// and will be dropped without changing the state.
+	ErrIneffective = errors.New("ineffective tx")
 )
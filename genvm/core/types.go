@@ -13,6 +13,15 @@ const (
 	MethodSpend = 16
 )
 
+const (
+	// EventSpawn ...
+	EventSpawn = types.TransactionEventSpawn
+	// EventSpend ...
+	EventSpend = types.TransactionEventSpend
+	// EventDrain ...
+	EventDrain = types.TransactionEventDrain
+)
+
 const TxSizeLimit = 1024
 
 type (
@@ -36,6 +45,9 @@ type (
 
 	// LayerID is a layer type.
 	LayerID = types.LayerID
+
+	// Event is an alias to types.TransactionEvent.
+	Event = types.TransactionEvent
 )
 
 //go:generate mockgen -package=mocks -destination=./mocks/handler.go github.com/spacemeshos/go-spacemesh/genvm/core Handler
@@ -105,6 +117,7 @@ type Host interface {
 	Spawn(scale.Encodable) error
 	Transfer(Address, uint64) error
 	Relay(expectedTemplate, address Address, call func(Host) error) error
+	Emit(Event)
 
 	Principal() Address
 	Handler() Handler
@@ -80,9 +80,17 @@ func (h *handler) Exec(host core.Host, method uint8, args scale.Encodable) error
 			return err
 		}
 	case core.MethodSpend:
-		if err := host.Template().(SpendTemplate).Spend(host, args.(*SpendArguments)); err != nil {
+		spend := args.(*SpendArguments)
+		if err := host.Template().(SpendTemplate).Spend(host, spend); err != nil {
 			return err
 		}
+		host.Emit(core.Event{
+			Type:        core.EventSpend,
+			Template:    h.address,
+			Principal:   host.Principal(),
+			Destination: spend.Destination,
+			Amount:      spend.Amount,
+		})
 	default:
 		return fmt.Errorf("%w: unknown method %d", core.ErrMalformed, method)
 	}
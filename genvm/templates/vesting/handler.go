@@ -57,9 +57,20 @@ func (h *handler) Load(state []byte) (core.Template, error) {
 func (h *handler) Exec(host core.Host, method uint8, args scale.Encodable) error {
 	if method == MethodDrainVault {
 		drain := args.(*DrainVaultArguments)
-		return host.Relay(vault.TemplateAddress, drain.Vault, func(host core.Host) error {
+		if err := host.Relay(vault.TemplateAddress, drain.Vault, func(host core.Host) error {
 			return host.Handler().Exec(host, core.MethodSpend, &drain.SpendArguments)
+		}); err != nil {
+			return err
+		}
+		host.Emit(core.Event{
+			Type:        core.EventDrain,
+			Template:    TemplateAddress,
+			Principal:   host.Principal(),
+			Account:     drain.Vault,
+			Destination: drain.Destination,
+			Amount:      drain.Amount,
 		})
+		return nil
 	}
 	return h.multisig.Exec(host, method, args)
 }
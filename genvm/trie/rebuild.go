@@ -0,0 +1,73 @@
+package trie
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/pruning"
+)
+
+// RebuildIfEmpty rebuilds the trie if it is empty while there are accounts, i.e. the database
+// was created before the trie was introduced or the trie was cleared by a migration.
+// It returns true if the trie was rebuilt.
+func RebuildIfEmpty(ctx context.Context, db *sql.Database) (bool, error) {
+	rebuilt := false
+	err := db.WithTx(ctx, func(tx *sql.Tx) error {
+		exists, err := accounts.HasTrie(tx)
+		if err != nil || exists {
+			return err
+		}
+		updated, err := accounts.UpdatedLayers(tx)
+		if err != nil || len(updated) == 0 {
+			return err
+		}
+		rebuilt = true
+		return Rebuild(tx)
+	})
+	return rebuilt, err
+}
+
+// Rebuild builds the trie from the accounts table and updates state hashes of the applied
+// layers to the roots of the trie. Layers before the pruned history keep their state hashes,
+// as versions of the accounts at those layers are not stored anymore.
+func Rebuild(db sql.Executor) error {
+	if err := accounts.ClearTrie(db); err != nil {
+		return err
+	}
+	updated, err := accounts.UpdatedLayers(db)
+	if err != nil {
+		return err
+	}
+	for _, lid := range updated {
+		batch, err := accounts.UpdatedIn(db, lid)
+		if err != nil {
+			return err
+		}
+		for _, account := range batch {
+			if err := UpdateAccount(db, account); err != nil {
+				return fmt.Errorf("update account %s: %w", account.Address, err)
+			}
+		}
+	}
+	pruned, err := pruning.Layer(db)
+	if err != nil {
+		return err
+	}
+	applied, err := layers.StateHashLayers(db, pruned)
+	if err != nil {
+		return err
+	}
+	for _, lid := range applied {
+		root, err := Root(db, lid)
+		if err != nil {
+			return err
+		}
+		if err := layers.UpdateStateHash(db, lid, root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package trie
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/pruning"
+)
+
+func TestRebuild(t *testing.T) {
+	const (
+		numLayers   = 5
+		numAccounts = 10
+	)
+	template := types.Address{1, 1}
+	expected := sql.InMemory()
+	db := sql.InMemory()
+	for lid := types.LayerID(1); lid <= numLayers; lid++ {
+		for i := 0; i < numAccounts; i++ {
+			if i%int(lid) != 0 {
+				continue
+			}
+			account := &types.Account{
+				Layer:     lid,
+				Address:   types.Address{byte(i)},
+				Balance:   uint64(i) * uint64(lid),
+				NextNonce: uint64(lid),
+			}
+			if i%2 == 0 {
+				account.TemplateAddress = &template
+				account.State = []byte{byte(i), byte(lid)}
+			}
+			for _, db := range []*sql.Database{expected, db} {
+				require.NoError(t, accounts.Update(db, account))
+			}
+			require.NoError(t, UpdateAccount(expected, account))
+		}
+		require.NoError(t, layers.UpdateStateHash(db, lid, types.Hash32{byte(lid)}))
+	}
+	require.NoError(t, pruning.SetLayer(db, 2))
+
+	require.NoError(t, Rebuild(db))
+	for lid := types.LayerID(1); lid <= numLayers; lid++ {
+		root, err := Root(expected, lid)
+		require.NoError(t, err)
+		require.NotEqual(t, types.Hash32{}, root)
+		rebuilt, err := Root(db, lid)
+		require.NoError(t, err)
+		require.Equal(t, root, rebuilt, "layer %s", lid)
+
+		hash, err := layers.GetStateHash(db, lid)
+		require.NoError(t, err)
+		if lid < 2 {
+			require.Equal(t, types.Hash32{byte(lid)}, hash, "pruned layer %s", lid)
+		} else {
+			require.Equal(t, root, hash, "layer %s", lid)
+		}
+	}
+}
+
+func TestRebuildIfEmpty(t *testing.T) {
+	migrations, err := sql.StateMigrations()
+	require.NoError(t, err)
+
+	// database created before the trie was introduced
+	path := filepath.Join(t.TempDir(), "state.sql")
+	db, err := sql.Open("file:"+path, sql.WithMigrations(migrations[:2].Apply))
+	require.NoError(t, err)
+	account := &types.Account{Layer: 1, Address: types.Address{1}, Balance: 100}
+	require.NoError(t, accounts.Update(db, account))
+	require.NoError(t, layers.UpdateStateHash(db, 1, types.Hash32{1}))
+	require.NoError(t, db.Close())
+
+	db, err = sql.Open("file:" + path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	rebuilt, err := RebuildIfEmpty(context.Background(), db)
+	require.NoError(t, err)
+	require.True(t, rebuilt)
+	root, err := Root(db, 1)
+	require.NoError(t, err)
+	require.Equal(t, leafHash(Key(account.Address), Value(account)), root)
+	hash, err := layers.GetStateHash(db, 1)
+	require.NoError(t, err)
+	require.Equal(t, root, hash)
+
+	rebuilt, err = RebuildIfEmpty(context.Background(), db)
+	require.NoError(t, err)
+	require.False(t, rebuilt)
+	rebuilt, err = RebuildIfEmpty(context.Background(), sql.InMemory())
+	require.NoError(t, err)
+	require.False(t, rebuilt)
+}
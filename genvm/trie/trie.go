@@ -0,0 +1,231 @@
+// Package trie implements a merkle trie over the accounts, persisted next to the accounts table.
+//
+// Accounts are stored in the binary trie keyed by the hash of the address. Leaf is placed
+// at the shortest prefix of the key that is not shared with any other key, therefore
+// the shape of the trie (and the root) depends only on the set of the accounts and not
+// on the order of updates. Empty subtrees are committed with the zero hash.
+//
+// Every node is versioned by the layer where it was updated, so that the root and proofs
+// can be computed for any layer that wasn't reverted.
+package trie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hash"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+)
+
+const (
+	leafTag byte = iota
+	nodeTag
+)
+
+const maxDepth = 8 * types.Hash32Length
+
+// Key returns the key of the account in the trie.
+func Key(address types.Address) types.Hash32 {
+	return hash.Sum(address[:])
+}
+
+// Value returns the value that commits to the account state.
+func Value(account *types.Account) types.Hash32 {
+	hasher := hash.New()
+	// writes to the hasher never fail and the account state is bounded by the vm
+	_, _ = codec.EncodeTo(hasher, account)
+	var rst types.Hash32
+	hasher.Sum(rst[:0])
+	return rst
+}
+
+// Root returns the root of the trie at the layer.
+func Root(db sql.Executor, layer types.LayerID) (types.Hash32, error) {
+	node, err := get(db, types.Hash32{}, 0, layer)
+	if err != nil || node == nil {
+		return types.Hash32{}, err
+	}
+	return node.Hash, nil
+}
+
+// Update inserts or updates the value of the key at the layer.
+func Update(db sql.Executor, layer types.LayerID, key, value types.Hash32) error {
+	depth := 0
+	for ; depth < maxDepth; depth++ {
+		node, err := get(db, key, depth, layer)
+		if err != nil {
+			return err
+		}
+		if node == nil || (node.Leaf != nil && *node.Leaf == key) {
+			break
+		}
+		if node.Leaf == nil {
+			continue
+		}
+		// another leaf occupies the position. move it down to the depth
+		// where keys diverge, intermediate nodes become internal once rehashed.
+		other := *node.Leaf
+		for depth < maxDepth && bit(other, depth) == bit(key, depth) {
+			depth++
+		}
+		if depth == maxDepth {
+			return fmt.Errorf("trie: keys collision %s", key)
+		}
+		depth++
+		if err := put(db, other, depth, layer, node.Hash, &other); err != nil {
+			return err
+		}
+		break
+	}
+	current := leafHash(key, value)
+	if err := put(db, key, depth, layer, current, &key); err != nil {
+		return err
+	}
+	for ; depth > 0; depth-- {
+		sibling, err := get(db, flip(key, depth), depth, layer)
+		if err != nil {
+			return err
+		}
+		var shash types.Hash32
+		if sibling != nil {
+			shash = sibling.Hash
+		}
+		current = parentHash(key, depth, current, shash)
+		if err := put(db, key, depth-1, layer, current, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prove returns hashes of the siblings on the path from the leaf with the key
+// to the root at the layer, starting from the leaf.
+func Prove(db sql.Executor, layer types.LayerID, key types.Hash32) ([]types.Hash32, error) {
+	depth := 0
+	for ; ; depth++ {
+		node, err := get(db, key, depth, layer)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil || (node.Leaf != nil && *node.Leaf != key) {
+			return nil, fmt.Errorf("%w: key %s at %v", sql.ErrNotFound, key, layer)
+		}
+		if node.Leaf != nil {
+			break
+		}
+	}
+	proof := make([]types.Hash32, 0, depth)
+	for ; depth > 0; depth-- {
+		sibling, err := get(db, flip(key, depth), depth, layer)
+		if err != nil {
+			return nil, err
+		}
+		var shash types.Hash32
+		if sibling != nil {
+			shash = sibling.Hash
+		}
+		proof = append(proof, shash)
+	}
+	return proof, nil
+}
+
+// Verify that the proof commits the value of the key to the root.
+func Verify(root, key, value types.Hash32, proof []types.Hash32) bool {
+	if len(proof) > maxDepth {
+		return false
+	}
+	current := leafHash(key, value)
+	for i, sibling := range proof {
+		current = parentHash(key, len(proof)-i, current, sibling)
+	}
+	return current == root
+}
+
+// AccountProof proves the account state against the state root at the layer.
+type AccountProof struct {
+	Layer   types.LayerID
+	Root    types.Hash32
+	Account types.Account
+	// Proof is a list of sibling hashes from the leaf to the root.
+	Proof []types.Hash32
+}
+
+// Verify the proof against the trusted state root.
+func (p *AccountProof) Verify(root types.Hash32) bool {
+	return Verify(root, Key(p.Account.Address), Value(&p.Account), p.Proof)
+}
+
+// UpdateAccount commits the account to the trie at the layer of the account.
+func UpdateAccount(db sql.Executor, account *types.Account) error {
+	return Update(db, account.Layer, Key(account.Address), Value(account))
+}
+
+// ProveAccount returns the proof for the state of the account at the layer.
+func ProveAccount(db sql.Executor, address types.Address, layer types.LayerID) (*AccountProof, error) {
+	account, err := accounts.Get(db, address, layer)
+	if err != nil {
+		return nil, err
+	}
+	root, err := Root(db, layer)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := Prove(db, layer, Key(address))
+	if err != nil {
+		return nil, err
+	}
+	return &AccountProof{Layer: layer, Root: root, Account: account, Proof: proof}, nil
+}
+
+func get(db sql.Executor, key types.Hash32, depth int, layer types.LayerID) (*accounts.TrieNode, error) {
+	node, err := accounts.GetTrieNode(db, uint16(depth), prefix(key, depth), layer)
+	if errors.Is(err, sql.ErrNotFound) {
+		return nil, nil
+	}
+	return node, err
+}
+
+func put(db sql.Executor, key types.Hash32, depth int, layer types.LayerID, value types.Hash32, leaf *types.Hash32) error {
+	return accounts.UpdateTrieNode(db, &accounts.TrieNode{
+		Depth:  uint16(depth),
+		Prefix: prefix(key, depth),
+		Hash:   value,
+		Leaf:   leaf,
+	}, layer)
+}
+
+func leafHash(key, value types.Hash32) types.Hash32 {
+	return hash.Sum([]byte{leafTag}, key[:], value[:])
+}
+
+// parentHash computes the hash of the parent for the node at the depth on the path of the key.
+func parentHash(key types.Hash32, depth int, node, sibling types.Hash32) types.Hash32 {
+	if bit(key, depth-1) == 0 {
+		return hash.Sum([]byte{nodeTag}, node[:], sibling[:])
+	}
+	return hash.Sum([]byte{nodeTag}, sibling[:], node[:])
+}
+
+func bit(key types.Hash32, i int) byte {
+	return (key[i/8] >> (7 - i%8)) & 1
+}
+
+// prefix returns first depth bits of the key, remaining bits of the last byte are zeroed.
+func prefix(key types.Hash32, depth int) []byte {
+	n := (depth + 7) / 8
+	rst := make([]byte, n)
+	copy(rst, key[:n])
+	if depth%8 != 0 {
+		rst[n-1] &= 0xff << (8 - depth%8)
+	}
+	return rst
+}
+
+// flip returns the key of the sibling for the node at the depth.
+func flip(key types.Hash32, depth int) types.Hash32 {
+	key[(depth-1)/8] ^= 0x80 >> ((depth - 1) % 8)
+	return key
+}
@@ -0,0 +1,142 @@
+package trie
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+)
+
+func genValues(n int) map[types.Hash32]types.Hash32 {
+	rst := map[types.Hash32]types.Hash32{}
+	for i := 0; i < n; i++ {
+		rst[types.RandomHash()] = types.RandomHash()
+	}
+	return rst
+}
+
+func TestRootEmpty(t *testing.T) {
+	root, err := Root(sql.InMemory(), types.LayerID(10))
+	require.NoError(t, err)
+	require.Equal(t, types.Hash32{}, root)
+}
+
+func TestRootIndependentOfOrder(t *testing.T) {
+	values := genValues(100)
+	keys := make([]types.Hash32, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	var roots []types.Hash32
+	for i := 0; i < 3; i++ {
+		db := sql.InMemory()
+		rand.Shuffle(len(keys), func(i, j int) {
+			keys[i], keys[j] = keys[j], keys[i]
+		})
+		for _, key := range keys {
+			require.NoError(t, Update(db, 0, key, values[key]))
+		}
+		root, err := Root(db, 0)
+		require.NoError(t, err)
+		roots = append(roots, root)
+	}
+	require.Equal(t, roots[0], roots[1])
+	require.Equal(t, roots[0], roots[2])
+}
+
+func TestProve(t *testing.T) {
+	db := sql.InMemory()
+	values := genValues(50)
+	for key, value := range values {
+		require.NoError(t, Update(db, 0, key, value))
+	}
+	root, err := Root(db, 0)
+	require.NoError(t, err)
+	for key, value := range values {
+		proof, err := Prove(db, 0, key)
+		require.NoError(t, err)
+		require.True(t, Verify(root, key, value, proof))
+		require.False(t, Verify(root, key, types.RandomHash(), proof))
+		require.False(t, Verify(types.RandomHash(), key, value, proof))
+	}
+
+	_, err = Prove(db, 0, types.RandomHash())
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
+
+func TestSingleLeaf(t *testing.T) {
+	db := sql.InMemory()
+	key, value := types.RandomHash(), types.RandomHash()
+	require.NoError(t, Update(db, 0, key, value))
+	root, err := Root(db, 0)
+	require.NoError(t, err)
+	proof, err := Prove(db, 0, key)
+	require.NoError(t, err)
+	require.Empty(t, proof)
+	require.True(t, Verify(root, key, value, proof))
+}
+
+func TestVersioned(t *testing.T) {
+	db := sql.InMemory()
+	values := genValues(10)
+	for key, value := range values {
+		require.NoError(t, Update(db, 1, key, value))
+	}
+	before, err := Root(db, 1)
+	require.NoError(t, err)
+
+	var updated types.Hash32
+	for key := range values {
+		updated = key
+		break
+	}
+	require.NoError(t, Update(db, 2, updated, types.RandomHash()))
+	require.NoError(t, Update(db, 2, types.RandomHash(), types.RandomHash()))
+	after, err := Root(db, 2)
+	require.NoError(t, err)
+	require.NotEqual(t, before, after)
+
+	root, err := Root(db, 1)
+	require.NoError(t, err)
+	require.Equal(t, before, root)
+	proof, err := Prove(db, 1, updated)
+	require.NoError(t, err)
+	require.True(t, Verify(before, updated, values[updated], proof))
+
+	require.NoError(t, accounts.RevertTrie(db, 1))
+	root, err = Root(db, 2)
+	require.NoError(t, err)
+	require.Equal(t, before, root)
+}
+
+func TestAccountProof(t *testing.T) {
+	db := sql.InMemory()
+	for i := 0; i < 10; i++ {
+		account := &types.Account{
+			Layer:     types.LayerID(1),
+			Address:   types.Address{byte(i)},
+			Balance:   uint64(i),
+			NextNonce: uint64(i),
+		}
+		require.NoError(t, accounts.Update(db, account))
+		require.NoError(t, UpdateAccount(db, account))
+	}
+	root, err := Root(db, 1)
+	require.NoError(t, err)
+
+	proof, err := ProveAccount(db, types.Address{3}, 5)
+	require.NoError(t, err)
+	require.Equal(t, root, proof.Root)
+	require.Equal(t, uint64(3), proof.Account.Balance)
+	require.True(t, proof.Verify(root))
+
+	proof.Account.NextNonce++
+	require.False(t, proof.Verify(root))
+
+	_, err = ProveAccount(db, types.Address{3}, 0)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
@@ -17,7 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/vault"
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/vesting"
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/wallet"
-	"github.com/spacemeshos/go-spacemesh/hash"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/accounts"
@@ -115,6 +115,17 @@ func (v *VM) GetStateRoot() (types.Hash32, error) {
 	return root, err
 }
 
+// GetAccountProof returns the state of the account at the layer together with the proof
+// that it is committed in the state root of that layer.
+func (v *VM) GetAccountProof(address types.Address, layer types.LayerID) (*trie.AccountProof, error) {
+	tx, err := v.db.Tx(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Release()
+	return trie.ProveAccount(tx, address, layer)
+}
+
 // GetAllAccounts returns a dump of all accounts in global state.
 func (v *VM) GetAllAccounts() ([]*types.Account, error) {
 	return accounts.All(v.db)
@@ -131,6 +142,10 @@ func (v *VM) revert(lid types.LayerID) error {
 	if err != nil {
 		return err
 	}
+	err = accounts.RevertTrie(tx, lid)
+	if err != nil {
+		return err
+	}
 	err = rewards.Revert(tx, lid)
 	if err != nil {
 		return err
@@ -183,6 +198,9 @@ func (v *VM) ApplyGenesis(genesis []types.Account) error {
 		if err := accounts.Update(tx, account); err != nil {
 			return fmt.Errorf("inserting genesis account: %w", err)
 		}
+		if err := trie.UpdateAccount(tx, account); err != nil {
+			return fmt.Errorf("committing genesis account: %w", err)
+		}
 	}
 	return tx.Commit()
 }
@@ -213,8 +231,6 @@ func (v *VM) Apply(lctx ApplyContext, txs []types.Transaction, blockRewards []ty
 	t3 := time.Now()
 	blockDurationRewards.Observe(float64(time.Since(t2)))
 
-	hasher := hash.New()
-	encoder := scale.NewEncoder(hasher)
 	total := 0
 
 	tx, err := v.db.TxImmediate(context.Background())
@@ -237,16 +253,18 @@ func (v *VM) Apply(lctx ApplyContext, txs []types.Transaction, blockRewards []ty
 		if err != nil {
 			return false
 		}
-		account.EncodeScale(encoder)
-		return true
+		err = trie.UpdateAccount(tx, account)
+		return err == nil
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
 	}
 	writesPerBlock.Observe(float64(total))
 
-	var hash types.Hash32
-	hasher.Sum(hash[:0])
+	hash, err := trie.Root(tx, lctx.Layer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
+	}
 	if err := layers.UpdateStateHash(tx, lctx.Layer, hash); err != nil {
 		return nil, nil, err
 	}
@@ -315,58 +333,19 @@ func (v *VM) execute(lctx ApplyContext, ss *core.StagedCache, txs []types.Transa
 			invalidTxCount.Inc()
 			continue
 		}
-		ctx := req.ctx
-		args := req.args
-
-		if header.GasPrice == 0 {
-			logger.With().Warning("ineffective transaction. zero gas price",
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw()})
-			invalidTxCount.Inc()
-			continue
-		}
-		if intrinsic := core.IntrinsicGas(ctx.Gas.BaseGas, tx.GetRaw().Raw); ctx.PrincipalAccount.Balance < intrinsic {
-			logger.With().Warning("ineffective transaction. intrinstic gas not covered",
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-				log.Uint64("intrinsic gas", intrinsic),
-			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw()})
-			invalidTxCount.Inc()
-			continue
-		}
-		if limit < ctx.Header.MaxGas {
-			logger.With().Warning("ineffective transaction. out of block gas",
-				log.Uint64("block gas limit", v.cfg.GasLimit),
-				log.Uint64("current limit", limit),
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw()})
-			invalidTxCount.Inc()
-			continue
-		}
-
-		// NOTE this part is executed only for transactions that weren't verified
+		// NOTE signature is verified only for transactions that weren't verified
 		// when saved into database by txs module
-		if !tx.Verified() && !req.Verify() {
-			logger.With().Warning("ineffective transaction. failed verify",
+		if err := req.checkEffective(header, limit, !tx.Verified()); err != nil {
+			logger.With().Warning("ineffective transaction",
 				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw()})
-			invalidTxCount.Inc()
-			continue
-		}
-
-		if ctx.PrincipalAccount.NextNonce > ctx.Header.Nonce {
-			logger.With().Warning("ineffective transaction. nonce too low",
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
+				log.Object("account", &req.ctx.PrincipalAccount),
+				log.Err(err),
 			)
-			ineffective = append(ineffective, types.Transaction{RawTx: tx.GetRaw(), TxHeader: header})
+			dropped := types.Transaction{RawTx: tx.GetRaw()}
+			if errors.Is(err, errNonceTooLow) {
+				dropped.TxHeader = header
+			}
+			ineffective = append(ineffective, dropped)
 			invalidTxCount.Inc()
 			continue
 		}
@@ -374,52 +353,126 @@ func (v *VM) execute(lctx ApplyContext, ss *core.StagedCache, txs []types.Transa
 		t2 := time.Now()
 		logger.With().Debug("applying transaction",
 			log.Object("header", header),
-			log.Object("account", &ctx.PrincipalAccount),
+			log.Object("account", &req.ctx.PrincipalAccount),
 		)
-
-		rst := types.TransactionWithResult{}
-		rst.Layer = lctx.Layer
-
-		err = ctx.Consume(ctx.Header.MaxGas)
-		if err == nil {
-			err = ctx.PrincipalHandler.Exec(ctx, ctx.Header.Method, args)
-		}
+		rst, err := req.execute(logger)
 		if err != nil {
-			logger.With().Debug("transaction failed",
-				log.Object("header", header),
-				log.Object("account", &ctx.PrincipalAccount),
-				log.Err(err),
-			)
-			if errors.Is(err, core.ErrInternal) {
-				return nil, nil, 0, err
-			}
+			return nil, nil, 0, err
 		}
 		transactionDurationExecute.Observe(float64(time.Since(t2)))
 
-		rst.RawTx = txs[i].GetRaw()
-		rst.TxHeader = &ctx.Header
-		rst.Status = types.TransactionSuccess
-		if err != nil {
-			rst.Status = types.TransactionFailure
-			rst.Message = err.Error()
-		}
-		rst.Gas = ctx.Consumed()
-		rst.Fee = ctx.Fee()
-		rst.Addresses = ctx.Updated()
-
-		err = ctx.Apply(ss)
-		if err != nil {
-			return nil, nil, 0, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
-		}
-		fees += ctx.Fee()
-		limit -= ctx.Consumed()
+		fees += rst.Fee
+		limit -= rst.Gas
 
-		executed = append(executed, rst)
+		executed = append(executed, *rst)
 		transactionDuration.Observe(float64(time.Since(t1)))
 	}
 	return executed, ineffective, fees, nil
 }
 
+// Simulate executes the transaction on top of the latest applied state without persisting any changes.
+// Pending transactions are executed before it, in order, and ineffective pending transactions are ignored.
+//
+// Returns core.ErrIneffective if the transaction would be dropped without being executed.
+func (v *VM) Simulate(pending []types.Transaction, raw types.RawTx) (*types.TransactionWithResult, error) {
+	lid, err := layers.GetLastApplied(v.db)
+	if err != nil {
+		return nil, err
+	}
+	lid = lid.Add(1)
+	if lid.Before(types.GetEffectiveGenesis()) {
+		lid = types.GetEffectiveGenesis()
+	}
+	// the cache is discarded after the simulation, state is only read from the database
+	ss := core.NewStagedCache(core.DBLoader{Executor: v.db})
+	for _, tx := range pending {
+		_, err := v.simulate(lid, ss, tx.GetRaw(), !tx.Verified())
+		if err != nil && errors.Is(err, core.ErrInternal) {
+			return nil, err
+		}
+	}
+	return v.simulate(lid, ss, raw, true)
+}
+
+func (v *VM) simulate(lid types.LayerID, ss *core.StagedCache, raw types.RawTx, verify bool) (*types.TransactionWithResult, error) {
+	req := &Request{
+		vm:      v,
+		cache:   ss,
+		lid:     lid,
+		raw:     raw,
+		decoder: scale.NewDecoder(bytes.NewReader(raw.Raw)),
+	}
+	header, err := req.Parse()
+	if err != nil {
+		return nil, err
+	}
+	if err := req.checkEffective(header, v.cfg.GasLimit, verify); err != nil {
+		return nil, err
+	}
+	return req.execute(v.logger)
+}
+
+// errNonceTooLow is wrapped by the error of the transaction that was dropped because the nonce was
+// already used. Unlike other ineffective transactions, it is dropped with the parsed header.
+var errNonceTooLow = fmt.Errorf("%w: nonce too low", core.ErrIneffective)
+
+// checkEffective returns an error wrapping core.ErrIneffective if the parsed transaction must be dropped
+// without execution. The signature is checked only if verify is true.
+func (r *Request) checkEffective(header *core.Header, limit uint64, verify bool) error {
+	ctx := r.ctx
+	switch {
+	case header.GasPrice == 0:
+		return fmt.Errorf("%w: zero gas price", core.ErrIneffective)
+	case ctx.PrincipalAccount.Balance < core.IntrinsicGas(ctx.Gas.BaseGas, r.raw.Raw):
+		return fmt.Errorf("%w: intrinsic gas not covered", core.ErrIneffective)
+	case limit < header.MaxGas:
+		return fmt.Errorf("%w: max gas %d over block gas limit %d", core.ErrIneffective, header.MaxGas, limit)
+	case verify && !r.Verify():
+		return fmt.Errorf("%w: failed verify", core.ErrIneffective)
+	case ctx.PrincipalAccount.NextNonce > header.Nonce:
+		return fmt.Errorf("%w: %d, expected %d", errNonceTooLow, header.Nonce, ctx.PrincipalAccount.NextNonce)
+	}
+	return nil
+}
+
+// execute the transaction that passed checkEffective and apply the changes to the cache.
+// Failure of the transaction is recorded in the result, only core.ErrInternal is returned.
+func (r *Request) execute(logger log.Log) (*types.TransactionWithResult, error) {
+	ctx := r.ctx
+	err := ctx.Consume(ctx.Header.MaxGas)
+	if err == nil {
+		err = ctx.PrincipalHandler.Exec(ctx, ctx.Header.Method, r.args)
+	}
+	if err != nil {
+		logger.With().Debug("transaction failed",
+			log.Object("header", &ctx.Header),
+			log.Object("account", &ctx.PrincipalAccount),
+			log.Err(err),
+		)
+		if errors.Is(err, core.ErrInternal) {
+			return nil, err
+		}
+	}
+	rst := &types.TransactionWithResult{
+		Transaction: types.Transaction{RawTx: r.raw, TxHeader: &ctx.Header},
+	}
+	rst.Layer = r.lid
+	rst.Status = types.TransactionSuccess
+	if err != nil {
+		rst.Status = types.TransactionFailure
+		rst.Message = err.Error()
+	} else {
+		rst.Events = ctx.Events()
+	}
+	rst.Gas = ctx.Consumed()
+	rst.Fee = ctx.Fee()
+	rst.Addresses = ctx.Updated()
+	if err := ctx.Apply(r.cache); err != nil {
+		return nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
+	}
+	return rst, nil
+}
+
 // Request used to implement 2-step validation flow.
 // After Parse is executed - conservative cache may do validation and skip Verify
 // if transaction can't be executed.
@@ -26,7 +26,6 @@ import (
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/vault"
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/vesting"
 	"github.com/spacemeshos/go-spacemesh/genvm/templates/wallet"
-	"github.com/spacemeshos/go-spacemesh/hash"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
@@ -2275,23 +2274,88 @@ func TestStateHashFromUpdatedAccounts(t *testing.T) {
 	require.NoError(tt, err)
 	require.Empty(tt, skipped)
 
-	expected := types.Hash32{}
-	hasher := hash.New()
-	encoder := scale.NewEncoder(hasher)
-	for _, pos := range []int{0, 1, 2, 4} {
-		account, err := accounts.Get(tt.db, tt.accounts[pos].getAddress(), lid)
+	statehash, err := layers.GetStateHash(tt.db, lid)
+	require.NoError(t, err)
+	require.NotEqual(t, types.Hash32{}, statehash)
+
+	root, err = tt.GetStateRoot()
+	require.NoError(t, err)
+	require.Equal(t, statehash, root)
+
+	for _, account := range tt.accounts {
+		expected, err := accounts.Get(tt.db, account.getAddress(), lid)
+		require.NoError(t, err)
+		proof, err := tt.GetAccountProof(account.getAddress(), lid)
 		require.NoError(t, err)
-		account.EncodeScale(encoder)
+		require.Equal(t, expected, proof.Account)
+		require.Equal(t, statehash, proof.Root)
+		require.True(t, proof.Verify(statehash))
+
+		proof.Account.Balance++
+		require.False(t, proof.Verify(statehash))
 	}
-	hasher.Sum(expected[:0])
+}
 
-	statehash, err := layers.GetStateHash(tt.db, lid)
+func TestSimulate(t *testing.T) {
+	tt := newTester(t).addSingleSig(2).applyGenesis()
+	spawn := tt.selfSpawn(0)
+	rst, err := tt.Simulate(nil, spawn)
 	require.NoError(t, err)
-	require.Equal(t, expected, statehash)
+	require.Equal(t, types.TransactionSuccess, rst.Status)
+	require.NotZero(t, rst.Gas)
+	require.Equal(t, rst.Gas*rst.GasPrice, rst.Fee)
+	require.Equal(t, []types.Address{tt.accounts[0].getAddress()}, rst.Addresses)
 
-	root, err = tt.GetStateRoot()
+	// nothing is persisted
+	account, err := accounts.Latest(tt.db, tt.accounts[0].getAddress())
+	require.NoError(t, err)
+	require.Nil(t, account.TemplateAddress)
+	require.Zero(t, account.NextNonce)
+
+	spend := tt.spend(0, 1, 100)
+	_, err = tt.Simulate(nil, spend)
+	require.ErrorIs(t, err, core.ErrNotSpawned)
+
+	pending := notVerified(spawn)
+	rst, err = tt.Simulate(pending, spend)
+	require.NoError(t, err)
+	require.Equal(t, types.TransactionSuccess, rst.Status)
+	require.ElementsMatch(t, []types.Address{tt.accounts[0].getAddress(), tt.accounts[1].getAddress()}, rst.Addresses)
+
+	_, err = tt.Simulate(pending, spawn)
+	require.ErrorIs(t, err, core.ErrIneffective)
+
+	rst, err = tt.Simulate(pending, tt.spend(0, 1, math.MaxUint64/2))
+	require.NoError(t, err)
+	require.Equal(t, types.TransactionFailure, rst.Status)
+	require.Contains(t, rst.Message, core.ErrNoBalance.Error())
+	require.NotZero(t, rst.Fee)
+}
+
+func TestAccountProofAfterRevert(t *testing.T) {
+	tt := newTester(t).addSingleSig(4).applyGenesis()
+	genesis := types.GetEffectiveGenesis().Sub(1)
+	before, err := tt.GetAccountProof(tt.accounts[0].getAddress(), genesis)
+	require.NoError(t, err)
+
+	lid := types.GetEffectiveGenesis()
+	skipped, _, err := tt.Apply(testContext(lid), notVerified(tt.selfSpawn(0)), nil)
+	require.NoError(t, err)
+	require.Empty(t, skipped)
+
+	after, err := tt.GetAccountProof(tt.accounts[0].getAddress(), lid)
+	require.NoError(t, err)
+	require.NotEqual(t, before.Root, after.Root)
+	// proofs for earlier layers are still available
+	proof, err := tt.GetAccountProof(tt.accounts[0].getAddress(), genesis)
 	require.NoError(t, err)
-	require.Equal(t, expected, root)
+	require.Equal(t, before, proof)
+
+	require.NoError(t, tt.Revert(genesis))
+	proof, err = tt.GetAccountProof(tt.accounts[0].getAddress(), lid)
+	require.NoError(t, err)
+	require.Equal(t, before.Root, proof.Root)
+	require.True(t, proof.Verify(before.Root))
 }
 
 func BenchmarkWallet(b *testing.B) {
@@ -2351,6 +2415,55 @@ func notVerified(raw ...types.RawTx) []types.Transaction {
 	return rst
 }
 
+func TestTransactionEvents(t *testing.T) {
+	tt := newTester(t).
+		addVesting(1, 1, 2).
+		addVault(1, 1_000, 1_000, types.GetEffectiveGenesis(), types.GetEffectiveGenesis().Add(1)).
+		addSingleSig(2).
+		applyGenesis()
+	var (
+		vestingAddr = tt.accounts[0].getAddress()
+		vaultAddr   = tt.accounts[1].getAddress()
+		walletAddr  = tt.accounts[2].getAddress()
+		other       = tt.accounts[3].getAddress()
+	)
+	lid := types.GetEffectiveGenesis().Add(2)
+	_, results, err := tt.Apply(testContext(lid), notVerified(
+		tt.selfSpawn(0),
+		tt.spawn(0, 1),
+		tt.selfSpawn(2),
+		tt.spend(2, 3, 100),
+		(&drainVault{0, 1, 3, 200}).gen(tt),
+		tt.spend(2, 3, math.MaxUint64/2),
+	), nil)
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+
+	require.Equal(t, []types.TransactionEvent{{
+		Type: types.TransactionEventSpawn, Template: vesting.TemplateAddress, Principal: vestingAddr, Account: vestingAddr,
+	}}, results[0].Events)
+	require.Equal(t, []types.TransactionEvent{{
+		Type: types.TransactionEventSpawn, Template: vault.TemplateAddress, Principal: vestingAddr, Account: vaultAddr,
+	}}, results[1].Events)
+	require.Equal(t, []types.TransactionEvent{{
+		Type: types.TransactionEventSpawn, Template: wallet.TemplateAddress, Principal: walletAddr, Account: walletAddr,
+	}}, results[2].Events)
+	require.Equal(t, []types.TransactionEvent{{
+		Type: types.TransactionEventSpend, Template: wallet.TemplateAddress, Principal: walletAddr, Destination: other, Amount: 100,
+	}}, results[3].Events)
+	require.Equal(t, []types.TransactionEvent{{
+		Type:        types.TransactionEventDrain,
+		Template:    vesting.TemplateAddress,
+		Principal:   vestingAddr,
+		Account:     vaultAddr,
+		Destination: other,
+		Amount:      200,
+	}}, results[4].Events)
+	// events are not recorded for failed transactions
+	require.Equal(t, types.TransactionFailure, results[5].Status)
+	require.Empty(t, results[5].Events)
+}
+
 func TestMain(m *testing.M) {
 	types.SetLayersPerEpoch(2)
 	os.Exit(m.Run())
@@ -179,6 +179,7 @@ type consensusProcess struct {
 	layer            types.LayerID
 	oracle           Rolacle // the roles oracle provider
 	signer           *signing.EdSigner
+	vrfSigner        *signing.VRFSigner
 	nid              types.NodeID
 	nonce            *types.VRFPostIndex
 	publisher        pubsub.Publisher
@@ -216,6 +217,10 @@ func newConsensusProcess(
 	clock RoundClock,
 	logger log.Log,
 ) *consensusProcess {
+	vrfSigner, err := signing.VRFSigner()
+	if err != nil {
+		logger.With().Fatal("failed to create vrf signer", log.Err(err))
+	}
 	proc := &consensusProcess{
 		State: State{
 			round:          preRound,
@@ -225,6 +230,7 @@ func newConsensusProcess(
 		layer:     layer,
 		oracle:    oracle,
 		signer:    signing,
+		vrfSigner: vrfSigner,
 		nid:       nid,
 		nonce:     nonce,
 		publisher: p2p,
@@ -302,8 +308,12 @@ func (proc *consensusProcess) eventLoop() {
 				logger.With().Error("failed to init msg builder", log.Err(err))
 				return nil
 			}
-			m := builder.SetType(pre).Sign(proc.signer).Build()
-			proc.sendMessage(ctx, m)
+			builder, err = builder.SetType(pre).Sign(proc.signer)
+			if err != nil {
+				logger.With().Error("failed to sign pre-round message", log.Err(err))
+				return nil
+			}
+			proc.sendMessage(ctx, builder.Build())
 		} else {
 			logger.With().Debug("should not participate",
 				log.Uint32("current_round", proc.getRound()),
@@ -526,6 +536,12 @@ func (proc *consensusProcess) sendMessage(ctx context.Context, msg *Message) boo
 	)
 	logger := proc.WithContext(ctx)
 
+	if msg.Signature == types.EmptyEdSignature {
+		// signer refused to sign the message, e.g. it conflicts with a message signed before
+		logger.Error("message is not signed")
+		return false
+	}
+
 	if err := proc.publisher.Publish(ctx, pubsub.HareProtocol, msg.Bytes()); err != nil {
 		logger.With().Error("failed to broadcast round message", log.Err(err))
 		return false
@@ -591,8 +607,12 @@ func (proc *consensusProcess) beginStatusRound(ctx context.Context) {
 		proc.WithContext(ctx).With().Error("failed to init msg builder", proc.layer, log.Err(err))
 		return
 	}
-	statusMsg := b.SetType(status).Sign(proc.signer).Build()
-	proc.sendMessage(ctx, statusMsg)
+	b, err = b.SetType(status).Sign(proc.signer)
+	if err != nil {
+		proc.WithContext(ctx).With().Error("failed to sign status message", proc.layer, log.Err(err))
+		return
+	}
+	proc.sendMessage(ctx, b.Build())
 }
 
 func (proc *consensusProcess) beginProposalRound(ctx context.Context) {
@@ -612,8 +632,12 @@ func (proc *consensusProcess) beginProposalRound(ctx context.Context) {
 		}
 		svp := proc.statusesTracker.BuildSVP()
 		if svp != nil {
-			proposalMsg := builder.SetType(proposal).SetSVP(svp).Sign(proc.signer).Build()
-			proc.sendMessage(ctx, proposalMsg)
+			builder, err = builder.SetType(proposal).SetSVP(svp).Sign(proc.signer)
+			if err != nil {
+				proc.WithContext(ctx).With().Error("failed to sign proposal message", proc.layer, log.Err(err))
+				return
+			}
+			proc.sendMessage(ctx, builder.Build())
 		} else {
 			proc.WithContext(ctx).With().Error("failed to build SVP", proc.layer)
 		}
@@ -647,7 +671,11 @@ func (proc *consensusProcess) beginCommitRound(ctx context.Context) {
 		proc.WithContext(ctx).With().Error("failed to init msg builder", proc.layer, log.Err(err))
 		return
 	}
-	builder = builder.SetType(commit).Sign(proc.signer)
+	builder, err = builder.SetType(commit).Sign(proc.signer)
+	if err != nil {
+		proc.WithContext(ctx).With().Error("failed to sign commit message", proc.layer, log.Err(err))
+		return
+	}
 	commitMsg := builder.Build()
 	proc.sendMessage(ctx, commitMsg)
 }
@@ -709,7 +737,11 @@ func (proc *consensusProcess) beginNotifyRound(ctx context.Context) {
 		return
 	}
 
-	builder = builder.SetType(notify).SetCertificate(proc.certificate).Sign(proc.signer)
+	builder, err = builder.SetType(notify).SetCertificate(proc.certificate).Sign(proc.signer)
+	if err != nil {
+		logger.With().Error("failed to sign notify message", proc.layer, log.Err(err))
+		return
+	}
 	notifyMsg := builder.Build()
 	logger.With().Debug("sending notify message", notifyMsg)
 	proc.sendMessage(ctx, notifyMsg)
@@ -763,7 +795,7 @@ func (proc *consensusProcess) initDefaultBuilder(s *Set) (*messageBuilder, error
 	}
 	builder := newMessageBuilder().SetLayer(proc.layer)
 	builder = builder.SetRoundCounter(proc.getRound()).SetCommittedRound(proc.committedRound).SetValues(s)
-	proof, err := proc.oracle.Proof(context.TODO(), *proc.nonce, proc.layer, proc.getRound())
+	proof, err := proc.oracle.Proof(context.TODO(), proc.vrfSigner, *proc.nonce, proc.layer, proc.getRound())
 	if err != nil {
 		return nil, fmt.Errorf("init default builder: %w", err)
 	}
@@ -942,7 +974,7 @@ func (proc *consensusProcess) currentRole(ctx context.Context) role {
 	if proc.nonce == nil {
 		logger.Fatal("currentRole: missing vrf nonce")
 	}
-	proof, err := proc.oracle.Proof(ctx, *proc.nonce, proc.layer, proc.getRound())
+	proof, err := proc.oracle.Proof(ctx, proc.vrfSigner, *proc.nonce, proc.layer, proc.getRound())
 	if err != nil {
 		logger.With().Error("failed to get eligibility proof from oracle", log.Err(err))
 		return passive
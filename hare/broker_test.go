@@ -47,7 +47,9 @@ func createMessage(tb testing.TB, instanceID types.LayerID) []byte {
 	sr, err := signing.NewEdSigner()
 	require.NoError(tb, err)
 	b := newMessageBuilder()
-	msg := b.SetLayer(instanceID).Sign(sr).Build()
+	b, err = b.SetLayer(instanceID).Sign(sr)
+	require.NoError(tb, err)
+	msg := b.Build()
 	return mustEncode(tb, msg)
 }
 
@@ -698,7 +700,9 @@ func TestBroker_Flow(t *testing.T) {
 		SetCommittedRound(preRound).
 		SetValues(NewDefaultEmptySet()).
 		SetEligibilityCount(1)
-	m1 := builder.Sign(signer1).Build()
+	builder, err = builder.Sign(signer1)
+	require.NoError(t, err)
+	m1 := builder.Build()
 	b.HandleMessage(context.Background(), "", mustEncode(t, m1))
 
 	ch1, e := b.Register(context.Background(), instanceID1)
@@ -715,7 +719,9 @@ func TestBroker_Flow(t *testing.T) {
 		SetCommittedRound(preRound).
 		SetValues(NewDefaultEmptySet()).
 		SetEligibilityCount(1)
-	m2 := builder.Sign(signer2).Build()
+	builder, err = builder.Sign(signer2)
+	require.NoError(t, err)
+	m2 := builder.Build()
 	ch2, e := b.Register(context.Background(), instanceID2)
 	r.NoError(e)
 
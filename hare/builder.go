@@ -134,10 +134,14 @@ func (mb *messageBuilder) SetCertificate(certificate *Certificate) *messageBuild
 }
 
 // Sign calls the provided signer to calculate the signature and then set it accordingly.
-func (mb *messageBuilder) Sign(signer *signing.EdSigner) *messageBuilder {
-	mb.msg.Signature = signer.Sign(signing.HARE, mb.msg.SignedBytes())
+func (mb *messageBuilder) Sign(signer *signing.EdSigner) (*messageBuilder, error) {
+	sig, err := signer.TrySign(signing.HARE, mb.msg.SignedBytes())
+	if err != nil {
+		return nil, err
+	}
+	mb.msg.Signature = sig
 	mb.msg.SmesherID = signer.NodeID()
-	return mb
+	return mb, nil
 }
 
 // SetType sets message type.
@@ -22,11 +22,22 @@ func marshallUnmarshall(t *testing.T, msg *Message) *Message {
 	return m
 }
 
+// mustSign signs the message in the helpers that build messages with local signers.
+func mustSign(mb *messageBuilder, signer *signing.EdSigner) *messageBuilder {
+	mb, err := mb.Sign(signer)
+	if err != nil {
+		panic(err)
+	}
+	return mb
+}
+
 func TestBuilder_TestBuild(t *testing.T) {
 	b := newMessageBuilder()
 	signer, err := signing.NewEdSigner()
 	require.NoError(t, err)
-	msg := b.SetLayer(instanceID1).Sign(signer).Build()
+	b, err = b.SetLayer(instanceID1).Sign(signer)
+	require.NoError(t, err)
+	msg := b.Build()
 
 	m := marshallUnmarshall(t, msg)
 	assert.Equal(t, m, msg)
@@ -63,7 +74,9 @@ func TestMessageFromBuffer(t *testing.T) {
 	b := newMessageBuilder()
 	signer, err := signing.NewEdSigner()
 	require.NoError(t, err)
-	msg := b.SetLayer(instanceID1).Sign(signer).Build()
+	b, err = b.SetLayer(instanceID1).Sign(signer)
+	require.NoError(t, err)
+	msg := b.Build()
 
 	buf, err := codec.Encode(msg)
 	require.NoError(t, err)
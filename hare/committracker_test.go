@@ -15,8 +15,7 @@ func BuildCommitMsg(signer *signing.EdSigner, s *Set) *Message {
 	builder := newMessageBuilder()
 	builder.SetType(commit).SetLayer(instanceID1).SetRoundCounter(commitRound).SetCommittedRound(ki).SetValues(s)
 	builder.SetEligibilityCount(1)
-	builder = builder.Sign(signer)
-	return builder.Build()
+	return mustSign(builder, signer).Build()
 }
 
 func TestCommitTracker_OnCommit(t *testing.T) {
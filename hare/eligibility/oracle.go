@@ -64,7 +64,6 @@ type Oracle struct {
 	lock           sync.Mutex
 	beacons        system.BeaconGetter
 	cdb            *datastore.CachedDB
-	vrfSigner      *signing.VRFSigner
 	vrfVerifier    vrfVerifier
 	nonceFetcher   nonceFetcher
 	layersPerEpoch uint32
@@ -100,7 +99,6 @@ func New(
 	beacons system.BeaconGetter,
 	db *datastore.CachedDB,
 	vrfVerifier vrfVerifier,
-	vrfSigner *signing.VRFSigner,
 	layersPerEpoch uint32,
 	cfg config.Config,
 	logger log.Log,
@@ -115,7 +113,6 @@ func New(
 		beacons:        beacons,
 		cdb:            db,
 		vrfVerifier:    vrfVerifier,
-		vrfSigner:      vrfSigner,
 		layersPerEpoch: layersPerEpoch,
 		activesCache:   ac,
 		fallback:       map[types.EpochID][]types.ATXID{},
@@ -361,13 +358,13 @@ func (o *Oracle) CalcEligibility(
 	return uint16(n), nil
 }
 
-// Proof returns the role proof for the current Layer & Round.
-func (o *Oracle) Proof(ctx context.Context, nonce types.VRFPostIndex, layer types.LayerID, round uint32) (types.VrfSignature, error) {
+// Proof returns the role proof of the signer for the current Layer & Round.
+func (o *Oracle) Proof(ctx context.Context, signer *signing.VRFSigner, nonce types.VRFPostIndex, layer types.LayerID, round uint32) (types.VrfSignature, error) {
 	msg, err := o.buildVRFMessage(ctx, nonce, layer, round)
 	if err != nil {
 		return types.EmptyVrfSignature, err
 	}
-	return o.vrfSigner.Sign(msg), nil
+	return signer.TrySign(msg)
 }
 
 // Returns a map of all active node IDs in the specified layer id.
@@ -59,7 +59,7 @@ func defaultOracle(t testing.TB) *testOracle {
 	nonceFetcher := NewMocknonceFetcher(ctrl)
 
 	to := &testOracle{
-		Oracle: New(mb, cdb, verifier, nil, defLayersPerEpoch, config.Config{ConfidenceParam: confidenceParam}, lg,
+		Oracle: New(mb, cdb, verifier, defLayersPerEpoch, config.Config{ConfidenceParam: confidenceParam}, lg,
 			withNonceFetcher(nonceFetcher),
 		),
 		mBeacon:       mb,
@@ -340,7 +340,7 @@ func Test_VrfSignVerify(t *testing.T) {
 	require.NoError(t, err)
 
 	o := defaultOracle(t)
-	o.vrfSigner, err = signer.VRFSigner()
+	vrfSigner, err := signer.VRFSigner()
 	require.NoError(t, err)
 	nid := signer.NodeID()
 	nonce := types.VRFPostIndex(1)
@@ -387,7 +387,7 @@ func Test_VrfSignVerify(t *testing.T) {
 
 	o.vrfVerifier = signing.NewVRFVerifier()
 
-	proof, err := o.Proof(context.Background(), nonce, lid, 1)
+	proof, err := o.Proof(context.Background(), vrfSigner, nonce, lid, 1)
 	require.NoError(t, err)
 
 	res, err := o.CalcEligibility(context.Background(), lid, 1, 10, nid, nonce, proof)
@@ -404,14 +404,14 @@ func Test_Proof_BeaconError(t *testing.T) {
 
 	signer, err := signing.NewEdSigner()
 	require.NoError(t, err)
-	o.vrfSigner, err = signer.VRFSigner()
+	vrfSigner, err := signer.VRFSigner()
 	require.NoError(t, err)
 
 	layer := types.LayerID(2)
 	errUnknown := errors.New("unknown")
 	o.mBeacon.EXPECT().GetBeacon(layer.GetEpoch()).Return(types.EmptyBeacon, errUnknown).Times(1)
 
-	_, err = o.Proof(context.Background(), types.VRFPostIndex(rand.Uint64()), layer, 3)
+	_, err = o.Proof(context.Background(), vrfSigner, types.VRFPostIndex(rand.Uint64()), layer, 3)
 	require.ErrorIs(t, err, errUnknown)
 }
 
@@ -425,8 +425,7 @@ func Test_Proof(t *testing.T) {
 	vrfSigner, err := signer.VRFSigner()
 	require.NoError(t, err)
 
-	o.vrfSigner = vrfSigner
-	sig, err := o.Proof(context.Background(), types.VRFPostIndex(rand.Uint64()), layer, 3)
+	sig, err := o.Proof(context.Background(), vrfSigner, types.VRFPostIndex(rand.Uint64()), layer, 3)
 	require.Nil(t, err)
 	require.NotNil(t, sig)
 }
@@ -105,7 +105,6 @@ type Hare struct {
 	publisher  pubsub.Publisher
 	layerClock LayerClock
 	broker     *Broker
-	sign       *signing.EdSigner
 	blockGenCh chan LayerOutput
 
 	// channel to receive MalfeasanceGossip generated by the broker and the consensus processes.
@@ -122,11 +121,13 @@ type Hare struct {
 	mu         sync.Mutex
 	lastLayer  types.LayerID
 	outputs    map[types.LayerID][]types.ProposalID
-	cps        map[types.LayerID]Consensus
+	cps        map[types.LayerID]*layerInstance
 
 	factory consensusFactory
 
-	nodeID types.NodeID
+	// nodeID is the identity of the primary signer.
+	nodeID  types.NodeID
+	signers []*signing.EdSigner
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -172,7 +173,7 @@ func New(
 
 	ev := newEligibilityValidator(rolacle, conf.N, conf.ExpectedLeaders, logger)
 	h.mchMalfeasance = make(chan *types.MalfeasanceGossip, conf.N)
-	h.sign = sign
+	h.signers = []*signing.EdSigner{sign}
 	h.blockGenCh = ch
 
 	h.beacons = beacons
@@ -183,9 +184,9 @@ func New(
 	h.networkDelta = conf.WakeupDelta
 	h.outputChan = make(chan TerminationOutput, h.config.Hdist)
 	h.outputs = make(map[types.LayerID][]types.ProposalID, h.config.Hdist) // we keep results about LayerBuffer past layers
-	h.cps = make(map[types.LayerID]Consensus, h.config.LimitConcurrent)
+	h.cps = make(map[types.LayerID]*layerInstance, h.config.LimitConcurrent)
 	h.factory = func(ctx context.Context, conf config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, signing *signing.EdSigner, nonce *types.VRFPostIndex, p2p pubsub.Publisher, comm communication, clock RoundClock) Consensus {
-		return newConsensusProcess(ctx, conf, instanceId, s, oracle, stateQ, signing, edVerifier, nid, nonce, p2p, comm, ev, clock, logger)
+		return newConsensusProcess(ctx, conf, instanceId, s, oracle, stateQ, signing, edVerifier, signing.NodeID(), nonce, p2p, comm, ev, clock, logger)
 	}
 
 	h.nodeID = nid
@@ -203,6 +204,25 @@ func New(
 	return h
 }
 
+// Register adds an identity that participates in the consensus processes started by hare.
+// It must be called before Start.
+func (h *Hare) Register(sig *signing.EdSigner) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, existing := range h.signers {
+		if existing.NodeID() == sig.NodeID() {
+			return
+		}
+	}
+	h.signers = append(h.signers, sig)
+}
+
+func (h *Hare) getSigners() []*signing.EdSigner {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.signers
+}
+
 // GetHareMsgHandler returns the gossip handler for hare protocol message.
 func (h *Hare) GetHareMsgHandler() pubsub.GossipHandler {
 	return h.broker.HandleMessage
@@ -327,12 +347,15 @@ func (h *Hare) onTick(ctx context.Context, lid types.LayerID) (bool, error) {
 		return false, nil
 	}
 
+	signers := h.getSigners()
 	// call to start the calculation of active set size beforehand
 	h.eg.Go(func() error {
-		// this is called only for its side effects, but at least print the error if it returns one
-		if isActive, err := h.rolacle.IsIdentityActiveOnConsensusView(ctx, h.nodeID, lid); err != nil {
-			logger.With().Error("error checking if identity is active",
-				log.Bool("isActive", isActive), log.Err(err))
+		for _, sig := range signers {
+			// this is called only for its side effects, but at least print the error if it returns one
+			if isActive, err := h.rolacle.IsIdentityActiveOnConsensusView(ctx, sig.NodeID(), lid); err != nil {
+				logger.With().Error("error checking if identity is active",
+					sig.NodeID(), log.Bool("isActive", isActive), log.Err(err))
+			}
 		}
 		return nil
 	})
@@ -360,13 +383,15 @@ func (h *Hare) onTick(ctx context.Context, lid types.LayerID) (bool, error) {
 		return false, nil
 	}
 
-	var nonce *types.VRFPostIndex
-	nnc, err := h.msh.VRFNonce(h.nodeID, h.lastLayer.GetEpoch())
-	if err != nil && !errors.Is(err, sql.ErrNotFound) {
-		logger.With().Error("failed to get vrf nonce", log.Err(err))
-		return false, fmt.Errorf("vrf nonce: %w", err)
-	} else if err == nil {
-		nonce = &nnc
+	nonces := make([]*types.VRFPostIndex, len(signers))
+	for i, sig := range signers {
+		nnc, err := h.msh.VRFNonce(sig.NodeID(), h.lastLayer.GetEpoch())
+		if err != nil && !errors.Is(err, sql.ErrNotFound) {
+			logger.With().Error("failed to get vrf nonce", sig.NodeID(), log.Err(err))
+			return false, fmt.Errorf("vrf nonce: %w", err)
+		} else if err == nil {
+			nonces[i] = &nnc
+		}
 	}
 
 	ch, err := h.broker.Register(ctx, lid)
@@ -374,49 +399,118 @@ func (h *Hare) onTick(ctx context.Context, lid types.LayerID) (bool, error) {
 		logger.With().Error("failed to register with broker", log.Err(err))
 		return false, fmt.Errorf("broker register: %w", err)
 	}
-	comm := communication{
-		inbox:  ch,
-		mchOut: h.mchMalfeasance,
-		report: h.outputChan,
+	inst := &layerInstance{stop: func() {}}
+	inboxes := []chan any{ch}
+	if len(signers) > 1 {
+		var fctx context.Context
+		fctx, inst.stop = context.WithCancel(h.ctx)
+		inboxes = fanOut(fctx, ch, len(signers))
+	}
+	for i, sig := range signers {
+		comm := communication{
+			inbox:  inboxes[i],
+			mchOut: h.mchMalfeasance,
+			report: h.outputChan,
+		}
+		props := goodProposals(logger, h.msh, sig.NodeID(), lid, beacon)
+		preNumProposals.Add(float64(len(props)))
+		set := NewSet(props)
+		cp := h.factory(ctx, h.config, lid, set, h.rolacle, sig, nonces[i], h.publisher, comm, clock)
+		logger.With().Info("starting hare", sig.NodeID(), log.Int("num_proposals", len(props)))
+		inst.cps = append(inst.cps, cp)
+	}
+	// register the instance before starting, so that early terminations are accounted for
+	h.addCP(logger, lid, inst)
+	for _, cp := range inst.cps {
+		cp.Start()
 	}
-	props := goodProposals(logger, h.msh, h.nodeID, lid, beacon)
-	preNumProposals.Add(float64(len(props)))
-	set := NewSet(props)
-	cp := h.factory(ctx, h.config, lid, set, h.rolacle, h.sign, nonce, h.publisher, comm, clock)
-
-	logger.With().Info("starting hare", log.Int("num_proposals", len(props)))
-	cp.Start()
-	h.addCP(logger, cp)
 	h.patrol.SetHareInCharge(lid)
 	return true, nil
 }
 
-func (h *Hare) addCP(logger log.Log, cp Consensus) {
+// layerInstance holds the consensus processes running for a layer, one for every registered identity.
+type layerInstance struct {
+	cps []Consensus
+	// stop terminates forwarding of messages from the broker to the consensus processes.
+	stop       func()
+	terminated int
+	collected  bool
+}
+
+// fanOut forwards every message from the broker inbox to n inboxes, one for every consensus process.
+func fanOut(ctx context.Context, in chan any, n int) []chan any {
+	outs := make([]chan any, n)
+	for i := range outs {
+		outs[i] = make(chan any, inboxCapacity)
+	}
+	go func() {
+		for {
+			select {
+			case msg := <-in:
+				for _, out := range outs {
+					select {
+					case out <- msg:
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return outs
+}
+
+func (h *Hare) addCP(logger log.Log, lid types.LayerID, inst *layerInstance) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	h.cps[cp.ID()] = cp
+	h.cps[lid] = inst
 	logger.With().Info("number of consensus processes (after register)",
 		log.Int("count", len(h.cps)))
 }
 
-func (h *Hare) getCP(lid types.LayerID) Consensus {
+func (h *Hare) getCP(lid types.LayerID) *layerInstance {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	return h.cps[lid]
 }
 
+// onTermination records the termination of a consensus process for the layer.
+// collect is true if the output of the process should be collected, that is the first
+// completed output or the last output if none of the processes completed.
+// last is true if all consensus processes for the layer have terminated.
+func (h *Hare) onTermination(lid types.LayerID, completed bool) (collect, last bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	inst, ok := h.cps[lid]
+	if !ok {
+		return true, true
+	}
+	inst.terminated++
+	last = inst.terminated >= len(inst.cps)
+	if !inst.collected && (completed || last) {
+		inst.collected = true
+		collect = true
+	}
+	return collect, last
+}
+
 func (h *Hare) removeCP(logger log.Log, lid types.LayerID) {
-	cp := h.getCP(lid)
-	if cp == nil {
+	inst := h.getCP(lid)
+	if inst == nil {
 		logger.With().Error("failed to find consensus process", lid)
 		return
 	}
 	// do not hold lock while waiting for consensus process to terminate
-	cp.Stop()
+	inst.stop()
+	for _, cp := range inst.cps {
+		cp.Stop()
+	}
 
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	delete(h.cps, cp.ID())
+	delete(h.cps, lid)
 	logger.With().Info("number of consensus processes (after deregister)",
 		log.Int("count", len(h.cps)))
 }
@@ -525,17 +619,22 @@ func (h *Hare) outputCollectionLoop(ctx context.Context) {
 			ctx := log.WithNewSessionID(ctx)
 			logger := h.WithContext(ctx).WithFields(layerID)
 
-			// collect coinflip, regardless of success
-			logger.With().Debug("recording weak coin result for layer",
-				log.Bool("weak_coin", coin))
-			if err := h.weakCoin.Set(layerID, coin); err != nil {
-				logger.With().Error("failed to set weak coin for layer", log.Err(err))
+			collect, last := h.onTermination(layerID, out.Completed())
+			if collect {
+				// collect coinflip, regardless of success
+				logger.With().Debug("recording weak coin result for layer",
+					log.Bool("weak_coin", coin))
+				if err := h.weakCoin.Set(layerID, coin); err != nil {
+					logger.With().Error("failed to set weak coin for layer", log.Err(err))
+				}
+				if err := h.collectOutput(ctx, out); err != nil {
+					logger.With().Warning("error collecting output from hare", log.Err(err))
+				}
 			}
-			if err := h.collectOutput(ctx, out); err != nil {
-				logger.With().Warning("error collecting output from hare", log.Err(err))
+			if last {
+				h.broker.Unregister(ctx, out.ID())
+				h.removeCP(logger, out.ID())
 			}
-			h.broker.Unregister(ctx, out.ID())
-			h.removeCP(logger, out.ID())
 		case <-h.ctx.Done():
 			return
 		}
@@ -423,6 +423,79 @@ func TestHare_onTick_notMining(t *testing.T) {
 	require.ElementsMatch(t, types.ToProposalIDs(pList), out.Proposals)
 }
 
+func TestHare_onTick_MultipleIdentities(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.N = 2
+	cfg.RoundDuration = 1
+	cfg.Hdist = 1
+	clock := newMockClock()
+	mockMesh := newMockMesh(t)
+	h := createTestHare(t, mockMesh, cfg, clock, noopPubSub(t), t.Name())
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	h.Register(signer)
+	h.Register(signer) // registering twice is a no-op
+	ids := []types.NodeID{h.nodeID, signer.NodeID()}
+
+	h.networkDelta = 0
+	var (
+		mu      sync.Mutex
+		created []types.NodeID
+	)
+	started := make(chan struct{}, len(ids))
+	h.factory = func(ctx context.Context, cfg config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, sig *signing.EdSigner, _ *types.VRFPostIndex, p2p pubsub.Publisher, comm communication, clock RoundClock) Consensus {
+		mu.Lock()
+		defer mu.Unlock()
+		created = append(created, sig.NodeID())
+		mcp := newMockConsensusProcess(cfg, instanceId, s, oracle, sig, p2p, comm.report, make(chan struct{}))
+		go func() {
+			<-mcp.started
+			started <- struct{}{}
+		}()
+		return mcp
+	}
+
+	require.NoError(t, h.Start(context.Background()))
+
+	lyrID := types.GetEffectiveGenesis().Add(1)
+	beacon := types.RandomBeacon()
+	pList := []*types.Proposal{
+		randomProposal(lyrID, beacon),
+		randomProposal(lyrID, beacon),
+	}
+	for _, id := range ids {
+		mockMesh.EXPECT().GetEpochAtx(lyrID.GetEpoch(), id).Return(nil, sql.ErrNotFound)
+		mockMesh.EXPECT().VRFNonce(id, lyrID.GetEpoch()).Return(types.VRFPostIndex(1), nil)
+		h.mockRoracle.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), id, lyrID).Return(true, nil)
+	}
+	mockMesh.EXPECT().Proposals(lyrID).Return(pList, nil).Times(len(ids))
+	// the output is collected once for all identities
+	h.mockCoin.EXPECT().Set(lyrID, gomock.Any())
+
+	mockBeacons := smocks.NewMockBeaconGetter(gomock.NewController(t))
+	h.beacons = mockBeacons
+	mockBeacons.EXPECT().GetBeacon(lyrID.GetEpoch()).Return(beacon, nil).Times(1)
+
+	ok, err := h.onTick(context.Background(), lyrID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	for range ids {
+		<-started
+	}
+	out := <-h.blockGenCh
+	require.Equal(t, lyrID, out.Layer)
+	require.ElementsMatch(t, types.ToProposalIDs(pList), out.Proposals)
+	require.Eventually(t, func() bool {
+		return h.getCP(lyrID) == nil
+	}, time.Second, 10*time.Millisecond)
+	h.Close()
+
+	require.Empty(t, h.blockGenCh)
+	mu.Lock()
+	defer mu.Unlock()
+	require.ElementsMatch(t, ids, created)
+}
+
 func TestHare_onTick_NoBeacon(t *testing.T) {
 	lyr := types.LayerID(199)
 
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
@@ -17,7 +18,7 @@ type layerPatrol interface {
 type Rolacle interface {
 	Validate(context.Context, types.LayerID, uint32, int, types.NodeID, types.VrfSignature, uint16) (bool, error)
 	CalcEligibility(context.Context, types.LayerID, uint32, int, types.NodeID, types.VRFPostIndex, types.VrfSignature) (uint16, error)
-	Proof(context.Context, types.VRFPostIndex, types.LayerID, uint32) (types.VrfSignature, error)
+	Proof(context.Context, *signing.VRFSigner, types.VRFPostIndex, types.LayerID, uint32) (types.VrfSignature, error)
 	IsIdentityActiveOnConsensusView(context.Context, types.NodeID, types.LayerID) (bool, error)
 }
 
@@ -300,14 +300,18 @@ func TestMessageValidator_ValidateMessage(t *testing.T) {
 	v := proc.validator
 	b, err := proc.initDefaultBuilder(proc.value)
 	require.Nil(t, err)
-	preround := b.SetType(pre).Sign(proc.signer).Build()
+	b, err = b.SetType(pre).Sign(proc.signer)
+	require.NoError(t, err)
+	preround := b.Build()
 	preround.SmesherID = proc.signer.NodeID()
 	require.True(t, v.SyntacticallyValidateMessage(context.Background(), preround))
 	e := v.ContextuallyValidateMessage(context.Background(), preround, 0)
 	require.Nil(t, e)
 	b, err = proc.initDefaultBuilder(proc.value)
 	require.Nil(t, err)
-	status := b.SetType(status).Sign(proc.signer).Build()
+	b, err = b.SetType(status).Sign(proc.signer)
+	require.NoError(t, err)
+	status := b.Build()
 	status.SmesherID = proc.signer.NodeID()
 	e = v.ContextuallyValidateMessage(context.Background(), status, 0)
 	require.Nil(t, e)
@@ -20,7 +20,7 @@ func BuildNotifyMsg(signing *signing.EdSigner, s *Set) *Message {
 	cert.AggMsgs.Messages = []Message{*BuildCommitMsg(signing, s)}
 	builder.SetCertificate(cert)
 	builder.SetEligibilityCount(1)
-	return builder.Sign(signing).Build()
+	return mustSign(builder, signing).Build()
 }
 
 func TestNotifyTracker_OnNotify(t *testing.T) {
@@ -20,7 +20,7 @@ func buildStatusMsg(sig *signing.EdSigner, s *Set, ki uint32) *Message {
 		SetCommittedRound(ki).
 		SetValues(s).
 		SetEligibilityCount(1)
-	return builder.Sign(sig).Build()
+	return mustSign(builder, sig).Build()
 }
 
 func BuildStatusMsg(sig *signing.EdSigner, s *Set) *Message {
@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime/pprof"
+	"strconv"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+const (
+	// ProfileCPU is a cpu profile collected over ProfilesConfig.CPUDuration.
+	ProfileCPU = "cpu"
+	// ProfileHeap is a snapshot of the heap.
+	ProfileHeap = "heap"
+	// ProfileGoroutine is a snapshot of all goroutines.
+	ProfileGoroutine = "goroutine"
+)
+
+// ProfilesConfig configures continuous profiling.
+type ProfilesConfig struct {
+	// URL is the endpoint where profiles are uploaded.
+	URL string
+	// Period is the interval between profile captures.
+	Period time.Duration
+	// CPUDuration is the duration of the cpu profile. It must be shorter than Period.
+	// The cpu profile is not captured if it is zero, e.g. when cpu is profiled by another profiler.
+	CPUDuration time.Duration
+	// Name, NodeID and GenesisID tag uploaded profiles.
+	Name      string
+	NodeID    string
+	GenesisID string
+}
+
+// StartPushingProfiles periodically captures cpu, heap and goroutine profiles and uploads
+// them to the url with a POST request per profile. Body of the request is the profile in pprof
+// format and the query tags it with the profile type, name, node id, genesis id and capture time.
+//
+// Profiles are pushed until ctx is canceled.
+func StartPushingProfiles(ctx context.Context, cfg ProfilesConfig) error {
+	if _, err := url.Parse(cfg.URL); err != nil {
+		return fmt.Errorf("parse profiles url %s: %w", cfg.URL, err)
+	}
+	if cfg.Period <= 0 {
+		return fmt.Errorf("profiles push period must be positive, got %v", cfg.Period)
+	}
+	if cfg.CPUDuration < 0 || cfg.CPUDuration >= cfg.Period {
+		return fmt.Errorf("cpu profile duration %v must be shorter than push period %v", cfg.CPUDuration, cfg.Period)
+	}
+	p := &profilesPusher{cfg: cfg, client: &http.Client{Timeout: cfg.Period}}
+	go p.run(ctx)
+	return nil
+}
+
+type profilesPusher struct {
+	cfg    ProfilesConfig
+	client *http.Client
+}
+
+func (p *profilesPusher) run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Period)
+	defer ticker.Stop()
+	for {
+		p.pushAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *profilesPusher) pushAll(ctx context.Context) {
+	profiles := []string{ProfileHeap, ProfileGoroutine}
+	if p.cfg.CPUDuration > 0 {
+		profiles = append([]string{ProfileCPU}, profiles...)
+	}
+	for _, profile := range profiles {
+		captured := time.Now()
+		var buf bytes.Buffer
+		if err := capture(ctx, &buf, profile, p.cfg.CPUDuration); err != nil {
+			if ctx.Err() == nil {
+				log.With().Warning("failed to capture profile", log.String("profile", profile), log.Err(err))
+			}
+			continue
+		}
+		if err := p.upload(ctx, profile, captured, &buf); err != nil {
+			if ctx.Err() == nil {
+				log.With().Warning("failed to push profile", log.String("profile", profile), log.Err(err))
+			}
+		}
+	}
+}
+
+func capture(ctx context.Context, w io.Writer, profile string, cpuDuration time.Duration) error {
+	if profile != ProfileCPU {
+		return pprof.Lookup(profile).WriteTo(w, 0)
+	}
+	// fails if cpu is already profiled, e.g. by the pprof server
+	if err := pprof.StartCPUProfile(w); err != nil {
+		return err
+	}
+	defer pprof.StopCPUProfile()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(cpuDuration):
+		return nil
+	}
+}
+
+func (p *profilesPusher) upload(ctx context.Context, profile string, captured time.Time, body io.Reader) error {
+	target, err := url.Parse(p.cfg.URL)
+	if err != nil {
+		return err
+	}
+	query := target.Query()
+	query.Set("type", profile)
+	query.Set("name", p.cfg.Name)
+	query.Set("node_id", p.cfg.NodeID)
+	query.Set("genesis_id", p.cfg.GenesisID)
+	query.Set("from", strconv.FormatInt(captured.Unix(), 10))
+	target.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -10,21 +12,31 @@ import (
 )
 
 // StartPushingMetrics begins pushing metrics to the url specified by the --metrics-push flag
-// with period specified by the --metrics-push-period flag.
-func StartPushingMetrics(url string, periodSec int, nodeID, networkID string) {
+// with period specified by the --metrics-push-period flag. Metrics are pushed once immediately,
+// and then periodically until ctx is canceled.
+func StartPushingMetrics(ctx context.Context, url string, periodSec int, nodeID, networkID string) error {
+	if periodSec <= 0 {
+		return fmt.Errorf("metrics push period must be positive, got %d", periodSec)
+	}
 	period := time.Duration(periodSec) * time.Second
-	ticker := time.NewTicker(period)
 
 	pusher := push.New(url, "go-spacemesh").Gatherer(stdprometheus.DefaultGatherer).
 		Grouping("node_id", nodeID).
 		Grouping("network_id", networkID)
 
 	go func() {
-		for range ticker.C {
-			err := pusher.Push()
-			if err != nil {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			if err := pusher.PushContext(ctx); err != nil && ctx.Err() == nil {
 				log.With().Warning("failed to push metrics", log.Err(err))
 			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
 		}
 	}()
+	return nil
 }
@@ -1,16 +1,20 @@
 package metrics
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStartPushMetrics(t *testing.T) {
@@ -47,3 +51,58 @@ func TestStartPushMetrics(t *testing.T) {
 		t.Fatal("can't push to server", err)
 	}
 }
+
+func TestStartPushingProfiles(t *testing.T) {
+	type upload struct {
+		query url.Values
+		body  []byte
+	}
+	uploads := make(chan upload, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPost, r.Method)
+		uploads <- upload{query: r.URL.Query(), body: body}
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := ProfilesConfig{
+		URL:         ts.URL,
+		Period:      time.Minute,
+		CPUDuration: 10 * time.Millisecond,
+		Name:        "test",
+		NodeID:      "node",
+		GenesisID:   "genesis",
+	}
+	require.NoError(t, StartPushingProfiles(ctx, cfg))
+
+	for _, profile := range []string{ProfileCPU, ProfileHeap, ProfileGoroutine} {
+		select {
+		case u := <-uploads:
+			require.Equal(t, profile, u.query.Get("type"))
+			require.Equal(t, "test", u.query.Get("name"))
+			require.Equal(t, "node", u.query.Get("node_id"))
+			require.Equal(t, "genesis", u.query.Get("genesis_id"))
+			require.NotEmpty(t, u.body)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for profile", profile)
+		}
+	}
+
+	cfg.CPUDuration = cfg.Period
+	require.Error(t, StartPushingProfiles(ctx, cfg))
+
+	// cpu profile is skipped if disabled
+	cfg.CPUDuration = 0
+	require.NoError(t, StartPushingProfiles(ctx, cfg))
+	for _, profile := range []string{ProfileHeap, ProfileGoroutine} {
+		select {
+		case u := <-uploads:
+			require.Equal(t, profile, u.query.Get("type"))
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for profile", profile)
+		}
+	}
+}
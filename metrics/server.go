@@ -4,17 +4,23 @@ package metrics
 import (
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/spacemeshos/go-spacemesh/log"
 )
 
+var serverOnce sync.Once
+
 // StartMetricsServer begins listening and supplying metrics on localhost:`metricsPort`/metrics.
+// The server is started once per process, subsequent calls are no-op.
 func StartMetricsServer(metricsPort int) {
-	http.Handle("/metrics", promhttp.Handler())
-	go func() {
-		err := http.ListenAndServe(fmt.Sprintf(":%v", metricsPort), nil)
-		log.With().Warning("Metrics server stopped: %v", log.Err(err))
-	}()
+	serverOnce.Do(func() {
+		http.Handle("/metrics", promhttp.Handler())
+		go func() {
+			err := http.ListenAndServe(fmt.Sprintf(":%v", metricsPort), nil)
+			log.With().Warning("Metrics server stopped: %v", log.Err(err))
+		}()
+	})
 }
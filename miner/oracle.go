@@ -143,7 +143,10 @@ func (o *Oracle) calcEligibilityProofs(atx *types.ActivationTxHeader, epoch type
 		if err != nil {
 			logger.With().Fatal("failed to serialize VRF msg", log.Err(err))
 		}
-		vrfSig := o.vrfSigner.Sign(message)
+		vrfSig, err := o.vrfSigner.TrySign(message)
+		if err != nil {
+			return nil, fmt.Errorf("oracle sign vrf msg: %w", err)
+		}
 		eligibleLayer := proposals.CalcEligibleLayer(epoch, o.layersPerEpoch, vrfSig)
 		eligibilityProofs[eligibleLayer] = append(eligibilityProofs[eligibleLayer], types.VotingEligibility{
 			J:   counter,
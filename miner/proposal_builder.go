@@ -255,9 +255,13 @@ func (pb *ProposalBuilder) createProposal(
 	if p.EpochData != nil {
 		p.ActiveSet = epochEligibility.ActiveSet
 	}
-	p.Ballot.Signature = pb.signer.Sign(signing.BALLOT, p.Ballot.SignedBytes())
+	if p.Ballot.Signature, err = pb.signer.TrySign(signing.BALLOT, p.Ballot.SignedBytes()); err != nil {
+		return nil, fmt.Errorf("sign ballot: %w", err)
+	}
 	p.SmesherID = pb.signer.NodeID()
-	p.Signature = pb.signer.Sign(signing.BALLOT, p.SignedBytes())
+	if p.Signature, err = pb.signer.TrySign(signing.BALLOT, p.SignedBytes()); err != nil {
+		return nil, fmt.Errorf("sign proposal: %w", err)
+	}
 	if err := p.Initialize(); err != nil {
 		logger.With().Fatal("proposal failed to initialize", log.Err(err))
 	}
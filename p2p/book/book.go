@@ -73,6 +73,27 @@ func isDns(raw Address) bool {
 	return false
 }
 
+// Transports that can be recognized from the address.
+const (
+	TCP  = "tcp"
+	QUIC = "quic"
+)
+
+// Transport returns the name of the transport that is used to dial the address.
+// Empty string is returned if transport can't be recognized, for example for dnsaddr.
+func Transport(raw Address) string {
+	transport := ""
+	for _, protocol := range raw.Protocols() {
+		switch protocol.Code {
+		case ma.P_TCP:
+			transport = TCP
+		case ma.P_QUIC, ma.P_QUIC_V1:
+			transport = QUIC
+		}
+	}
+	return transport
+}
+
 func bucketize(raw Address) bucket {
 	if manet.IsPublicAddr(raw) || isDns(raw) {
 		return public
@@ -86,6 +107,8 @@ type addressInfo struct {
 	Raw       jsonAddress `json:"raw"`
 	Class     class       `json:"class"`
 	Connected bool        `json:"connected"`
+	// Other are addresses of the same peer for the transports other than the transport of Raw.
+	Other []jsonAddress `json:"other,omitempty"`
 
 	shareable bool // true if item is in shareable array
 	bucket    bucket
@@ -94,6 +117,37 @@ type addressInfo struct {
 	success   int
 }
 
+func (a *addressInfo) addrs() []Address {
+	rst := make([]Address, 0, 1+len(a.Other))
+	rst = append(rst, a.Raw.Address)
+	for _, other := range a.Other {
+		rst = append(rst, other.Address)
+	}
+	return rst
+}
+
+// update replaces the address with the same transport as raw or adds
+// raw as an address for a new transport. Protected addresses are never replaced.
+func (a *addressInfo) update(raw Address) {
+	transport := Transport(raw)
+	if transport == Transport(a.Raw.Address) {
+		if !a.protected && !a.Raw.Address.Equal(raw) {
+			a.Raw.Address = raw
+			a.bucket = bucketize(raw)
+		}
+		return
+	}
+	for i := range a.Other {
+		if transport == Transport(a.Other[i].Address) {
+			if !a.protected {
+				a.Other[i].Address = raw
+			}
+			return
+		}
+	}
+	a.Other = append(a.Other, jsonAddress{raw})
+}
+
 type jsonAddress struct {
 	Address
 }
@@ -176,9 +230,8 @@ func (b *Book) Add(src, id ID, raw Address) {
 		b.shareable = append(b.shareable, addr)
 		b.queue.PushBack(addr)
 		b.known[id] = addr
-	} else if addr.Raw.Address != raw && !addr.protected {
-		addr.Raw.Address = raw
-		addr.bucket = bucket
+	} else {
+		addr.update(raw)
 	}
 }
 
@@ -240,12 +293,24 @@ func (b *Book) Update(id ID, events ...Event) {
 	}
 }
 
+// DrainQueue returns addresses of up to n peers from the front of the queue.
+// Peer may have an address for every transport it supports.
 func (b *Book) DrainQueue(n int) []Address {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	return take(n, b.drainQueue())
+	rst := make([]Address, 0, n)
+	next := b.drainQueue()
+	for i := 0; i < n; i++ {
+		addr := next()
+		if addr == nil {
+			break
+		}
+		rst = append(rst, addr.addrs()...)
+	}
+	return rst
 }
 
+// TakeShareable returns up to n addresses that can be shared with src.
 func (b *Book) TakeShareable(src ID, n int) []Address {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -337,7 +402,7 @@ func (b *Book) iterShareable(src ID, bucket bucket) iterator {
 		b.shareable[i], b.shareable[j] = b.shareable[j], b.shareable[i]
 	})
 	i := 0
-	return func() Address {
+	return func() *addressInfo {
 		for {
 			if i == len(b.shareable) {
 				return nil
@@ -351,7 +416,7 @@ func (b *Book) iterShareable(src ID, bucket bucket) iterator {
 			} else {
 				i++
 				if rst.bucket == bucket && rst.ID != src {
-					return rst.Raw.Address
+					return rst
 				}
 			}
 		}
@@ -359,28 +424,32 @@ func (b *Book) iterShareable(src ID, bucket bucket) iterator {
 }
 
 func (b *Book) drainQueue() iterator {
-	return func() Address {
+	return func() *addressInfo {
 		for {
 			if b.queue.Len() == 0 {
 				return nil
 			}
-			rst := b.queue.Remove(b.queue.Front())
-			if rst.(*addressInfo).Class == deleted {
+			rst := b.queue.Remove(b.queue.Front()).(*addressInfo)
+			if rst.Class == deleted {
 				continue
 			}
-			return rst.(*addressInfo).Raw.Address
+			return rst
 		}
 	}
 }
 
-type iterator func() Address
+type iterator func() *addressInfo
 
+// take returns up to n addresses, addresses of the same peer are taken together
+// unless the limit is reached.
 func take(n int, next iterator) []Address {
 	rst := make([]Address, 0, n)
 	for addr := next(); addr != nil; addr = next() {
-		rst = append(rst, addr)
-		if len(rst) == cap(rst) {
-			return rst
+		for _, raw := range addr.addrs() {
+			rst = append(rst, raw)
+			if len(rst) == cap(rst) {
+				return rst
+			}
 		}
 	}
 	return rst
@@ -77,6 +77,26 @@ func share(src book.ID, n int, ids ...book.ID) step {
 	}
 }
 
+func drainAddrs(n int, addrs ...string) step {
+	return func(ts *testState) {
+		rst := ts.book.DrainQueue(n)
+		require.Len(ts, rst, len(addrs))
+		for i, addr := range addrs {
+			require.Equal(ts, addr, rst[i].String(), "i=%d", i)
+		}
+	}
+}
+
+func shareAddrs(src book.ID, n int, addrs ...string) step {
+	return func(ts *testState) {
+		rst := ts.book.TakeShareable(src, n)
+		require.Len(ts, rst, len(addrs))
+		for i, addr := range addrs {
+			require.Equal(ts, addr, rst[i].String(), "i=%d", i)
+		}
+	}
+}
+
 func shareExpectNil(src book.ID, n int) step {
 	return func(ts *testState) {
 		require.Nil(ts, ts.book.TakeShareable(src, n))
@@ -428,6 +448,34 @@ func TestBook(t *testing.T) {
 4927299508238403564
 `),
 		}},
+		{"address for every transport", []step{
+			add("1", "/ip4/0.0.0.0/tcp/1111"),
+			add("1", "/ip4/0.0.0.0/udp/1111/quic-v1"),
+			add("1", "/ip4/0.0.0.0/udp/1112/quic-v1"),
+			add("2", "/ip4/0.0.0.0/udp/2222/quic-v1"),
+			drainAddrs(2, "/ip4/0.0.0.0/tcp/1111", "/ip4/0.0.0.0/udp/1112/quic-v1", "/ip4/0.0.0.0/udp/2222/quic-v1"),
+			repeat(2,
+				update("1", book.Success),
+				update("2", book.Success),
+			),
+			shareAddrs("2", 3, "/ip4/0.0.0.0/tcp/1111", "/ip4/0.0.0.0/udp/1112/quic-v1"),
+			shareAddrs("2", 1, "/ip4/0.0.0.0/tcp/1111"),
+			persist(`
+{"id":"1","raw":"/ip4/0.0.0.0/tcp/1111","class":3,"connected":false,"other":["/ip4/0.0.0.0/udp/1112/quic-v1"]}
+{"id":"2","raw":"/ip4/0.0.0.0/udp/2222/quic-v1","class":3,"connected":false}
+6977492865610809946
+`),
+			recover(),
+			drainAddrs(2, "/ip4/0.0.0.0/tcp/1111", "/ip4/0.0.0.0/udp/1112/quic-v1", "/ip4/0.0.0.0/udp/2222/quic-v1"),
+		}},
+		{"protected learns new transport", []step{
+			add("1", "/dns4/protect/tcp/1111"),
+			update("1", book.Protect),
+			add("1", "/ip4/0.0.0.0/tcp/1111"),
+			add("1", "/ip4/0.0.0.0/udp/1111/quic-v1"),
+			add("1", "/ip4/0.0.0.0/udp/1112/quic-v1"),
+			drainAddrs(1, "/dns4/protect/tcp/1111", "/ip4/0.0.0.0/udp/1111/quic-v1"),
+		}},
 		{"stats", []step{
 			add("1", "/ip4/0.0.0.0/tcp/1111"),
 			stats(book.Stats{Total: 1, Private: 1, Learned: 1}),
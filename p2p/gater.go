@@ -0,0 +1,419 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+const (
+	accessListFilename = "access.json"
+	// alwaysConnectTag protects always connect peers from trimming by the connection manager.
+	alwaysConnectTag = "always-connect"
+	// alwaysConnectInterval is how often the gater reconnects to always connect peers
+	// and removes expired entries.
+	alwaysConnectInterval = 30 * time.Second
+)
+
+var (
+	// ErrInvalidAccessEntry is returned if the access list entry can't be parsed.
+	ErrInvalidAccessEntry = errors.New("invalid access list entry")
+	// ErrAccessEntryNotFound is returned when removing an entry that is not in the access list.
+	ErrAccessEntryNotFound = errors.New("access list entry not found")
+)
+
+// AccessKind is a kind of the access list entry.
+type AccessKind string
+
+const (
+	// AccessDenyPeer rejects connections with the peer ID.
+	AccessDenyPeer AccessKind = "deny-peer"
+	// AccessDenyCIDR rejects connections with the peers that have an address in the ip range.
+	AccessDenyCIDR AccessKind = "deny-cidr"
+	// AccessAlwaysConnect keeps a connection with the peer, the connection is protected from trimming.
+	AccessAlwaysConnect AccessKind = "always-connect"
+)
+
+// AccessEntry is an entry of the access list.
+type AccessEntry struct {
+	Kind AccessKind `json:"kind"`
+	// Value is a peer ID for AccessDenyPeer, an ip range in CIDR notation for AccessDenyCIDR
+	// and a multiaddr with the peer ID for AccessAlwaysConnect.
+	Value string `json:"value"`
+	// Expires is the time when the entry is removed from the access list. Zero means never.
+	Expires time.Time `json:"expires"`
+}
+
+func (e *AccessEntry) expired(now time.Time) bool {
+	return !e.Expires.IsZero() && !now.Before(e.Expires)
+}
+
+type accessListFile struct {
+	Entries []AccessEntry `json:"entries"`
+}
+
+type deniedNet struct {
+	*net.IPNet
+	expires time.Time
+}
+
+// Gater is a connection gater backed by the access list that is persisted in the data directory.
+//
+// Connections with denied peers and addresses are rejected, connections with
+// always connect peers are restored and protected from trimming by the connection manager.
+// Always connect peers are never rejected because of the denied ip range.
+type Gater struct {
+	logger log.Log
+	path   string
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries []AccessEntry
+	denied  map[peer.ID]time.Time
+	nets    []deniedNet
+	always  map[peer.ID]peer.AddrInfo
+	h       host.Host
+
+	cancel context.CancelFunc
+	eg     errgroup.Group
+}
+
+// NewGater loads the access list from the directory. If dir is empty the access list is not persisted.
+func NewGater(logger log.Log, dir string) (*Gater, error) {
+	g := &Gater{
+		logger: logger,
+		now:    time.Now,
+	}
+	var file accessListFile
+	if dir != "" {
+		g.path = filepath.Join(dir, accessListFilename)
+		data, err := os.ReadFile(g.path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("read access list %s: %w", g.path, err)
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &file); err != nil {
+				return nil, fmt.Errorf("unmarshal access list %s: %w", g.path, err)
+			}
+		}
+	}
+	for i := range file.Entries {
+		if err := normalize(&file.Entries[i]); err != nil {
+			return nil, fmt.Errorf("access list %s: %w", g.path, err)
+		}
+	}
+	g.entries = file.Entries
+	g.index()
+	return g, nil
+}
+
+func normalize(entry *AccessEntry) error {
+	switch entry.Kind {
+	case AccessDenyPeer:
+		id, err := peer.Decode(entry.Value)
+		if err != nil {
+			return fmt.Errorf("%w: peer id %q: %v", ErrInvalidAccessEntry, entry.Value, err)
+		}
+		entry.Value = id.String()
+	case AccessDenyCIDR:
+		_, ipnet, err := net.ParseCIDR(entry.Value)
+		if err != nil {
+			return fmt.Errorf("%w: cidr %q: %v", ErrInvalidAccessEntry, entry.Value, err)
+		}
+		entry.Value = ipnet.String()
+	case AccessAlwaysConnect:
+		info, err := peer.AddrInfoFromString(entry.Value)
+		if err != nil {
+			return fmt.Errorf("%w: peer address %q: %v", ErrInvalidAccessEntry, entry.Value, err)
+		}
+		if len(info.Addrs) == 0 {
+			return fmt.Errorf("%w: peer address %q without transport", ErrInvalidAccessEntry, entry.Value)
+		}
+		addrs, err := peer.AddrInfoToP2pAddrs(info)
+		if err != nil {
+			return fmt.Errorf("%w: peer address %q: %v", ErrInvalidAccessEntry, entry.Value, err)
+		}
+		entry.Value = addrs[0].String()
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidAccessEntry, entry.Kind)
+	}
+	return nil
+}
+
+// index rebuilds lookup tables from the entries. Must be called with the lock held.
+func (g *Gater) index() {
+	g.denied = map[peer.ID]time.Time{}
+	g.nets = nil
+	g.always = map[peer.ID]peer.AddrInfo{}
+	for _, entry := range g.entries {
+		switch entry.Kind {
+		case AccessDenyPeer:
+			id, _ := peer.Decode(entry.Value)
+			g.denied[id] = entry.Expires
+		case AccessDenyCIDR:
+			_, ipnet, _ := net.ParseCIDR(entry.Value)
+			g.nets = append(g.nets, deniedNet{IPNet: ipnet, expires: entry.Expires})
+		case AccessAlwaysConnect:
+			info, _ := peer.AddrInfoFromString(entry.Value)
+			g.always[info.ID] = *info
+		}
+	}
+}
+
+// persist writes the access list to the file. Must be called with the lock held.
+func (g *Gater) persist() error {
+	if g.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(accessListFile{Entries: g.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal access list: %w", err)
+	}
+	tmp := g.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write access list %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, g.path); err != nil {
+		return fmt.Errorf("rename access list %s: %w", tmp, err)
+	}
+	return nil
+}
+
+// Add inserts the entry into the access list or updates the expiration of the existing entry.
+// Connected peers that are denied by the entry are disconnected.
+func (g *Gater) Add(entry AccessEntry) error {
+	if err := normalize(&entry); err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	entries := append(make([]AccessEntry, 0, len(g.entries)+1), g.entries...)
+	replaced := false
+	for i := range entries {
+		if entries[i].Kind == entry.Kind && entries[i].Value == entry.Value {
+			entries[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		entries = append(entries, entry)
+	}
+	return g.update(entries)
+}
+
+// Remove deletes the entry with the kind and value from the access list.
+func (g *Gater) Remove(kind AccessKind, value string) error {
+	entry := AccessEntry{Kind: kind, Value: value}
+	if err := normalize(&entry); err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	entries := make([]AccessEntry, 0, len(g.entries))
+	for _, existing := range g.entries {
+		if existing.Kind != entry.Kind || existing.Value != entry.Value {
+			entries = append(entries, existing)
+		}
+	}
+	if len(entries) == len(g.entries) {
+		return fmt.Errorf("%w: %s %s", ErrAccessEntryNotFound, kind, value)
+	}
+	return g.update(entries)
+}
+
+// update replaces the entries, must be called with the lock held.
+func (g *Gater) update(entries []AccessEntry) error {
+	previous := g.entries
+	g.entries = entries
+	if err := g.persist(); err != nil {
+		g.entries = previous
+		return err
+	}
+	if g.h != nil {
+		for id := range g.always {
+			g.h.ConnManager().Unprotect(id, alwaysConnectTag)
+		}
+	}
+	g.index()
+	g.apply()
+	return nil
+}
+
+// DenyPeer denies connections with the peer for the duration.
+func (g *Gater) DenyPeer(p Peer, duration time.Duration) error {
+	return g.Add(AccessEntry{Kind: AccessDenyPeer, Value: p.String(), Expires: g.now().Add(duration)})
+}
+
+// Entries returns the entries of the access list that didn't expire.
+func (g *Gater) Entries() []AccessEntry {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.now()
+	rst := make([]AccessEntry, 0, len(g.entries))
+	for _, entry := range g.entries {
+		if !entry.expired(now) {
+			rst = append(rst, entry)
+		}
+	}
+	return rst
+}
+
+// apply disconnects denied peers and protects always connect peers. Must be called with the lock held.
+func (g *Gater) apply() {
+	if g.h == nil {
+		return
+	}
+	for id := range g.always {
+		g.h.ConnManager().Protect(id, alwaysConnectTag)
+	}
+	for _, conn := range g.h.Network().Conns() {
+		if g.deniedPeer(conn.RemotePeer()) || g.deniedAddr(conn.RemotePeer(), conn.RemoteMultiaddr()) {
+			g.logger.With().Info("disconnecting denied peer",
+				log.Stringer("peer", conn.RemotePeer()),
+				log.Stringer("address", conn.RemoteMultiaddr()),
+			)
+			if err := conn.Close(); err != nil {
+				g.logger.With().Debug("failed to close connection", log.Err(err))
+			}
+		}
+	}
+}
+
+func (g *Gater) deniedPeer(id peer.ID) bool {
+	expires, exists := g.denied[id]
+	return exists && (expires.IsZero() || g.now().Before(expires))
+}
+
+func (g *Gater) deniedAddr(id peer.ID, addr ma.Multiaddr) bool {
+	if _, exists := g.always[id]; exists || addr == nil {
+		return false
+	}
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	now := g.now()
+	for _, ipnet := range g.nets {
+		if ipnet.Contains(ip) && (ipnet.expires.IsZero() || now.Before(ipnet.expires)) {
+			return true
+		}
+	}
+	return false
+}
+
+// start protects always connect peers on the host and starts a background loop that keeps
+// connections with them.
+func (g *Gater) start(h host.Host) {
+	g.mu.Lock()
+	g.h = h
+	g.apply()
+	g.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancel = cancel
+	g.eg.Go(func() error {
+		ticker := time.NewTicker(alwaysConnectInterval)
+		defer ticker.Stop()
+		for {
+			g.maintain(ctx)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// maintain removes expired entries and connects to always connect peers.
+func (g *Gater) maintain(ctx context.Context) {
+	g.mu.Lock()
+	now := g.now()
+	entries := make([]AccessEntry, 0, len(g.entries))
+	for _, entry := range g.entries {
+		if !entry.expired(now) {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) != len(g.entries) {
+		if err := g.update(entries); err != nil {
+			g.logger.With().Warning("failed to remove expired access list entries", log.Err(err))
+		}
+	}
+	var disconnected []peer.AddrInfo
+	for id, info := range g.always {
+		if g.h.Network().Connectedness(id) != network.Connected {
+			disconnected = append(disconnected, info)
+		}
+	}
+	g.mu.Unlock()
+
+	for _, info := range disconnected {
+		if err := g.h.Connect(ctx, info); err != nil {
+			g.logger.With().Debug("failed to connect to always connect peer",
+				log.Stringer("peer", info.ID),
+				log.Err(err),
+			)
+		}
+	}
+}
+
+func (g *Gater) stop() {
+	if g.cancel != nil {
+		g.cancel()
+	}
+	_ = g.eg.Wait()
+}
+
+// InterceptPeerDial implements connmgr.ConnectionGater.
+func (g *Gater) InterceptPeerDial(id peer.ID) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !g.deniedPeer(id)
+}
+
+// InterceptAddrDial implements connmgr.ConnectionGater.
+func (g *Gater) InterceptAddrDial(id peer.ID, addr ma.Multiaddr) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !g.deniedPeer(id) && !g.deniedAddr(id, addr)
+}
+
+// InterceptAccept implements connmgr.ConnectionGater.
+func (g *Gater) InterceptAccept(conn network.ConnMultiaddrs) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// peer id is not known before the handshake, so always connect peers will be allowed in InterceptSecured.
+	if len(g.always) > 0 {
+		return true
+	}
+	return !g.deniedAddr("", conn.RemoteMultiaddr())
+}
+
+// InterceptSecured implements connmgr.ConnectionGater.
+func (g *Gater) InterceptSecured(_ network.Direction, id peer.ID, conn network.ConnMultiaddrs) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !g.deniedPeer(id) && !g.deniedAddr(id, conn.RemoteMultiaddr())
+}
+
+// InterceptUpgraded implements connmgr.ConnectionGater.
+func (g *Gater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
@@ -0,0 +1,147 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+const testPeerID = "12D3KooWDS4mbE2Cqysjf6GBMtWnhcaoBYC6M3FNkTeZqCNFCNkf"
+
+func TestGater_Entries(t *testing.T) {
+	dir := t.TempDir()
+	g, err := NewGater(logtest.New(t), dir)
+	require.NoError(t, err)
+	require.Empty(t, g.Entries())
+
+	require.ErrorIs(t, g.Add(AccessEntry{Kind: AccessDenyPeer, Value: "bad"}), ErrInvalidAccessEntry)
+	require.ErrorIs(t, g.Add(AccessEntry{Kind: AccessDenyCIDR, Value: "10.0.0.0"}), ErrInvalidAccessEntry)
+	require.ErrorIs(t, g.Add(AccessEntry{Kind: AccessAlwaysConnect, Value: "/p2p/" + testPeerID}), ErrInvalidAccessEntry)
+	require.ErrorIs(t, g.Add(AccessEntry{Kind: "unknown", Value: testPeerID}), ErrInvalidAccessEntry)
+
+	entries := []AccessEntry{
+		{Kind: AccessDenyPeer, Value: testPeerID},
+		{Kind: AccessDenyCIDR, Value: "10.0.0.0/8"},
+		{Kind: AccessAlwaysConnect, Value: "/ip4/10.0.0.1/tcp/7513/p2p/" + testPeerID},
+	}
+	for _, entry := range entries {
+		require.NoError(t, g.Add(entry))
+	}
+	// cidr is normalized and the existing entry is updated
+	require.NoError(t, g.Add(AccessEntry{Kind: AccessDenyCIDR, Value: "10.1.2.3/8"}))
+	require.Equal(t, entries, g.Entries())
+
+	g, err = NewGater(logtest.New(t), dir)
+	require.NoError(t, err)
+	require.Equal(t, entries, g.Entries())
+
+	require.ErrorIs(t, g.Remove(AccessDenyCIDR, "192.168.0.0/16"), ErrAccessEntryNotFound)
+	require.NoError(t, g.Remove(AccessDenyCIDR, "10.0.0.0/8"))
+	require.Equal(t, []AccessEntry{entries[0], entries[2]}, g.Entries())
+
+	g, err = NewGater(logtest.New(t), dir)
+	require.NoError(t, err)
+	require.Equal(t, []AccessEntry{entries[0], entries[2]}, g.Entries())
+}
+
+func TestGater_Expires(t *testing.T) {
+	g, err := NewGater(logtest.New(t), "")
+	require.NoError(t, err)
+	now := time.Now()
+	g.now = func() time.Time { return now }
+
+	id, err := peer.Decode(testPeerID)
+	require.NoError(t, err)
+	require.NoError(t, g.DenyPeer(id, time.Minute))
+	require.False(t, g.InterceptPeerDial(id))
+	require.Len(t, g.Entries(), 1)
+
+	now = now.Add(time.Minute)
+	require.True(t, g.InterceptPeerDial(id))
+	require.Empty(t, g.Entries())
+}
+
+func newGatedHost(tb testing.TB, dir string) (host.Host, *Gater) {
+	tb.Helper()
+	g, err := NewGater(logtest.New(tb), dir)
+	require.NoError(tb, err)
+	h, err := libp2p.New(
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		libp2p.ConnectionGater(g),
+	)
+	require.NoError(tb, err)
+	g.start(h)
+	tb.Cleanup(func() {
+		g.stop()
+		require.NoError(tb, h.Close())
+	})
+	return h, g
+}
+
+func newHost(tb testing.TB) host.Host {
+	tb.Helper()
+	h, err := libp2p.New(libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	require.NoError(tb, err)
+	tb.Cleanup(func() { require.NoError(tb, h.Close()) })
+	return h
+}
+
+func info(h host.Host) peer.AddrInfo {
+	return peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()}
+}
+
+func TestGater_Deny(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	h, g := newGatedHost(t, t.TempDir())
+	other := newHost(t)
+
+	require.NoError(t, other.Connect(ctx, info(h)))
+	require.NoError(t, g.Add(AccessEntry{Kind: AccessDenyPeer, Value: other.ID().String()}))
+	require.Eventually(t, func() bool {
+		return h.Network().Connectedness(other.ID()) != network.Connected
+	}, time.Second, 10*time.Millisecond)
+	require.Error(t, h.Connect(ctx, info(other)))
+	// connection is rejected by the host after the handshake
+	_ = other.Connect(network.WithForceDirectDial(ctx, "test"), info(h))
+	require.Eventually(t, func() bool {
+		return h.Network().Connectedness(other.ID()) != network.Connected &&
+			other.Network().Connectedness(h.ID()) != network.Connected
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, g.Remove(AccessDenyPeer, other.ID().String()))
+	require.NoError(t, other.Connect(network.WithForceDirectDial(ctx, "test"), info(h)))
+
+	require.NoError(t, g.Add(AccessEntry{Kind: AccessDenyCIDR, Value: "127.0.0.0/8"}))
+	require.Eventually(t, func() bool {
+		return h.Network().Connectedness(other.ID()) != network.Connected
+	}, time.Second, 10*time.Millisecond)
+	require.Error(t, other.Connect(network.WithForceDirectDial(ctx, "test"), info(h)))
+}
+
+func TestGater_AlwaysConnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	h, g := newGatedHost(t, t.TempDir())
+	trusted := newHost(t)
+
+	require.NoError(t, g.Add(AccessEntry{Kind: AccessDenyCIDR, Value: "127.0.0.0/8"}))
+	addr := fmt.Sprintf("%s/p2p/%s", trusted.Addrs()[0], trusted.ID())
+	require.NoError(t, g.Add(AccessEntry{Kind: AccessAlwaysConnect, Value: addr}))
+	require.True(t, h.ConnManager().IsProtected(trusted.ID(), alwaysConnectTag))
+
+	g.maintain(ctx)
+	require.Equal(t, network.Connected, h.Network().Connectedness(trusted.ID()))
+
+	require.NoError(t, g.Remove(AccessAlwaysConnect, addr))
+	require.False(t, h.ConnManager().IsProtected(trusted.ID(), alwaysConnectTag))
+}
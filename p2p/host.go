@@ -11,10 +11,12 @@ import (
 	"github.com/libp2p/go-libp2p/p2p/muxer/yamux"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	quic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p/book"
 	p2pmetrics "github.com/spacemeshos/go-spacemesh/p2p/metrics"
 )
 
@@ -22,6 +24,8 @@ import (
 func DefaultConfig() Config {
 	return Config{
 		Listen:             "/ip4/0.0.0.0/tcp/7513",
+		ListenQUIC:         "/ip4/0.0.0.0/udp/7513/quic-v1",
+		Transports:         []string{book.TCP},
 		Flood:              false,
 		MinPeers:           6,
 		LowPeers:           40,
@@ -46,6 +50,11 @@ type Config struct {
 	LowPeers         int      `mapstructure:"low-peers"`
 	HighPeers        int      `mapstructure:"high-peers"`
 	AdvertiseAddress string   `mapstructure:"advertise-address"`
+	// ListenQUIC is the address for the quic transport. Used only if quic is in Transports.
+	ListenQUIC string `mapstructure:"listen-quic"`
+	// Transports enabled on the host in the order of preference, supported are tcp and quic.
+	// Peers are dialed over the most preferred transport that they advertised.
+	Transports []string `mapstructure:"transports"`
 }
 
 // New initializes libp2p host configured for spacemesh.
@@ -61,6 +70,10 @@ func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20,
 	if err != nil {
 		return nil, fmt.Errorf("p2p create conn mgr: %w", err)
 	}
+	gater, err := NewGater(logger, cfg.DataDir)
+	if err != nil {
+		return nil, err
+	}
 	streamer := *yamux.DefaultTransport
 	ps, err := pstoremem.NewPeerstore()
 	if err != nil {
@@ -68,18 +81,35 @@ func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20,
 	}
 	lopts := []libp2p.Option{
 		libp2p.Identity(key),
-		libp2p.ListenAddrStrings(cfg.Listen),
 		libp2p.UserAgent("go-spacemesh"),
 		libp2p.DisableRelay(),
 
-		libp2p.Transport(tcp.NewTCPTransport),
 		libp2p.Security(noise.ID, noise.New),
 		libp2p.Muxer("/yamux/1.0.0", &streamer),
 
 		libp2p.ConnectionManager(cm),
+		libp2p.ConnectionGater(gater),
 		libp2p.Peerstore(ps),
 		libp2p.BandwidthReporter(p2pmetrics.NewBandwidthCollector()),
 	}
+	transports := cfg.Transports
+	if len(transports) == 0 {
+		transports = []string{book.TCP}
+	}
+	listen := make([]string, 0, len(transports))
+	for _, transport := range transports {
+		switch transport {
+		case book.TCP:
+			lopts = append(lopts, libp2p.Transport(tcp.NewTCPTransport))
+			listen = append(listen, cfg.Listen)
+		case book.QUIC:
+			lopts = append(lopts, libp2p.Transport(quic.NewTransport))
+			listen = append(listen, cfg.ListenQUIC)
+		default:
+			return nil, fmt.Errorf("unknown transport %q", transport)
+		}
+	}
+	lopts = append(lopts, libp2p.ListenAddrStrings(listen...))
 	if !cfg.DisableNatPort {
 		lopts = append(lopts, libp2p.NATPortMap())
 	}
@@ -94,6 +124,6 @@ func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20,
 	)
 	// TODO(dshulyak) this is small mess. refactor to avoid this patching
 	// both New and Upgrade should use options.
-	opts = append(opts, WithConfig(cfg), WithLog(logger))
+	opts = append(opts, WithConfig(cfg), WithLog(logger), WithGater(gater))
 	return Upgrade(h, genesisID, opts...)
 }
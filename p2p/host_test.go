@@ -0,0 +1,66 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p/book"
+)
+
+func newTestHost(tb testing.TB, transports ...string) *Host {
+	tb.Helper()
+	cfg := DefaultConfig()
+	cfg.DataDir = tb.TempDir()
+	cfg.DisableNatPort = true
+	cfg.Listen = "/ip4/127.0.0.1/tcp/0"
+	cfg.ListenQUIC = "/ip4/127.0.0.1/udp/0/quic-v1"
+	cfg.Transports = transports
+	h, err := New(context.Background(), logtest.New(tb), cfg, types.Hash20{})
+	require.NoError(tb, err)
+	tb.Cleanup(func() { h.Stop() })
+	return h
+}
+
+func TestNew_Transports(t *testing.T) {
+	t.Run("unknown", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.DataDir = t.TempDir()
+		cfg.Transports = []string{"udp"}
+		_, err := New(context.Background(), logtest.New(t), cfg, types.Hash20{})
+		require.ErrorContains(t, err, "unknown transport")
+	})
+	t.Run("tcp and quic", func(t *testing.T) {
+		server := newTestHost(t, book.TCP, book.QUIC)
+		client := newTestHost(t, book.QUIC, book.TCP)
+
+		listening := map[string]int{}
+		for _, addr := range server.Addrs() {
+			listening[book.Transport(addr)]++
+		}
+		require.Positive(t, listening[book.TCP])
+		require.Positive(t, listening[book.QUIC])
+
+		for _, transport := range []string{book.QUIC, book.TCP} {
+			info := peer.AddrInfo{ID: server.ID()}
+			for _, addr := range server.Addrs() {
+				if book.Transport(addr) == transport {
+					info.Addrs = append(info.Addrs, addr)
+				}
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			require.NoError(t, client.Connect(ctx, info))
+			cancel()
+			conns := client.Network().ConnsToPeer(server.ID())
+			require.Len(t, conns, 1)
+			require.Equal(t, transport, book.Transport(conns[0].RemoteMultiaddr()))
+			require.NoError(t, client.Network().ClosePeer(server.ID()))
+			client.Peerstore().ClearAddrs(server.ID())
+		}
+	})
+}
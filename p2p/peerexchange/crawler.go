@@ -20,16 +20,18 @@ type crawler struct {
 	logger log.Log
 	host   host.Host
 
-	book *book.Book
-	disc *peerExchange
+	book       *book.Book
+	disc       *peerExchange
+	transports []string
 }
 
-func newCrawler(logger log.Log, h host.Host, book *book.Book, disc *peerExchange) *crawler {
+func newCrawler(logger log.Log, h host.Host, book *book.Book, disc *peerExchange, transports []string) *crawler {
 	return &crawler{
-		logger: logger,
-		host:   h,
-		disc:   disc,
-		book:   book,
+		logger:     logger,
+		host:       h,
+		disc:       disc,
+		book:       book,
+		transports: transports,
 	}
 }
 
@@ -42,17 +44,18 @@ func (r *crawler) Crawl(ctx context.Context, concurrent int) error {
 	if len(addrs) == 0 {
 		return errors.New("can't connect to the network without addresses")
 	}
+	infos, err := peer.AddrInfosFromP2pAddrs(addrs...)
+	if err != nil {
+		return fmt.Errorf("can't parse %v: %w", addrs, err)
+	}
 	var eg errgroup.Group
-	for _, addr := range addrs {
-		src, err := peer.AddrInfoFromP2pAddr(addr)
-		if err != nil {
-			return fmt.Errorf("can't parse %v: %w", addr, err)
-		}
+	for i := range infos {
+		src := &infos[i]
 		if src.ID == r.host.ID() {
 			continue
 		}
 		eg.Go(func() error {
-			peers, err := getPeers(ctx, r.host, r.disc, src)
+			peers, err := getPeers(ctx, r.host, r.disc, src, r.transports)
 			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					return nil
@@ -81,8 +84,47 @@ type maddrIdTuple struct {
 	Addr ma.Multiaddr
 }
 
-func getPeers(ctx context.Context, h host.Host, disc *peerExchange, peer *peer.AddrInfo) ([]maddrIdTuple, error) {
-	err := h.Connect(ctx, *peer)
+// connect dials the peer over the transports in the order of preference.
+// Next transport is dialed only if dial over the previous transports failed.
+func connect(ctx context.Context, h host.Host, info *peer.AddrInfo, transports []string) error {
+	groups := byTransport(info.Addrs, transports)
+	if len(groups) == 0 {
+		return h.Connect(ctx, *info)
+	}
+	var err error
+	for _, addrs := range groups {
+		err = h.Connect(ctx, peer.AddrInfo{ID: info.ID, Addrs: addrs})
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// byTransport groups addresses by transport in the order of preference.
+// Addresses with transports that are not in the preference list are in the last group.
+func byTransport(addrs []ma.Multiaddr, transports []string) [][]ma.Multiaddr {
+	groups := make([][]ma.Multiaddr, len(transports)+1)
+	for _, addr := range addrs {
+		i := 0
+		for ; i < len(transports); i++ {
+			if book.Transport(addr) == transports[i] {
+				break
+			}
+		}
+		groups[i] = append(groups[i], addr)
+	}
+	rst := groups[:0]
+	for _, group := range groups {
+		if len(group) > 0 {
+			rst = append(rst, group)
+		}
+	}
+	return rst
+}
+
+func getPeers(ctx context.Context, h host.Host, disc *peerExchange, peer *peer.AddrInfo, transports []string) ([]maddrIdTuple, error) {
+	err := connect(ctx, h, peer, transports)
 	if err != nil {
 		return nil, err
 	}
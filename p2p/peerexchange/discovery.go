@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"net"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -36,6 +35,9 @@ type Config struct {
 	AdvertiseAddress     string // Address to advertise to a peers.
 	MinPeers             int
 	FastCrawl, SlowCrawl time.Duration
+	// Transports in the order of preference. Address is advertised for every transport
+	// and peers are dialed over the most preferred transport first. Defaults to tcp.
+	Transports []string
 }
 
 // Discovery is struct that holds the protocol components, the protocol definition, the addr book data structure and more.
@@ -56,6 +58,9 @@ type Discovery struct {
 // New creates a Discovery instance.
 func New(logger log.Log, h host.Host, config Config) (*Discovery, error) {
 	ctx, cancel := context.WithCancel(context.Background())
+	if len(config.Transports) == 0 {
+		config.Transports = []string{book.TCP}
+	}
 	d := &Discovery{
 		cfg:    config,
 		logger: logger,
@@ -64,23 +69,26 @@ func New(logger log.Log, h host.Host, config Config) (*Discovery, error) {
 		book:   book.New(),
 	}
 	d.collector = newCollector(d.book)
-	var advertise ma.Multiaddr
+	var advertise []ma.Multiaddr
 	if len(config.AdvertiseAddress) > 0 {
-		var err error
-		advertise, err = ma.NewMultiaddr(config.AdvertiseAddress)
+		addr, err := ma.NewMultiaddr(config.AdvertiseAddress)
 		if err != nil {
 			return nil, fmt.Errorf("address to advertise (%s) is invalid: %w", config.AdvertiseAddress, err)
 		}
-		for _, proto := range advertise.Protocols() {
+		for _, proto := range addr.Protocols() {
 			if proto.Code == ma.P_P2P {
-				return nil, fmt.Errorf("address to advertise (%s) includes p2p identity", advertise.String())
+				return nil, fmt.Errorf("address to advertise (%s) includes p2p identity", addr.String())
 			}
 		}
+		advertise = []ma.Multiaddr{addr}
 	} else {
-		var err error
-		advertise, err = ma.NewComponent("tcp", strconv.Itoa(int(portFromHost(logger, h))))
-		if err != nil {
-			return nil, fmt.Errorf("create tcp multiaddr %w", err)
+		advertise = advertisedAddresses(logger, h, config.Transports)
+		if len(advertise) == 0 {
+			addr, err := ma.NewComponent("tcp", "0")
+			if err != nil {
+				return nil, fmt.Errorf("create tcp multiaddr %w", err)
+			}
+			advertise = []ma.Multiaddr{addr}
 		}
 	}
 	for _, addr := range config.Bootnodes {
@@ -92,7 +100,7 @@ func New(logger log.Log, h host.Host, config Config) (*Discovery, error) {
 		d.book.Update(id.String(), book.Protect)
 	}
 	protocol := newPeerExchange(h, d.book, advertise, logger)
-	d.crawl = newCrawler(logger, h, d.book, protocol)
+	d.crawl = newCrawler(logger, h, d.book, protocol, config.Transports)
 	if len(config.DataDir) != 0 {
 		if err := d.recovery(ctx); err != nil {
 			return nil, err
@@ -150,14 +158,8 @@ func (d *Discovery) watchPortChanges(ctx context.Context, protocol *peerExchange
 		for {
 			select {
 			case <-sub.Out():
-				port := portFromHost(d.logger, d.host)
-				if port != 0 {
-					advertise, err := ma.NewComponent("tcp", strconv.Itoa(int(portFromHost(d.logger, d.host))))
-					if err != nil {
-						d.logger.With().Error("failed to create tcp multiaddr", log.Err(err))
-					} else {
-						protocol.UpdateAdvertisedAddress(advertise)
-					}
+				if advertise := advertisedAddresses(d.logger, d.host, d.cfg.Transports); len(advertise) > 0 {
+					protocol.UpdateAdvertisedAddresses(advertise)
 				}
 			case <-ctx.Done():
 				return ctx.Err()
@@ -208,17 +210,50 @@ func (d *Discovery) scanPeers(ctx context.Context) {
 	})
 }
 
-// AdvertisedAddress returns advertised address.
+// AdvertisedAddress returns the most preferred advertised address.
 func (d *Discovery) AdvertisedAddress() ma.Multiaddr {
 	return d.crawl.disc.AdvertisedAddress()
 }
 
+// AdvertisedAddresses returns advertised addresses in the order of preference.
+func (d *Discovery) AdvertisedAddresses() []ma.Multiaddr {
+	return d.crawl.disc.AdvertisedAddresses()
+}
+
 var errNotFound = errors.New("not found")
 
-func portFromHost(logger log.Log, h host.Host) uint16 {
-	addr, err := bestNetAddress(h)
+// advertisedAddresses returns the address without ip for every transport that the host listens on.
+func advertisedAddresses(logger log.Log, h host.Host, transports []string) []ma.Multiaddr {
+	rst := make([]ma.Multiaddr, 0, len(transports))
+	for _, transport := range transports {
+		port := portFromHost(logger, h, transport)
+		if port == 0 {
+			continue
+		}
+		var raw string
+		switch transport {
+		case book.QUIC:
+			raw = fmt.Sprintf("/udp/%d/quic-v1", port)
+		default:
+			raw = fmt.Sprintf("/tcp/%d", port)
+		}
+		addr, err := ma.NewMultiaddr(raw)
+		if err != nil {
+			logger.With().Error("failed to create multiaddr", log.String("address", raw), log.Err(err))
+			continue
+		}
+		rst = append(rst, addr)
+	}
+	return rst
+}
+
+func portFromHost(logger log.Log, h host.Host, transport string) uint16 {
+	addr, err := bestNetAddress(h, transport)
 	if err != nil {
-		logger.With().Warning("failed to find best host address. host won't be dialable", log.Err(err))
+		logger.With().Warning("failed to find best host address. host won't be dialable",
+			log.String("transport", transport),
+			log.Err(err),
+		)
 		return 0
 	}
 	logger.With().Info("selected new best address", log.String("address", addr.String()))
@@ -230,21 +265,23 @@ func portFromHost(logger log.Log, h host.Host) uint16 {
 	return port
 }
 
-// bestNetAddress returns routable or first one.
-func bestNetAddress(h host.Host) (ma.Multiaddr, error) {
-	routable, err := routableNetAddress(h)
+// bestNetAddress returns routable or first one for the transport.
+func bestNetAddress(h host.Host, transport string) (ma.Multiaddr, error) {
+	routable, err := routableNetAddress(h, transport)
 	if err == nil {
 		return routable, nil
 	}
-	if len(h.Addrs()) > 0 {
-		return h.Addrs()[0], nil
+	for _, addr := range h.Addrs() {
+		if book.Transport(addr) == transport {
+			return addr, nil
+		}
 	}
 	return nil, errNotFound
 }
 
-func routableNetAddress(h host.Host) (ma.Multiaddr, error) {
+func routableNetAddress(h host.Host, transport string) (ma.Multiaddr, error) {
 	for _, addr := range h.Addrs() {
-		if manet.IsPublicAddr(addr) {
+		if manet.IsPublicAddr(addr) && book.Transport(addr) == transport {
 			return addr, nil
 		}
 	}
@@ -252,13 +289,12 @@ func routableNetAddress(h host.Host) (ma.Multiaddr, error) {
 }
 
 func portFromAddress(addr ma.Multiaddr) (uint16, error) {
-	netaddr, err := manet.ToNetAddr(addr)
+	portStr, err := addr.ValueForProtocol(ma.P_TCP)
 	if err != nil {
-		return 0, fmt.Errorf("cast addr %s to netaddr: %w", addr, err)
+		portStr, err = addr.ValueForProtocol(ma.P_UDP)
 	}
-	_, portStr, err := net.SplitHostPort(netaddr.String())
 	if err != nil {
-		return 0, fmt.Errorf("parsed netaddr %s is not in expected format: %w", netaddr, err)
+		return 0, fmt.Errorf("addr %s doesn't have a port: %w", addr, err)
 	}
 	port, err := strconv.Atoi(portStr)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
@@ -21,13 +22,17 @@ import (
 
 const (
 	protocolName = "/peerexchange/v1.0.0"
+	// protocolV2Name is the version of the protocol where request includes
+	// an advertised address for every transport.
+	protocolV2Name = "/peerexchange/v2.0.0"
 	// messageTimeout is the timeout for the whole stream lifetime.
 	messageTimeout = 10 * time.Second
 	sharedPeers    = 10
+	maxAdvertised  = 4
 )
 
 type peerExchange struct {
-	advertise atomic.Pointer[ma.Multiaddr]
+	advertise atomic.Pointer[[]ma.Multiaddr]
 
 	h      host.Host
 	book   *book.Book
@@ -35,59 +40,85 @@ type peerExchange struct {
 }
 
 // newPeerExchange is a constructor for a protocol protocol provider.
-func newPeerExchange(h host.Host, rt *book.Book, advertise ma.Multiaddr, log log.Log) *peerExchange {
+func newPeerExchange(h host.Host, rt *book.Book, advertise []ma.Multiaddr, log log.Log) *peerExchange {
 	pe := &peerExchange{
 		h:      h,
 		book:   rt,
 		logger: log,
 	}
-	pe.UpdateAdvertisedAddress(advertise)
+	pe.UpdateAdvertisedAddresses(advertise)
 	h.SetStreamHandler(protocolName, pe.handler)
+	h.SetStreamHandler(protocolV2Name, pe.handler)
 	return pe
 }
 
 func (p *peerExchange) handler(stream network.Stream) {
 	defer stream.Close()
 	t := time.Now()
-	logger := p.logger.WithFields(log.String("protocol", protocolName),
+	logger := p.logger.WithFields(log.String("protocol", string(stream.Protocol())),
 		log.String("from", stream.Conn().RemotePeer().Pretty())).With()
 
-	buf, _, err := codec.DecodeByteSlice(stream)
-	if err != nil {
-		logger.Debug("failed to read advertised address", log.Err(err))
-		return
-	}
-	addr, err := ma.NewMultiaddrBytes(buf)
-	if err != nil {
-		logger.Debug("failed to cast bytes to multiaddr", log.Err(err))
-		return
-	}
-	logger.Debug("got request from address", log.Stringer("address", addr))
-	if len(addr.Protocols()) == 1 {
-		// in some setups we rely on a behavior that peer can learn routable address
-		// of the other node, even if that node is not aware of its own routable address
-		ip, err := manet.ToIP(stream.Conn().RemoteMultiaddr())
+	var addrs []ma.Multiaddr
+	if stream.Protocol() == protocolV2Name {
+		raw, _, err := scale.DecodeStringSliceWithLimit(scale.NewDecoder(stream), maxAdvertised)
 		if err != nil {
-			logger.Debug("failed to recover ip from the connection", log.Err(err))
+			logger.Debug("failed to read advertised addresses", log.Err(err))
 			return
 		}
-		ipcomp, err := manet.FromIP(ip)
+		for _, str := range raw {
+			addr, err := ma.NewMultiaddr(str)
+			if err != nil {
+				logger.Debug("failed to parse multiaddr", log.Err(err))
+				return
+			}
+			addrs = append(addrs, addr)
+		}
+	} else {
+		buf, _, err := codec.DecodeByteSlice(stream)
 		if err != nil {
-			logger.Error("failed to create multiaddr from ip", log.Stringer("ip", ip))
+			logger.Debug("failed to read advertised address", log.Err(err))
 			return
 		}
-		addr = ipcomp.Encapsulate(addr)
+		addr, err := ma.NewMultiaddrBytes(buf)
+		if err != nil {
+			logger.Debug("failed to cast bytes to multiaddr", log.Err(err))
+			return
+		}
+		addrs = append(addrs, addr)
 	}
 	id, err := ma.NewComponent("p2p", stream.Conn().RemotePeer().String())
 	if err != nil {
 		logger.Error("failed to create p2p component", log.Err(err))
 		return
 	}
-	p.book.Add(book.SELF, stream.Conn().RemotePeer().String(), addr.Encapsulate(id))
+	for _, addr := range addrs {
+		logger.Debug("got request from address", log.Stringer("address", addr))
+		if protocols := addr.Protocols(); len(protocols) > 0 &&
+			(protocols[0].Code == ma.P_TCP || protocols[0].Code == ma.P_UDP) {
+			// in some setups we rely on a behavior that peer can learn routable address
+			// of the other node, even if that node is not aware of its own routable address
+			ip, err := manet.ToIP(stream.Conn().RemoteMultiaddr())
+			if err != nil {
+				logger.Debug("failed to recover ip from the connection", log.Err(err))
+				return
+			}
+			ipcomp, err := manet.FromIP(ip)
+			if err != nil {
+				logger.Error("failed to create multiaddr from ip", log.Stringer("ip", ip))
+				return
+			}
+			addr = ipcomp.Encapsulate(addr)
+		}
+		p.book.Add(book.SELF, stream.Conn().RemotePeer().String(), addr.Encapsulate(id))
+	}
 
 	share := p.book.TakeShareable(stream.Conn().RemotePeer().String(), sharedPeers)
 	response := make([]string, 0, len(share))
 	for _, addr := range share {
+		// peers that use the first version of the protocol don't support quic
+		if stream.Protocol() != protocolV2Name && book.Transport(addr) == book.QUIC {
+			continue
+		}
 		response = append(response, addr.String())
 	}
 	// todo: limit results to message size
@@ -107,25 +138,38 @@ func (p *peerExchange) handler(stream network.Stream) {
 		log.Duration("time_to_make", time.Since(t)))
 }
 
-// UpdateAdvertisedAddress updates advertised address.
-func (p *peerExchange) UpdateAdvertisedAddress(address ma.Multiaddr) {
-	p.advertise.Store(&address)
+// UpdateAdvertisedAddresses updates advertised addresses.
+func (p *peerExchange) UpdateAdvertisedAddresses(addresses []ma.Multiaddr) {
+	p.advertise.Store(&addresses)
 }
 
-// AdvertisedAddress returns advertised address.
-func (p *peerExchange) AdvertisedAddress() ma.Multiaddr {
+// AdvertisedAddresses returns advertised addresses in the order of preference.
+func (p *peerExchange) AdvertisedAddresses() []ma.Multiaddr {
 	return *p.advertise.Load()
 }
 
+// AdvertisedAddress returns the most preferred advertised address.
+func (p *peerExchange) AdvertisedAddress() ma.Multiaddr {
+	return p.AdvertisedAddresses()[0]
+}
+
+// legacyAddress returns an address that can be advertised to peers that use the first
+// version of the protocol.
+func (p *peerExchange) legacyAddress() ma.Multiaddr {
+	for _, addr := range p.AdvertisedAddresses() {
+		if book.Transport(addr) != book.QUIC {
+			return addr
+		}
+	}
+	return p.AdvertisedAddress()
+}
+
 // Request addresses from a remote node, it will block and return the results returned from the node.
 func (p *peerExchange) Request(ctx context.Context, pid peer.ID) ([]string, error) {
 	logger := p.logger.WithContext(ctx).WithFields(
 		log.String("type", "getaddresses"),
 		log.String("to", pid.String())).With()
-	advertise := p.AdvertisedAddress()
-	logger.Debug("sending request", log.Stringer("advertised address", advertise))
-
-	stream, err := p.h.NewStream(network.WithNoDial(ctx, "existing"), pid, protocolName)
+	stream, err := p.h.NewStream(network.WithNoDial(ctx, "existing"), pid, protocolV2Name, protocolName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create a discovery stream: %w", err)
 	}
@@ -133,7 +177,23 @@ func (p *peerExchange) Request(ctx context.Context, pid peer.ID) ([]string, erro
 
 	_ = stream.SetDeadline(time.Now().Add(messageTimeout))
 	defer stream.SetDeadline(time.Time{})
-	if _, err := codec.EncodeByteSlice(stream, advertise.Bytes()); err != nil {
+	if stream.Protocol() == protocolV2Name {
+		advertise := p.AdvertisedAddresses()
+		if len(advertise) > maxAdvertised {
+			advertise = advertise[:maxAdvertised]
+		}
+		request := make([]string, 0, len(advertise))
+		for _, addr := range advertise {
+			request = append(request, addr.String())
+		}
+		logger.Debug("sending request", log.String("advertised addresses", strings.Join(request, ", ")))
+		_, err = codec.EncodeStringSlice(stream, request)
+	} else {
+		advertise := p.legacyAddress()
+		logger.Debug("sending request", log.Stringer("advertised address", advertise))
+		_, err = codec.EncodeByteSlice(stream, advertise.Bytes())
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to send GetAddress request: %w", err)
 	}
 
@@ -2,6 +2,7 @@ package peerexchange
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -19,7 +21,7 @@ import (
 )
 
 func routablePort(h host.Host) (uint16, error) {
-	addr, err := routableNetAddress(h)
+	addr, err := routableNetAddress(h, book.TCP)
 	if err != nil {
 		return 0, err
 	}
@@ -39,7 +41,7 @@ func buildPeer(tb testing.TB, l log.Log, h host.Host) *peerExchange {
 
 func buildPeerWithAddress(tb testing.TB, l log.Log, h host.Host, addr ma.Multiaddr) *peerExchange {
 	tb.Helper()
-	return newPeerExchange(h, book.New(), addr, l)
+	return newPeerExchange(h, book.New(), []ma.Multiaddr{addr}, l)
 }
 
 func contains[T any](array []T, object T) bool {
@@ -105,3 +107,100 @@ func TestDiscovery_FilteringAddresses(t *testing.T) {
 		assert.NotContains(t, addresses, dnsNode)
 	}
 }
+
+func TestDiscovery_AdvertiseTransports(t *testing.T) {
+	logger := logtest.New(t)
+	mesh, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	advertise := []ma.Multiaddr{
+		ma.StringCast("/udp/7513/quic-v1"),
+		ma.StringCast("/tcp/7513"),
+	}
+	sender := newPeerExchange(mesh.Hosts()[0], book.New(), advertise, logger)
+	receiver := buildPeer(t, logger, mesh.Hosts()[1])
+
+	_, err = sender.Request(context.Background(), receiver.h.ID())
+	require.NoError(t, err)
+
+	ip, err := manet.ToIP(mesh.Hosts()[0].Addrs()[0])
+	require.NoError(t, err)
+	ipcomp, err := manet.FromIP(ip)
+	require.NoError(t, err)
+	added := receiver.book.DrainQueue(1)
+	require.Len(t, added, 2)
+	for i, addr := range advertise {
+		expected := fmt.Sprintf("%s%s/p2p/%s", ipcomp, addr, sender.h.ID())
+		require.Equal(t, expected, added[i].String())
+	}
+}
+
+func TestDiscovery_LegacyProtocol(t *testing.T) {
+	logger := logtest.New(t)
+	mesh, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	sender := newPeerExchange(mesh.Hosts()[0], book.New(), []ma.Multiaddr{
+		ma.StringCast("/udp/7513/quic-v1"),
+		ma.StringCast("/dns4/bootnode.spacemesh.io/tcp/5003"),
+	}, logger)
+	receiver := buildPeer(t, logger, mesh.Hosts()[1])
+	// receiver that supports only the first version of the protocol
+	receiver.h.RemoveStreamHandler(protocolV2Name)
+
+	quic := "/dns4/quic.spacemesh.io/udp/5003/quic-v1/p2p/12D3KooWGQrF3pHrR1W7P6nh8gypYxtFS93SnmvtN6qpyeSo7T2u"
+	receiver.book.Add(book.SELF, "quic", ma.StringCast(quic))
+
+	for i := 0; i < 10; i++ {
+		addresses, err := sender.Request(context.Background(), receiver.h.ID())
+		require.NoError(t, err)
+		require.NotContains(t, addresses, quic)
+	}
+
+	id, err := ma.NewComponent("p2p", sender.h.ID().String())
+	require.NoError(t, err)
+	added := receiver.book.DrainQueue(2)
+	require.Len(t, added, 2)
+	require.True(t, ma.StringCast("/dns4/bootnode.spacemesh.io/tcp/5003").Encapsulate(id).Equal(added[1]))
+}
+
+func TestByTransport(t *testing.T) {
+	tcp := ma.StringCast("/ip4/0.0.0.0/tcp/7513")
+	quic := ma.StringCast("/ip4/0.0.0.0/udp/7513/quic-v1")
+	dnsaddr := ma.StringCast("/dnsaddr/bootnode.spacemesh.io")
+	for _, tc := range []struct {
+		desc       string
+		addrs      []ma.Multiaddr
+		transports []string
+		expected   [][]ma.Multiaddr
+	}{
+		{
+			desc:       "prefer quic",
+			addrs:      []ma.Multiaddr{tcp, quic},
+			transports: []string{book.QUIC, book.TCP},
+			expected:   [][]ma.Multiaddr{{quic}, {tcp}},
+		},
+		{
+			desc:       "prefer tcp",
+			addrs:      []ma.Multiaddr{quic, tcp},
+			transports: []string{book.TCP, book.QUIC},
+			expected:   [][]ma.Multiaddr{{tcp}, {quic}},
+		},
+		{
+			desc:       "unknown last",
+			addrs:      []ma.Multiaddr{dnsaddr, quic, tcp},
+			transports: []string{book.TCP},
+			expected:   [][]ma.Multiaddr{{tcp}, {dnsaddr, quic}},
+		},
+		{
+			desc:       "empty",
+			transports: []string{book.TCP},
+			expected:   [][]ma.Multiaddr{},
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, byTransport(tc.addrs, tc.transports))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package pubsub
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -139,6 +140,10 @@ const (
 	ValidationReject = pubsub.ValidationReject
 )
 
+// ErrValidationReject is wrapped by the errors of handlers when the data is malformed or malicious,
+// regardless of the protocol that delivered it. Peers that sent such data might be penalized.
+var ErrValidationReject = errors.New("validation reject")
+
 // ChainGossipHandler helper to chain multiple GossipHandler together. Called synchronously and in the order.
 func ChainGossipHandler(handlers ...GossipHandler) GossipHandler {
 	return func(ctx context.Context, pid peer.ID, msg []byte) ValidationResult {
@@ -48,6 +48,14 @@ func WithNodeReporter(reporter func()) Opt {
 	}
 }
 
+// WithGater sets the connection gater that was used to create the host.
+// Gater is started with the Host and stopped when the Host is stopped.
+func WithGater(gater *Gater) Opt {
+	return func(fh *Host) {
+		fh.gater = gater
+	}
+}
+
 // Host is a conveniency wrapper for all p2p related functionality required to run
 // a full spacemesh node.
 type Host struct {
@@ -62,6 +70,7 @@ type Host struct {
 
 	discovery *peerexchange.Discovery
 	hs        *handshake.Handshake
+	gater     *Gater
 }
 
 // TODO(dshulyak) IsBootnode should be a configuration option.
@@ -108,10 +117,14 @@ func Upgrade(h host.Host, genesisID types.Hash20, opts ...Opt) (*Host, error) {
 		MinPeers:         cfg.MinPeers,
 		SlowCrawl:        10 * time.Minute,
 		FastCrawl:        10 * time.Second,
+		Transports:       cfg.Transports,
 	}); err != nil {
 		return nil, fmt.Errorf("failed to initialize peerexchange discovery: %w", err)
 	}
 	fh.hs = handshake.New(fh, genesisID, handshake.WithLog(fh.logger))
+	if fh.gater != nil {
+		fh.gater.start(h)
+	}
 	if fh.nodeReporter != nil {
 		fh.Network().Notify(&network.NotifyBundle{
 			ConnectedF: func(network.Network, network.Conn) {
@@ -135,10 +148,23 @@ func (fh *Host) PeerCount() uint64 {
 	return uint64(len(fh.Host.Network().Peers()))
 }
 
+// Gater returns the connection gater of the host, or nil if the host was created without it.
+func (fh *Host) Gater() *Gater {
+	return fh.gater
+}
+
+// ClosePeer closes all connections to the peer.
+func (fh *Host) ClosePeer(peer Peer) error {
+	return fh.Host.Network().ClosePeer(peer)
+}
+
 // Stop background workers and release external resources.
 func (fh *Host) Stop() error {
 	fh.discovery.Stop()
 	fh.hs.Stop()
+	if fh.gater != nil {
+		fh.gater.stop()
+	}
 	if err := fh.Host.Close(); err != nil {
 		return fmt.Errorf("failed to close libp2p host: %w", err)
 	}
@@ -27,7 +27,7 @@ import (
 )
 
 var (
-	errMalformedData         = errors.New("malformed data")
+	errMalformedData         = fmt.Errorf("%w: malformed data", pubsub.ErrValidationReject)
 	errInitialize            = errors.New("failed to initialize")
 	errInvalidATXID          = errors.New("ballot has invalid ATXID")
 	errMissingEpochData      = errors.New("epoch data is missing in ref ballot")
@@ -164,7 +164,7 @@ func (h *Handler) HandleSyncedBallot(ctx context.Context, peer p2p.Peer, data []
 	}
 
 	if !h.edVerifier.Verify(signing.BALLOT, b.SmesherID, b.SignedBytes(), b.Signature) {
-		return fmt.Errorf("failed to verify ballot signature")
+		return fmt.Errorf("%w: failed to verify ballot signature", pubsub.ErrValidationReject)
 	}
 
 	// set the ballot and smesher ID when received
@@ -241,10 +241,10 @@ func (h *Handler) handleProposalData(ctx context.Context, peer p2p.Peer, data []
 	metrics.ReportMessageLatency(pubsub.ProposalProtocol, pubsub.ProposalProtocol, latency)
 
 	if !h.edVerifier.Verify(signing.BALLOT, p.SmesherID, p.SignedBytes(), p.Signature) {
-		return fmt.Errorf("failed to verify proposal signature")
+		return fmt.Errorf("%w: failed to verify proposal signature", pubsub.ErrValidationReject)
 	}
 	if !h.edVerifier.Verify(signing.BALLOT, p.Ballot.SmesherID, p.Ballot.SignedBytes(), p.Ballot.Signature) {
-		return fmt.Errorf("failed to verify ballot signature")
+		return fmt.Errorf("%w: failed to verify ballot signature", pubsub.ErrValidationReject)
 	}
 
 	// set the proposal ID when received
@@ -0,0 +1,9 @@
+package prune
+
+import "github.com/spacemeshos/go-spacemesh/common/types"
+
+//go:generate mockgen -package=prune -destination=./mocks.go -source=./interface.go
+
+type verifiedLayer interface {
+	LatestComplete() types.LayerID
+}
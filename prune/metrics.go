@@ -0,0 +1,40 @@
+package prune
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spacemeshos/go-spacemesh/metrics"
+)
+
+const (
+	namespace = "prune"
+
+	proposalsKind    = "proposals"
+	ballotsKind      = "ballots"
+	proposalTxsKind  = "proposal_txs"
+	certificatesKind = "certificates"
+	accountsKind     = "accounts"
+	trieKind         = "accounts_trie"
+)
+
+var (
+	deletedCount = metrics.NewCounter(
+		"deleted",
+		namespace,
+		"number of pruned records",
+		[]string{"kind"},
+	)
+	prunedLayer = metrics.NewGauge(
+		"layer",
+		namespace,
+		"data before this layer is pruned",
+		nil,
+	).WithLabelValues()
+	pruneDuration = metrics.NewHistogramWithBuckets(
+		"duration",
+		namespace,
+		"duration of pruning a batch of layers in seconds",
+		nil,
+		prometheus.ExponentialBuckets(0.01, 2, 10),
+	).WithLabelValues()
+)
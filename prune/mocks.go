@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interface.go
+
+// Package prune is a generated GoMock package.
+package prune
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	types "github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// MockverifiedLayer is a mock of verifiedLayer interface.
+type MockverifiedLayer struct {
+	ctrl     *gomock.Controller
+	recorder *MockverifiedLayerMockRecorder
+}
+
+// MockverifiedLayerMockRecorder is the mock recorder for MockverifiedLayer.
+type MockverifiedLayerMockRecorder struct {
+	mock *MockverifiedLayer
+}
+
+// NewMockverifiedLayer creates a new mock instance.
+func NewMockverifiedLayer(ctrl *gomock.Controller) *MockverifiedLayer {
+	mock := &MockverifiedLayer{ctrl: ctrl}
+	mock.recorder = &MockverifiedLayerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockverifiedLayer) EXPECT() *MockverifiedLayerMockRecorder {
+	return m.recorder
+}
+
+// LatestComplete mocks base method.
+func (m *MockverifiedLayer) LatestComplete() types.LayerID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestComplete")
+	ret0, _ := ret[0].(types.LayerID)
+	return ret0
+}
+
+// LatestComplete indicates an expected call of LatestComplete.
+func (mr *MockverifiedLayerMockRecorder) LatestComplete() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestComplete", reflect.TypeOf((*MockverifiedLayer)(nil).LatestComplete))
+}
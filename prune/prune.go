@@ -0,0 +1,239 @@
+// Package prune deletes historical data that is not needed to participate in consensus
+// or to serve the state from the node database.
+package prune
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/proposals"
+	"github.com/spacemeshos/go-spacemesh/sql/pruning"
+	"github.com/spacemeshos/go-spacemesh/sql/snapshots"
+	"github.com/spacemeshos/go-spacemesh/sql/transactions"
+	"github.com/spacemeshos/go-spacemesh/tortoise/snapshot"
+)
+
+// ErrRetentionTooShort is returned if the retention window is shorter than tortoise may revert.
+var ErrRetentionTooShort = errors.New("prune: retention too short")
+
+// Config for pruning of historical data.
+type Config struct {
+	// Enabled turns on pruning. Nodes with pruning enabled refuse to serve pruned data to peers.
+	Enabled bool `mapstructure:"enabled"`
+	// Retention is the number of layers before the latest verified and applied layer
+	// whose data is kept.
+	Retention uint32 `mapstructure:"retention"`
+	// Interval between pruning runs.
+	Interval time.Duration `mapstructure:"interval"`
+	// BatchLayers is the maximal number of layers pruned in one database transaction.
+	BatchLayers uint32 `mapstructure:"batch-layers"`
+}
+
+// DefaultConfig returns the default pruning config. Pruning is disabled by default.
+func DefaultConfig() Config {
+	return Config{
+		Retention:   10_000,
+		Interval:    time.Minute,
+		BatchLayers: 100,
+	}
+}
+
+// Validate that the retention window is safe for the tortoise with the given hdist and zdist.
+// Layers within hdist + zdist from the verified layer can still be changed by hare and tortoise,
+// therefore data of such layers must not be pruned.
+func (c *Config) Validate(hdist, zdist uint32) error {
+	if !c.Enabled {
+		return nil
+	}
+	if minimum := hdist + zdist; c.Retention < minimum {
+		return fmt.Errorf("%w: %d layers, minimum %d", ErrRetentionTooShort, c.Retention, minimum)
+	}
+	if c.BatchLayers == 0 {
+		return fmt.Errorf("prune: batch layers must be positive")
+	}
+	return nil
+}
+
+// Opt for configuring Pruner.
+type Opt func(*Pruner)
+
+// WithLogger configures logger for Pruner.
+func WithLogger(logger log.Log) Opt {
+	return func(p *Pruner) {
+		p.logger = logger
+	}
+}
+
+// WithConfig configures Pruner.
+func WithConfig(cfg Config) Opt {
+	return func(p *Pruner) {
+		p.cfg = cfg
+	}
+}
+
+// Pruner deletes in the background proposals, ballot bodies, certificates and superseded versions
+// of the accounts state older than the retention window.
+//
+// Ballot bodies are deleted only before the window of the earliest tortoise snapshot and before
+// the epoch of the pruned layer, as the tortoise recovers ballots within the window and ballots
+// of the current epoch refer to the reference ballot of the smesher.
+type Pruner struct {
+	logger   log.Log
+	cfg      Config
+	db       *sql.Database
+	verified verifiedLayer
+}
+
+// New creates a Pruner.
+func New(db *sql.Database, verified verifiedLayer, opts ...Opt) *Pruner {
+	p := &Pruner{
+		logger:   log.NewNop(),
+		cfg:      DefaultConfig(),
+		db:       db,
+		verified: verified,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Run prunes the database every interval until the context is canceled.
+func (p *Pruner) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Prune(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			p.logger.With().Error("failed to prune", log.Err(err))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune deletes historical data before the retention window. Data is deleted in batches
+// of layers, every batch in its own database transaction.
+func (p *Pruner) Prune(ctx context.Context) error {
+	cutoff, err := p.cutoff()
+	if err != nil {
+		return err
+	}
+	for {
+		pruned, err := pruning.Layer(p.db)
+		if err != nil {
+			return err
+		}
+		if pruned >= cutoff {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		to := cutoff
+		if pruned.Add(p.cfg.BatchLayers).Before(to) {
+			to = pruned.Add(p.cfg.BatchLayers)
+		}
+		if err := p.pruneBatch(ctx, pruned, to); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Pruner) cutoff() (types.LayerID, error) {
+	applied, err := layers.GetLastApplied(p.db)
+	if err != nil {
+		return 0, err
+	}
+	latest := p.verified.LatestComplete()
+	if applied.Before(latest) {
+		latest = applied
+	}
+	if latest.Uint32() <= p.cfg.Retention {
+		return 0, nil
+	}
+	return latest.Sub(p.cfg.Retention), nil
+}
+
+func (p *Pruner) pruneBatch(ctx context.Context, from, to types.LayerID) error {
+	start := time.Now()
+	var nproposals, nballots, ntxs, ncerts, naccounts, nnodes int
+	if err := p.db.WithTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		if nproposals, err = proposals.DeleteBefore(tx, to); err != nil {
+			return err
+		}
+		bound, err := ballotsBound(tx, to)
+		if err != nil {
+			return err
+		}
+		if nballots, err = ballots.PruneBodies(tx, bound); err != nil {
+			return err
+		}
+		if ntxs, err = transactions.DeleteProposalTXsBefore(tx, to); err != nil {
+			return err
+		}
+		if ncerts, err = certificates.PruneBefore(tx, to); err != nil {
+			return err
+		}
+		if naccounts, err = accounts.PruneHistory(tx, to); err != nil {
+			return err
+		}
+		if nnodes, err = accounts.PruneTrieHistory(tx, to); err != nil {
+			return err
+		}
+		return pruning.SetLayer(tx, to)
+	}); err != nil {
+		return fmt.Errorf("prune [%s, %s): %w", from, to, err)
+	}
+	deletedCount.WithLabelValues(proposalsKind).Add(float64(nproposals))
+	deletedCount.WithLabelValues(ballotsKind).Add(float64(nballots))
+	deletedCount.WithLabelValues(proposalTxsKind).Add(float64(ntxs))
+	deletedCount.WithLabelValues(certificatesKind).Add(float64(ncerts))
+	deletedCount.WithLabelValues(accountsKind).Add(float64(naccounts))
+	deletedCount.WithLabelValues(trieKind).Add(float64(nnodes))
+	prunedLayer.Set(float64(to))
+	pruneDuration.Observe(time.Since(start).Seconds())
+	p.logger.With().Debug("pruned historical data",
+		log.Stringer("from", from),
+		log.Stringer("to", to),
+		log.Int("proposals", nproposals),
+		log.Int("ballots", nballots),
+		log.Int("certificates", ncerts),
+		log.Int("accounts", naccounts),
+		log.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
+
+// ballotsBound returns the layer before which bodies of the ballots can be pruned.
+func ballotsBound(db sql.Executor, to types.LayerID) (types.LayerID, error) {
+	_, data, err := snapshots.Earliest(db)
+	if errors.Is(err, sql.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var snap snapshot.Snapshot
+	if err := codec.Decode(data, &snap); err != nil {
+		return 0, fmt.Errorf("decode tortoise snapshot: %w", err)
+	}
+	bound := to.GetEpoch().FirstLayer()
+	if evicted := snap.Evicted.Add(1); evicted.Before(bound) {
+		bound = evicted
+	}
+	return bound, nil
+}
@@ -0,0 +1,189 @@
+package prune
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/proposals"
+	"github.com/spacemeshos/go-spacemesh/sql/pruning"
+	"github.com/spacemeshos/go-spacemesh/sql/snapshots"
+	"github.com/spacemeshos/go-spacemesh/tortoise/snapshot"
+)
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(4)
+	res := m.Run()
+	os.Exit(res)
+}
+
+func createHistory(t *testing.T, db *sql.Database, last types.LayerID) {
+	t.Helper()
+	for lid := types.LayerID(1); lid <= last; lid++ {
+		ballot := types.NewExistingBallot(types.BallotID{byte(lid)}, types.RandomEdSignature(), types.RandomNodeID(), lid)
+		require.NoError(t, ballots.Add(db, &ballot))
+		proposal := &types.Proposal{
+			InnerProposal: types.InnerProposal{Ballot: ballot},
+			Signature:     types.RandomEdSignature(),
+		}
+		proposal.SetID(types.ProposalID{byte(lid)})
+		require.NoError(t, proposals.Add(db, proposal))
+		require.NoError(t, certificates.Add(db, lid, &types.Certificate{BlockID: types.BlockID{byte(lid)}}))
+		require.NoError(t, accounts.Update(db, &types.Account{
+			Address: types.Address{1},
+			Layer:   lid,
+			Balance: uint64(lid),
+		}))
+		require.NoError(t, layers.SetApplied(db, lid, types.BlockID{byte(lid)}))
+	}
+}
+
+func TestPrune(t *testing.T) {
+	db := sql.InMemory()
+	createHistory(t, db, 20)
+	verified := NewMockverifiedLayer(gomock.NewController(t))
+	verified.EXPECT().LatestComplete().Return(types.LayerID(18)).AnyTimes()
+
+	cfg := DefaultConfig()
+	cfg.Enabled = true
+	cfg.Retention = 5
+	cfg.BatchLayers = 4
+	pruner := New(db, verified, WithConfig(cfg), WithLogger(logtest.New(t)))
+	require.NoError(t, pruner.Prune(context.Background()))
+
+	pruned, err := pruning.Layer(db)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID(13), pruned)
+
+	for lid := types.LayerID(1); lid <= 20; lid++ {
+		has, err := proposals.Has(db, types.ProposalID{byte(lid)})
+		require.NoError(t, err)
+		require.Equal(t, !lid.Before(pruned), has, "layer %s", lid)
+
+		certs, err := certificates.Get(db, lid)
+		require.NoError(t, err)
+		require.Equal(t, !lid.Before(pruned), certs[0].Cert != nil, "layer %s", lid)
+
+		// ballot bodies are kept without tortoise snapshots
+		_, err = ballots.Get(db, types.BallotID{byte(lid)})
+		require.NoError(t, err)
+
+		account, err := accounts.Get(db, types.Address{1}, lid)
+		require.NoError(t, err)
+		if lid.Before(pruned) {
+			require.Zero(t, account.Balance)
+		} else {
+			require.Equal(t, uint64(lid), account.Balance)
+		}
+	}
+
+	// nothing to do until more layers are verified
+	require.NoError(t, pruner.Prune(context.Background()))
+	pruned, err = pruning.Layer(db)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID(13), pruned)
+}
+
+func TestPrune_Ballots(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		evicted types.LayerID
+		bound   types.LayerID
+	}{
+		{"tortoise window", 7, 8},
+		// ballots of the current epoch of the pruned layer refer to the reference ballots
+		{"epoch", 15, 12},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			db := sql.InMemory()
+			createHistory(t, db, 20)
+			for lid, evicted := range map[types.LayerID]types.LayerID{17: tc.evicted, 18: 16} {
+				data, err := codec.Encode(&snapshot.Snapshot{Evicted: evicted})
+				require.NoError(t, err)
+				require.NoError(t, snapshots.Add(db, lid, data))
+			}
+			verified := NewMockverifiedLayer(gomock.NewController(t))
+			verified.EXPECT().LatestComplete().Return(types.LayerID(18)).AnyTimes()
+
+			cfg := DefaultConfig()
+			cfg.Enabled = true
+			cfg.Retention = 5
+			pruner := New(db, verified, WithConfig(cfg), WithLogger(logtest.New(t)))
+			require.NoError(t, pruner.Prune(context.Background()))
+
+			for lid := types.LayerID(1); lid <= 20; lid++ {
+				has, err := ballots.Has(db, types.BallotID{byte(lid)})
+				require.NoError(t, err)
+				require.True(t, has)
+				_, err = ballots.Get(db, types.BallotID{byte(lid)})
+				if lid.Before(tc.bound) {
+					require.ErrorIs(t, err, sql.ErrNotFound, "layer %s", lid)
+				} else {
+					require.NoError(t, err, "layer %s", lid)
+				}
+			}
+		})
+	}
+}
+
+func TestPrune_Canceled(t *testing.T) {
+	db := sql.InMemory()
+	createHistory(t, db, 10)
+	verified := NewMockverifiedLayer(gomock.NewController(t))
+	verified.EXPECT().LatestComplete().Return(types.LayerID(10)).AnyTimes()
+
+	cfg := DefaultConfig()
+	cfg.Retention = 1
+	pruner := New(db, verified, WithConfig(cfg))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, pruner.Prune(ctx), context.Canceled)
+	require.NoError(t, pruner.Run(ctx))
+}
+
+func TestRun(t *testing.T) {
+	db := sql.InMemory()
+	createHistory(t, db, 10)
+	verified := NewMockverifiedLayer(gomock.NewController(t))
+	verified.EXPECT().LatestComplete().Return(types.LayerID(10)).AnyTimes()
+
+	cfg := DefaultConfig()
+	cfg.Retention = 2
+	cfg.Interval = 10 * time.Millisecond
+	pruner := New(db, verified, WithConfig(cfg))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- pruner.Run(ctx) }()
+	require.Eventually(t, func() bool {
+		pruned, err := pruning.Layer(db)
+		return err == nil && pruned == 8
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Retention = 10
+	require.NoError(t, cfg.Validate(10, 8))
+
+	cfg.Enabled = true
+	require.ErrorIs(t, cfg.Validate(10, 8), ErrRetentionTooShort)
+	cfg.Retention = 18
+	require.NoError(t, cfg.Validate(10, 8))
+	cfg.BatchLayers = 0
+	require.Error(t, cfg.Validate(10, 8))
+}
@@ -0,0 +1,162 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	keystoreVersion = 1
+	keystoreKDF     = "argon2id"
+	keystoreCipher  = "xchacha20-poly1305"
+
+	argonTime    = 3
+	argonMemory  = 64 * 1024 // in KiB
+	argonThreads = 4
+	saltSize     = 16
+)
+
+// ErrInvalidPassphrase is returned if the keystore can't be decrypted with the passphrase.
+var ErrInvalidPassphrase = errors.New("invalid passphrase")
+
+// Keystore is the format of the passphrase encrypted private key. The key is derived from
+// the passphrase with argon2id and the private key is encrypted with xchacha20-poly1305.
+// The public key is authenticated as additional data.
+type Keystore struct {
+	Version    int         `json:"version"`
+	PublicKey  string      `json:"publicKey"`
+	KDF        KDFParams   `json:"kdf"`
+	Cipher     CipherParam `json:"cipher"`
+	Ciphertext string      `json:"ciphertext"`
+}
+
+// KDFParams are the parameters of the key derivation function.
+type KDFParams struct {
+	Name    string `json:"name"`
+	Salt    string `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// CipherParam are the parameters of the cipher.
+type CipherParam struct {
+	Name  string `json:"name"`
+	Nonce string `json:"nonce"`
+}
+
+// EncryptKey encrypts the private key with the passphrase and returns the json encoded Keystore.
+func EncryptKey(priv PrivateKey, passphrase []byte) ([]byte, error) {
+	if len(priv) != PrivateKeySize {
+		return nil, fmt.Errorf("invalid key size %d/%d", len(priv), PrivateKeySize)
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	ks := Keystore{
+		Version:   keystoreVersion,
+		PublicKey: hex.EncodeToString(Public(priv)),
+		KDF: KDFParams{
+			Name:    keystoreKDF,
+			Salt:    hex.EncodeToString(salt),
+			Time:    argonTime,
+			Memory:  argonMemory,
+			Threads: argonThreads,
+		},
+	}
+	aead, err := chacha20poly1305.NewX(ks.KDF.derive(passphrase, salt))
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	ks.Cipher = CipherParam{Name: keystoreCipher, Nonce: hex.EncodeToString(nonce)}
+	ks.Ciphertext = hex.EncodeToString(aead.Seal(nil, nonce, priv, []byte(ks.PublicKey)))
+	return json.MarshalIndent(&ks, "", "  ")
+}
+
+// DecryptKey decrypts the json encoded Keystore with the passphrase.
+func DecryptKey(data, passphrase []byte) (PrivateKey, error) {
+	var ks Keystore
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("decode keystore: %w", err)
+	}
+	if ks.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	if ks.KDF.Name != keystoreKDF {
+		return nil, fmt.Errorf("unsupported kdf %s", ks.KDF.Name)
+	}
+	if ks.Cipher.Name != keystoreCipher {
+		return nil, fmt.Errorf("unsupported cipher %s", ks.Cipher.Name)
+	}
+	salt, err := hex.DecodeString(ks.KDF.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("decode salt: %w", err)
+	}
+	nonce, err := hex.DecodeString(ks.Cipher.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("decode nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(ks.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decode ciphertext: %w", err)
+	}
+	aead, err := chacha20poly1305.NewX(ks.KDF.derive(passphrase, salt))
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce size %d", len(nonce))
+	}
+	priv, err := aead.Open(nil, nonce, ciphertext, []byte(ks.PublicKey))
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	if len(priv) != PrivateKeySize {
+		return nil, fmt.Errorf("invalid key size %d/%d", len(priv), PrivateKeySize)
+	}
+	if hex.EncodeToString(ed25519.NewKeyFromSeed(priv[:ed25519.SeedSize])[ed25519.SeedSize:]) != ks.PublicKey {
+		return nil, errors.New("private key doesn't match the public key")
+	}
+	return priv, nil
+}
+
+// ParseKey decodes the private key from the hex encoded key, or from the Keystore
+// encrypted with the passphrase.
+func ParseKey(data, passphrase []byte) (PrivateKey, error) {
+	if IsEncryptedKey(data) {
+		if len(passphrase) == 0 {
+			return nil, errors.New("key is encrypted, passphrase is required")
+		}
+		return DecryptKey(data, passphrase)
+	}
+	priv, err := hex.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("decoding private key: %w", err)
+	}
+	if len(priv) != PrivateKeySize {
+		return nil, fmt.Errorf("invalid key size %d/%d", len(priv), PrivateKeySize)
+	}
+	return priv, nil
+}
+
+// IsEncryptedKey returns true if data is a Keystore rather than a hex encoded private key.
+func IsEncryptedKey(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+func (p KDFParams) derive(passphrase, salt []byte) []byte {
+	return argon2.IDKey(passphrase, salt, p.Time, p.Memory, p.Threads, chacha20poly1305.KeySize)
+}
@@ -0,0 +1,29 @@
+package signing
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystore(t *testing.T) {
+	signer, err := NewEdSigner()
+	require.NoError(t, err)
+
+	data, err := EncryptKey(signer.PrivateKey(), []byte("passphrase"))
+	require.NoError(t, err)
+	require.True(t, IsEncryptedKey(data))
+	require.False(t, IsEncryptedKey([]byte("0a0b")))
+	require.NotContains(t, string(data), hex.EncodeToString(signer.PrivateKey()))
+
+	priv, err := DecryptKey(data, []byte("passphrase"))
+	require.NoError(t, err)
+	require.Equal(t, signer.PrivateKey(), priv)
+
+	_, err = DecryptKey(data, []byte("other"))
+	require.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	_, err = EncryptKey(PrivateKey{1, 2}, []byte("passphrase"))
+	require.Error(t, err)
+}
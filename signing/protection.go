@@ -0,0 +1,268 @@
+package signing
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+const interchangeVersion = 1
+
+// signedRecord is a message signed by an identity that must not be followed by a different
+// message with the same key: an ATX per publish epoch, a ballot per layer and a hare message
+// per layer and round.
+type signedRecord struct {
+	domain domain
+	// layer is the publish epoch of the ATX.
+	layer uint32
+	round uint32
+	hash  types.Hash32
+}
+
+// protectedRecord returns the record of the message, or nil if the message is not protected.
+func protectedRecord(d domain, msg []byte) (*signedRecord, error) {
+	switch d {
+	case ATX:
+		var md types.ATXMetadata
+		if err := codec.Decode(msg, &md); err != nil {
+			return nil, fmt.Errorf("decode atx metadata: %w", err)
+		}
+		return &signedRecord{domain: d, layer: md.PublishEpoch.Uint32(), hash: md.MsgHash}, nil
+	case BALLOT:
+		// proposals are signed in the same domain, they include the ballot that was signed before
+		var md types.BallotMetadata
+		if err := codec.Decode(msg, &md); err != nil {
+			return nil, nil
+		}
+		return &signedRecord{domain: d, layer: md.Layer.Uint32(), hash: md.MsgHash}, nil
+	case HARE:
+		// certificates of blocks are signed in the same domain, they can't be used to prove malfeasance
+		var md types.HareMetadata
+		if err := codec.Decode(msg, &md); err != nil {
+			return nil, nil
+		}
+		return &signedRecord{domain: d, layer: md.Layer.Uint32(), round: md.Round, hash: md.MsgHash}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// Protection is the slashing protection database. It records ATXs, ballots and hare messages
+// signed by the identities of the node, and refuses to sign a message that conflicts with
+// a recorded one, as such pair is a proof of malfeasance of the identity.
+//
+// The database is kept separately from the state of the node, so that it survives
+// the restore of the state from a checkpoint or a backup.
+type Protection struct {
+	db *sql.Database
+}
+
+// OpenProtection opens the slashing protection database at the uri.
+func OpenProtection(uri string) (*Protection, error) {
+	db, err := sql.Open(uri,
+		sql.WithConnections(1),
+		sql.WithMigrations(protectionMigrations.Apply),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("open slashing protection: %w", err)
+	}
+	return &Protection{db: db}, nil
+}
+
+var protectionMigrations = sql.MigrationList{
+	sql.NewSQLMigration(1, "signed_messages", `create table signed_messages
+	(
+		node_id CHAR(32) NOT NULL,
+		domain  INT NOT NULL,
+		layer   INT NOT NULL,
+		round   INT NOT NULL,
+		hash    CHAR(32) NOT NULL,
+		PRIMARY KEY (node_id, domain, layer, round)
+	) WITHOUT ROWID;`, ""),
+}
+
+// Close closes the database.
+func (p *Protection) Close() error {
+	return p.db.Close()
+}
+
+// Check records the message that is going to be signed by the identity. It returns
+// ErrConflictingMessage if the identity signed a different message with the same key before.
+func (p *Protection) Check(id types.NodeID, d domain, msg []byte) error {
+	rec, err := protectedRecord(d, msg)
+	if err != nil || rec == nil {
+		return err
+	}
+	return p.db.WithTxImmediate(context.Background(), func(tx *sql.Tx) error {
+		return addRecord(tx, id, rec)
+	})
+}
+
+func addRecord(db sql.Executor, id types.NodeID, rec *signedRecord) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, id.Bytes())
+		stmt.BindInt64(2, int64(rec.domain))
+		stmt.BindInt64(3, int64(rec.layer))
+		stmt.BindInt64(4, int64(rec.round))
+	}
+	var (
+		prev   types.Hash32
+		exists bool
+	)
+	if _, err := db.Exec(`select hash from signed_messages
+		where node_id = ?1 and domain = ?2 and layer = ?3 and round = ?4;`, enc,
+		func(stmt *sql.Statement) bool {
+			stmt.ColumnBytes(0, prev[:])
+			exists = true
+			return false
+		}); err != nil {
+		return fmt.Errorf("get signed message: %w", err)
+	}
+	if exists {
+		if prev != rec.hash {
+			return fmt.Errorf("%w: %s %d/%d by %s: signed %s, requested %s", ErrConflictingMessage,
+				rec.domain, rec.layer, rec.round, id.ShortString(), prev.ShortString(), rec.hash.ShortString())
+		}
+		return nil
+	}
+	if _, err := db.Exec(`insert into signed_messages (node_id, domain, layer, round, hash)
+		values (?1, ?2, ?3, ?4, ?5);`,
+		func(stmt *sql.Statement) {
+			enc(stmt)
+			stmt.BindBytes(5, rec.hash[:])
+		}, nil); err != nil {
+		return fmt.Errorf("insert signed message: %w", err)
+	}
+	return nil
+}
+
+// Interchange is the format to export the slashing protection records and import them on
+// another machine.
+type Interchange struct {
+	Version    int                   `json:"version"`
+	GenesisID  types.Hash20          `json:"genesisId"`
+	Identities []InterchangeIdentity `json:"identities"`
+}
+
+// InterchangeIdentity are the records of the identity.
+type InterchangeIdentity struct {
+	// NodeID is the hex encoded public key of the identity.
+	NodeID  string         `json:"nodeId"`
+	ATXs    []SignedATX    `json:"atxs"`
+	Ballots []SignedBallot `json:"ballots"`
+	Hare    []SignedHare   `json:"hare"`
+}
+
+// SignedATX is the hash of the ATX signed for the publish epoch.
+type SignedATX struct {
+	Epoch uint32       `json:"epoch"`
+	Hash  types.Hash32 `json:"hash"`
+}
+
+// SignedBallot is the hash of the ballot signed for the layer.
+type SignedBallot struct {
+	Layer uint32       `json:"layer"`
+	Hash  types.Hash32 `json:"hash"`
+}
+
+// SignedHare is the hash of the hare message signed for the layer and round.
+type SignedHare struct {
+	Layer uint32       `json:"layer"`
+	Round uint32       `json:"round"`
+	Hash  types.Hash32 `json:"hash"`
+}
+
+// Export writes all records in the Interchange format.
+func (p *Protection) Export(w io.Writer, genesis types.Hash20) error {
+	ic := Interchange{Version: interchangeVersion, GenesisID: genesis, Identities: []InterchangeIdentity{}}
+	var current *InterchangeIdentity
+	if _, err := p.db.Exec(`select node_id, domain, layer, round, hash from signed_messages
+		order by node_id, domain, layer, round;`, nil,
+		func(stmt *sql.Statement) bool {
+			var (
+				id   types.NodeID
+				hash types.Hash32
+			)
+			stmt.ColumnBytes(0, id[:])
+			stmt.ColumnBytes(4, hash[:])
+			if current == nil || current.NodeID != id.String() {
+				ic.Identities = append(ic.Identities, InterchangeIdentity{
+					NodeID:  id.String(),
+					ATXs:    []SignedATX{},
+					Ballots: []SignedBallot{},
+					Hare:    []SignedHare{},
+				})
+				current = &ic.Identities[len(ic.Identities)-1]
+			}
+			layer := uint32(stmt.ColumnInt64(2))
+			switch domain(stmt.ColumnInt64(1)) {
+			case ATX:
+				current.ATXs = append(current.ATXs, SignedATX{Epoch: layer, Hash: hash})
+			case BALLOT:
+				current.Ballots = append(current.Ballots, SignedBallot{Layer: layer, Hash: hash})
+			case HARE:
+				current.Hare = append(current.Hare, SignedHare{
+					Layer: layer,
+					Round: uint32(stmt.ColumnInt64(3)),
+					Hash:  hash,
+				})
+			}
+			return true
+		}); err != nil {
+		return fmt.Errorf("list signed messages: %w", err)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(&ic)
+}
+
+// Import adds the records in the Interchange format to the database. Nothing is imported
+// if the records are for a different genesis or if any of them conflicts with a recorded one.
+func (p *Protection) Import(r io.Reader, genesis types.Hash20) error {
+	var ic Interchange
+	if err := json.NewDecoder(r).Decode(&ic); err != nil {
+		return fmt.Errorf("decode interchange: %w", err)
+	}
+	if ic.Version != interchangeVersion {
+		return fmt.Errorf("unsupported interchange version %d", ic.Version)
+	}
+	if ic.GenesisID != genesis {
+		return fmt.Errorf("interchange is for genesis %s, expected %s", ic.GenesisID.ShortString(), genesis.ShortString())
+	}
+	return p.db.WithTxImmediate(context.Background(), func(tx *sql.Tx) error {
+		for _, identity := range ic.Identities {
+			raw, err := hex.DecodeString(identity.NodeID)
+			if err != nil || len(raw) != types.NodeIDSize {
+				return fmt.Errorf("invalid node id %q", identity.NodeID)
+			}
+			id := types.BytesToNodeID(raw)
+			for _, atx := range identity.ATXs {
+				if err := addRecord(tx, id, &signedRecord{domain: ATX, layer: atx.Epoch, hash: atx.Hash}); err != nil {
+					return err
+				}
+			}
+			for _, ballot := range identity.Ballots {
+				if err := addRecord(tx, id, &signedRecord{domain: BALLOT, layer: ballot.Layer, hash: ballot.Hash}); err != nil {
+					return err
+				}
+			}
+			for _, msg := range identity.Hare {
+				if err := addRecord(tx, id, &signedRecord{
+					domain: HARE,
+					layer:  msg.Layer,
+					round:  msg.Round,
+					hash:   msg.Hash,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,144 @@
+package signing
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+func newProtection(tb testing.TB) *Protection {
+	tb.Helper()
+	protection, err := OpenProtection("file::memory:?mode=memory")
+	require.NoError(tb, err)
+	tb.Cleanup(func() { require.NoError(tb, protection.Close()) })
+	return protection
+}
+
+func encode(tb testing.TB, value codec.Encodable) []byte {
+	tb.Helper()
+	data, err := codec.Encode(value)
+	require.NoError(tb, err)
+	return data
+}
+
+func TestProtection_Check(t *testing.T) {
+	protection := newProtection(t)
+	id := types.NodeID{1}
+
+	t.Run("atx", func(t *testing.T) {
+		atx := encode(t, &types.ATXMetadata{PublishEpoch: 3, MsgHash: types.Hash32{1}})
+		require.NoError(t, protection.Check(id, ATX, atx))
+		require.NoError(t, protection.Check(id, ATX, atx))
+		require.ErrorIs(t, protection.Check(id, ATX,
+			encode(t, &types.ATXMetadata{PublishEpoch: 3, MsgHash: types.Hash32{2}})), ErrConflictingMessage)
+		require.NoError(t, protection.Check(id, ATX,
+			encode(t, &types.ATXMetadata{PublishEpoch: 4, MsgHash: types.Hash32{2}})))
+		require.NoError(t, protection.Check(types.NodeID{2}, ATX,
+			encode(t, &types.ATXMetadata{PublishEpoch: 3, MsgHash: types.Hash32{2}})))
+		require.Error(t, protection.Check(id, ATX, []byte("not an atx")))
+	})
+	t.Run("ballot", func(t *testing.T) {
+		require.NoError(t, protection.Check(id, BALLOT,
+			encode(t, &types.BallotMetadata{Layer: 10, MsgHash: types.Hash32{1}})))
+		require.ErrorIs(t, protection.Check(id, BALLOT,
+			encode(t, &types.BallotMetadata{Layer: 10, MsgHash: types.Hash32{2}})), ErrConflictingMessage)
+		// proposals are signed in the ballot domain
+		require.NoError(t, protection.Check(id, BALLOT, []byte("proposal")))
+	})
+	t.Run("hare", func(t *testing.T) {
+		require.NoError(t, protection.Check(id, HARE,
+			encode(t, &types.HareMetadata{Layer: 10, Round: 1, MsgHash: types.Hash32{1}})))
+		require.NoError(t, protection.Check(id, HARE,
+			encode(t, &types.HareMetadata{Layer: 10, Round: 2, MsgHash: types.Hash32{2}})))
+		require.ErrorIs(t, protection.Check(id, HARE,
+			encode(t, &types.HareMetadata{Layer: 10, Round: 1, MsgHash: types.Hash32{2}})), ErrConflictingMessage)
+		// certificates of blocks are signed in the hare domain
+		require.NoError(t, protection.Check(id, HARE, []byte("certificate")))
+	})
+	t.Run("other domains", func(t *testing.T) {
+		require.NoError(t, protection.Check(id, BEACON, []byte("first")))
+		require.NoError(t, protection.Check(id, BEACON, []byte("second")))
+	})
+}
+
+func TestProtection_Persisted(t *testing.T) {
+	uri := "file:" + filepath.Join(t.TempDir(), "protection.sql")
+	protection, err := OpenProtection(uri)
+	require.NoError(t, err)
+	id := types.NodeID{1}
+	require.NoError(t, protection.Check(id, BALLOT,
+		encode(t, &types.BallotMetadata{Layer: 10, MsgHash: types.Hash32{1}})))
+	require.NoError(t, protection.Close())
+
+	protection, err = OpenProtection(uri)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, protection.Close()) })
+	require.ErrorIs(t, protection.Check(id, BALLOT,
+		encode(t, &types.BallotMetadata{Layer: 10, MsgHash: types.Hash32{2}})), ErrConflictingMessage)
+}
+
+func TestProtection_ExportImport(t *testing.T) {
+	genesis := types.Hash20{1}
+	first, second := types.NodeID{1}, types.NodeID{2}
+	protection := newProtection(t)
+	require.NoError(t, protection.Check(first, ATX,
+		encode(t, &types.ATXMetadata{PublishEpoch: 3, MsgHash: types.Hash32{1}})))
+	require.NoError(t, protection.Check(first, BALLOT,
+		encode(t, &types.BallotMetadata{Layer: 12, MsgHash: types.Hash32{2}})))
+	require.NoError(t, protection.Check(second, HARE,
+		encode(t, &types.HareMetadata{Layer: 12, Round: 3, MsgHash: types.Hash32{3}})))
+
+	var buf bytes.Buffer
+	require.NoError(t, protection.Export(&buf, genesis))
+	exported := buf.Bytes()
+
+	t.Run("import", func(t *testing.T) {
+		other := newProtection(t)
+		require.NoError(t, other.Import(bytes.NewReader(exported), genesis))
+		// importing the same records again is a noop
+		require.NoError(t, other.Import(bytes.NewReader(exported), genesis))
+
+		require.ErrorIs(t, other.Check(first, ATX,
+			encode(t, &types.ATXMetadata{PublishEpoch: 3, MsgHash: types.Hash32{2}})), ErrConflictingMessage)
+		require.ErrorIs(t, other.Check(first, BALLOT,
+			encode(t, &types.BallotMetadata{Layer: 12, MsgHash: types.Hash32{1}})), ErrConflictingMessage)
+		require.ErrorIs(t, other.Check(second, HARE,
+			encode(t, &types.HareMetadata{Layer: 12, Round: 3, MsgHash: types.Hash32{1}})), ErrConflictingMessage)
+
+		var reexported bytes.Buffer
+		require.NoError(t, other.Export(&reexported, genesis))
+		require.Equal(t, exported, reexported.Bytes())
+	})
+	t.Run("different genesis", func(t *testing.T) {
+		other := newProtection(t)
+		require.Error(t, other.Import(bytes.NewReader(exported), types.Hash20{2}))
+	})
+	t.Run("conflicting records", func(t *testing.T) {
+		other := newProtection(t)
+		require.NoError(t, other.Check(second, HARE,
+			encode(t, &types.HareMetadata{Layer: 12, Round: 3, MsgHash: types.Hash32{4}})))
+		require.ErrorIs(t, other.Import(bytes.NewReader(exported), genesis), ErrConflictingMessage)
+
+		// nothing is imported
+		require.NoError(t, other.Check(first, ATX,
+			encode(t, &types.ATXMetadata{PublishEpoch: 3, MsgHash: types.Hash32{2}})))
+	})
+}
+
+func TestEdSigner_Protection(t *testing.T) {
+	signer, err := NewEdSigner(WithProtection(newProtection(t)))
+	require.NoError(t, err)
+
+	ballot := encode(t, &types.BallotMetadata{Layer: 10, MsgHash: types.Hash32{1}})
+	sig, err := signer.TrySign(BALLOT, ballot)
+	require.NoError(t, err)
+	require.Equal(t, sig, signer.Sign(BALLOT, ballot))
+
+	_, err = signer.TrySign(BALLOT, encode(t, &types.BallotMetadata{Layer: 10, MsgHash: types.Hash32{2}}))
+	require.ErrorIs(t, err, ErrConflictingMessage)
+}
@@ -0,0 +1,128 @@
+package signing
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+const signerServiceName = "spacemesh.signer.v1.SignerService"
+
+// ErrConflictingMessage is returned when the message conflicts with a message signed before,
+// and signing it would allow to prove malfeasance of the identity.
+var ErrConflictingMessage = errors.New("message conflicts with a previously signed message")
+
+// scaleCodec encodes grpc messages of the SignerService with scale.
+type scaleCodec struct{}
+
+func (scaleCodec) Name() string {
+	return "scale"
+}
+
+func (scaleCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(codec.Encodable)
+	if !ok {
+		return nil, fmt.Errorf("message %T is not scale encodable", v)
+	}
+	return codec.Encode(msg)
+}
+
+func (scaleCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(codec.Decodable)
+	if !ok {
+		return fmt.Errorf("message %T is not scale decodable", v)
+	}
+	return codec.Decode(data, msg)
+}
+
+// RemoteSigner is a Signer that delegates signing to the SignerService running
+// in a separate process, so that the private key is not held by the node.
+type RemoteSigner struct {
+	conn    *grpc.ClientConn
+	pub     ed25519.PublicKey
+	timeout time.Duration
+}
+
+// NewRemoteSigner connects to the SignerService at the address and queries the public key
+// of the identity. The connection is neither encrypted nor authenticated, so the service must
+// listen on a unix socket (unix:///path/to/socket) that is accessible only by the node.
+func NewRemoteSigner(ctx context.Context, address string, timeout time.Duration) (*RemoteSigner, error) {
+	if !strings.HasPrefix(address, "unix://") {
+		return nil, fmt.Errorf("remote signer address %s must be a unix socket (unix:///path/to/socket)", address)
+	}
+	conn, err := grpc.DialContext(ctx, address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(scaleCodec{})),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial remote signer %s: %w", address, err)
+	}
+	rs := &RemoteSigner{conn: conn, timeout: timeout}
+	var resp PublicKeyResponse
+	if err := rs.invoke("PublicKey", &PublicKeyRequest{}, &resp); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("query public key of remote signer %s: %w", address, err)
+	}
+	if len(resp.PublicKey) != ed25519.PublicKeySize {
+		conn.Close()
+		return nil, fmt.Errorf("invalid public key size %d from remote signer %s", len(resp.PublicKey), address)
+	}
+	rs.pub = resp.PublicKey
+	return rs, nil
+}
+
+func (rs *RemoteSigner) invoke(method string, req, resp any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rs.timeout)
+	defer cancel()
+	err := rs.conn.Invoke(ctx, "/"+signerServiceName+"/"+method, req, resp)
+	if status.Code(err) == codes.FailedPrecondition {
+		msg := strings.TrimPrefix(status.Convert(err).Message(), ErrConflictingMessage.Error()+": ")
+		return fmt.Errorf("%w: %s", ErrConflictingMessage, msg)
+	}
+	return err
+}
+
+// PublicKey returns the public key of the remote identity.
+func (rs *RemoteSigner) PublicKey() ed25519.PublicKey {
+	return rs.pub
+}
+
+// Sign requests the signature of the message from the remote signer and verifies it.
+func (rs *RemoteSigner) Sign(prefix []byte, d domain, msg []byte) (types.EdSignature, error) {
+	var resp SignResponse
+	if err := rs.invoke("Sign", &SignRequest{Prefix: prefix, Domain: uint8(d), Message: msg}, &resp); err != nil {
+		return types.EmptyEdSignature, err
+	}
+	if !ed25519.Verify(rs.pub, signedMessage(prefix, d, msg), resp.Signature[:]) {
+		return types.EmptyEdSignature, errors.New("remote signer returned invalid signature")
+	}
+	return resp.Signature, nil
+}
+
+// Prove requests the vrf signature of the message from the remote signer and verifies it.
+func (rs *RemoteSigner) Prove(msg []byte) (types.VrfSignature, error) {
+	var resp ProveResponse
+	if err := rs.invoke("Prove", &ProveRequest{Message: msg}, &resp); err != nil {
+		return types.VrfSignature{}, err
+	}
+	if !VRFVerify(types.BytesToNodeID(rs.pub), msg, resp.Signature) {
+		return types.VrfSignature{}, errors.New("remote signer returned invalid vrf signature")
+	}
+	return resp.Signature, nil
+}
+
+// Close closes the connection to the remote signer.
+func (rs *RemoteSigner) Close() error {
+	return rs.conn.Close()
+}
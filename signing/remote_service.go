@@ -0,0 +1,126 @@
+package signing
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// SignerService signs messages with the private key on behalf of RemoteSigner.
+//
+// It refuses to sign an ATX, a ballot or a hare message that conflicts with a message recorded
+// in the slashing protection database. Such pairs are proofs of malfeasance of the identity.
+type SignerService struct {
+	signer     keySigner
+	protection *Protection
+	logger     log.Log
+}
+
+// NewSignerService creates a SignerService for the private key.
+func NewSignerService(priv PrivateKey, protection *Protection, logger log.Log) *SignerService {
+	return &SignerService{
+		signer:     keySigner{priv: priv},
+		protection: protection,
+		logger:     logger,
+	}
+}
+
+// NewSignerServer creates a grpc server with the SignerService registered.
+// Messages of the service are scale encoded.
+func NewSignerServer(svc *SignerService, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append(opts, grpc.ForceServerCodec(scaleCodec{}))...)
+	server.RegisterService(&signerServiceDesc, svc)
+	return server
+}
+
+// PublicKey returns the public key of the identity.
+func (s *SignerService) PublicKey(context.Context, *PublicKeyRequest) (*PublicKeyResponse, error) {
+	return &PublicKeyResponse{PublicKey: s.signer.PublicKey()}, nil
+}
+
+// Sign signs the message unless it conflicts with a message signed before.
+func (s *SignerService) Sign(_ context.Context, req *SignRequest) (*SignResponse, error) {
+	d := domain(req.Domain)
+	nodeID := types.BytesToNodeID(s.signer.PublicKey())
+	if err := s.protection.Check(nodeID, d, req.Message); err != nil {
+		s.logger.With().Warning("refused to sign message", log.Stringer("domain", d), log.Err(err))
+		if errors.Is(err, ErrConflictingMessage) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	sig, err := s.signer.Sign(req.Prefix, d, req.Message)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &SignResponse{Signature: sig}, nil
+}
+
+// Prove returns the vrf signature of the message.
+func (s *SignerService) Prove(_ context.Context, req *ProveRequest) (*ProveResponse, error) {
+	sig, err := s.signer.Prove(req.Message)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &ProveResponse{Signature: sig}, nil
+}
+
+type signerServer interface {
+	PublicKey(context.Context, *PublicKeyRequest) (*PublicKeyResponse, error)
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	Prove(context.Context, *ProveRequest) (*ProveResponse, error)
+}
+
+var signerServiceDesc = grpc.ServiceDesc{
+	ServiceName: signerServiceName,
+	HandlerType: (*signerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PublicKey",
+			Handler: unaryHandler("PublicKey", func(srv signerServer, ctx context.Context, req *PublicKeyRequest) (any, error) {
+				return srv.PublicKey(ctx, req)
+			}),
+		},
+		{
+			MethodName: "Sign",
+			Handler: unaryHandler("Sign", func(srv signerServer, ctx context.Context, req *SignRequest) (any, error) {
+				return srv.Sign(ctx, req)
+			}),
+		},
+		{
+			MethodName: "Prove",
+			Handler: unaryHandler("Prove", func(srv signerServer, ctx context.Context, req *ProveRequest) (any, error) {
+				return srv.Prove(ctx, req)
+			}),
+		},
+	},
+}
+
+// unaryHandler is the equivalent of the handler generated by protoc-gen-go-grpc.
+func unaryHandler[T any](
+	method string,
+	call func(signerServer, context.Context, *T) (any, error),
+) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(T)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(srv.(signerServer), ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: "/" + signerServiceName + "/" + method,
+		}
+		return interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return call(srv.(signerServer), ctx, req.(*T))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package signing
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+func startSignerService(tb testing.TB, priv PrivateKey) string {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), "signer.sock")
+	lis, err := net.Listen("unix", path)
+	require.NoError(tb, err)
+	server := NewSignerServer(NewSignerService(priv, newProtection(tb), logtest.New(tb)))
+	go server.Serve(lis)
+	tb.Cleanup(server.Stop)
+	return "unix://" + path
+}
+
+func TestRemoteSigner(t *testing.T) {
+	local, err := NewEdSigner(WithPrefix([]byte("prefix")))
+	require.NoError(t, err)
+	address := startSignerService(t, local.PrivateKey())
+
+	rs, err := NewRemoteSigner(context.Background(), address, time.Second)
+	require.NoError(t, err)
+	t.Cleanup(func() { rs.Close() })
+	remote, err := NewEdSigner(WithSigner(rs), WithPrefix([]byte("prefix")))
+	require.NoError(t, err)
+	require.Nil(t, remote.PrivateKey())
+	require.Equal(t, local.NodeID(), remote.NodeID())
+
+	msg := []byte("message")
+	require.Equal(t, local.Sign(HARE, msg), remote.Sign(HARE, msg))
+
+	localVRF, err := local.VRFSigner()
+	require.NoError(t, err)
+	remoteVRF, err := remote.VRFSigner()
+	require.NoError(t, err)
+	require.Equal(t, localVRF.Sign(msg), remoteVRF.Sign(msg))
+
+	t.Run("conflicting atx", func(t *testing.T) {
+		atx, err := codec.Encode(&types.ATXMetadata{PublishEpoch: 3, MsgHash: types.Hash32{1}})
+		require.NoError(t, err)
+		_, err = remote.TrySign(ATX, atx)
+		require.NoError(t, err)
+		// the same atx can be signed again
+		_, err = remote.TrySign(ATX, atx)
+		require.NoError(t, err)
+
+		other, err := codec.Encode(&types.ATXMetadata{PublishEpoch: 3, MsgHash: types.Hash32{2}})
+		require.NoError(t, err)
+		_, err = remote.TrySign(ATX, other)
+		require.ErrorIs(t, err, ErrConflictingMessage)
+		require.Panics(t, func() { remote.Sign(ATX, other) })
+
+		next, err := codec.Encode(&types.ATXMetadata{PublishEpoch: 4, MsgHash: types.Hash32{2}})
+		require.NoError(t, err)
+		_, err = remote.TrySign(ATX, next)
+		require.NoError(t, err)
+	})
+	t.Run("conflicting ballot", func(t *testing.T) {
+		ballot, err := codec.Encode(&types.BallotMetadata{Layer: 10, MsgHash: types.Hash32{1}})
+		require.NoError(t, err)
+		_, err = remote.TrySign(BALLOT, ballot)
+		require.NoError(t, err)
+
+		other, err := codec.Encode(&types.BallotMetadata{Layer: 10, MsgHash: types.Hash32{2}})
+		require.NoError(t, err)
+		_, err = remote.TrySign(BALLOT, other)
+		require.ErrorIs(t, err, ErrConflictingMessage)
+	})
+}
+
+func TestRemoteSignerUnixOnly(t *testing.T) {
+	_, err := NewRemoteSigner(context.Background(), "127.0.0.1:9000", time.Second)
+	require.ErrorContains(t, err, "must be a unix socket")
+}
@@ -0,0 +1,35 @@
+package signing
+
+import "github.com/spacemeshos/go-spacemesh/common/types"
+
+//go:generate scalegen
+
+// PublicKeyRequest is the request of SignerService.PublicKey.
+type PublicKeyRequest struct{}
+
+// PublicKeyResponse is the response of SignerService.PublicKey.
+type PublicKeyResponse struct {
+	PublicKey []byte `scale:"max=32"`
+}
+
+// SignRequest is the request of SignerService.Sign.
+type SignRequest struct {
+	Prefix  []byte `scale:"max=64"`
+	Domain  uint8
+	Message []byte `scale:"max=8388608"` // proposals with the maximal number of transactions fit
+}
+
+// SignResponse is the response of SignerService.Sign.
+type SignResponse struct {
+	Signature types.EdSignature
+}
+
+// ProveRequest is the request of SignerService.Prove.
+type ProveRequest struct {
+	Message []byte `scale:"max=4096"`
+}
+
+// ProveResponse is the response of SignerService.Prove.
+type ProveResponse struct {
+	Signature types.VrfSignature
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 
 	oasis "github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519/extra/ecvrf"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
@@ -21,6 +22,7 @@ const (
 	BALLOT
 	HARE
 	POET
+	BOOTSTRAP
 )
 
 // String returns the string representation of a domain.
@@ -36,14 +38,53 @@ func (d domain) String() string {
 		return "HARE"
 	case POET:
 		return "POET"
+	case BOOTSTRAP:
+		return "BOOTSTRAP"
 	default:
 		return "UNKNOWN"
 	}
 }
 
+// Signer holds the key of an identity. EdSigner and VRFSigner produce signatures with
+// a Signer that holds the key locally, unless EdSigner is created WithSigner.
+type Signer interface {
+	// PublicKey returns the ed25519 public key of the identity.
+	PublicKey() ed25519.PublicKey
+	// Sign signs the message in the domain. The signed data is prefix, domain and message.
+	Sign(prefix []byte, d domain, msg []byte) (types.EdSignature, error)
+	// Prove returns the vrf signature for the message.
+	Prove(msg []byte) (types.VrfSignature, error)
+}
+
+// keySigner signs with the private key in memory.
+type keySigner struct {
+	priv PrivateKey
+}
+
+func (s keySigner) PublicKey() ed25519.PublicKey {
+	return s.priv.Public().(ed25519.PublicKey)
+}
+
+func (s keySigner) Sign(prefix []byte, d domain, m []byte) (types.EdSignature, error) {
+	return *(*[types.EdSignatureSize]byte)(ed25519.Sign(s.priv, signedMessage(prefix, d, m))), nil
+}
+
+func (s keySigner) Prove(msg []byte) (types.VrfSignature, error) {
+	return *(*[types.VrfSignatureSize]byte)(ecvrf.Prove(oasis.PrivateKey(s.priv), msg)), nil
+}
+
+func signedMessage(prefix []byte, d domain, m []byte) []byte {
+	msg := make([]byte, 0, len(prefix)+1+len(m))
+	msg = append(msg, prefix...)
+	msg = append(msg, byte(d))
+	return append(msg, m...)
+}
+
 type edSignerOption struct {
-	priv   PrivateKey
-	prefix []byte
+	priv       PrivateKey
+	signer     Signer
+	prefix     []byte
+	protection *Protection
 }
 
 // EdSignerOptionFunc modifies EdSigner.
@@ -75,6 +116,23 @@ func WithPrivateKey(priv PrivateKey) EdSignerOptionFunc {
 	}
 }
 
+// WithSigner delegates signing to the signer instead of the local private key.
+func WithSigner(signer Signer) EdSignerOptionFunc {
+	return func(opt *edSignerOption) error {
+		opt.signer = signer
+		return nil
+	}
+}
+
+// WithProtection makes EdSigner record signed messages in the slashing protection database
+// and refuse to sign messages that conflict with the recorded ones.
+func WithProtection(protection *Protection) EdSignerOptionFunc {
+	return func(opt *edSignerOption) error {
+		opt.protection = protection
+		return nil
+	}
+}
+
 // WithKeyFromRand sets the private key used by EdSigner using predictable randomness source.
 func WithKeyFromRand(rand io.Reader) EdSignerOptionFunc {
 	return func(opt *edSignerOption) error {
@@ -90,9 +148,12 @@ func WithKeyFromRand(rand io.Reader) EdSignerOptionFunc {
 
 // EdSigner represents an ED25519 signer.
 type EdSigner struct {
-	priv PrivateKey
+	// priv is nil if signing is delegated to another signer.
+	priv   PrivateKey
+	signer Signer
 
-	prefix []byte
+	prefix     []byte
+	protection *Protection
 }
 
 // NewEdSigner returns an auto-generated ed signer.
@@ -105,6 +166,9 @@ func NewEdSigner(opts ...EdSignerOptionFunc) (*EdSigner, error) {
 		}
 	}
 
+	if cfg.signer != nil {
+		return &EdSigner{signer: cfg.signer, prefix: cfg.prefix, protection: cfg.protection}, nil
+	}
 	if cfg.priv == nil {
 		_, priv, err := ed25519.GenerateKey(nil)
 		if err != nil {
@@ -113,20 +177,39 @@ func NewEdSigner(opts ...EdSignerOptionFunc) (*EdSigner, error) {
 		cfg.priv = priv
 	}
 	sig := &EdSigner{
-		priv:   cfg.priv,
-		prefix: cfg.prefix,
+		priv:       cfg.priv,
+		signer:     keySigner{priv: cfg.priv},
+		prefix:     cfg.prefix,
+		protection: cfg.protection,
 	}
 	return sig, nil
 }
 
-// Sign signs the provided message.
+// Sign signs the provided message. It panics if the message can't be signed, e.g. if a remote
+// signer is not reachable or the message conflicts with the slashing protection database.
+// Use TrySign unless the signer holds the key locally and has no protection.
 func (es *EdSigner) Sign(d domain, m []byte) types.EdSignature {
-	msg := make([]byte, 0, len(es.prefix)+1+len(m))
-	msg = append(msg, es.prefix...)
-	msg = append(msg, byte(d))
-	msg = append(msg, m...)
+	sig, err := es.TrySign(d, m)
+	if err != nil {
+		log.With().Panic("failed to sign message", log.Stringer("domain", d), log.Err(err))
+	}
+	return sig
+}
 
-	return *(*[types.EdSignatureSize]byte)(ed25519.Sign(es.priv, msg))
+// TrySign signs the provided message or returns an error if the message can't be signed.
+// ErrConflictingMessage is returned if the message conflicts with a message recorded
+// in the slashing protection database.
+func (es *EdSigner) TrySign(d domain, m []byte) (types.EdSignature, error) {
+	if es.protection != nil {
+		if err := es.protection.Check(es.NodeID(), d, m); err != nil {
+			return types.EmptyEdSignature, fmt.Errorf("sign %s message: %w", d, err)
+		}
+	}
+	sig, err := es.signer.Sign(es.prefix, d, m)
+	if err != nil {
+		return types.EmptyEdSignature, fmt.Errorf("sign %s message: %w", d, err)
+	}
+	return sig, nil
 }
 
 // NodeID returns the node ID of the signer.
@@ -136,10 +219,10 @@ func (es *EdSigner) NodeID() types.NodeID {
 
 // PublicKey returns the public key of the signer.
 func (es *EdSigner) PublicKey() *PublicKey {
-	return NewPublicKey(es.priv.Public().(ed25519.PublicKey))
+	return NewPublicKey(es.signer.PublicKey())
 }
 
-// PrivateKey returns private key.
+// PrivateKey returns private key. It is nil if signing is delegated to another signer.
 func (es *EdSigner) PrivateKey() PrivateKey {
 	return es.priv
 }
@@ -147,8 +230,8 @@ func (es *EdSigner) PrivateKey() PrivateKey {
 // VRFSigner wraps same ed25519 key to provide ecvrf.
 func (es *EdSigner) VRFSigner() (*VRFSigner, error) {
 	return &VRFSigner{
-		privateKey: oasis.PrivateKey(es.priv),
-		nodeID:     es.NodeID(),
+		signer: es.signer,
+		nodeID: es.NodeID(),
 	}, nil
 }
 
@@ -1,21 +1,37 @@
 package signing
 
 import (
-	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"fmt"
+
 	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519/extra/ecvrf"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
 )
 
 // VRFSigner is a signer for VRF purposes.
 type VRFSigner struct {
-	privateKey ed25519.PrivateKey
-	nodeID     types.NodeID
+	signer Signer
+	nodeID types.NodeID
 }
 
-// Sign signs a message for VRF purposes.
+// Sign signs a message for VRF purposes. It panics if the message can't be signed, e.g. if
+// a remote signer is not reachable. Use TrySign unless the signer holds the key locally.
 func (s VRFSigner) Sign(msg []byte) types.VrfSignature {
-	return *(*[types.VrfSignatureSize]byte)(ecvrf.Prove(s.privateKey, msg))
+	sig, err := s.TrySign(msg)
+	if err != nil {
+		log.With().Panic("failed to sign vrf message", log.Err(err))
+	}
+	return sig
+}
+
+// TrySign signs a message for VRF purposes or returns an error if the message can't be signed.
+func (s VRFSigner) TrySign(msg []byte) (types.VrfSignature, error) {
+	sig, err := s.signer.Prove(msg)
+	if err != nil {
+		return types.EmptyVrfSignature, fmt.Errorf("sign vrf message: %w", err)
+	}
+	return sig, nil
 }
 
 // NodeID of the signer.
@@ -121,6 +121,48 @@ func Snapshot(db sql.Executor, layer types.LayerID) ([]*types.Account, error) {
 	return rst, nil
 }
 
+// UpdatedLayers returns layers where any account was updated in ascending order.
+func UpdatedLayers(db sql.Executor) ([]types.LayerID, error) {
+	var rst []types.LayerID
+	if _, err := db.Exec("select distinct layer_updated from accounts order by layer_updated asc;", nil,
+		func(stmt *sql.Statement) bool {
+			rst = append(rst, types.LayerID(uint32(stmt.ColumnInt64(0))))
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("failed to load updated layers: %w", err)
+	}
+	return rst, nil
+}
+
+// UpdatedIn returns versions of the accounts updated in the layer.
+func UpdatedIn(db sql.Executor, layer types.LayerID) ([]*types.Account, error) {
+	var rst []*types.Account
+	if _, err := db.Exec(`select address, balance, next_nonce, layer_updated, template, state from accounts
+		where layer_updated = ?1 order by address asc;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(layer))
+		},
+		func(stmt *sql.Statement) bool {
+			var account types.Account
+			stmt.ColumnBytes(0, account.Address[:])
+			account.Balance = uint64(stmt.ColumnInt64(1))
+			account.NextNonce = uint64(stmt.ColumnInt64(2))
+			account.Layer = types.LayerID(uint32(stmt.ColumnInt64(3)))
+			if stmt.ColumnLen(4) > 0 {
+				var template types.Address
+				stmt.ColumnBytes(4, template[:])
+				account.TemplateAddress = &template
+				account.State = make([]byte, stmt.ColumnLen(5))
+				stmt.ColumnBytes(5, account.State)
+			}
+			rst = append(rst, &account)
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("failed to load accounts updated in %v: %w", layer, err)
+	}
+	return rst, nil
+}
+
 // Update account state at a certain layer.
 func Update(db sql.Executor, to *types.Account) error {
 	_, err := db.Exec(`insert into 
@@ -155,3 +197,20 @@ func Revert(db sql.Executor, after types.LayerID) error {
 	}
 	return nil
 }
+
+// PruneHistory deletes account versions updated before the layer that were superseded
+// by a version at or before the layer. The state at the layer and after is unchanged.
+func PruneHistory(db sql.Executor, before types.LayerID) (int, error) {
+	rows, err := db.Exec(`delete from accounts
+		where layer_updated < ?1 and exists (
+			select 1 from accounts newer where newer.address = accounts.address
+			and newer.layer_updated > accounts.layer_updated and newer.layer_updated <= ?1
+		) returning address;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(before))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune accounts history before %v: %w", before, err)
+	}
+	return rows, nil
+}
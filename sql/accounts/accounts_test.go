@@ -104,3 +104,54 @@ func TestSnapshot(t *testing.T) {
 		}
 	}
 }
+
+func TestPruneHistory(t *testing.T) {
+	db := sql.InMemory()
+	address := types.Address{1, 2, 3}
+	other := types.Address{3, 2, 1}
+	seq := genSeq(address, 5)
+	for _, update := range seq {
+		require.NoError(t, Update(db, update))
+	}
+	require.NoError(t, Update(db, &types.Account{Address: other, Layer: types.LayerID(1), Balance: 7}))
+
+	pruned, err := PruneHistory(db, types.LayerID(3))
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+
+	for lid := types.LayerID(3); lid <= 5; lid++ {
+		account, err := Get(db, address, lid)
+		require.NoError(t, err)
+		require.Equal(t, uint64(lid), account.Balance)
+	}
+	// versions before the layer are not available anymore
+	account, err := Get(db, address, types.LayerID(2))
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
+
+	// the only version of the account is never pruned
+	account, err = Get(db, other, types.LayerID(5))
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), account.Balance)
+}
+
+func TestPruneHistory_SupersededLater(t *testing.T) {
+	db := sql.InMemory()
+	address := types.Address{1, 2, 3}
+	require.NoError(t, Update(db, &types.Account{Address: address, Layer: types.LayerID(1), Balance: 1}))
+	require.NoError(t, Update(db, &types.Account{Address: address, Layer: types.LayerID(5), Balance: 5}))
+
+	// version from layer 1 is the state at layer 3
+	pruned, err := PruneHistory(db, types.LayerID(3))
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+
+	// and it is superseded at layer 6, even though it was updated before the previous prune
+	pruned, err = PruneHistory(db, types.LayerID(6))
+	require.NoError(t, err)
+	require.Equal(t, 1, pruned)
+
+	account, err := Get(db, address, types.LayerID(6))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), account.Balance)
+}
@@ -0,0 +1,116 @@
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// TrieNode is a node of the merkle trie over the accounts.
+// Node is identified by the depth and the key prefix of the same length.
+type TrieNode struct {
+	Depth  uint16
+	Prefix []byte
+	Hash   types.Hash32
+	// Leaf is the key of the account that is stored in the node.
+	// Nil for internal nodes.
+	Leaf *types.Hash32
+}
+
+// GetTrieNode returns the version of the trie node that was valid at the specified layer.
+func GetTrieNode(db sql.Executor, depth uint16, prefix []byte, layer types.LayerID) (*TrieNode, error) {
+	var node *TrieNode
+	_, err := db.Exec(`select hash, leaf from accounts_trie
+		where depth = ?1 and prefix = ?2 and layer <= ?3
+		order by layer desc limit 1;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(depth))
+			stmt.BindBytes(2, prefix)
+			stmt.BindInt64(3, int64(layer))
+		}, func(stmt *sql.Statement) bool {
+			node = &TrieNode{Depth: depth, Prefix: prefix}
+			stmt.ColumnBytes(0, node.Hash[:])
+			if stmt.ColumnLen(1) > 0 {
+				node.Leaf = &types.Hash32{}
+				stmt.ColumnBytes(1, node.Leaf[:])
+			}
+			return false
+		})
+	if err != nil {
+		return nil, fmt.Errorf("get trie node %d/%x at %v: %w", depth, prefix, layer, err)
+	}
+	if node == nil {
+		return nil, fmt.Errorf("%w trie node %d/%x at %v", sql.ErrNotFound, depth, prefix, layer)
+	}
+	return node, nil
+}
+
+// UpdateTrieNode writes the version of the trie node at the layer.
+func UpdateTrieNode(db sql.Executor, node *TrieNode, layer types.LayerID) error {
+	_, err := db.Exec(`insert into accounts_trie (depth, prefix, layer, hash, leaf)
+		values (?1, ?2, ?3, ?4, ?5)
+		on conflict do update set hash = ?4, leaf = ?5;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(node.Depth))
+			stmt.BindBytes(2, node.Prefix)
+			stmt.BindInt64(3, int64(layer))
+			stmt.BindBytes(4, node.Hash[:])
+			if node.Leaf == nil {
+				stmt.BindNull(5)
+			} else {
+				stmt.BindBytes(5, node.Leaf[:])
+			}
+		}, nil)
+	if err != nil {
+		return fmt.Errorf("update trie node %d/%x at %v: %w", node.Depth, node.Prefix, layer, err)
+	}
+	return nil
+}
+
+// ClearTrie removes all versions of the trie nodes.
+func ClearTrie(db sql.Executor) error {
+	if _, err := db.Exec("delete from accounts_trie;", nil, nil); err != nil {
+		return fmt.Errorf("failed to clear trie: %w", err)
+	}
+	return nil
+}
+
+// HasTrie returns true if any version of a trie node is stored.
+func HasTrie(db sql.Executor) (bool, error) {
+	rows, err := db.Exec("select 1 from accounts_trie limit 1;", nil, nil)
+	if err != nil {
+		return false, fmt.Errorf("has trie: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// RevertTrie removes versions of the trie nodes written after the layer.
+func RevertTrie(db sql.Executor, after types.LayerID) error {
+	_, err := db.Exec(`delete from accounts_trie where layer > ?1;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(after))
+		}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to revert trie up to %v: %w", after, err)
+	}
+	return nil
+}
+
+// PruneTrieHistory deletes versions of the trie nodes updated before the layer that were superseded
+// by a version at or before the layer. The trie at the layer and after is unchanged.
+func PruneTrieHistory(db sql.Executor, before types.LayerID) (int, error) {
+	rows, err := db.Exec(`delete from accounts_trie
+		where layer < ?1 and exists (
+			select 1 from accounts_trie newer
+			where newer.depth = accounts_trie.depth and newer.prefix = accounts_trie.prefix
+			and newer.layer > accounts_trie.layer and newer.layer <= ?1
+		) returning depth;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(before))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune trie history before %v: %w", before, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,89 @@
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestTrieNode(t *testing.T) {
+	db := sql.InMemory()
+	_, err := GetTrieNode(db, 0, []byte{}, 10)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	leaf := types.RandomHash()
+	nodes := []*TrieNode{
+		{Depth: 3, Prefix: []byte{0xe0}, Hash: types.RandomHash(), Leaf: &leaf},
+		{Depth: 3, Prefix: []byte{0xe0}, Hash: types.RandomHash()},
+	}
+	require.NoError(t, UpdateTrieNode(db, nodes[0], 1))
+	require.NoError(t, UpdateTrieNode(db, nodes[1], 3))
+
+	_, err = GetTrieNode(db, 3, []byte{0xe0}, 0)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	for _, lid := range []types.LayerID{1, 2} {
+		got, err := GetTrieNode(db, 3, []byte{0xe0}, lid)
+		require.NoError(t, err)
+		require.Equal(t, nodes[0], got)
+	}
+	got, err := GetTrieNode(db, 3, []byte{0xe0}, 3)
+	require.NoError(t, err)
+	require.Equal(t, nodes[1], got)
+
+	// updates within the same layer overwrite the node
+	nodes[1].Hash = types.RandomHash()
+	require.NoError(t, UpdateTrieNode(db, nodes[1], 3))
+	got, err = GetTrieNode(db, 3, []byte{0xe0}, 3)
+	require.NoError(t, err)
+	require.Equal(t, nodes[1], got)
+
+	require.NoError(t, RevertTrie(db, 2))
+	got, err = GetTrieNode(db, 3, []byte{0xe0}, 3)
+	require.NoError(t, err)
+	require.Equal(t, nodes[0], got)
+}
+
+func TestPruneTrieHistory(t *testing.T) {
+	db := sql.InMemory()
+	var nodes []*TrieNode
+	for lid := types.LayerID(1); lid <= 4; lid++ {
+		node := &TrieNode{Depth: 1, Prefix: []byte{0x80}, Hash: types.RandomHash()}
+		require.NoError(t, UpdateTrieNode(db, node, lid))
+		nodes = append(nodes, node)
+	}
+	pruned, err := PruneTrieHistory(db, types.LayerID(3))
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+
+	for i, lid := range []types.LayerID{3, 4} {
+		got, err := GetTrieNode(db, 1, []byte{0x80}, lid)
+		require.NoError(t, err)
+		require.Equal(t, nodes[2+i], got)
+	}
+	_, err = GetTrieNode(db, 1, []byte{0x80}, 2)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
+
+func TestPruneTrieHistory_SupersededLater(t *testing.T) {
+	db := sql.InMemory()
+	first := &TrieNode{Depth: 1, Prefix: []byte{0x80}, Hash: types.RandomHash()}
+	require.NoError(t, UpdateTrieNode(db, first, 1))
+	last := &TrieNode{Depth: 1, Prefix: []byte{0x80}, Hash: types.RandomHash()}
+	require.NoError(t, UpdateTrieNode(db, last, 5))
+
+	pruned, err := PruneTrieHistory(db, types.LayerID(3))
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+	got, err := GetTrieNode(db, 1, []byte{0x80}, 3)
+	require.NoError(t, err)
+	require.Equal(t, first, got)
+
+	pruned, err = PruneTrieHistory(db, types.LayerID(6))
+	require.NoError(t, err)
+	require.Equal(t, 1, pruned)
+	_, err = GetTrieNode(db, 1, []byte{0x80}, 4)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
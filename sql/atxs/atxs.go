@@ -9,7 +9,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
-const fullQuery = "select id, atx, base_tick_height, tick_count, pubkey, effective_num_units, received, epoch, sequence, coinbase from atxs"
+const fullQuery = "select id, atx, base_tick_height, tick_count, pubkey, effective_num_units, received, epoch, sequence, coinbase, commitment_atx, nonce from atxs"
 
 func load(db sql.Executor, query string, enc sql.Encoder) (*types.VerifiedActivationTx, error) {
 	var (
@@ -22,15 +22,29 @@ func load(db sql.Executor, query string, enc sql.Encoder) (*types.VerifiedActiva
 			id types.ATXID
 		)
 		stmt.ColumnBytes(0, id[:])
-		if _, decodeErr := codec.DecodeFrom(stmt.ColumnReader(1), &a); decodeErr != nil {
-			myerr = fmt.Errorf("decode %w", decodeErr)
-			return true
+		effectiveNumUnits := uint32(stmt.ColumnInt32(5))
+		if stmt.ColumnLen(1) > 0 {
+			if _, decodeErr := codec.DecodeFrom(stmt.ColumnReader(1), &a); decodeErr != nil {
+				myerr = fmt.Errorf("decode %w", decodeErr)
+				return true
+			}
+		} else {
+			// checkpointed atx doesn't have the full atx stored, only the fields
+			// that are persisted in separate columns are available.
+			a.NumUnits = effectiveNumUnits
+			if stmt.ColumnLen(10) > 0 {
+				a.CommitmentATX = &types.ATXID{}
+				stmt.ColumnBytes(10, a.CommitmentATX[:])
+			}
+			if stmt.ColumnLen(11) > 0 {
+				nonce := types.VRFPostIndex(stmt.ColumnInt64(11))
+				a.VRFNonce = &nonce
+			}
 		}
 		a.SetID(id)
 		baseTickHeight := uint64(stmt.ColumnInt64(2))
 		tickCount := uint64(stmt.ColumnInt64(3))
 		stmt.ColumnBytes(4, a.SmesherID[:])
-		effectiveNumUnits := uint32(stmt.ColumnInt32(5))
 		a.SetEffectiveNumUnits(effectiveNumUnits)
 		a.SetReceived(time.Unix(0, stmt.ColumnInt64(6)).Local())
 		a.PublishEpoch = types.EpochID(uint32(stmt.ColumnInt(7)))
@@ -238,7 +252,8 @@ func GetBlob(db sql.Executor, id []byte) (buf []byte, err error) {
 			return true
 		}); err != nil {
 		return nil, fmt.Errorf("get %s: %w", types.BytesToHash(id), err)
-	} else if rows == 0 {
+	} else if rows == 0 || len(buf) == 0 {
+		// checkpointed atxs are stored without the blob and can't be served to peers
 		return nil, fmt.Errorf("%w: atx %s", sql.ErrNotFound, types.BytesToHash(id))
 	}
 	return buf, nil
@@ -354,3 +369,30 @@ func LatestN(db sql.Executor, n int) ([]CheckpointAtx, error) {
 	}
 	return rst, nil
 }
+
+// AddCheckpointed adds a checkpointed ATX to the database.
+// Checkpointed ATXs are stored without the full encoded ATX, only with the
+// fields that are required to continue building the mesh after recovery.
+func AddCheckpointed(db sql.Executor, catx *CheckpointAtx) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, catx.ID.Bytes())
+		stmt.BindInt64(2, int64(catx.Epoch))
+		stmt.BindInt64(3, int64(catx.NumUnits))
+		stmt.BindBytes(4, catx.CommitmentATX.Bytes())
+		stmt.BindInt64(5, int64(catx.VRFNonce))
+		stmt.BindBytes(6, catx.SmesherID.Bytes())
+		stmt.BindInt64(7, time.Now().UnixNano())
+		stmt.BindInt64(8, int64(catx.BaseTickHeight))
+		stmt.BindInt64(9, int64(catx.TickCount))
+		stmt.BindInt64(10, int64(catx.Sequence))
+		stmt.BindBytes(11, catx.Coinbase.Bytes())
+	}
+
+	_, err := db.Exec(`
+		insert into atxs (id, epoch, effective_num_units, commitment_atx, nonce, pubkey, received, base_tick_height, tick_count, sequence, coinbase)
+		values (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11);`, enc, nil)
+	if err != nil {
+		return fmt.Errorf("insert checkpoint ATX %v: %w", catx.ID, err)
+	}
+	return nil
+}
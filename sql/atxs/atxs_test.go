@@ -574,3 +574,39 @@ func TestPositioningID(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckpointATX(t *testing.T) {
+	db := sql.InMemory()
+
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	catx := &atxs.CheckpointAtx{
+		ID:             types.RandomATXID(),
+		Epoch:          types.EpochID(3),
+		CommitmentATX:  types.RandomATXID(),
+		VRFNonce:       types.VRFPostIndex(119),
+		NumUnits:       4,
+		BaseTickHeight: 1000,
+		TickCount:      1,
+		SmesherID:      sig.NodeID(),
+		Sequence:       100,
+		Coinbase:       types.Address{1, 2, 3},
+	}
+	require.NoError(t, atxs.AddCheckpointed(db, catx))
+	got, err := atxs.Get(db, catx.ID)
+	require.NoError(t, err)
+	require.Equal(t, catx.ID, got.ID())
+	require.Equal(t, catx.Epoch, got.PublishEpoch)
+	require.Equal(t, catx.CommitmentATX, *got.CommitmentATX)
+	require.Equal(t, catx.VRFNonce, *got.VRFNonce)
+	require.Equal(t, catx.NumUnits, got.NumUnits)
+	require.Equal(t, catx.NumUnits, got.EffectiveNumUnits())
+	require.Equal(t, catx.BaseTickHeight, got.BaseTickHeight())
+	require.Equal(t, catx.TickCount, got.TickCount())
+	require.Equal(t, catx.SmesherID, got.SmesherID)
+	require.Equal(t, catx.Sequence, got.Sequence)
+	require.Equal(t, catx.Coinbase, got.Coinbase)
+
+	_, err = atxs.GetBlob(db, catx.ID.Bytes())
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
@@ -0,0 +1,197 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"crawshaw.io/sqlite"
+)
+
+// restoreSuffix is appended to the path of the database to stage a backup for restore.
+const restoreSuffix = ".restore"
+
+// ErrInvalidBackup is returned if the backup fails the integrity check or its schema
+// version is not supported.
+var ErrInvalidBackup = errors.New("database: invalid backup")
+
+// Backup writes a consistent copy of the database to the file at path using the sqlite
+// backup api. The database remains available for reads and writes while the copy is made.
+//
+// The copy is written to a temporary file and verified before it is renamed to path,
+// so path contains either a complete backup or nothing. Existing file is not overwritten.
+func (db *Database) Backup(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup %s: %w", path, os.ErrExist)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("backup %s: %w", path, err)
+	}
+	tmp := path + ".tmp"
+	if err := removeDB(tmp); err != nil {
+		return err
+	}
+	if err := db.backup(ctx, tmp); err != nil {
+		removeDB(tmp)
+		return err
+	}
+	if _, err := Verify(tmp); err != nil {
+		removeDB(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		removeDB(tmp)
+		return fmt.Errorf("rename backup: %w", err)
+	}
+	return nil
+}
+
+func (db *Database) backup(ctx context.Context, path string) error {
+	conn := db.pool.Get(ctx)
+	if conn == nil {
+		return ErrNoConnection
+	}
+	defer db.pool.Put(conn)
+	// the copy keeps the header of the source, so the backup is in wal mode as well. it is still
+	// contained in a single file: the wal is checkpointed and removed when dst is closed.
+	dst, err := sqlite.OpenConn(path, sqlite.SQLITE_OPEN_READWRITE|sqlite.SQLITE_OPEN_CREATE)
+	if err != nil {
+		return fmt.Errorf("open backup %s: %w", path, err)
+	}
+	defer dst.Close()
+	b, err := conn.BackupInit("", "", dst)
+	if err != nil {
+		return fmt.Errorf("init backup: %w", err)
+	}
+	// the whole database is copied in a single step, it holds one read transaction on the source.
+	// copy that spans several steps is restarted every time the database is modified by
+	// another connection, and may never finish on a busy node.
+	if err := b.Step(-1); err != nil {
+		b.Finish()
+		return fmt.Errorf("copy database: %w", err)
+	}
+	if err := b.Finish(); err != nil {
+		return fmt.Errorf("finish backup: %w", err)
+	}
+	return nil
+}
+
+// Verify checks the integrity of the database file at path, and that the node supports its
+// schema version. It returns the schema version of the database.
+func Verify(path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("verify %s: %w", path, err)
+	}
+	conn, err := sqlite.OpenConn(path, sqlite.SQLITE_OPEN_READWRITE)
+	if err != nil {
+		return 0, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer conn.Close()
+
+	var problems []string
+	if _, err := exec(conn, "PRAGMA integrity_check;", nil, func(stmt *Statement) bool {
+		if result := stmt.ColumnText(0); result != "ok" {
+			problems = append(problems, result)
+		}
+		return true
+	}); err != nil {
+		return 0, fmt.Errorf("%w: integrity check of %s: %v", ErrInvalidBackup, path, err)
+	}
+	if len(problems) > 0 {
+		return 0, fmt.Errorf("%w: integrity check of %s: %s", ErrInvalidBackup, path, strings.Join(problems, "; "))
+	}
+
+	var version int
+	if _, err := exec(conn, "PRAGMA user_version;", nil, func(stmt *Statement) bool {
+		version = stmt.ColumnInt(0)
+		return true
+	}); err != nil {
+		return 0, fmt.Errorf("read user_version: %w", err)
+	}
+	supported, err := SchemaVersion()
+	if err != nil {
+		return 0, err
+	}
+	if version == 0 || version > supported {
+		return 0, fmt.Errorf("%w: schema version %d of %s, supported versions 1-%d",
+			ErrInvalidBackup, version, path, supported)
+	}
+	return version, nil
+}
+
+// StageRestore verifies the backup and copies it next to the database at path. The staged
+// backup replaces the database when it is opened next time with ApplyRestore.
+func StageRestore(backup, path string) error {
+	if _, err := Verify(backup); err != nil {
+		return err
+	}
+	src, err := os.Open(backup)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	staged := path + restoreSuffix
+	tmp := staged + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", tmp, err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("copy backup: %w", err)
+	}
+	if err := dst.Sync(); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("sync %s: %w", tmp, err)
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, staged); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("stage backup: %w", err)
+	}
+	return nil
+}
+
+// ApplyRestore replaces the database at path with the backup staged by StageRestore, if any.
+// It must be called before the database is opened. It returns true if the database was replaced.
+//
+// The staged backup is renamed over the database, so the database is either the old
+// or the restored one. If interrupted, the restore is completed by the next call.
+func ApplyRestore(path string) (bool, error) {
+	staged := path + restoreSuffix
+	if _, err := os.Stat(staged); errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if _, err := Verify(staged); err != nil {
+		return false, err
+	}
+	// wal of the old database must not be applied to the restored one
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return false, fmt.Errorf("remove %s: %w", path+suffix, err)
+		}
+	}
+	if err := os.Rename(staged, path); err != nil {
+		return false, fmt.Errorf("restore %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// removeDB removes the database file and its journals.
+func removeDB(path string) error {
+	for _, suffix := range []string{"", "-journal", "-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", path+suffix, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package sql
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func countRows(tb testing.TB, db *Database) int {
+	tb.Helper()
+	var count int
+	_, err := db.Exec("select count(*) from backup_test;", nil, func(stmt *Statement) bool {
+		count = stmt.ColumnInt(0)
+		return true
+	})
+	require.NoError(tb, err)
+	return count
+}
+
+func insertRow(tb testing.TB, db *Database, value int) {
+	tb.Helper()
+	_, err := db.Exec("insert into backup_test (value) values (?1);", func(stmt *Statement) {
+		stmt.BindInt64(1, int64(value))
+	}, nil)
+	require.NoError(tb, err)
+}
+
+func TestBackupRestore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.sql")
+	db, err := Open("file:" + path)
+	require.NoError(t, err)
+	_, err = db.Exec("create table backup_test (value INT);", nil, nil)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		insertRow(t, db, i)
+	}
+
+	backup := filepath.Join(dir, "backup.sql")
+	require.NoError(t, db.Backup(context.Background(), backup))
+	require.ErrorIs(t, db.Backup(context.Background(), backup), os.ErrExist)
+	_, err = os.Stat(backup + ".tmp")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(backup + "-wal")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	version, err := Verify(backup)
+	require.NoError(t, err)
+	expected, err := SchemaVersion()
+	require.NoError(t, err)
+	require.Equal(t, expected, version)
+
+	// changes after the backup are lost on restore
+	insertRow(t, db, 10)
+	require.Equal(t, 11, countRows(t, db))
+	require.NoError(t, db.Close())
+
+	restored, err := ApplyRestore(path)
+	require.NoError(t, err)
+	require.False(t, restored)
+
+	require.NoError(t, StageRestore(backup, path))
+	restored, err = ApplyRestore(path)
+	require.NoError(t, err)
+	require.True(t, restored)
+	_, err = os.Stat(path + restoreSuffix)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	db, err = Open("file:" + path)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	require.Equal(t, 10, countRows(t, db))
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("not a database", func(t *testing.T) {
+		path := filepath.Join(dir, "garbage.sql")
+		require.NoError(t, os.WriteFile(path, []byte("not a database, just some bytes"), 0o600))
+		_, err := Verify(path)
+		require.ErrorIs(t, err, ErrInvalidBackup)
+		require.ErrorIs(t, StageRestore(path, filepath.Join(dir, "state.sql")), ErrInvalidBackup)
+	})
+	t.Run("no schema", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.sql")
+		db, err := Open("file:"+path, WithMigrations(nil))
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+		_, err = Verify(path)
+		require.ErrorIs(t, err, ErrInvalidBackup)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := Verify(filepath.Join(dir, "missing.sql"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
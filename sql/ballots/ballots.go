@@ -71,10 +71,11 @@ func Has(db sql.Executor, id types.BallotID) (bool, error) {
 }
 
 // Get ballot with id from database.
+// Returns sql.ErrNotFound if the ballot is unknown or its body was pruned.
 func Get(db sql.Executor, id types.BallotID) (rst *types.Ballot, err error) {
 	if rows, err := db.Exec(`select pubkey, ballot, length(identities.proof)
 	from ballots left join identities using(pubkey)
-	where id = ?1;`,
+	where id = ?1 and ballot is not null;`,
 		func(stmt *sql.Statement) {
 			stmt.BindBytes(1, id.Bytes())
 		}, func(stmt *sql.Statement) bool {
@@ -92,11 +93,11 @@ func Get(db sql.Executor, id types.BallotID) (rst *types.Ballot, err error) {
 	return rst, nil
 }
 
-// Layer returns full body ballot for layer.
+// Layer returns full body ballot for layer. Ballots with pruned bodies are skipped.
 func Layer(db sql.Executor, lid types.LayerID) (rst []*types.Ballot, err error) {
 	if _, err = db.Exec(`select id, pubkey, ballot, length(identities.proof)
 		from ballots left join identities using(pubkey)
-		where layer = ?1;`, func(stmt *sql.Statement) {
+		where layer = ?1 and ballot is not null;`, func(stmt *sql.Statement) {
 		stmt.BindInt64(1, int64(lid))
 	}, func(stmt *sql.Statement) bool {
 		id := types.BallotID{}
@@ -118,9 +119,22 @@ func Layer(db sql.Executor, lid types.LayerID) (rst []*types.Ballot, err error)
 	return rst, err
 }
 
-// IDsInLayer returns ballots ids in the layer.
+// PruneBodies deletes bodies of the ballots before the layer. Ids of the ballots are kept.
+func PruneBodies(db sql.Executor, before types.LayerID) (int, error) {
+	rows, err := db.Exec("update ballots set ballot = null where layer < ?1 and ballot is not null returning id;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(before))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("prune ballots before %s: %w", before, err)
+	}
+	return rows, nil
+}
+
+// IDsInLayer returns ballots ids in the layer. Ids of the pruned ballots are not returned,
+// as their bodies can't be served.
 func IDsInLayer(db sql.Executor, lid types.LayerID) (rst []types.BallotID, err error) {
-	if _, err := db.Exec("select id from ballots where layer = ?1;", func(stmt *sql.Statement) {
+	if _, err := db.Exec("select id from ballots where layer = ?1 and ballot is not null;", func(stmt *sql.Statement) {
 		stmt.BindInt64(1, int64(lid.Uint32()))
 	}, func(stmt *sql.Statement) bool {
 		id := types.BallotID{}
@@ -176,7 +190,7 @@ func LayerBallotByNodeID(db sql.Executor, lid types.LayerID, nodeID types.NodeID
 	}
 	if rows, err := db.Exec(`
 		select id, ballot from ballots
-		where layer = ?1 and pubkey = ?2
+		where layer = ?1 and pubkey = ?2 and ballot is not null
 		limit 1;`, enc, dec); err != nil {
 		return nil, fmt.Errorf("same layer ballot %v: %w", lid, err)
 	} else if rows == 0 {
@@ -254,8 +268,10 @@ func FirstInEpoch(db sql.Executor, atx types.ATXID, epoch types.EpochID) (*types
 		return true
 	}
 	rows, err = db.Exec(`
-		select id, pubkey, ballot from ballots where atx = ?1 and layer between ?2 and ?3
-		order by layer asc, id asc limit 1;`, enc, dec)
+		select id, pubkey, ballot from (
+			select id, pubkey, ballot from ballots where atx = ?1 and layer between ?2 and ?3
+			order by layer asc, id asc limit 1
+		) where ballot is not null;`, enc, dec)
 	if err != nil {
 		return nil, fmt.Errorf("ballot by atx %s: %w", atx, err)
 	}
@@ -291,8 +307,10 @@ func AllFirstInEpoch(db sql.Executor, epoch types.EpochID) ([]*types.Ballot, err
 		return true
 	}
 	if _, err := db.Exec(`
-		select id, ballot, min(layer) from ballots where layer between ?1 and ?2
-		group by pubkey;`, enc, dec); err != nil {
+		select id, ballot from (
+			select id, ballot, min(layer) from ballots where layer between ?1 and ?2
+			group by pubkey
+		) where ballot is not null;`, enc, dec); err != nil {
 		return nil, fmt.Errorf("query first ballots in epoch %d: %w", epoch, err)
 	}
 	if err != nil {
@@ -89,6 +89,63 @@ func TestHas(t *testing.T) {
 	require.True(t, exists)
 }
 
+func TestPruneBodies(t *testing.T) {
+	db := sql.InMemory()
+	pub := types.RandomNodeID()
+	var all []types.Ballot
+	for lid := types.LayerID(1); lid <= 4; lid++ {
+		ballot := types.NewExistingBallot(types.BallotID{byte(lid)}, types.RandomEdSignature(), pub, lid)
+		require.NoError(t, Add(db, &ballot))
+		all = append(all, ballot)
+	}
+	pruned, err := PruneBodies(db, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+	pruned, err = PruneBodies(db, 3)
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+
+	for i, ballot := range all {
+		exists, err := Has(db, ballot.ID())
+		require.NoError(t, err)
+		require.True(t, exists)
+		rst, err := Layer(db, ballot.Layer)
+		require.NoError(t, err)
+		ids, err := IDsInLayer(db, ballot.Layer)
+		require.NoError(t, err)
+		byNode, byNodeErr := LayerBallotByNodeID(db, ballot.Layer, pub)
+		stored, err := Get(db, ballot.ID())
+		if ballot.Layer < 3 {
+			require.ErrorIs(t, err, sql.ErrNotFound)
+			require.ErrorIs(t, byNodeErr, sql.ErrNotFound)
+			require.Empty(t, rst)
+			require.Empty(t, ids)
+		} else {
+			require.NoError(t, err)
+			require.NoError(t, byNodeErr)
+			require.Equal(t, &all[i], stored)
+			require.Equal(t, []*types.Ballot{&all[i]}, rst)
+			require.Equal(t, []types.BallotID{ballot.ID()}, ids)
+			require.Equal(t, ballot.ID(), byNode.ID())
+		}
+	}
+
+	// the first ballot in epoch 0 is pruned, the first ballot in epoch 1 is not
+	_, err = FirstInEpoch(db, types.EmptyATXID, 0)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	first, err := FirstInEpoch(db, types.EmptyATXID, 1)
+	require.NoError(t, err)
+	require.Equal(t, all[2].ID(), first.ID())
+
+	firsts, err := AllFirstInEpoch(db, 0)
+	require.NoError(t, err)
+	require.Empty(t, firsts)
+	firsts, err = AllFirstInEpoch(db, 1)
+	require.NoError(t, err)
+	require.Len(t, firsts, 1)
+	require.Equal(t, all[2].ID(), firsts[0].ID())
+}
+
 func TestLatest(t *testing.T) {
 	db := sql.InMemory()
 	latest, err := LatestLayer(db)
@@ -147,3 +147,16 @@ func SetInvalid(db sql.Executor, lid types.LayerID, bid types.BlockID) error {
 	}
 	return nil
 }
+
+// PruneBefore drops certificates from layers before the specified layer.
+// Hare output and validity of the blocks are kept.
+func PruneBefore(db sql.Executor, lid types.LayerID) (int, error) {
+	rows, err := db.Exec(`update certificates set cert = null where layer < ?1 and cert is not null returning layer;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("prune certs before %s: %w", lid, err)
+	}
+	return rows, nil
+}
@@ -93,6 +93,26 @@ func TestCertificates(t *testing.T) {
 	require.False(t, got[1].Valid)
 }
 
+func TestPruneBefore(t *testing.T) {
+	db := sql.InMemory()
+	for lid := types.LayerID(1); lid <= 3; lid++ {
+		require.NoError(t, Add(db, lid, makeCert(lid, types.BlockID{byte(lid)})))
+	}
+	pruned, err := PruneBefore(db, types.LayerID(3))
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+
+	for lid := types.LayerID(1); lid <= 3; lid++ {
+		got, err := Get(db, lid)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, lid == 3, got[0].Cert != nil)
+		hare, err := GetHareOutput(db, lid)
+		require.NoError(t, err)
+		require.Equal(t, types.BlockID{byte(lid)}, hare)
+	}
+}
+
 func TestHareOutput(t *testing.T) {
 	db := sql.InMemory()
 	lid := types.LayerID(10)
@@ -0,0 +1,75 @@
+package journal
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Add persists encoded event with the cursor.
+func Add(db sql.Executor, cursor uint64, data []byte) error {
+	if _, err := db.Exec("insert into events_journal (cursor, data) values (?1, ?2);",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(cursor))
+			stmt.BindBytes(2, data)
+		}, nil); err != nil {
+		return fmt.Errorf("add event %d: %w", cursor, err)
+	}
+	return nil
+}
+
+// Last returns the cursor of the latest event. Returns 0 if journal is empty.
+func Last(db sql.Executor) (uint64, error) {
+	var last uint64
+	if _, err := db.Exec("select max(cursor) from events_journal;", nil,
+		func(stmt *sql.Statement) bool {
+			last = uint64(stmt.ColumnInt64(0))
+			return false
+		}); err != nil {
+		return 0, fmt.Errorf("last event: %w", err)
+	}
+	return last, nil
+}
+
+// First returns the cursor of the oldest event. Returns 0 if journal is empty.
+func First(db sql.Executor) (uint64, error) {
+	var first uint64
+	if _, err := db.Exec("select min(cursor) from events_journal;", nil,
+		func(stmt *sql.Statement) bool {
+			first = uint64(stmt.ColumnInt64(0))
+			return false
+		}); err != nil {
+		return 0, fmt.Errorf("first event: %w", err)
+	}
+	return first, nil
+}
+
+// Iterate calls fn for up to limit events with cursor in the range (from, to], in order.
+// Iteration stops early if fn returns false.
+func Iterate(db sql.Executor, from, to uint64, limit int, fn func(cursor uint64, data []byte) bool) error {
+	if _, err := db.Exec(`select cursor, data from events_journal
+		where cursor > ?1 and cursor <= ?2 order by cursor limit ?3;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(from))
+			stmt.BindInt64(2, int64(to))
+			stmt.BindInt64(3, int64(limit))
+		}, func(stmt *sql.Statement) bool {
+			data := make([]byte, stmt.ColumnLen(1))
+			stmt.ColumnBytes(1, data)
+			return fn(uint64(stmt.ColumnInt64(0)), data)
+		}); err != nil {
+		return fmt.Errorf("iterate events (%d, %d]: %w", from, to, err)
+	}
+	return nil
+}
+
+// Prune deletes events with cursor lower or equal to the given cursor.
+func Prune(db sql.Executor, cursor uint64) error {
+	if _, err := db.Exec("delete from events_journal where cursor <= ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(cursor))
+		}, nil); err != nil {
+		return fmt.Errorf("prune events up to %d: %w", cursor, err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package journal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestJournal(t *testing.T) {
+	db := sql.InMemory()
+
+	last, err := Last(db)
+	require.NoError(t, err)
+	require.Zero(t, last)
+
+	for cursor := uint64(1); cursor <= 10; cursor++ {
+		require.NoError(t, Add(db, cursor, []byte{byte(cursor)}))
+	}
+	last, err = Last(db)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), last)
+
+	var cursors []uint64
+	require.NoError(t, Iterate(db, 3, 8, 3, func(cursor uint64, data []byte) bool {
+		require.Equal(t, []byte{byte(cursor)}, data)
+		cursors = append(cursors, cursor)
+		return true
+	}))
+	require.Equal(t, []uint64{4, 5, 6}, cursors)
+
+	require.NoError(t, Prune(db, 4))
+	first, err := First(db)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), first)
+	require.Error(t, Add(db, 10, []byte{1}))
+}
@@ -84,6 +84,22 @@ func UpdateStateHash(db sql.Executor, lid types.LayerID, hash types.Hash32) erro
 	return nil
 }
 
+// StateHashLayers returns layers that have a state hash, starting from the layer, in ascending order.
+func StateHashLayers(db sql.Executor, from types.LayerID) ([]types.LayerID, error) {
+	var rst []types.LayerID
+	if _, err := db.Exec("select id from layers where state_hash is not null and id >= ?1 order by id asc;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(from))
+		},
+		func(stmt *sql.Statement) bool {
+			rst = append(rst, types.LayerID(uint32(stmt.ColumnInt64(0))))
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("failed to load layers with state hash: %w", err)
+	}
+	return rst, nil
+}
+
 // GetLatestStateHash loads latest state hash.
 func GetLatestStateHash(db sql.Executor) (rst types.Hash32, err error) {
 	if rows, err := db.Exec("select state_hash from layers where state_hash is not null;",
@@ -1,87 +1,536 @@
 package sql
 
 import (
-	"bufio"
-	"bytes"
+	"context"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"path"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 //go:embed migrations/*.sql
 var embedded embed.FS
 
-type migration struct {
-	order   int
-	name    string
-	content *bufio.Scanner
-}
+var (
+	// ErrChecksumMismatch is returned if the applied migration differs from the migration
+	// known to the node.
+	ErrChecksumMismatch = errors.New("database: migration checksum mismatch")
+	// ErrUnknownMigration is returned if the database has a migration applied that is not known
+	// to the node, e.g. the database was migrated by a newer version of the node.
+	ErrUnknownMigration = errors.New("database: unknown migration")
+	// ErrIrreversible is returned if the migration can't be rolled back.
+	ErrIrreversible = errors.New("database: migration can't be rolled back")
+	// ErrNoMigrations is returned when rolling back a database without applied migrations.
+	ErrNoMigrations = errors.New("database: no applied migrations")
+)
+
+// goMigrations are go coded migrations of the state database, e.g. transforms of the data
+// that can't be expressed in sql. They are ordered together with the embedded scripts.
+// The list is static, so that the schema version doesn't depend on the packages linked
+// into the binary.
+var goMigrations = MigrationList{}
 
 // Migrations is interface for migrations provider.
 type Migrations func(Executor) error
 
-func embeddedMigrations(db Executor) error {
+// Migration is a numbered change of the schema or the data of the database.
+type Migration interface {
+	// Order is the version of the schema after the migration is applied.
+	Order() int
+	Name() string
+	// Checksum identifies the content of the migration. It is recorded when the migration
+	// is applied, and the database is not opened if the content of the applied migration changed.
+	Checksum() string
+	Apply(Executor) error
+	// Rollback reverts the migration. ErrIrreversible is returned if it can't be reverted.
+	Rollback(Executor) error
+}
+
+// NewSQLMigration creates a migration from the up script and the optional down script.
+// Statements of the scripts are separated by semicolons.
+func NewSQLMigration(order int, name, up, down string) Migration {
+	return &sqlMigration{order: order, name: name, up: up, down: down}
+}
+
+type sqlMigration struct {
+	order    int
+	name     string
+	up, down string
+}
+
+func (m *sqlMigration) Order() int {
+	return m.order
+}
+
+func (m *sqlMigration) Name() string {
+	return m.name
+}
+
+func (m *sqlMigration) Checksum() string {
+	hash := sha256.Sum256([]byte(m.up))
+	return hex.EncodeToString(hash[:])
+}
+
+func (m *sqlMigration) Apply(db Executor) error {
+	return execScript(db, m.up)
+}
+
+func (m *sqlMigration) Rollback(db Executor) error {
+	if m.down == "" {
+		return ErrIrreversible
+	}
+	return execScript(db, m.down)
+}
+
+func execScript(db Executor, script string) error {
+	for _, stmt := range strings.SplitAfter(script, ";") {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		if _, err := db.Exec(stmt, nil, nil); err != nil {
+			return fmt.Errorf("exec %s: %w", stmt, err)
+		}
+	}
+	return nil
+}
+
+// GoMigration is a migration coded in go.
+//
+// The checksum of the migration is derived from its version and description,
+// the description must be changed if the migration is changed after release.
+type GoMigration struct {
+	Version     int
+	Description string
+	Up          func(Executor) error
+	// Down is nil if the migration can't be reverted.
+	Down func(Executor) error
+}
+
+func (m *GoMigration) Order() int {
+	return m.Version
+}
+
+func (m *GoMigration) Name() string {
+	return m.Description
+}
+
+func (m *GoMigration) Checksum() string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("go:%d:%s", m.Version, m.Description)))
+	return hex.EncodeToString(hash[:])
+}
+
+func (m *GoMigration) Apply(db Executor) error {
+	return m.Up(db)
+}
+
+func (m *GoMigration) Rollback(db Executor) error {
+	if m.Down == nil {
+		return ErrIrreversible
+	}
+	return m.Down(db)
+}
+
+// MigrationState is the state of the migration in the database.
+type MigrationState string
+
+const (
+	// MigrationPending is not applied yet.
+	MigrationPending MigrationState = "pending"
+	// MigrationApplied is applied with the same content as the migration known to the node.
+	MigrationApplied MigrationState = "applied"
+	// MigrationMismatch is applied with a different content than the migration known to the node.
+	MigrationMismatch MigrationState = "checksum mismatch"
+	// MigrationUnknown is applied but not known to the node.
+	MigrationUnknown MigrationState = "unknown"
+)
+
+// MigrationStatus is the status of a migration in the database.
+type MigrationStatus struct {
+	Version  int
+	Name     string
+	Checksum string
+	State    MigrationState
+	// AppliedAt is zero if the migration is pending, or if it was applied before versions
+	// of the schema were recorded.
+	AppliedAt time.Time
+}
+
+// MigrationList is a list of migrations ordered by version.
+type MigrationList []Migration
+
+// StateMigrations returns the migrations of the state database: the embedded
+// up and down scripts and the go coded migrations.
+func StateMigrations() (MigrationList, error) {
 	files, err := embedded.ReadDir("migrations")
 	if err != nil {
-		return fmt.Errorf("readdir migrations: %w", err)
+		return nil, fmt.Errorf("readdir migrations: %w", err)
 	}
-	var migrations []migration
+	scripts := map[int]*sqlMigration{}
 	for _, file := range files {
-		parts := strings.Split(file.Name(), "_")
-		if len(parts) < 1 {
-			return fmt.Errorf("invalid migration %s", file.Name())
+		var (
+			isDown bool
+			base   string
+		)
+		switch {
+		case strings.HasSuffix(file.Name(), ".up.sql"):
+			base = strings.TrimSuffix(file.Name(), ".up.sql")
+		case strings.HasSuffix(file.Name(), ".down.sql"):
+			base, isDown = strings.TrimSuffix(file.Name(), ".down.sql"), true
+		default:
+			return nil, fmt.Errorf("invalid migration %s: must end with .up.sql or .down.sql", file.Name())
+		}
+		prefix, name, found := strings.Cut(base, "_")
+		if !found {
+			return nil, fmt.Errorf("invalid migration %s", file.Name())
 		}
-		order, err := strconv.Atoi(parts[0])
+		order, err := strconv.Atoi(prefix)
 		if err != nil {
-			return fmt.Errorf("invalid migration %s: %w", file.Name(), err)
+			return nil, fmt.Errorf("invalid migration %s: %w", file.Name(), err)
 		}
 		fpath := path.Join("migrations", file.Name())
 		content, err := embedded.ReadFile(fpath)
 		if err != nil {
-			return fmt.Errorf("readfile %s: %w", fpath, err)
+			return nil, fmt.Errorf("readfile %s: %w", fpath, err)
 		}
-		scanner := bufio.NewScanner(bytes.NewBuffer(content))
-		scanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
-			if i := bytes.Index(data, []byte(";")); i >= 0 {
-				return i + 1, data[0 : i+1], nil
-			}
-			return 0, nil, nil
-		})
-		migrations = append(migrations, migration{
-			order:   order,
-			name:    file.Name(),
-			content: scanner,
-		})
+		m, exists := scripts[order]
+		if !exists {
+			m = &sqlMigration{order: order, name: name}
+			scripts[order] = m
+		} else if m.name != name {
+			return nil, fmt.Errorf("migration %d has different names %s and %s", order, m.name, name)
+		}
+		if isDown {
+			m.down = string(content)
+		} else {
+			m.up = string(content)
+		}
+	}
+	migrations := make(MigrationList, 0, len(scripts)+len(goMigrations))
+	for _, m := range scripts {
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up script", m.order, m.name)
+		}
+		migrations = append(migrations, m)
 	}
+	migrations = append(migrations, goMigrations...)
 	sort.Slice(migrations, func(i, j int) bool {
-		return migrations[i].order < migrations[j].order
+		return migrations[i].Order() < migrations[j].Order()
 	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Order() == migrations[i-1].Order() {
+			return nil, fmt.Errorf("duplicate migration %d", migrations[i].Order())
+		}
+	}
+	return migrations, nil
+}
 
-	var current int
+// SchemaVersion returns the version of the schema after all migrations of the state database are applied.
+func SchemaVersion() (int, error) {
+	migrations, err := StateMigrations()
+	if err != nil {
+		return 0, err
+	}
+	return migrations.Version(), nil
+}
 
-	if _, err := db.Exec("PRAGMA user_version;", nil, func(stmt *Statement) bool {
-		current = stmt.ColumnInt(0)
-		return true
-	}); err != nil {
-		return fmt.Errorf("read user_version %w", err)
+func embeddedMigrations(db Executor) error {
+	migrations, err := StateMigrations()
+	if err != nil {
+		return err
+	}
+	return migrations.Apply(db)
+}
+
+// Version returns the version of the schema after all migrations are applied.
+func (l MigrationList) Version() int {
+	if len(l) == 0 {
+		return 0
+	}
+	return l[len(l)-1].Order()
+}
+
+func (l MigrationList) find(version int) Migration {
+	i := sort.Search(len(l), func(i int) bool { return l[i].Order() >= version })
+	if i < len(l) && l[i].Order() == version {
+		return l[i]
 	}
+	return nil
+}
 
-	for _, m := range migrations {
-		if m.order <= current {
+// Apply applies pending migrations. It returns an error if any of the applied migrations
+// is not in the list or has a different checksum.
+//
+// It should be executed in a transaction, so that the database is left untouched if any
+// of the migrations fails.
+func (l MigrationList) Apply(db Executor) error {
+	if err := l.ensureVersions(db); err != nil {
+		return err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+	if err := l.check(applied); err != nil {
+		return err
+	}
+	pending := 0
+	for _, m := range l {
+		if _, exists := applied[m.Order()]; exists {
 			continue
 		}
-		for m.content.Scan() {
-			if _, err := db.Exec(m.content.Text(), nil, nil); err != nil {
-				return fmt.Errorf("exec %s: %w", m.content.Text(), err)
+		if err := m.Apply(db); err != nil {
+			return fmt.Errorf("apply migration %04d_%s: %w", m.Order(), m.Name(), err)
+		}
+		if err := addVersion(db, m, time.Now()); err != nil {
+			return err
+		}
+		pending++
+	}
+	if pending == 0 {
+		return nil
+	}
+	// user_version is kept for the versions of the node that don't record versions of the schema
+	return setUserVersion(db, l.Version())
+}
+
+// Status returns the status of all migrations in the list and migrations applied to the database
+// that are not in the list. It doesn't modify the database.
+func (l MigrationList) Status(db Executor) ([]MigrationStatus, error) {
+	exists, err := hasVersionsTable(db)
+	if err != nil {
+		return nil, err
+	}
+	applied := map[int]MigrationStatus{}
+	if exists {
+		if applied, err = appliedMigrations(db); err != nil {
+			return nil, err
+		}
+	} else {
+		// versions are recorded when the database is migrated next time
+		version, err := userVersion(db)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range l {
+			if m.Order() <= version {
+				applied[m.Order()] = MigrationStatus{Version: m.Order(), Name: m.Name(), Checksum: m.Checksum()}
+			}
+		}
+		for v := l.Version() + 1; v <= version; v++ {
+			applied[v] = MigrationStatus{Version: v}
+		}
+	}
+	statuses := make([]MigrationStatus, 0, len(l)+len(applied))
+	for _, m := range l {
+		status := MigrationStatus{Version: m.Order(), Name: m.Name(), Checksum: m.Checksum(), State: MigrationPending}
+		if record, exists := applied[m.Order()]; exists {
+			status.AppliedAt = record.AppliedAt
+			status.State = MigrationApplied
+			if record.Checksum != m.Checksum() {
+				status.State = MigrationMismatch
 			}
 		}
-		// binding values in pragma statement is not allowed
-		if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d;", m.order), nil, nil); err != nil {
-			return fmt.Errorf("update user_version to %d: %w", m.order, err)
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		if l.find(record.Version) == nil {
+			record.State = MigrationUnknown
+			statuses = append(statuses, record)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// DryRun applies pending migrations in a transaction that is rolled back. It returns
+// the migrations that would be applied, and the error if any of them fails.
+func (l MigrationList) DryRun(db *Database) ([]MigrationStatus, error) {
+	statuses, err := l.Status(db)
+	if err != nil {
+		return nil, err
+	}
+	var pending []MigrationStatus
+	for _, status := range statuses {
+		if status.State == MigrationPending {
+			pending = append(pending, status)
+		}
+	}
+	tx, err := db.Tx(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Release()
+	return pending, l.Apply(tx)
+}
+
+// Rollback reverts the latest applied migration. The database is left untouched if
+// the migration fails to revert.
+func (l MigrationList) Rollback(db *Database) (*MigrationStatus, error) {
+	var reverted *MigrationStatus
+	if err := db.WithTxImmediate(context.Background(), func(tx *Tx) error {
+		if err := l.ensureVersions(tx); err != nil {
+			return err
+		}
+		applied, err := appliedMigrations(tx)
+		if err != nil {
+			return err
+		}
+		if err := l.check(applied); err != nil {
+			return err
 		}
+		versions := make([]int, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		if len(versions) == 0 {
+			return ErrNoMigrations
+		}
+		sort.Ints(versions)
+		latest := versions[len(versions)-1]
+		m := l.find(latest)
+		if err := m.Rollback(tx); err != nil {
+			return fmt.Errorf("rollback migration %04d_%s: %w", m.Order(), m.Name(), err)
+		}
+		if _, err := tx.Exec("delete from schema_version where version = ?1;", func(stmt *Statement) {
+			stmt.BindInt64(1, int64(latest))
+		}, nil); err != nil {
+			return fmt.Errorf("delete version %d: %w", latest, err)
+		}
+		previous := 0
+		if len(versions) > 1 {
+			previous = versions[len(versions)-2]
+		}
+		if err := setUserVersion(tx, previous); err != nil {
+			return err
+		}
+		status := applied[latest]
+		status.State = MigrationPending
+		reverted = &status
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// check returns an error if any of the applied migrations is not in the list or has a different checksum.
+func (l MigrationList) check(applied map[int]MigrationStatus) error {
+	for version, record := range applied {
+		m := l.find(version)
+		if m == nil {
+			return fmt.Errorf("%w: version %d (%s)", ErrUnknownMigration, version, record.Name)
+		}
+		if m.Checksum() != record.Checksum {
+			return fmt.Errorf("%w: version %d (%s)", ErrChecksumMismatch, version, m.Name())
+		}
+	}
+	return nil
+}
+
+// ensureVersions creates the table with applied migrations. If the table doesn't exist, migrations
+// up to the user_version of the database are recorded as applied, as they were applied before
+// the versions were recorded.
+func (l MigrationList) ensureVersions(db Executor) error {
+	exists, err := hasVersionsTable(db)
+	if err != nil || exists {
+		return err
+	}
+	version, err := userVersion(db)
+	if err != nil {
+		return err
+	}
+	if version > l.Version() {
+		return fmt.Errorf("%w: database version %d, latest known %d", ErrUnknownMigration, version, l.Version())
+	}
+	if _, err := db.Exec(`create table schema_version
+	(
+		version  INT PRIMARY KEY,
+		name     TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied  INT NOT NULL
+	) WITHOUT ROWID;`, nil, nil); err != nil {
+		return fmt.Errorf("create schema_version: %w", err)
+	}
+	for _, m := range l {
+		if m.Order() > version {
+			break
+		}
+		if err := addVersion(db, m, time.Time{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasVersionsTable(db Executor) (bool, error) {
+	rows, err := db.Exec("select 1 from sqlite_master where type = 'table' and name = 'schema_version';", nil, nil)
+	if err != nil {
+		return false, fmt.Errorf("check schema_version: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func appliedMigrations(db Executor) (map[int]MigrationStatus, error) {
+	applied := map[int]MigrationStatus{}
+	if _, err := db.Exec("select version, name, checksum, applied from schema_version;", nil,
+		func(stmt *Statement) bool {
+			status := MigrationStatus{
+				Version:  int(stmt.ColumnInt64(0)),
+				Name:     stmt.ColumnText(1),
+				Checksum: stmt.ColumnText(2),
+				State:    MigrationApplied,
+			}
+			if at := stmt.ColumnInt64(3); at != 0 {
+				status.AppliedAt = time.Unix(at, 0)
+			}
+			applied[status.Version] = status
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("read schema_version: %w", err)
+	}
+	return applied, nil
+}
+
+func addVersion(db Executor, m Migration, applied time.Time) error {
+	var at int64
+	if !applied.IsZero() {
+		at = applied.Unix()
+	}
+	if _, err := db.Exec("insert into schema_version (version, name, checksum, applied) values (?1, ?2, ?3, ?4);",
+		func(stmt *Statement) {
+			stmt.BindInt64(1, int64(m.Order()))
+			stmt.BindText(2, m.Name())
+			stmt.BindText(3, m.Checksum())
+			stmt.BindInt64(4, at)
+		}, nil); err != nil {
+		return fmt.Errorf("record version %d: %w", m.Order(), err)
+	}
+	return nil
+}
+
+func userVersion(db Executor) (int, error) {
+	var current int
+	if _, err := db.Exec("PRAGMA user_version;", nil, func(stmt *Statement) bool {
+		current = stmt.ColumnInt(0)
+		return true
+	}); err != nil {
+		return 0, fmt.Errorf("read user_version %w", err)
+	}
+	return current, nil
+}
+
+func setUserVersion(db Executor, version int) error {
+	// binding values in pragma statement is not allowed
+	if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d;", version), nil, nil); err != nil {
+		return fmt.Errorf("update user_version to %d: %w", version, err)
 	}
 	return nil
 }
@@ -0,0 +1,6 @@
+CREATE TABLE recovery
+(
+    id         VARCHAR PRIMARY KEY,
+    restore    INT NOT NULL,
+    received   INT NOT NULL
+) WITHOUT ROWID;
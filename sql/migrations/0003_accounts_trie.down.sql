@@ -0,0 +1,2 @@
+DROP INDEX accounts_trie_by_layer;
+DROP TABLE accounts_trie;
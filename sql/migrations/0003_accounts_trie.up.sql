@@ -0,0 +1,10 @@
+CREATE TABLE accounts_trie
+(
+    depth  INT NOT NULL,
+    prefix BLOB NOT NULL,
+    layer  INT NOT NULL,
+    hash   CHAR(32) NOT NULL,
+    leaf   CHAR(32),
+    PRIMARY KEY (depth, prefix, layer DESC)
+) WITHOUT ROWID;
+CREATE INDEX accounts_trie_by_layer ON accounts_trie (layer);
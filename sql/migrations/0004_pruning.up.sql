@@ -0,0 +1,5 @@
+CREATE TABLE pruning
+(
+    id     INT PRIMARY KEY CHECK (id = 1),
+    layer  INT NOT NULL
+) WITHOUT ROWID;
@@ -0,0 +1 @@
+DROP TABLE tortoise_snapshots;
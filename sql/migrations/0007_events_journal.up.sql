@@ -0,0 +1,5 @@
+CREATE TABLE events_journal
+(
+    cursor INTEGER PRIMARY KEY,
+    data   BLOB NOT NULL
+);
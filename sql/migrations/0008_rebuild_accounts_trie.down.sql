@@ -0,0 +1 @@
+DELETE FROM accounts_trie;
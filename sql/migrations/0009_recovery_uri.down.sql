@@ -0,0 +1 @@
+ALTER TABLE recovery DROP COLUMN uri;
@@ -0,0 +1 @@
+ALTER TABLE recovery ADD COLUMN uri VARCHAR;
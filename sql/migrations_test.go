@@ -1,6 +1,9 @@
 package sql
 
 import (
+	"context"
+	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,5 +18,240 @@ func TestMigrationsAppliedOnce(t *testing.T) {
 		return true
 	})
 	require.NoError(t, err)
-	require.Equal(t, version, 1)
+	require.Equal(t, version, 9)
+}
+
+func openWith(tb testing.TB, path string, migrations MigrationList) (*Database, error) {
+	tb.Helper()
+	var opt Opt = WithMigrations(nil)
+	if migrations != nil {
+		opt = WithMigrations(migrations.Apply)
+	}
+	db, err := Open("file:"+path, opt)
+	if err == nil {
+		tb.Cleanup(func() { db.Close() })
+	}
+	return db, err
+}
+
+func testMigrations() MigrationList {
+	return MigrationList{
+		NewSQLMigration(1, "first", "create table first (id INT);", "drop table first;"),
+		&GoMigration{
+			Version:     2,
+			Description: "fill first",
+			Up: func(db Executor) error {
+				for i := 0; i < 3; i++ {
+					if _, err := db.Exec("insert into first (id) values (?1);", func(stmt *Statement) {
+						stmt.BindInt64(1, int64(i))
+					}, nil); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			Down: func(db Executor) error {
+				_, err := db.Exec("delete from first;", nil, nil)
+				return err
+			},
+		},
+		NewSQLMigration(3, "second", "create table second (id INT); create index second_by_id on second (id);", ""),
+	}
+}
+
+func states(statuses []MigrationStatus) []MigrationState {
+	var rst []MigrationState
+	for _, status := range statuses {
+		rst = append(rst, status.State)
+	}
+	return rst
+}
+
+func TestMigrations_Status(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sql")
+	migrations := testMigrations()
+	db, err := openWith(t, path, migrations[:2])
+	require.NoError(t, err)
+
+	statuses, err := migrations.Status(db)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	require.Equal(t, []MigrationState{MigrationApplied, MigrationApplied, MigrationPending}, states(statuses))
+	require.Equal(t, "fill first", statuses[1].Name)
+	require.Equal(t, migrations[1].Checksum(), statuses[1].Checksum)
+	require.False(t, statuses[1].AppliedAt.IsZero())
+	require.True(t, statuses[2].AppliedAt.IsZero())
+
+	statuses, err = migrations[:1].Status(db)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationState{MigrationApplied, MigrationUnknown}, states(statuses))
+}
+
+func TestMigrations_RecordsLegacyVersions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sql")
+	migrations := testMigrations()
+	db, err := openWith(t, path, nil)
+	require.NoError(t, err)
+	// migrated by the node that doesn't record versions
+	require.NoError(t, migrations[0].Apply(db))
+	require.NoError(t, migrations[1].Apply(db))
+	require.NoError(t, setUserVersion(db, 2))
+
+	statuses, err := migrations.Status(db)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationState{MigrationApplied, MigrationApplied, MigrationPending}, states(statuses))
+	require.True(t, statuses[0].AppliedAt.IsZero())
+
+	db, err = openWith(t, path, migrations)
+	require.NoError(t, err)
+	statuses, err = migrations.Status(db)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationState{MigrationApplied, MigrationApplied, MigrationApplied}, states(statuses))
+	require.True(t, statuses[1].AppliedAt.IsZero())
+	require.False(t, statuses[2].AppliedAt.IsZero())
+
+	var count int
+	_, err = db.Exec("select count(*) from first;", nil, func(stmt *Statement) bool {
+		count = stmt.ColumnInt(0)
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+}
+
+func TestMigrations_FailedLeavesDatabaseUntouched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sql")
+	migrations := testMigrations()
+	failing := append(migrations[:2:2], &GoMigration{
+		Version:     3,
+		Description: "failing",
+		Up: func(db Executor) error {
+			return errors.New("test")
+		},
+	})
+	_, err := openWith(t, path, failing)
+	require.ErrorContains(t, err, "failing")
+
+	db, err := openWith(t, path, nil)
+	require.NoError(t, err)
+	statuses, err := migrations.Status(db)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationState{MigrationPending, MigrationPending, MigrationPending}, states(statuses))
+	exists, err := hasVersionsTable(db)
+	require.NoError(t, err)
+	require.False(t, exists)
+	rows, err := db.Exec("select 1 from sqlite_master where name = 'first';", nil, nil)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
+
+func TestMigrations_Checks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sql")
+	migrations := testMigrations()
+	_, err := openWith(t, path, migrations)
+	require.NoError(t, err)
+
+	changed := append(migrations[:2:2], NewSQLMigration(3, "second", "create table second (id TEXT);", ""))
+	_, err = openWith(t, path, changed)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	_, err = openWith(t, path, migrations[:2])
+	require.ErrorIs(t, err, ErrUnknownMigration)
+
+	db, err := openWith(t, path, nil)
+	require.NoError(t, err)
+	statuses, err := changed.Status(db)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationState{MigrationApplied, MigrationApplied, MigrationMismatch}, states(statuses))
+}
+
+func TestMigrations_DryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sql")
+	migrations := testMigrations()
+	_, err := openWith(t, path, migrations[:1])
+	require.NoError(t, err)
+
+	db, err := openWith(t, path, nil)
+	require.NoError(t, err)
+	pending, err := migrations.DryRun(db)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	require.Equal(t, 2, pending[0].Version)
+	require.Equal(t, 3, pending[1].Version)
+
+	statuses, err := migrations.Status(db)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationState{MigrationApplied, MigrationPending, MigrationPending}, states(statuses))
+
+	failing := append(migrations[:1:1], &GoMigration{
+		Version:     2,
+		Description: "failing",
+		Up: func(db Executor) error {
+			return errors.New("test")
+		},
+	})
+	pending, err = failing.DryRun(db)
+	require.ErrorContains(t, err, "failing")
+	require.Len(t, pending, 1)
+}
+
+func TestMigrations_Rollback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sql")
+	migrations := testMigrations()
+	db, err := openWith(t, path, migrations)
+	require.NoError(t, err)
+
+	_, err = migrations.Rollback(db)
+	require.ErrorIs(t, err, ErrIrreversible)
+	statuses, err := migrations.Status(db)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationState{MigrationApplied, MigrationApplied, MigrationApplied}, states(statuses))
+
+	reversible := append(migrations[:2:2],
+		NewSQLMigration(3, "second", "create table second (id INT); create index second_by_id on second (id);",
+			"drop index second_by_id; drop table second;"))
+	db, err = openWith(t, path, nil)
+	require.NoError(t, err)
+	for _, version := range []int{3, 2, 1} {
+		reverted, err := reversible.Rollback(db)
+		require.NoError(t, err)
+		require.Equal(t, version, reverted.Version)
+		current, err := userVersion(db)
+		require.NoError(t, err)
+		require.Equal(t, version-1, current)
+	}
+	_, err = reversible.Rollback(db)
+	require.ErrorIs(t, err, ErrNoMigrations)
+	rows, err := db.Exec("select 1 from sqlite_master where name in ('first', 'second');", nil, nil)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	_, err = openWith(t, path, reversible)
+	require.NoError(t, err)
+}
+
+func TestStateMigrations_Rollback(t *testing.T) {
+	migrations, err := StateMigrations()
+	require.NoError(t, err)
+	require.Equal(t, 9, migrations.Version())
+
+	path := filepath.Join(t.TempDir(), "state.sql")
+	db, err := openWith(t, path, migrations)
+	require.NoError(t, err)
+	for version := migrations.Version(); version > 1; version-- {
+		reverted, err := migrations.Rollback(db)
+		require.NoError(t, err)
+		require.Equal(t, version, reverted.Version)
+	}
+	_, err = migrations.Rollback(db)
+	require.ErrorIs(t, err, ErrIrreversible)
+	require.NoError(t, db.WithTx(context.Background(), func(tx *Tx) error {
+		return migrations.Apply(tx)
+	}))
+
+	statuses, err := migrations.Status(db)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.Equal(t, MigrationApplied, status.State, status.Name)
+	}
 }
@@ -83,3 +83,35 @@ func TestGet(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, proposal, got)
 }
+
+func TestDeleteBefore(t *testing.T) {
+	db := sql.InMemory()
+	var ids []types.ProposalID
+	for lid := types.LayerID(1); lid <= 4; lid++ {
+		ballot := types.NewExistingBallot(types.BallotID{byte(lid)}, types.RandomEdSignature(), types.RandomNodeID(), lid)
+		require.NoError(t, ballots.Add(db, &ballot))
+		proposal := &types.Proposal{
+			InnerProposal: types.InnerProposal{
+				Ballot: ballot,
+				TxIDs:  []types.TransactionID{{byte(lid)}},
+			},
+			Signature: types.RandomEdSignature(),
+		}
+		proposal.SetID(types.ProposalID{byte(lid)})
+		require.NoError(t, Add(db, proposal))
+		ids = append(ids, proposal.ID())
+	}
+
+	deleted, err := DeleteBefore(db, types.LayerID(3))
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	for i, id := range ids {
+		has, err := Has(db, id)
+		require.NoError(t, err)
+		require.Equal(t, i >= 2, has)
+	}
+	// ballots are not affected
+	has, err := ballots.Has(db, types.BallotID{1})
+	require.NoError(t, err)
+	require.True(t, has)
+}
@@ -0,0 +1,34 @@
+package pruning
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// SetLayer records that historical data before the layer was pruned.
+func SetLayer(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec(`insert into pruning (id, layer) values (1, ?1)
+		on conflict do update set layer = ?1;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid))
+		}, nil); err != nil {
+		return fmt.Errorf("set pruned layer %s: %w", lid, err)
+	}
+	return nil
+}
+
+// Layer returns the layer before which historical data was pruned.
+// Zero if the database was never pruned.
+func Layer(db sql.Executor) (types.LayerID, error) {
+	var lid types.LayerID
+	if _, err := db.Exec("select layer from pruning where id = 1;", nil,
+		func(stmt *sql.Statement) bool {
+			lid = types.LayerID(uint32(stmt.ColumnInt64(0)))
+			return false
+		}); err != nil {
+		return 0, fmt.Errorf("pruned layer: %w", err)
+	}
+	return lid, nil
+}
@@ -0,0 +1,25 @@
+package pruning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestLayer(t *testing.T) {
+	db := sql.InMemory()
+
+	lid, err := Layer(db)
+	require.NoError(t, err)
+	require.Zero(t, lid)
+
+	for _, expected := range []types.LayerID{10, 20} {
+		require.NoError(t, SetLayer(db, expected))
+		lid, err = Layer(db)
+		require.NoError(t, err)
+		require.Equal(t, expected, lid)
+	}
+}
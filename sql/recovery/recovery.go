@@ -0,0 +1,60 @@
+package recovery
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// SetCheckpoint records that the database was recovered from the checkpoint
+// with the given id read from the uri, and that the mesh should be restored from the given layer.
+func SetCheckpoint(db sql.Executor, id, uri string, restore types.LayerID) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindText(1, id)
+		stmt.BindInt64(2, int64(restore))
+		stmt.BindInt64(3, time.Now().UnixNano())
+		stmt.BindText(4, uri)
+	}
+	if _, err := db.Exec(`insert into recovery (id, restore, received, uri) values (?1, ?2, ?3, ?4);`, enc, nil); err != nil {
+		return fmt.Errorf("set checkpoint %s: %w", id, err)
+	}
+	return nil
+}
+
+// CheckpointInfo returns the id and the restore layer of the latest checkpoint
+// the database was recovered from.
+func CheckpointInfo(db sql.Executor) (id string, restore types.LayerID, err error) {
+	dec := func(stmt *sql.Statement) bool {
+		id = stmt.ColumnText(0)
+		restore = types.LayerID(uint32(stmt.ColumnInt64(1)))
+		return true
+	}
+	rows, err := db.Exec("select id, restore from recovery order by received desc limit 1;", nil, dec)
+	if err != nil {
+		return "", 0, fmt.Errorf("checkpoint info: %w", err)
+	}
+	if rows == 0 {
+		return "", 0, fmt.Errorf("checkpoint info: %w", sql.ErrNotFound)
+	}
+	return id, restore, nil
+}
+
+// CheckpointUri returns the uri of the latest checkpoint the database was recovered from.
+// It is empty if the checkpoint was recorded before uris were recorded.
+func CheckpointUri(db sql.Executor) (string, error) {
+	var uri string
+	dec := func(stmt *sql.Statement) bool {
+		uri = stmt.ColumnText(0)
+		return true
+	}
+	rows, err := db.Exec("select uri from recovery order by received desc limit 1;", nil, dec)
+	if err != nil {
+		return "", fmt.Errorf("checkpoint uri: %w", err)
+	}
+	if rows == 0 {
+		return "", fmt.Errorf("checkpoint uri: %w", sql.ErrNotFound)
+	}
+	return uri, nil
+}
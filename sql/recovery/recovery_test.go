@@ -0,0 +1,32 @@
+package recovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestCheckpointInfo(t *testing.T) {
+	db := sql.InMemory()
+
+	_, _, err := CheckpointInfo(db)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	_, err = CheckpointUri(db)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	uri := "https://example.com/checkpoint"
+	require.NoError(t, SetCheckpoint(db, "snapshot-15-restore-18", uri, types.LayerID(18)))
+	id, restore, err := CheckpointInfo(db)
+	require.NoError(t, err)
+	require.Equal(t, "snapshot-15-restore-18", id)
+	require.Equal(t, types.LayerID(18), restore)
+	got, err := CheckpointUri(db)
+	require.NoError(t, err)
+	require.Equal(t, uri, got)
+
+	require.Error(t, SetCheckpoint(db, "snapshot-15-restore-18", uri, types.LayerID(18)))
+}
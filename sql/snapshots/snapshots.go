@@ -0,0 +1,105 @@
+package snapshots
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Add persists encoded tortoise state after the layer was processed.
+func Add(db sql.Executor, lid types.LayerID, data []byte) error {
+	if _, err := db.Exec(`insert into tortoise_snapshots (layer, data) values (?1, ?2)
+		on conflict do update set data = ?2;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid))
+			stmt.BindBytes(2, data)
+		}, nil); err != nil {
+		return fmt.Errorf("add tortoise snapshot %s: %w", lid, err)
+	}
+	return nil
+}
+
+// Latest returns the latest snapshot and the layer it was made at.
+// Returns sql.ErrNotFound if there are no snapshots.
+func Latest(db sql.Executor) (types.LayerID, []byte, error) {
+	var (
+		lid  types.LayerID
+		data []byte
+	)
+	rows, err := db.Exec("select layer, data from tortoise_snapshots order by layer desc limit 1;", nil,
+		func(stmt *sql.Statement) bool {
+			lid = types.LayerID(uint32(stmt.ColumnInt64(0)))
+			data = make([]byte, stmt.ColumnLen(1))
+			stmt.ColumnBytes(1, data)
+			return false
+		})
+	if err != nil {
+		return 0, nil, fmt.Errorf("latest tortoise snapshot: %w", err)
+	}
+	if rows == 0 {
+		return 0, nil, fmt.Errorf("latest tortoise snapshot: %w", sql.ErrNotFound)
+	}
+	return lid, data, nil
+}
+
+// Before returns the latest snapshot made before the layer.
+// Returns sql.ErrNotFound if there are no such snapshots.
+func Before(db sql.Executor, lid types.LayerID) (types.LayerID, []byte, error) {
+	var (
+		before = lid
+		data   []byte
+	)
+	rows, err := db.Exec("select layer, data from tortoise_snapshots where layer < ?1 order by layer desc limit 1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(before))
+		},
+		func(stmt *sql.Statement) bool {
+			lid = types.LayerID(uint32(stmt.ColumnInt64(0)))
+			data = make([]byte, stmt.ColumnLen(1))
+			stmt.ColumnBytes(1, data)
+			return false
+		})
+	if err != nil {
+		return 0, nil, fmt.Errorf("tortoise snapshot before %s: %w", before, err)
+	}
+	if rows == 0 {
+		return 0, nil, fmt.Errorf("tortoise snapshot before %s: %w", before, sql.ErrNotFound)
+	}
+	return lid, data, nil
+}
+
+// Earliest returns the earliest kept snapshot and the layer it was made at.
+// Returns sql.ErrNotFound if there are no snapshots.
+func Earliest(db sql.Executor) (types.LayerID, []byte, error) {
+	var (
+		lid  types.LayerID
+		data []byte
+	)
+	rows, err := db.Exec("select layer, data from tortoise_snapshots order by layer asc limit 1;", nil,
+		func(stmt *sql.Statement) bool {
+			lid = types.LayerID(uint32(stmt.ColumnInt64(0)))
+			data = make([]byte, stmt.ColumnLen(1))
+			stmt.ColumnBytes(1, data)
+			return false
+		})
+	if err != nil {
+		return 0, nil, fmt.Errorf("earliest tortoise snapshot: %w", err)
+	}
+	if rows == 0 {
+		return 0, nil, fmt.Errorf("earliest tortoise snapshot: %w", sql.ErrNotFound)
+	}
+	return lid, data, nil
+}
+
+// Prune deletes all snapshots except the latest keep.
+func Prune(db sql.Executor, keep int) error {
+	if _, err := db.Exec(`delete from tortoise_snapshots where layer not in
+		(select layer from tortoise_snapshots order by layer desc limit ?1);`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(keep))
+		}, nil); err != nil {
+		return fmt.Errorf("prune tortoise snapshots: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package snapshots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestSnapshots(t *testing.T) {
+	db := sql.InMemory()
+
+	_, _, err := Latest(db)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	_, _, err = Earliest(db)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	for _, lid := range []types.LayerID{10, 30, 20} {
+		require.NoError(t, Add(db, lid, []byte{byte(lid)}))
+	}
+	lid, data, err := Latest(db)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID(30), lid)
+	require.Equal(t, []byte{30}, data)
+
+	require.NoError(t, Add(db, 30, []byte{31}))
+	_, data, err = Latest(db)
+	require.NoError(t, err)
+	require.Equal(t, []byte{31}, data)
+
+	lid, data, err = Earliest(db)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID(10), lid)
+	require.Equal(t, []byte{10}, data)
+
+	lid, data, err = Before(db, 30)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID(20), lid)
+	require.Equal(t, []byte{20}, data)
+	_, _, err = Before(db, 10)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	require.NoError(t, Prune(db, 2))
+	lid, _, err = Earliest(db)
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID(20), lid)
+	var layers []types.LayerID
+	_, err = db.Exec("select layer from tortoise_snapshots order by layer;", nil, func(stmt *sql.Statement) bool {
+		layers = append(layers, types.LayerID(uint32(stmt.ColumnInt64(0))))
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, []types.LayerID{20, 30}, layers)
+}
@@ -192,3 +192,54 @@ func TestIterateSnapshot(t *testing.T) {
 		require.Equal(t, expect, n)
 	}
 }
+
+func TestIterateResultsWithEvents(t *testing.T) {
+	db := sql.InMemory()
+	gen := fixture.NewTransactionResultGenerator()
+	tx := gen.Next()
+	principal := types.Address{3}
+	events := []types.TransactionEvent{
+		{
+			Type:      types.TransactionEventSpawn,
+			Template:  types.Address{1},
+			Principal: principal,
+			Account:   principal,
+		},
+		{
+			Type:        types.TransactionEventSpend,
+			Template:    types.Address{1},
+			Principal:   principal,
+			Destination: types.Address{2},
+			Amount:      100,
+		},
+	}
+	require.NoError(t, Add(db, &tx.Transaction, time.Time{}))
+	require.NoError(t, db.WithTx(context.Background(), func(dtx *sql.Tx) error {
+		require.Error(t, AddEvents(dtx, tx.ID, events))
+		require.NoError(t, AddResult(dtx, tx.ID, &tx.TransactionResult))
+		require.NoError(t, AddEvents(dtx, tx.ID, events))
+		return nil
+	}))
+
+	var rst []types.TransactionWithResult
+	require.NoError(t, IterateResults(db, ResultsFilter{TID: &tx.ID}, func(tx *types.TransactionWithResult) bool {
+		rst = append(rst, *tx)
+		return true
+	}))
+	require.Len(t, rst, 1)
+	require.Equal(t, events, rst[0].Events)
+
+	require.NoError(t, db.WithTx(context.Background(), func(dtx *sql.Tx) error {
+		return UndoLayers(dtx, tx.Layer)
+	}))
+	require.NoError(t, db.WithTx(context.Background(), func(dtx *sql.Tx) error {
+		return AddResult(dtx, tx.ID, &tx.TransactionResult)
+	}))
+	rst = nil
+	require.NoError(t, IterateResults(db, ResultsFilter{TID: &tx.ID}, func(tx *types.TransactionWithResult) bool {
+		rst = append(rst, *tx)
+		return true
+	}))
+	require.Len(t, rst, 1)
+	require.Empty(t, rst[0].Events)
+}
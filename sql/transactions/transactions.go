@@ -59,6 +59,19 @@ func AddToProposal(db sql.Executor, tid types.TransactionID, lid types.LayerID,
 	return nil
 }
 
+// DeleteProposalTXsBefore deletes associations of transactions with proposals from layers
+// before the specified layer.
+func DeleteProposalTXsBefore(db sql.Executor, lid types.LayerID) (int, error) {
+	rows, err := db.Exec(`delete from proposal_transactions where layer < ?1 returning tid;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("delete proposal txs before %s: %w", lid, err)
+	}
+	return rows, nil
+}
+
 // HasProposalTX returns true if the given transaction is included in the given proposal.
 func HasProposalTX(db sql.Executor, pid types.ProposalID, tid types.TransactionID) (bool, error) {
 	rows, err := db.Exec("select 1 from proposal_transactions where pid = ?1 and tid = ?2",
@@ -129,7 +142,7 @@ func UndoLayers(db *sql.Tx, from types.LayerID) error {
 		return fmt.Errorf("delete addresses mapping %w", err)
 	}
 	_, err = db.Exec(`update transactions 
-		set layer = null, block = null, result = null 
+		set layer = null, block = null, result = null, events = null 
 		where layer >= ?1`,
 		func(stmt *sql.Statement) {
 			stmt.BindInt64(1, int64(from))
@@ -340,6 +353,30 @@ func AddResult(db *sql.Tx, id types.TransactionID, rst *types.TransactionResult)
 	return nil
 }
 
+// AddEvents adds events emitted by the templates while executing the transaction.
+// Must be called after the result is added.
+func AddEvents(db sql.Executor, id types.TransactionID, events []types.TransactionEvent) error {
+	buf, err := codec.EncodeSlice(events)
+	if err != nil {
+		return fmt.Errorf("encode events %w", err)
+	}
+	if rows, err := db.Exec(`update transactions set events = ?2
+		where id = ?1 and result is not null returning id;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id[:])
+			stmt.BindBytes(2, buf)
+		},
+		func(stmt *sql.Statement) bool {
+			return false
+		},
+	); err != nil {
+		return fmt.Errorf("insert events for %s: %w", id, err)
+	} else if rows == 0 {
+		return fmt.Errorf("invalid state for %s", id)
+	}
+	return nil
+}
+
 // TransactionInProposal returns lowest layer of the proposal where tx is included after the specified layer.
 func TransactionInProposal(db sql.Executor, id types.TransactionID, after types.LayerID) (types.LayerID, error) {
 	var rst types.LayerID
@@ -154,6 +154,28 @@ func TestAddToProposal(t *testing.T) {
 	require.False(t, has)
 }
 
+func TestDeleteProposalTXsBefore(t *testing.T) {
+	db := sql.InMemory()
+
+	rng := rand.New(rand.NewSource(1001))
+	signer, err := signing.NewEdSigner(signing.WithKeyFromRand(rng))
+	require.NoError(t, err)
+	tx := createTX(t, signer, types.Address{1}, 1, 191, 1)
+	require.NoError(t, transactions.Add(db, tx, time.Now()))
+	for lid := types.LayerID(1); lid <= 3; lid++ {
+		require.NoError(t, transactions.AddToProposal(db, tx.ID, lid, types.ProposalID{byte(lid)}))
+	}
+
+	deleted, err := transactions.DeleteProposalTXsBefore(db, types.LayerID(3))
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	for lid := types.LayerID(1); lid <= 3; lid++ {
+		has, err := transactions.HasProposalTX(db, types.ProposalID{byte(lid)}, tx.ID)
+		require.NoError(t, err)
+		require.Equal(t, lid == 3, has)
+	}
+}
+
 func TestAddToBlock(t *testing.T) {
 	db := sql.InMemory()
 
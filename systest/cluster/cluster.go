@@ -14,6 +14,7 @@ import (
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"golang.org/x/exp/maps"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 	apimetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
@@ -540,6 +541,11 @@ func (c *Cluster) Client(i int) *NodeClient {
 	return c.clients[i]
 }
 
+// Conn returns the grpc connection to the i-th client.
+func (c *Cluster) Conn(i int) grpc.ClientConnInterface {
+	return c.clients[i]
+}
+
 // Wait for i-th client to be up.
 func (c *Cluster) Wait(tctx *testcontext.Context, i int) error {
 	_, err := c.Client(i).Resolve(tctx)
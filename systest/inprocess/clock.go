@@ -0,0 +1,52 @@
+package inprocess
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// skewedClock is the clock of a node. It reads the time from the clock shared by the network
+// with an offset that can be changed while the node is running.
+type skewedClock struct {
+	clock.Clock
+	offset atomic.Int64
+}
+
+func (c *skewedClock) skew(d time.Duration) {
+	c.offset.Store(int64(d))
+}
+
+func (c *skewedClock) Now() time.Time {
+	return c.Clock.Now().Add(time.Duration(c.offset.Load()))
+}
+
+func (c *skewedClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *skewedClock) Until(t time.Time) time.Duration {
+	return t.Sub(c.Now())
+}
+
+func (c *skewedClock) WithDeadline(parent context.Context, d time.Time) (context.Context, context.CancelFunc) {
+	return c.Clock.WithDeadline(parent, d.Add(-time.Duration(c.offset.Load())))
+}
+
+// follow advances the mock clock together with the wall clock until the context is canceled.
+func follow(ctx context.Context, mock *clock.Mock, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			mock.Add(now.Sub(last))
+			last = now
+		}
+	}
+}
@@ -0,0 +1,519 @@
+// Package inprocess runs a network of full nodes in the test process.
+//
+// Nodes are instances of the node App connected through an in-memory libp2p network.
+// They read time from a mock clock shared by the network, that follows the wall clock,
+// and submit challenges to a local PoET stand-in. The network can be partitioned,
+// the clock of a node can be skewed and nodes can be killed and restarted with their state.
+//
+// Network implements validation.Network, so that the consensus and sync checks of the system
+// tests can be used with it.
+//
+// Components of the node that read the wall clock directly (hare rounds, beacon rounds,
+// timeouts) are not affected by the skew of the node clock.
+package inprocess
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/spacemeshos/post/initialization"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/spacemeshos/go-spacemesh/api/grpcserver"
+	"github.com/spacemeshos/go-spacemesh/cmd/node"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/config"
+	"github.com/spacemeshos/go-spacemesh/config/presets"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+)
+
+// ErrNotRunning is returned for a node that was killed and not restarted.
+var ErrNotRunning = errors.New("node is not running")
+
+const (
+	defaultGenesisDelay = 30 * time.Second
+	defaultPoetDuration = 500 * time.Millisecond
+	followInterval      = 10 * time.Millisecond
+	stopTimeout         = 30 * time.Second
+)
+
+// Opt is an option for Network.
+type Opt func(*Network)
+
+// WithSize sets the number of nodes. Defaults to 4.
+func WithSize(size int) Opt {
+	return func(n *Network) {
+		n.size = size
+	}
+}
+
+// WithSmeshers sets the number of nodes that run smeshing, they are the first nodes
+// of the network. Defaults to all nodes.
+func WithSmeshers(smeshers int) Opt {
+	return func(n *Network) {
+		n.smeshers = smeshers
+	}
+}
+
+// WithNodeConfig updates the config of the nodes. It is applied to the fastnet preset before
+// the network sets data directories, listeners, genesis time and smeshing of each node.
+func WithNodeConfig(update func(*config.Config)) Opt {
+	return func(n *Network) {
+		n.update = update
+	}
+}
+
+// WithGenesisDelay sets the time from the start of the network to genesis. Defaults to 30s.
+func WithGenesisDelay(delay time.Duration) Opt {
+	return func(n *Network) {
+		n.genesisDelay = delay
+	}
+}
+
+// WithPoetDuration sets the duration of the sequential work of a PoET round. Defaults to 500ms.
+func WithPoetDuration(d time.Duration) Opt {
+	return func(n *Network) {
+		n.poetDuration = d
+	}
+}
+
+// WithLogger sets the logger of the network, nodes log with its named children.
+func WithLogger(logger log.Log) Opt {
+	return func(n *Network) {
+		n.logger = logger
+	}
+}
+
+// Network is a network of nodes that run in the same process.
+type Network struct {
+	logger       log.Log
+	size         int
+	smeshers     int
+	update       func(*config.Config)
+	genesisDelay time.Duration
+	poetDuration time.Duration
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	clock   *clock.Mock
+	follow  sync.WaitGroup
+	mesh    mocknet.Mocknet
+	poet    *Poet
+	genesis time.Time
+	layer   time.Duration
+
+	mu     sync.Mutex
+	nodes  []*Node
+	groups []int
+
+	closeOnce sync.Once
+}
+
+// Node is a node of the network.
+type Node struct {
+	index  int
+	logger log.Log
+	conf   config.Config
+	key    crypto.PrivKey
+	addr   ma.Multiaddr
+	clock  *skewedClock
+	conn   *grpc.ClientConn
+
+	app    *node.App
+	cancel context.CancelFunc
+	done   chan error
+}
+
+// New starts a network of nodes. The network is closed when the test completes.
+func New(tb testing.TB, opts ...Opt) (*Network, error) {
+	n := &Network{
+		logger:       log.NewNop(),
+		size:         4,
+		smeshers:     -1,
+		genesisDelay: defaultGenesisDelay,
+		poetDuration: defaultPoetDuration,
+		clock:        clock.NewMock(),
+		mesh:         mocknet.New(),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	if n.smeshers < 0 || n.smeshers > n.size {
+		n.smeshers = n.size
+	}
+	n.ctx, n.cancel = context.WithCancel(context.Background())
+	tb.Cleanup(n.Close)
+
+	base, err := presets.Get("fastnet")
+	if err != nil {
+		return nil, err
+	}
+	base.P2P.MinPeers = n.size - 1
+	base.POET.PhaseShift = base.LayerDuration * time.Duration(base.LayersPerEpoch) / 2
+	base.POET.CycleGap = base.POET.PhaseShift
+	base.POET.GracePeriod = base.LayerDuration
+	base.API = grpcserver.DefaultConfig()
+	base.API.PublicServices = []grpcserver.Service{
+		grpcserver.Debug, grpcserver.GlobalState, grpcserver.Mesh,
+		grpcserver.Transaction, grpcserver.Node, grpcserver.Activation,
+	}
+	base.API.PrivateServices = nil
+	base.Genesis = config.DefaultTestGenesisConfig()
+	if n.update != nil {
+		n.update(&base)
+	}
+
+	// the mock clock starts at the wall clock, genesis is rounded as the config keeps seconds
+	n.clock.Set(time.Now())
+	n.genesis = n.clock.Now().Add(n.genesisDelay).Truncate(time.Second)
+	n.layer = base.LayerDuration
+	base.Genesis.GenesisTime = n.genesis.Format(time.RFC3339)
+	types.SetLayersPerEpoch(base.LayersPerEpoch)
+
+	n.follow.Add(1)
+	go func() {
+		defer n.follow.Done()
+		follow(n.ctx, n.clock, followInterval)
+	}()
+
+	dir := tb.TempDir()
+	epoch := base.LayerDuration * time.Duration(base.LayersPerEpoch)
+	n.poet, err = NewPoet(n.clock, n.genesis, epoch, base.POET, n.poetDuration, filepath.Join(dir, "poet"))
+	if err != nil {
+		return nil, err
+	}
+
+	n.nodes = make([]*Node, n.size)
+	n.groups = make([]int, n.size)
+	for i := range n.nodes {
+		nd, err := n.newNode(i, base, filepath.Join(dir, fmt.Sprintf("node-%d", i)))
+		if err != nil {
+			return nil, err
+		}
+		n.nodes[i] = nd
+	}
+	for _, nd := range n.nodes {
+		if err := n.start(nd); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+func (n *Network) newNode(i int, base config.Config, dir string) (*Node, error) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate p2p key: %w", err)
+	}
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/10.0.%d.%d/tcp/7513", i/256, i%256))
+	if err != nil {
+		return nil, err
+	}
+	listener, err := freeListener()
+	if err != nil {
+		return nil, err
+	}
+	// the api server listens asynchronously, calls wait for it instead of failing
+	conn, err := grpc.Dial(listener,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial node %d: %w", i, err)
+	}
+
+	conf := base
+	genesis := *base.Genesis
+	conf.Genesis = &genesis
+	conf.DataDirParent = dir
+	conf.FileLock = filepath.Join(dir, "LOCK")
+	conf.P2P.DataDir = filepath.Join(dir, "p2p")
+	conf.API.PublicListener = listener
+	conf.API.JSONListener = ""
+	conf.SMESHING.Start = i < n.smeshers
+	conf.SMESHING.CoinbaseAccount = types.GenerateAddress([]byte(fmt.Sprintf("node-%d", i))).String()
+	conf.SMESHING.Opts.DataDir = filepath.Join(dir, "post")
+	conf.SMESHING.Opts.NumUnits = conf.POST.MinNumUnits
+	conf.SMESHING.Opts.ProviderID = int(initialization.CPUProviderID())
+	conf.SMESHING.Opts.Throttle = false
+	if err := os.MkdirAll(conf.P2P.DataDir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Node{
+		index:  i,
+		logger: n.logger.Named(fmt.Sprintf("node-%d", i)),
+		conf:   conf,
+		key:    key,
+		addr:   addr,
+		clock:  &skewedClock{Clock: n.clock},
+		conn:   conn,
+	}, nil
+}
+
+// freeListener returns a local address with a port that is free at the moment.
+func freeListener() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("find free port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().String(), nil
+}
+
+// start runs the node App until the node is stopped and links it with the nodes in the same group.
+func (n *Network) start(nd *Node) error {
+	ctx, cancel := context.WithCancel(n.ctx)
+	h, err := n.mesh.AddPeer(nd.key, nd.addr)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("add node %d to network: %w", nd.index, err)
+	}
+	host, err := p2p.Upgrade(h, nd.conf.Genesis.GenesisID(),
+		p2p.WithConfig(nd.conf.P2P),
+		p2p.WithLog(nd.logger.Named("p2p")),
+		p2p.WithContext(ctx),
+	)
+	if err != nil {
+		cancel()
+		h.Close()
+		return fmt.Errorf("upgrade host of node %d: %w", nd.index, err)
+	}
+	// app updates the config when it starts
+	conf := nd.conf
+	app := node.New(
+		node.WithConfig(&conf),
+		node.WithLog(nd.logger),
+		node.WithClock(nd.clock),
+		node.WithHost(host),
+		node.WithPoetClients(n.poet),
+	)
+	if err := app.Initialize(); err != nil {
+		cancel()
+		host.Stop()
+		return fmt.Errorf("initialize node %d: %w", nd.index, err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Start(ctx)
+	}()
+	select {
+	case <-app.Started():
+	case err := <-done:
+		cancel()
+		n.cleanup(app)
+		return fmt.Errorf("start node %d: %w", nd.index, err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	nd.app, nd.cancel, nd.done = app, cancel, done
+	return n.link()
+}
+
+func (n *Network) cleanup(app *node.App) {
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	app.Cleanup(ctx)
+}
+
+// link connects the running nodes in the same group and disconnects the nodes in different groups.
+// Must be called with the lock held.
+func (n *Network) link() error {
+	for i, first := range n.nodes {
+		if first == nil || first.app == nil {
+			continue
+		}
+		for j, second := range n.nodes[i+1:] {
+			j += i + 1
+			if second == nil || second.app == nil {
+				continue
+			}
+			pi, pj := peerID(first), peerID(second)
+			linked := len(n.mesh.LinksBetweenPeers(pi, pj)) > 0
+			if n.groups[i] == n.groups[j] {
+				if !linked {
+					if _, err := n.mesh.LinkPeers(pi, pj); err != nil {
+						return fmt.Errorf("link nodes %d and %d: %w", i, j, err)
+					}
+				}
+				if _, err := n.mesh.ConnectPeers(pi, pj); err != nil {
+					return fmt.Errorf("connect nodes %d and %d: %w", i, j, err)
+				}
+				continue
+			}
+			if linked {
+				if err := n.mesh.UnlinkPeers(pi, pj); err != nil {
+					return fmt.Errorf("unlink nodes %d and %d: %w", i, j, err)
+				}
+			}
+			if err := n.mesh.DisconnectPeers(pi, pj); err != nil {
+				return fmt.Errorf("disconnect nodes %d and %d: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+func peerID(nd *Node) peer.ID {
+	id, err := peer.IDFromPrivateKey(nd.key)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// stop stops the node App and removes the links of the node, so that the node can be started
+// again with the same identity.
+func (n *Network) stop(nd *Node) error {
+	n.mu.Lock()
+	app, cancel, done := nd.app, nd.cancel, nd.done
+	nd.app, nd.cancel, nd.done = nil, nil, nil
+	n.mu.Unlock()
+	if app == nil {
+		return ErrNotRunning
+	}
+	cancel()
+	err := <-done
+	n.cleanup(app)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	id := peerID(nd)
+	for _, other := range n.mesh.Peers() {
+		if len(n.mesh.LinksBetweenPeers(id, other)) == 0 {
+			continue
+		}
+		if err := n.mesh.UnlinkPeers(id, other); err != nil {
+			return fmt.Errorf("unlink node %d: %w", nd.index, err)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("node %d exited: %w", nd.index, err)
+	}
+	return nil
+}
+
+// Total returns the number of nodes in the network, including the nodes that are not running.
+func (n *Network) Total() int {
+	return len(n.nodes)
+}
+
+// Conn returns the connection to the grpc api of the i-th node. The connection stays valid
+// when the node is restarted.
+func (n *Network) Conn(i int) grpc.ClientConnInterface {
+	return n.nodes[i].conn
+}
+
+// App returns the App of the i-th node, or nil if the node is not running.
+func (n *Network) App(i int) *node.App {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.nodes[i].app
+}
+
+// Poet returns the PoET stand-in that is used by the nodes.
+func (n *Network) Poet() *Poet {
+	return n.poet
+}
+
+// Genesis returns the genesis time of the network.
+func (n *Network) Genesis() time.Time {
+	return n.genesis
+}
+
+// WaitLayer waits until the network clock reaches the start of the layer.
+func (n *Network) WaitLayer(ctx context.Context, layer types.LayerID) error {
+	target := n.genesis.Add(time.Duration(layer.Uint32()) * n.layer)
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	for n.clock.Now().Before(target) {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// Partition splits the network into groups of nodes. Nodes are connected only to the nodes
+// in the same group, nodes that are not in any group are isolated.
+func (n *Network) Partition(groups ...[]int) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	assigned := make([]int, len(n.nodes))
+	for i := range assigned {
+		// isolated nodes are in groups of their own
+		assigned[i] = len(groups) + 1 + i
+	}
+	for g, group := range groups {
+		for _, i := range group {
+			if i < 0 || i >= len(n.nodes) {
+				return fmt.Errorf("node %d is not in the network of %d nodes", i, len(n.nodes))
+			}
+			assigned[i] = g + 1
+		}
+	}
+	n.groups = assigned
+	return n.link()
+}
+
+// Heal connects all running nodes.
+func (n *Network) Heal() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.groups = make([]int, len(n.nodes))
+	return n.link()
+}
+
+// Skew sets the offset of the i-th node clock from the network clock. The offset is kept
+// when the node is restarted.
+func (n *Network) Skew(i int, offset time.Duration) {
+	n.nodes[i].clock.skew(offset)
+}
+
+// Kill stops the i-th node. The state of the node is kept on disk.
+func (n *Network) Kill(i int) error {
+	return n.stop(n.nodes[i])
+}
+
+// Restart starts the i-th node with the state it had when it was killed.
+func (n *Network) Restart(i int) error {
+	if n.App(i) != nil {
+		return fmt.Errorf("node %d is running", i)
+	}
+	return n.start(n.nodes[i])
+}
+
+// Close stops all nodes and the network clock.
+func (n *Network) Close() {
+	n.closeOnce.Do(func() {
+		for _, nd := range n.nodes {
+			if nd == nil {
+				continue
+			}
+			if err := n.stop(nd); err != nil && !errors.Is(err, ErrNotRunning) {
+				n.logger.With().Warning("failed to stop node", log.Int("node", nd.index), log.Err(err))
+			}
+			nd.conn.Close()
+		}
+		n.cancel()
+		n.follow.Wait()
+		n.mesh.Close()
+	})
+}
@@ -0,0 +1,102 @@
+package inprocess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/config"
+	"github.com/spacemeshos/go-spacemesh/systest/validation"
+)
+
+func fastConfig(conf *config.Config) {
+	conf.LayerDuration = 5 * time.Second
+	conf.LayersPerEpoch = 4
+	conf.HARE.RoundDuration = 500 * time.Millisecond
+	conf.HARE.WakeupDelta = time.Second
+	conf.SyncInterval = 1
+}
+
+func connectedPeers(tb testing.TB, ctx context.Context, n *Network, i int) uint64 {
+	tb.Helper()
+	resp, err := pb.NewNodeServiceClient(n.Conn(i)).Status(ctx, &pb.StatusRequest{})
+	require.NoError(tb, err)
+	return resp.Status.ConnectedPeers
+}
+
+func TestNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	const size = 3
+	n, err := New(t,
+		WithSize(size),
+		WithSmeshers(0),
+		WithNodeConfig(fastConfig),
+		WithGenesisDelay(5*time.Second),
+	)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	for i := 0; i < size; i++ {
+		require.EqualValues(t, size-1, connectedPeers(t, ctx, n, i))
+	}
+	require.NoError(t, n.WaitLayer(ctx, types.LayerID(2)))
+	require.Eventually(t, func() bool {
+		return validation.Sync(n, 0)(ctx) == nil
+	}, 30*time.Second, time.Second)
+	require.NoError(t, validation.Consensus(n, 0, 0)(ctx))
+
+	require.NoError(t, n.Partition([]int{0, 1}))
+	require.EqualValues(t, 1, connectedPeers(t, ctx, n, 0))
+	require.Zero(t, connectedPeers(t, ctx, n, 2))
+	require.NoError(t, n.Heal())
+	require.EqualValues(t, size-1, connectedPeers(t, ctx, n, 2))
+
+	n.Skew(1, time.Second)
+	require.NoError(t, n.Kill(2))
+	require.ErrorIs(t, n.Kill(2), ErrNotRunning)
+	require.Nil(t, n.App(2))
+	require.EqualValues(t, 1, connectedPeers(t, ctx, n, 0))
+	require.NoError(t, n.Restart(2))
+	require.NotNil(t, n.App(2))
+	require.EqualValues(t, size-1, connectedPeers(t, ctx, n, 2))
+
+	require.NoError(t, n.WaitLayer(ctx, types.LayerID(4)))
+	require.Eventually(t, func() bool {
+		return validation.Sync(n, 0)(ctx) == nil
+	}, 30*time.Second, time.Second)
+	require.NoError(t, validation.Consensus(n, 0, 0)(ctx))
+}
+
+func TestNetwork_Smeshing(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	const size = 4
+	n, err := New(t,
+		WithSize(size),
+		WithNodeConfig(fastConfig),
+		WithGenesisDelay(10*time.Second),
+	)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	// nodes publish atxs in the first epoch and propose blocks starting from the third
+	require.NoError(t, n.WaitLayer(ctx, types.LayerID(14)))
+	require.NoError(t, validation.Consensus(n, 0, 2)(ctx))
+
+	require.NoError(t, n.Partition([]int{0, 1, 2}, []int{3}))
+	require.NoError(t, n.WaitLayer(ctx, types.LayerID(18)))
+	require.NoError(t, n.Heal())
+	require.NoError(t, n.WaitLayer(ctx, types.LayerID(26)))
+	require.Eventually(t, func() bool {
+		return validation.Consensus(n, 0, 2)(ctx) == nil
+	}, time.Minute, 5*time.Second)
+}
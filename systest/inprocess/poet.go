@@ -0,0 +1,181 @@
+package inprocess
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/spacemeshos/merkle-tree"
+	"github.com/spacemeshos/poet/hash"
+	"github.com/spacemeshos/poet/prover"
+	"github.com/spacemeshos/poet/shared"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// Poet is a local stand-in for a PoET service. It implements the client that is used by
+// the nodes, so that no server is needed.
+//
+// Rounds are derived from the network clock in the same way as the nodes derive them:
+// a round opens at the phase shift of the epoch and ends one cycle gap before the next one.
+// A challenge is added to the round that opens next. The sequential work of the round
+// runs for the configured duration when its proof is requested for the first time,
+// after that the round doesn't accept challenges.
+type Poet struct {
+	clock    clock.Clock
+	genesis  time.Time
+	epoch    time.Duration
+	cfg      activation.PoetConfig
+	duration time.Duration
+	dir      string
+
+	pub  ed25519.PublicKey
+	priv ed25519.PrivateKey
+
+	mu     sync.Mutex
+	rounds map[string]*poetRound
+}
+
+type poetRound struct {
+	members []types.Member
+	exists  map[types.Member]struct{}
+	proof   *types.PoetProofMessage
+}
+
+// NewPoet creates a PoET stand-in with a new key. Data of the sequential work is written to dir.
+func NewPoet(c clock.Clock, genesis time.Time, epoch time.Duration, cfg activation.PoetConfig, duration time.Duration, dir string) (*Poet, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate poet key: %w", err)
+	}
+	return &Poet{
+		clock:    c,
+		genesis:  genesis,
+		epoch:    epoch,
+		cfg:      cfg,
+		duration: duration,
+		dir:      dir,
+		pub:      pub,
+		priv:     priv,
+		rounds:   map[string]*poetRound{},
+	}, nil
+}
+
+// roundStart returns the start of the round with the index.
+func (p *Poet) roundStart(round int) time.Time {
+	return p.genesis.Add(time.Duration(round)*p.epoch + p.cfg.PhaseShift)
+}
+
+// openRound returns the index of the round that accepts challenges at the time.
+func (p *Poet) openRound(now time.Time) int {
+	elapsed := now.Sub(p.genesis) - p.cfg.PhaseShift
+	if elapsed < 0 {
+		return 0
+	}
+	return int(elapsed/p.epoch) + 1
+}
+
+// PowParams implements activation.PoetProvingServiceClient. PoW is not required.
+func (p *Poet) PowParams(context.Context) (*activation.PoetPowParams, error) {
+	return &activation.PoetPowParams{Challenge: make([]byte, 32)}, nil
+}
+
+// Submit implements activation.PoetProvingServiceClient.
+func (p *Poet) Submit(_ context.Context, _, challenge []byte, _ types.EdSignature, _ types.NodeID, _ activation.PoetPoW) (*types.PoetRound, error) {
+	if len(challenge) != len(types.Member{}) {
+		return nil, fmt.Errorf("%w: challenge must be %d bytes", activation.ErrInvalidRequest, len(types.Member{}))
+	}
+	index := p.openRound(p.clock.Now())
+	id := strconv.Itoa(index)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	round, exists := p.rounds[id]
+	if !exists {
+		round = &poetRound{exists: map[types.Member]struct{}{}}
+		p.rounds[id] = round
+	}
+	if round.proof != nil {
+		return nil, fmt.Errorf("%w: round %s is finished", activation.ErrUnavailable, id)
+	}
+	var member types.Member
+	copy(member[:], challenge)
+	if _, exists := round.exists[member]; !exists {
+		round.exists[member] = struct{}{}
+		round.members = append(round.members, member)
+	}
+	end := p.roundStart(index + 1).Add(-p.cfg.CycleGap)
+	return &types.PoetRound{ID: id, End: types.RoundEnd(end)}, nil
+}
+
+// PoetServiceID implements activation.PoetProvingServiceClient.
+func (p *Poet) PoetServiceID(context.Context) (types.PoetServiceID, error) {
+	return types.PoetServiceID{ServiceID: p.pub}, nil
+}
+
+// Proof implements activation.PoetProvingServiceClient.
+func (p *Poet) Proof(ctx context.Context, id string) (*types.PoetProofMessage, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	round, exists := p.rounds[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: round %s", activation.ErrNotFound, id)
+	}
+	if round.proof == nil {
+		proof, err := p.execute(ctx, id, round.members)
+		if err != nil {
+			return nil, err
+		}
+		round.proof = proof
+	}
+	return round.proof, nil
+}
+
+// execute runs the sequential work for the members of the round and signs the proof.
+func (p *Poet) execute(ctx context.Context, id string, members []types.Member) (*types.PoetProofMessage, error) {
+	tree, err := merkle.NewTree()
+	if err != nil {
+		return nil, fmt.Errorf("membership tree: %w", err)
+	}
+	for _, member := range members {
+		if err := tree.AddLeaf(member[:]); err != nil {
+			return nil, fmt.Errorf("membership tree: %w", err)
+		}
+	}
+	statement := tree.Root()
+
+	dir := filepath.Join(p.dir, "round-"+id)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create round dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	leaves, proof, err := prover.GenerateProofWithoutPersistency(ctx,
+		prover.TreeConfig{Datadir: dir},
+		hash.GenLabelHashFunc(statement),
+		hash.GenMerkleHashFunc(statement),
+		time.Now().Add(p.duration),
+		shared.T,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("generate proof for round %s: %w", id, err)
+	}
+	msg := &types.PoetProofMessage{
+		PoetProof: types.PoetProof{
+			MerkleProof: *proof,
+			Members:     members,
+			LeafCount:   leaves,
+		},
+		PoetServiceID: p.pub,
+		RoundID:       id,
+	}
+	copy(msg.Signature[:], ed25519.Sign(p.priv, msg.SignedBytes()))
+	return msg, nil
+}
@@ -0,0 +1,57 @@
+package inprocess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestPoet(t *testing.T) {
+	mock := clock.NewMock()
+	genesis := mock.Now().Add(time.Minute)
+	cfg := activation.PoetConfig{PhaseShift: 30 * time.Second, CycleGap: 20 * time.Second}
+	poet, err := NewPoet(mock, genesis, time.Minute, cfg, 100*time.Millisecond, t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	challenges := []types.Hash32{types.RandomHash(), types.RandomHash()}
+	for _, challenge := range challenges {
+		round, err := poet.Submit(ctx, nil, challenge.Bytes(), types.EmptyEdSignature, types.NodeID{}, activation.PoetPoW{})
+		require.NoError(t, err)
+		require.Equal(t, "0", round.ID)
+		require.Equal(t, genesis.Add(time.Minute+10*time.Second), round.End.IntoTime())
+	}
+	// round 0 started, challenges go to the next round
+	mock.Add(time.Minute + 30*time.Second)
+	round, err := poet.Submit(ctx, nil, types.RandomHash().Bytes(), types.EmptyEdSignature, types.NodeID{}, activation.PoetPoW{})
+	require.NoError(t, err)
+	require.Equal(t, "1", round.ID)
+
+	_, err = poet.Proof(ctx, "2")
+	require.ErrorIs(t, err, activation.ErrNotFound)
+
+	proof, err := poet.Proof(ctx, "0")
+	require.NoError(t, err)
+	require.Len(t, proof.Members, len(challenges))
+	for i, challenge := range challenges {
+		require.Equal(t, challenge.Bytes(), proof.Members[i][:])
+	}
+	require.NotZero(t, proof.LeafCount)
+	again, err := poet.Proof(ctx, "0")
+	require.NoError(t, err)
+	require.Equal(t, proof, again)
+
+	id, err := poet.PoetServiceID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, id.ServiceID, proof.PoetServiceID)
+	db := activation.NewPoetDb(sql.InMemory(), logtest.New(t), activation.WithPoetServiceKeys([][]byte{id.ServiceID}))
+	require.NoError(t, db.ValidateAndStore(ctx, proof))
+}
@@ -8,15 +8,14 @@ import (
 
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"golang.org/x/sync/errgroup"
-
-	"github.com/spacemeshos/go-spacemesh/systest/cluster"
+	"google.golang.org/grpc"
 )
 
 type ConsensusData struct {
 	Consensus, State []byte
 }
 
-func getConsensusData(ctx context.Context, distance int, node *cluster.NodeClient) *ConsensusData {
+func getConsensusData(ctx context.Context, distance int, node grpc.ClientConnInterface) *ConsensusData {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	meshapi := pb.NewMeshServiceClient(node)
@@ -74,7 +73,7 @@ func failMinority(failures []int, groups map[string][]int) {
 	}
 }
 
-func Consensus(c *cluster.Cluster, tolerate, distance int) Validation {
+func Consensus(c Network, tolerate, distance int) Validation {
 	cv := NewConsensusValidation(c.Total(), tolerate)
 	return func(ctx context.Context) error {
 		var (
@@ -83,7 +82,7 @@ func Consensus(c *cluster.Cluster, tolerate, distance int) Validation {
 		)
 		for i := 0; i < c.Total(); i++ {
 			i := i
-			node := c.Client(i)
+			node := c.Conn(i)
 			eg.Go(func() error {
 				iter.OnData(i, getConsensusData(ctx, distance, node))
 				return nil
@@ -7,8 +7,7 @@ import (
 
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"golang.org/x/sync/errgroup"
-
-	"github.com/spacemeshos/go-spacemesh/systest/cluster"
+	"google.golang.org/grpc"
 )
 
 // Periodic runs validation once in a period, starting immediately.
@@ -32,7 +31,13 @@ func Periodic(ctx context.Context, period time.Duration, f Validation) error {
 
 type Validation func(context.Context) error
 
-func isSynced(ctx context.Context, node *cluster.NodeClient) bool {
+// Network is a set of nodes that are validated through their grpc api.
+type Network interface {
+	Total() int
+	Conn(i int) grpc.ClientConnInterface
+}
+
+func isSynced(ctx context.Context, node grpc.ClientConnInterface) bool {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	svc := pb.NewNodeServiceClient(node)
@@ -43,7 +48,7 @@ func isSynced(ctx context.Context, node *cluster.NodeClient) bool {
 	return resp.Status.IsSynced
 }
 
-func Sync(c *cluster.Cluster, tolerate int) Validation {
+func Sync(c Network, tolerate int) Validation {
 	sv := &SyncValidation{
 		failures: make([]int, c.Total()),
 		tolerate: tolerate,
@@ -52,7 +57,7 @@ func Sync(c *cluster.Cluster, tolerate int) Validation {
 		var eg errgroup.Group
 		for i := 0; i < c.Total(); i++ {
 			i := i
-			node := c.Client(i)
+			node := c.Conn(i)
 			eg.Go(func() error {
 				return sv.OnData(i, isSynced(ctx, node))
 			})
@@ -43,8 +43,8 @@ func (o *option) validate() error {
 
 type OptionFunc func(*option) error
 
-// withClock specifies which clock the NodeClock should use. Defaults to the real clock.
-func withClock(clock clock.Clock) OptionFunc {
+// WithClock specifies which clock the NodeClock should use. Defaults to the real clock.
+func WithClock(clock clock.Clock) OptionFunc {
 	return func(opts *option) error {
 		opts.clock = clock
 		return nil
@@ -193,7 +193,7 @@ func Test_NodeClock_Await_WithClockMovingBackwards(t *testing.T) {
 	mClock.Set(now)
 
 	clock, err := NewClock(
-		withClock(mClock),
+		WithClock(mClock),
 		WithLayerDuration(layerDuration),
 		WithTickInterval(tickInterval),
 		WithGenesisTime(genesis),
@@ -242,7 +242,7 @@ func Test_NodeClock_NonMonotonicTick_Forward(t *testing.T) {
 	mClock.Set(genesis.Add(5 * layerDuration))
 
 	clock, err := NewClock(
-		withClock(mClock),
+		WithClock(mClock),
 		WithLayerDuration(layerDuration),
 		WithTickInterval(tickInterval),
 		WithGenesisTime(genesis),
@@ -281,7 +281,7 @@ func Test_NodeClock_NonMonotonicTick_Backward(t *testing.T) {
 	mClock.Set(genesis.Add(5 * layerDuration))
 
 	clock, err := NewClock(
-		withClock(mClock),
+		WithClock(mClock),
 		WithLayerDuration(layerDuration),
 		WithTickInterval(tickInterval),
 		WithGenesisTime(genesis),
@@ -378,7 +378,7 @@ func Fuzz_NodeClock_CurrentLayer(f *testing.F) {
 		mClock.Set(nowTime)
 
 		clock, err := NewClock(
-			withClock(mClock),
+			WithClock(mClock),
 			WithLayerDuration(layerTime),
 			WithTickInterval(tickInterval),
 			WithGenesisTime(genesisTime),
@@ -9,6 +9,8 @@ import (
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/common/types/result"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/tortoise/snapshot"
 )
 
 // Config for protocol parameters.
@@ -20,6 +22,8 @@ type Config struct {
 	MaxExceptions int    `mapstructure:"tortoise-max-exceptions"` // if candidate for base ballot has more than max exceptions it will be ignored
 	// number of layers to delay votes for blocks with bad beacon values during self-healing. ideally a full epoch.
 	BadBeaconVoteDelayLayers uint32 `mapstructure:"tortoise-delay-layers"`
+	// number of layers between persisted snapshots of the tortoise state. 0 disables snapshots.
+	SnapshotInterval uint32 `mapstructure:"tortoise-snapshot-interval"`
 
 	LayerSize uint32
 }
@@ -33,6 +37,7 @@ func DefaultConfig() Config {
 		WindowSize:               1000,
 		BadBeaconVoteDelayLayers: 0,
 		MaxExceptions:            30 * 100, // 100 layers of average size
+		SnapshotInterval:         100,
 	}
 }
 
@@ -44,6 +49,10 @@ type Tortoise struct {
 
 	mu   sync.Mutex
 	trtl *turtle
+
+	// db is set when tortoise is recovered from the database, snapshots are persisted only in that case.
+	db           sql.Executor
+	lastSnapshot types.LayerID
 }
 
 // Opt for configuring tortoise.
@@ -180,6 +189,13 @@ func (t *Tortoise) EncodeVotes(ctx context.Context, opts ...EncodeVotesOpts) (*t
 
 // TallyVotes up to the specified layer.
 func (t *Tortoise) TallyVotes(ctx context.Context, lid types.LayerID) {
+	if snap := t.tallyVotes(ctx, lid); snap != nil {
+		t.saveSnapshot(snap)
+	}
+}
+
+// tallyVotes returns a snapshot of the state if it is time to persist one.
+func (t *Tortoise) tallyVotes(ctx context.Context, lid types.LayerID) *snapshot.Snapshot {
 	start := time.Now()
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -187,6 +203,11 @@ func (t *Tortoise) TallyVotes(ctx context.Context, lid types.LayerID) {
 	start = time.Now()
 	t.trtl.onLayer(ctx, lid)
 	executeTallyVotes.Observe(float64(time.Since(start).Nanoseconds()))
+	if t.db == nil || t.cfg.SnapshotInterval == 0 ||
+		t.trtl.processed <= t.lastSnapshot || t.trtl.processed.Uint32()%t.cfg.SnapshotInterval != 0 {
+		return nil
+	}
+	return t.takeSnapshot()
 }
 
 // OnAtx is expected to be called before ballots that use this atx.
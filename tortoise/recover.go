@@ -4,23 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/snapshots"
 	"github.com/spacemeshos/go-spacemesh/system"
+	"github.com/spacemeshos/go-spacemesh/tortoise/snapshot"
 )
 
 // Recover tortoise state from database.
+//
+// State is restored from the latest snapshot that is consistent with the database,
+// and only layers after the snapshot are replayed. Older snapshots are tried if the latest
+// one can't be used, and all layers are replayed if none of them can be used.
+// Snapshots are persisted while layers are replayed, so that replay is not repeated on the next start.
 func Recover(db *datastore.CachedDB, beacon system.BeaconGetter, opts ...Opt) (*Tortoise, error) {
 	trtl, err := New(opts...)
 	if err != nil {
 		return nil, err
 	}
+	trtl.db = db
 	latest, err := ballots.LatestLayer(db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load latest known layer: %v", err)
@@ -28,7 +39,14 @@ func Recover(db *datastore.CachedDB, beacon system.BeaconGetter, opts ...Opt) (*
 	if latest <= types.GetEffectiveGenesis() {
 		return trtl, nil
 	}
-	for lid := types.GetEffectiveGenesis().Add(1); !lid.After(latest); lid = lid.Add(1) {
+	first := types.GetEffectiveGenesis().Add(1)
+	if trtl.cfg.SnapshotInterval > 0 {
+		if restored := recoverSnapshots(trtl, db, beacon, latest); restored != 0 {
+			trtl.logger.With().Info("recovered tortoise from snapshot", restored, log.Stringer("latest", latest))
+			first = restored.Add(1)
+		}
+	}
+	for lid := first; !lid.After(latest); lid = lid.Add(1) {
 		if err := RecoverLayer(context.Background(), trtl, db, beacon, lid); err != nil {
 			return nil, fmt.Errorf("failed to load tortoise state at layer %d: %w", lid, err)
 		}
@@ -36,22 +54,105 @@ func Recover(db *datastore.CachedDB, beacon system.BeaconGetter, opts ...Opt) (*
 	return trtl, nil
 }
 
-func RecoverLayer(ctx context.Context, trtl *Tortoise, db *datastore.CachedDB, beacon system.BeaconGetter, lid types.LayerID) error {
-	if lid.FirstInEpoch() {
-		if err := db.IterateEpochATXHeaders(lid.GetEpoch(), func(header *types.ActivationTxHeader) bool {
-			trtl.OnAtx(header)
-			return true
-		}); err != nil {
+// recoverSnapshots tries persisted snapshots starting from the latest one.
+// Returns the layer of the restored snapshot, or 0 if none of them can be used
+// and the state is left empty.
+func recoverSnapshots(trtl *Tortoise, db *datastore.CachedDB, beacon system.BeaconGetter, latest types.LayerID) types.LayerID {
+	before := types.LayerID(math.MaxUint32)
+	for {
+		lid, data, err := snapshots.Before(db, before)
+		if errors.Is(err, sql.ErrNotFound) {
+			return 0
+		}
+		if err != nil {
+			trtl.logger.With().Warning("failed to load tortoise snapshot, replaying all layers", log.Err(err))
+			return 0
+		}
+		err = recoverSnapshot(trtl, db, beacon, latest, lid, data)
+		if err == nil {
+			return lid
+		}
+		trtl.logger.With().Warning("tortoise snapshot can't be used", lid, log.Err(err))
+		// snapshot might have been partially loaded
+		trtl.trtl = newTurtle(trtl.logger, trtl.cfg)
+		trtl.lastSnapshot = 0
+		before = lid
+	}
+}
+
+// recoverSnapshot restores state from the snapshot and loads data that was stored
+// after the snapshot was made for layers within the sliding window.
+func recoverSnapshot(trtl *Tortoise, db *datastore.CachedDB, beacon system.BeaconGetter, latest, lid types.LayerID, data []byte) error {
+	if lid > latest {
+		return fmt.Errorf("snapshot at %s is after the latest layer %s", lid, latest)
+	}
+	var snap snapshot.Snapshot
+	if err := codec.Decode(data, &snap); err != nil {
+		return fmt.Errorf("decode snapshot at %s: %w", lid, err)
+	}
+	if snap.Processed != lid {
+		return fmt.Errorf("snapshot stored at %s was made at %s", lid, snap.Processed)
+	}
+	restored, err := restoreTurtle(trtl.logger, trtl.cfg, &snap)
+	if err != nil {
+		return err
+	}
+	for _, ballot := range restored.ballots[lid] {
+		exists, err := ballots.Has(db, ballot.id)
+		if err != nil {
 			return err
 		}
-		beacon, err := beacon.GetBeacon(lid.GetEpoch())
-		if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		if !exists {
+			return fmt.Errorf("ballot %s from snapshot at %s is not in the database", ballot.id, lid)
+		}
+	}
+	trtl.trtl = restored
+	trtl.lastSnapshot = lid
+	for layer := restored.evicted.Add(1); layer <= lid; layer++ {
+		if layer == restored.evicted.Add(1) || layer.FirstInEpoch() {
+			if err := recoverEpoch(trtl, db, beacon, layer.GetEpoch()); err != nil {
+				return err
+			}
+		}
+		if err := recoverLayerData(trtl, db, layer); err != nil {
 			return err
 		}
-		if err == nil {
-			trtl.OnBeacon(lid.GetEpoch(), beacon)
+	}
+	return nil
+}
+
+// RecoverLayer loads data for the layer from database and tallies votes.
+func RecoverLayer(ctx context.Context, trtl *Tortoise, db *datastore.CachedDB, beacon system.BeaconGetter, lid types.LayerID) error {
+	if lid.FirstInEpoch() {
+		if err := recoverEpoch(trtl, db, beacon, lid.GetEpoch()); err != nil {
+			return err
 		}
 	}
+	if err := recoverLayerData(trtl, db, lid); err != nil {
+		return err
+	}
+	trtl.TallyVotes(ctx, lid)
+	return nil
+}
+
+func recoverEpoch(trtl *Tortoise, db *datastore.CachedDB, beacon system.BeaconGetter, epoch types.EpochID) error {
+	if err := db.IterateEpochATXHeaders(epoch, func(header *types.ActivationTxHeader) bool {
+		trtl.OnAtx(header)
+		return true
+	}); err != nil {
+		return err
+	}
+	value, err := beacon.GetBeacon(epoch)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return err
+	}
+	if err == nil {
+		trtl.OnBeacon(epoch, value)
+	}
+	return nil
+}
+
+func recoverLayerData(trtl *Tortoise, db *datastore.CachedDB, lid types.LayerID) error {
 	blocksrst, err := blocks.Layer(db, lid)
 	if err != nil {
 		return err
@@ -88,6 +189,5 @@ func RecoverLayer(ctx context.Context, trtl *Tortoise, db *datastore.CachedDB, b
 	if err == nil {
 		trtl.OnWeakCoin(lid, coin)
 	}
-	trtl.TallyVotes(ctx, lid)
 	return nil
 }
@@ -0,0 +1,403 @@
+package tortoise
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/spacemeshos/fixed"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql/snapshots"
+	"github.com/spacemeshos/go-spacemesh/tortoise/snapshot"
+)
+
+// keepSnapshots is the number of persisted snapshots. Older snapshot is kept
+// in case the latest one can't be used.
+const keepSnapshots = 2
+
+var errRetriable = errors.New("ballots waiting for beacon")
+
+// takeSnapshot must be called with the lock held.
+func (t *Tortoise) takeSnapshot() *snapshot.Snapshot {
+	snap, err := t.trtl.snapshot()
+	if err != nil {
+		t.logger.With().Debug("tortoise snapshot skipped", t.trtl.processed, log.Err(err))
+		return nil
+	}
+	t.lastSnapshot = snap.Processed
+	return snap
+}
+
+// saveSnapshot persists the snapshot. Failure is not critical, tortoise will be recovered
+// from the previous snapshot or by replaying all layers.
+func (t *Tortoise) saveSnapshot(snap *snapshot.Snapshot) {
+	start := time.Now()
+	data, err := codec.Encode(snap)
+	if err != nil {
+		t.logger.With().Error("failed to encode tortoise snapshot", snap.Processed, log.Err(err))
+		return
+	}
+	if err := snapshots.Add(t.db, snap.Processed, data); err != nil {
+		t.logger.With().Error("failed to persist tortoise snapshot", snap.Processed, log.Err(err))
+		return
+	}
+	if err := snapshots.Prune(t.db, keepSnapshots); err != nil {
+		t.logger.With().Warning("failed to prune tortoise snapshots", log.Err(err))
+	}
+	t.logger.With().Info("persisted tortoise snapshot",
+		snap.Processed,
+		log.Int("size", len(data)),
+		log.Duration("duration", time.Since(start)),
+	)
+}
+
+func encodeWeight(w weight) snapshot.Weight {
+	var rst snapshot.Weight
+	copy(rst[:], w.Bytes())
+	return rst
+}
+
+func decodeWeight(w snapshot.Weight) weight {
+	return fixed.FromBytes(w[:])
+}
+
+func snapshotParams(cfg Config) snapshot.Params {
+	return snapshot.Params{
+		Version:          snapshot.Version,
+		Hdist:            cfg.Hdist,
+		Zdist:            cfg.Zdist,
+		WindowSize:       cfg.WindowSize,
+		LayersPerEpoch:   types.GetLayersPerEpoch(),
+		EffectiveGenesis: types.GetEffectiveGenesis(),
+	}
+}
+
+// snapshot encodes the state of the sliding window.
+// Snapshot can't be made while ballots are waiting for the beacon.
+func (t *turtle) snapshot() (*snapshot.Snapshot, error) {
+	if t.retriable.Len() > 0 {
+		return nil, errRetriable
+	}
+	snap := &snapshot.Snapshot{
+		Params:          snapshotParams(t.Config),
+		Last:            t.last,
+		Processed:       t.processed,
+		Verified:        t.verified,
+		Evicted:         t.evicted,
+		Counted:         t.full.counted,
+		Full:            t.isFull,
+		LocalThreshold:  encodeWeight(t.localThreshold),
+		TotalGoodWeight: encodeWeight(t.verifying.totalGoodWeight),
+		ChangedMin:      t.changedOpinion.min,
+		ChangedMax:      t.changedOpinion.max,
+	}
+
+	epochs := make([]types.EpochID, 0, len(t.epochs))
+	for eid := range t.epochs {
+		epochs = append(epochs, eid)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+	for _, eid := range epochs {
+		einfo := t.epochs[eid]
+		epoch := snapshot.Epoch{
+			ID:     eid,
+			Weight: encodeWeight(einfo.weight),
+			Height: einfo.height,
+		}
+		if einfo.beacon != nil {
+			epoch.HasBeacon = true
+			epoch.Beacon = *einfo.beacon
+		}
+		epoch.Atxs = make([]snapshot.Atx, 0, len(einfo.atxs))
+		for id, atx := range einfo.atxs {
+			epoch.Atxs = append(epoch.Atxs, snapshot.Atx{ID: id, Weight: atx.weight, Height: atx.height})
+		}
+		sort.Slice(epoch.Atxs, func(i, j int) bool { return bytes.Compare(epoch.Atxs[i].ID[:], epoch.Atxs[j].ID[:]) < 0 })
+		snap.Epochs = append(snap.Epochs, epoch)
+	}
+
+	lids := make([]types.LayerID, 0, len(t.layers))
+	for lid := range t.layers {
+		lids = append(lids, lid)
+	}
+	sort.Slice(lids, func(i, j int) bool { return lids[i] < lids[j] })
+	for _, lid := range lids {
+		linfo := t.layers[lid]
+		layer := snapshot.Layer{
+			ID:              lid,
+			Empty:           encodeWeight(linfo.empty),
+			HareTerminated:  linfo.hareTerminated,
+			Coinflip:        uint8(linfo.coinflip),
+			Opinion:         linfo.opinion,
+			GoodUncounted:   encodeWeight(linfo.verifying.goodUncounted),
+			ReferenceHeight: linfo.verifying.referenceHeight,
+		}
+		if linfo.prevOpinion != nil {
+			layer.HasPrevOpinion = true
+			layer.PrevOpinion = *linfo.prevOpinion
+		}
+		for _, block := range linfo.blocks {
+			layer.Blocks = append(layer.Blocks, snapshot.Block{
+				ID:       block.id,
+				Height:   block.height,
+				Hare:     uint8(block.hare),
+				Margin:   encodeWeight(block.margin),
+				Validity: uint8(block.validity),
+				Emitted:  uint8(block.emitted),
+				Data:     block.data,
+			})
+		}
+		snap.Layers = append(snap.Layers, layer)
+	}
+
+	// votes are shared between ballots. every vote is encoded once, votes for the evicted
+	// layers are not needed as tortoise never looks at them.
+	var (
+		nodes      []*layerVote
+		indexes    = map[*layerVote]uint32{}
+		references = map[*referenceInfo]uint32{}
+		delayed    = map[types.BallotID]types.LayerID{}
+	)
+	for lid, ballots := range t.full.delayed {
+		for _, ballot := range ballots {
+			delayed[ballot.id] = lid
+		}
+	}
+	blids := make([]types.LayerID, 0, len(t.ballots))
+	for lid := range t.ballots {
+		blids = append(blids, lid)
+	}
+	sort.Slice(blids, func(i, j int) bool { return blids[i] < blids[j] })
+	for _, lid := range blids {
+		for _, ballot := range t.ballots[lid] {
+			for current := ballot.votes.tail; current != nil && current.lid.After(t.evicted); current = current.prev {
+				if _, exists := indexes[current]; exists {
+					break
+				}
+				indexes[current] = 0
+				nodes = append(nodes, current)
+			}
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].lid < nodes[j].lid })
+	snap.Votes = make([]snapshot.LayerVote, 0, len(nodes))
+	for i, node := range nodes {
+		indexes[node] = uint32(i) + 1
+		vote := snapshot.LayerVote{
+			Layer:   node.lid,
+			Opinion: node.opinion,
+			Vote:    uint8(node.vote),
+		}
+		if node.prev != nil && node.prev.lid.After(t.evicted) {
+			vote.Prev = indexes[node.prev]
+			if vote.Prev == 0 {
+				return nil, fmt.Errorf("bug: vote for %s is not encoded before %s", node.prev.lid, node.lid)
+			}
+		}
+		for _, block := range node.supported {
+			vote.Supported = append(vote.Supported, snapshot.BlockRef{ID: block.id, Height: block.height})
+		}
+		snap.Votes = append(snap.Votes, vote)
+	}
+
+	for _, lid := range blids {
+		for _, ballot := range t.ballots[lid] {
+			ref, exists := references[ballot.reference]
+			if !exists {
+				ref = uint32(len(snap.References))
+				references[ballot.reference] = ref
+				snap.References = append(snap.References, snapshot.Reference{
+					Num:    ballot.reference.weight.Num().Uint64(),
+					Denom:  ballot.reference.weight.Denom().Uint64(),
+					Height: ballot.reference.height,
+					Beacon: ballot.reference.beacon,
+				})
+			}
+			encoded := snapshot.Ballot{
+				ID:        ballot.id,
+				Layer:     ballot.layer,
+				BaseID:    ballot.base.id,
+				BaseLayer: ballot.base.layer,
+				Malicious: ballot.malicious,
+				Weight:    encodeWeight(ballot.weight),
+				Reference: ref,
+				BadBeacon: ballot.conditions.badBeacon,
+				Delayed:   delayed[ballot.id],
+			}
+			if ballot.votes.tail != nil {
+				encoded.Votes = indexes[ballot.votes.tail]
+			}
+			snap.Ballots = append(snap.Ballots, encoded)
+		}
+	}
+
+	for lid, blocks := range t.updated {
+		for bid, valid := range blocks {
+			snap.Updated = append(snap.Updated, snapshot.Update{Layer: lid, Block: bid, Valid: valid})
+		}
+	}
+	sort.Slice(snap.Updated, func(i, j int) bool {
+		if snap.Updated[i].Layer != snap.Updated[j].Layer {
+			return snap.Updated[i].Layer < snap.Updated[j].Layer
+		}
+		return snap.Updated[i].Block.Compare(snap.Updated[j].Block)
+	})
+	return snap, nil
+}
+
+// restoreTurtle creates a turtle with the state from the snapshot.
+func restoreTurtle(logger log.Log, cfg Config, snap *snapshot.Snapshot) (*turtle, error) {
+	if expected := snapshotParams(cfg); snap.Params != expected {
+		return nil, fmt.Errorf("snapshot params %+v don't match %+v", snap.Params, expected)
+	}
+	t := &turtle{
+		Config: cfg,
+		state:  newState(),
+		logger: logger,
+	}
+	t.verifying = newVerifying(cfg, t.state)
+	t.full = newFullTortoise(cfg, t.state)
+
+	t.last = snap.Last
+	t.processed = snap.Processed
+	t.verified = snap.Verified
+	t.evicted = snap.Evicted
+	t.full.counted = snap.Counted
+	t.isFull = snap.Full
+	t.localThreshold = decodeWeight(snap.LocalThreshold)
+	t.verifying.totalGoodWeight = decodeWeight(snap.TotalGoodWeight)
+	t.changedOpinion.min = snap.ChangedMin
+	t.changedOpinion.max = snap.ChangedMax
+
+	for _, epoch := range snap.Epochs {
+		einfo := t.epoch(epoch.ID)
+		einfo.weight = decodeWeight(epoch.Weight)
+		einfo.height = epoch.Height
+		if epoch.HasBeacon {
+			beacon := epoch.Beacon
+			einfo.beacon = &beacon
+		}
+		for _, atx := range epoch.Atxs {
+			einfo.atxs[atx.ID] = atxInfo{weight: atx.Weight, height: atx.Height}
+		}
+	}
+	for _, layer := range snap.Layers {
+		linfo := t.layer(layer.ID)
+		linfo.empty = decodeWeight(layer.Empty)
+		linfo.hareTerminated = layer.HareTerminated
+		linfo.coinflip = sign(int8(layer.Coinflip))
+		linfo.opinion = layer.Opinion
+		linfo.verifying.goodUncounted = decodeWeight(layer.GoodUncounted)
+		linfo.verifying.referenceHeight = layer.ReferenceHeight
+		if layer.HasPrevOpinion {
+			if prev, exists := t.layers[layer.ID.Sub(1)]; exists {
+				if prev.opinion != layer.PrevOpinion {
+					return nil, fmt.Errorf("previous opinion for layer %s doesn't match", layer.ID)
+				}
+				linfo.prevOpinion = &prev.opinion
+			} else {
+				prev := layer.PrevOpinion
+				linfo.prevOpinion = &prev
+			}
+		}
+		for _, block := range layer.Blocks {
+			blocksNumber.Inc()
+			linfo.blocks = append(linfo.blocks, &blockInfo{
+				id:       block.ID,
+				layer:    layer.ID,
+				height:   block.Height,
+				hare:     sign(int8(block.Hare)),
+				margin:   decodeWeight(block.Margin),
+				validity: sign(int8(block.Validity)),
+				emitted:  sign(int8(block.Emitted)),
+				data:     block.Data,
+			})
+		}
+	}
+
+	nodes := make([]*layerVote, 0, len(snap.Votes))
+	for i, vote := range snap.Votes {
+		node := &layerVote{
+			lid:     vote.Layer,
+			opinion: vote.Opinion,
+			vote:    sign(int8(vote.Vote)),
+		}
+		if vote.Prev > 0 {
+			if int(vote.Prev) > i {
+				return nil, fmt.Errorf("vote %d refers to vote %d that is not decoded yet", i, vote.Prev-1)
+			}
+			node.prev = nodes[vote.Prev-1]
+		}
+		for _, ref := range vote.Supported {
+			block := t.getBlock(types.Vote{ID: ref.ID, LayerID: vote.Layer, Height: ref.Height})
+			if block == nil {
+				return nil, fmt.Errorf("supported block %s/%s is not in state", vote.Layer, ref.ID)
+			}
+			node.supported = append(node.supported, block)
+		}
+		nodes = append(nodes, node)
+	}
+
+	references := make([]*referenceInfo, 0, len(snap.References))
+	for _, ref := range snap.References {
+		if ref.Denom == 0 {
+			return nil, errors.New("reference weight with zero denominator")
+		}
+		references = append(references, &referenceInfo{
+			weight: new(big.Rat).SetFrac(new(big.Int).SetUint64(ref.Num), new(big.Int).SetUint64(ref.Denom)),
+			height: ref.Height,
+			beacon: ref.Beacon,
+		})
+	}
+	for _, ballot := range snap.Ballots {
+		if int(ballot.Reference) >= len(references) || int(ballot.Votes) > len(nodes) {
+			return nil, fmt.Errorf("ballot %s refers to unknown data", ballot.ID)
+		}
+		binfo := &ballotInfo{
+			id:    ballot.ID,
+			layer: ballot.Layer,
+			base: baseInfo{
+				id:    ballot.BaseID,
+				layer: ballot.BaseLayer,
+			},
+			malicious:  ballot.Malicious,
+			weight:     decodeWeight(ballot.Weight),
+			reference:  references[ballot.Reference],
+			conditions: conditions{badBeacon: ballot.BadBeacon},
+		}
+		if ballot.Votes > 0 {
+			binfo.votes.tail = nodes[ballot.Votes-1]
+		}
+		t.addBallot(binfo)
+		if ballot.Delayed != 0 {
+			delayedBallots.Inc()
+			t.full.delayed[ballot.Delayed] = append(t.full.delayed[ballot.Delayed], binfo)
+		}
+	}
+	for _, update := range snap.Updated {
+		if t.updated == nil {
+			t.updated = map[types.LayerID]map[types.BlockID]bool{}
+		}
+		if _, exists := t.updated[update.Layer]; !exists {
+			t.updated[update.Layer] = map[types.BlockID]bool{}
+		}
+		t.updated[update.Layer][update.Block] = update.Valid
+	}
+
+	lastLayer.Set(float64(t.last))
+	processedLayer.Set(float64(t.processed))
+	verifiedLayer.Set(float64(t.verified))
+	evictedLayer.Set(float64(t.evicted))
+	if t.isFull {
+		modeGauge.Set(1)
+	} else {
+		modeGauge.Set(0)
+	}
+	return t, nil
+}
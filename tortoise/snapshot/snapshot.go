@@ -0,0 +1,134 @@
+// Package snapshot defines encoding of the tortoise state persisted in the database,
+// so that the tortoise doesn't have to replay all layers from genesis on startup.
+package snapshot
+
+import (
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+//go:generate scalegen
+
+// Version of the encoding. Snapshots with other versions are ignored.
+const Version = 1
+
+// Weight is a fixed point number encoded with fixed.Fixed.Bytes.
+type Weight [16]byte
+
+// Params are parameters that the state depends on. Snapshot can't be used if any of them changed.
+type Params struct {
+	Version          uint32
+	Hdist            uint32
+	Zdist            uint32
+	WindowSize       uint32
+	LayersPerEpoch   uint32
+	EffectiveGenesis types.LayerID
+}
+
+// Snapshot is the state of the tortoise sliding window after processing a layer.
+type Snapshot struct {
+	Params Params
+
+	Last            types.LayerID
+	Processed       types.LayerID
+	Verified        types.LayerID
+	Evicted         types.LayerID
+	Counted         types.LayerID
+	Full            bool
+	LocalThreshold  Weight
+	TotalGoodWeight Weight
+	ChangedMin      types.LayerID
+	ChangedMax      types.LayerID
+
+	Epochs     []Epoch     `scale:"max=1000"`
+	Layers     []Layer     `scale:"max=1000000"`
+	Votes      []LayerVote `scale:"max=100000000"`
+	References []Reference `scale:"max=10000000"`
+	Ballots    []Ballot    `scale:"max=100000000"`
+	// Updated are validity changes that weren't consumed by the mesh yet.
+	Updated []Update `scale:"max=10000000"`
+}
+
+// Epoch is a weight of the epoch and atxs that target it.
+type Epoch struct {
+	ID        types.EpochID
+	Weight    Weight
+	Height    uint64
+	HasBeacon bool
+	Beacon    types.Beacon
+	Atxs      []Atx `scale:"max=10000000"`
+}
+
+type Atx struct {
+	ID     types.ATXID
+	Weight uint64
+	Height uint64
+}
+
+// Layer is the state of the layer in the window. Votes are encoded as int8 signs.
+type Layer struct {
+	ID              types.LayerID
+	Empty           Weight
+	HareTerminated  bool
+	Coinflip        uint8
+	Opinion         types.Hash32
+	HasPrevOpinion  bool
+	PrevOpinion     types.Hash32
+	GoodUncounted   Weight
+	ReferenceHeight uint64
+	Blocks          []Block `scale:"max=10000"`
+}
+
+type Block struct {
+	ID       types.BlockID
+	Height   uint64
+	Hare     uint8
+	Margin   Weight
+	Validity uint8
+	Emitted  uint8
+	Data     bool
+}
+
+// LayerVote is a vote of one or many ballots for the layer. Votes are shared between ballots
+// and linked to the vote for the previous layer by Prev, which is an index into Snapshot.Votes
+// plus one. Zero Prev means that there are no votes for the previous layers in the window.
+type LayerVote struct {
+	Layer     types.LayerID
+	Opinion   types.Hash32
+	Vote      uint8
+	Supported []BlockRef `scale:"max=10000"`
+	Prev      uint32
+}
+
+type BlockRef struct {
+	ID     types.BlockID
+	Height uint64
+}
+
+type Reference struct {
+	Num    uint64
+	Denom  uint64
+	Height uint64
+	Beacon types.Beacon
+}
+
+// Ballot refers to its reference and votes by indexes into Snapshot.References and
+// Snapshot.Votes. Votes are an index plus one, zero if the ballot doesn't have votes.
+type Ballot struct {
+	ID        types.BallotID
+	Layer     types.LayerID
+	BaseID    types.BallotID
+	BaseLayer types.LayerID
+	Malicious bool
+	Weight    Weight
+	Reference uint32
+	Votes     uint32
+	BadBeacon bool
+	// Delayed is the layer after which ballot is counted by the full tortoise. Zero if not delayed.
+	Delayed types.LayerID
+}
+
+type Update struct {
+	Layer types.LayerID
+	Block types.BlockID
+	Valid bool
+}
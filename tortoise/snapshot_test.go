@@ -0,0 +1,135 @@
+package tortoise
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/snapshots"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+	"github.com/spacemeshos/go-spacemesh/tortoise/snapshot"
+)
+
+func requireEqualTortoise(tb testing.TB, expected, actual *Tortoise, last types.LayerID) {
+	tb.Helper()
+	require.Equal(tb, expected.LatestComplete(), actual.LatestComplete())
+	ctx := context.Background()
+	expectedVotes, err := expected.EncodeVotes(ctx, EncodeVotesWithCurrent(last.Add(1)))
+	require.NoError(tb, err)
+	actualVotes, err := actual.EncodeVotes(ctx, EncodeVotesWithCurrent(last.Add(1)))
+	require.NoError(tb, err)
+	require.Equal(tb, expectedVotes, actualVotes)
+
+	from := expected.trtl.evicted.Add(1)
+	if actual.trtl.evicted.Add(1) > from {
+		from = actual.trtl.evicted.Add(1)
+	}
+	expectedResults, err := expected.Results(from, last)
+	require.NoError(tb, err)
+	actualResults, err := actual.Results(from, last)
+	require.NoError(tb, err)
+	require.Equal(tb, expectedResults, actualResults)
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+
+	ctx := context.Background()
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.WindowSize = 10
+	trtl := tortoiseFromSimState(t, s.GetState(0), WithConfig(cfg), WithLogger(logtest.New(t)))
+	var last types.LayerID
+	for _, lid := range sim.GenLayers(s,
+		sim.WithSequence(15),
+		sim.WithSequence(3, sim.WithoutHareOutput()),
+		sim.WithSequence(5),
+	) {
+		last = lid
+		trtl.TallyVotes(ctx, lid)
+	}
+	trtl.Updates()
+
+	snap, err := trtl.trtl.snapshot()
+	require.NoError(t, err)
+	require.NotEmpty(t, snap.Ballots)
+	data, err := codec.Encode(snap)
+	require.NoError(t, err)
+	var decoded snapshot.Snapshot
+	require.NoError(t, codec.Decode(data, &decoded))
+
+	restored, err := New(WithConfig(cfg), WithLogger(logtest.New(t)))
+	require.NoError(t, err)
+	restored.trtl, err = restoreTurtle(restored.logger, cfg, &decoded)
+	require.NoError(t, err)
+	resnap, err := restored.trtl.snapshot()
+	require.NoError(t, err)
+	require.Equal(t, snap, resnap)
+	requireEqualTortoise(t, trtl.Tortoise, restored, last)
+
+	t.Run("params mismatch", func(t *testing.T) {
+		other := cfg
+		other.Hdist++
+		_, err := restoreTurtle(restored.logger, other, &decoded)
+		require.ErrorContains(t, err, "params")
+	})
+}
+
+func TestRecoverFromSnapshot(t *testing.T) {
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.WindowSize = 10
+	cfg.SnapshotInterval = 10
+	var last types.LayerID
+	for _, lid := range sim.GenLayers(s, sim.WithSequence(25)) {
+		last = lid
+	}
+	state := s.GetState(0)
+
+	full, err := Recover(state.DB, state.Beacons, WithConfig(cfg), WithLogger(logtest.New(t)))
+	require.NoError(t, err)
+	lid, _, err := snapshots.Latest(state.DB)
+	require.NoError(t, err)
+	require.Less(t, last.Sub(cfg.SnapshotInterval), lid)
+	require.Equal(t, lid, full.lastSnapshot)
+
+	fromSnapshot, err := Recover(state.DB, state.Beacons, WithConfig(cfg), WithLogger(logtest.New(t)))
+	require.NoError(t, err)
+	require.Equal(t, lid, fromSnapshot.lastSnapshot)
+	requireEqualTortoise(t, full, fromSnapshot, last)
+
+	t.Run("fallback", func(t *testing.T) {
+		require.NoError(t, snapshots.Add(state.DB, last, []byte("corrupted")))
+		recovered, err := Recover(state.DB, state.Beacons, WithConfig(cfg), WithLogger(logtest.New(t)))
+		require.NoError(t, err)
+		require.Equal(t, lid, recovered.lastSnapshot)
+		requireEqualTortoise(t, full, recovered, last)
+	})
+	t.Run("replay", func(t *testing.T) {
+		_, err := state.DB.Exec("update tortoise_snapshots set data = ?1;", func(stmt *sql.Statement) {
+			stmt.BindBytes(1, []byte("corrupted"))
+		}, nil)
+		require.NoError(t, err)
+		recovered, err := Recover(state.DB, state.Beacons, WithConfig(cfg), WithLogger(logtest.New(t)))
+		require.NoError(t, err)
+		requireEqualTortoise(t, full, recovered, last)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		cfg := cfg
+		cfg.SnapshotInterval = 0
+		recovered, err := Recover(state.DB, state.Beacons, WithConfig(cfg), WithLogger(logtest.New(t)))
+		require.NoError(t, err)
+		require.Zero(t, recovered.lastSnapshot)
+	})
+}
@@ -26,6 +26,7 @@ var (
 	errBadNonce            = errors.New("bad nonce")
 	errInsufficientBalance = errors.New("insufficient balance")
 	errTooManyNonce        = errors.New("account has too many nonce pending")
+	errTxExpired           = errors.New("tx stayed in the mempool for too long")
 	errLayerNotInOrder     = errors.New("layers not applied in order")
 )
 
@@ -73,6 +74,7 @@ type accountCache struct {
 	moreInDB bool
 
 	cachedTXs map[types.TransactionID]*NanoTX // shared with the cache instance
+	policy    *MempoolPolicy                  // shared with the cache instance
 }
 
 func (ac *accountCache) nextNonce() uint64 {
@@ -90,10 +92,19 @@ func (ac *accountCache) availBalance() uint64 {
 }
 
 func (ac *accountCache) precheck(logger log.Log, ntx *NanoTX) (*list.Element, *candidate, error) {
-	if ac.txsByNonce.Len() >= maxTXsPerAcct {
+	cfg := ac.policy.Config()
+	limit := cfg.MaxTXsPerPrincipal
+	if limit <= 0 {
+		limit = maxTXsPerAcct
+	}
+	if ac.txsByNonce.Len() >= limit {
 		ac.moreInDB = true
 		return nil, nil, errTooManyNonce
 	}
+	if cfg.expired(ntx, time.Now()) {
+		ac.moreInDB = true
+		return nil, nil, errTxExpired
+	}
 	balance := ac.startBalance
 	var prev *list.Element
 	for e := ac.txsByNonce.Back(); e != nil; e = e.Prev() {
@@ -250,7 +261,8 @@ func (ac *accountCache) addBatch(logger log.Log, nonce2TXs map[uint64][]*NanoTX,
 			log.Uint64("fee", best.Fee()))
 
 		if err := ac.accept(logger, best, blockSeed); err != nil {
-			if errors.Is(err, errTooManyNonce) {
+			// txs with higher nonces can't be executed before the expired one
+			if errors.Is(err, errTooManyNonce) || errors.Is(err, errTxExpired) {
 				break
 			}
 			continue
@@ -307,6 +319,8 @@ func (ac *accountCache) add(logger log.Log, tx *types.Transaction, received time
 			mempoolTxCount.WithLabelValues(tooManyNonce).Inc()
 		} else if errors.Is(err, errInsufficientBalance) {
 			mempoolTxCount.WithLabelValues(balanceTooSmall).Inc()
+		} else if errors.Is(err, errTxExpired) {
+			mempoolTxCount.WithLabelValues(expired).Inc()
 		}
 		return err
 	}
@@ -352,7 +366,9 @@ func (ac *accountCache) addPendingFromNonce(logger log.Log, db *sql.Database, no
 
 // find the first nonce without a layer.
 // a nonce with a valid layer indicates that it's already packed in a proposal/block.
-func (ac *accountCache) getMempool(logger log.Log) []*NanoTX {
+// txs from the first one that is priced below the minimal gas price or expired are skipped,
+// as later nonces can't be executed without it.
+func (ac *accountCache) getMempool(logger log.Log, cfg MempoolConfig, now time.Time) []*NanoTX {
 	bests := make([]*NanoTX, 0, maxTXsPerAcct)
 	offset := 0
 	found := false
@@ -367,6 +383,14 @@ func (ac *accountCache) getMempool(logger log.Log) []*NanoTX {
 				cand.block(),
 				log.Uint64("nonce", cand.nonce()))
 		}
+		if found && (cand.best.GasPrice < cfg.MinGasPrice || cfg.expired(cand.best, now)) {
+			logger.With().Debug("tx skipped by mempool policy",
+				cand.id(),
+				log.Uint64("nonce", cand.nonce()),
+				log.Uint64("gas_price", cand.best.GasPrice),
+				log.Time("received", cand.best.Received))
+			break
+		}
 		if found {
 			bests = append(bests, cand.best)
 		} else {
@@ -411,16 +435,23 @@ type stateFunc func(types.Address) (uint64, uint64)
 type Cache struct {
 	logger log.Log
 	stateF stateFunc
+	policy *MempoolPolicy // shared with accountCache instances
 
 	mu        sync.Mutex
 	pending   map[types.Address]*accountCache
 	cachedTXs map[types.TransactionID]*NanoTX // shared with accountCache instances
 }
 
+// NewCache returns a Cache that only limits the number of txs per account.
 func NewCache(s stateFunc, logger log.Log) *Cache {
+	return newCache(s, logger, NewMempoolPolicy(MempoolConfig{}))
+}
+
+func newCache(s stateFunc, logger log.Log, policy *MempoolPolicy) *Cache {
 	return &Cache{
 		logger:    logger,
 		stateF:    s,
+		policy:    policy,
 		pending:   make(map[types.Address]*accountCache),
 		cachedTXs: make(map[types.TransactionID]*NanoTX),
 	}
@@ -508,6 +539,7 @@ func (c *Cache) BuildFromTXs(rst []*types.MeshTransaction, blockSeed []byte) err
 			acctsAdded++
 		}
 	}
+	c.enforcePolicy(c.logger)
 	c.logger.Info("added pending tx for %d accounts", acctsAdded)
 	return nil
 }
@@ -525,6 +557,7 @@ func (c *Cache) createAcctIfNotPresent(addr types.Address) {
 			startBalance: balance,
 			txsByNonce:   list.New(),
 			cachedTXs:    c.cachedTXs,
+			policy:       c.policy,
 		}
 	}
 }
@@ -552,8 +585,10 @@ func (c *Cache) cleanupAccounts(accounts map[types.Address]struct{}) {
 //     re-evaluate it after each layer is applied.
 //   - errTooManyNonce: when a principal has way too many nonces, we don't want to blow up the memory. they should
 //     be stored in db and retrieved after each earlier nonce is applied.
+//   - errTxExpired: the tx stays in db, but it is not reconsidered for the mempool.
 func acceptable(err error) bool {
-	return err == nil || errors.Is(err, errInsufficientBalance) || errors.Is(err, errTooManyNonce)
+	return err == nil || errors.Is(err, errInsufficientBalance) || errors.Is(err, errTooManyNonce) ||
+		errors.Is(err, errTxExpired)
 }
 
 func (c *Cache) Add(ctx context.Context, db *sql.Database, tx *types.Transaction, received time.Time, mustPersist bool) error {
@@ -573,9 +608,89 @@ func (c *Cache) Add(ctx context.Context, db *sql.Database, tx *types.Transaction
 			return dbErr
 		}
 	}
+	if err == nil {
+		c.enforcePolicy(logger)
+	}
 	return err
 }
 
+// enforcePolicy evicts expired txs and, if the mempool is over capacity, txs with the lowest fee.
+// evicted txs stay in db.
+func (c *Cache) enforcePolicy(logger log.Log) {
+	cfg := c.policy.Config()
+	if cfg.TTL > 0 {
+		c.evictExpired(logger, cfg, time.Now())
+	}
+	if cfg.MaxSize > 0 {
+		c.evictLowestFee(logger, cfg.MaxSize)
+	}
+}
+
+// evictExpired evicts expired txs together with all txs with higher nonces of the same account.
+func (c *Cache) evictExpired(logger log.Log, cfg MempoolConfig, now time.Time) {
+	for _, acct := range c.pending {
+		var evict *list.Element
+		for e := acct.txsByNonce.Front(); e != nil; e = e.Next() {
+			if cfg.expired(e.Value.(*candidate).best, now) {
+				evict = e
+				break
+			}
+		}
+		for evict != nil {
+			next := evict.Next()
+			removed := acct.txsByNonce.Remove(evict).(*candidate)
+			delete(c.cachedTXs, removed.id())
+			acct.moreInDB = true
+			mempoolEvictedCount.WithLabelValues(evictedExpired).Inc()
+			logger.With().Debug("evicted expired tx from mempool",
+				removed.id(),
+				acct.addr,
+				log.Uint64("nonce", removed.nonce()),
+				log.Time("received", removed.best.Received))
+			evict = next
+		}
+	}
+}
+
+// evictLowestFee evicts txs with the lowest fee until the mempool fits maxSize.
+// only the tx with the highest nonce of an account can be evicted so that the remaining
+// txs of the account stay executable. txs that are already packed in a proposal/block
+// are not evicted.
+func (c *Cache) evictLowestFee(logger log.Log, maxSize int) {
+	for len(c.cachedTXs) > maxSize {
+		var (
+			victim *accountCache
+			lowest *candidate
+		)
+		for _, acct := range c.pending {
+			back := acct.txsByNonce.Back()
+			if back == nil {
+				continue
+			}
+			cand := back.Value.(*candidate)
+			if cand.layer() != 0 {
+				continue
+			}
+			if lowest == nil || cand.best.Fee() < lowest.best.Fee() {
+				victim, lowest = acct, cand
+			}
+		}
+		if victim == nil {
+			return
+		}
+		victim.txsByNonce.Remove(victim.txsByNonce.Back())
+		delete(c.cachedTXs, lowest.id())
+		victim.moreInDB = true
+		mempoolEvictedCount.WithLabelValues(evictedLowFee).Inc()
+		logger.With().Debug("evicted tx with the lowest fee from full mempool",
+			lowest.id(),
+			victim.addr,
+			log.Uint64("nonce", lowest.nonce()),
+			log.Uint64("fee", lowest.best.Fee()),
+			log.Int("max_size", maxSize))
+	}
+}
+
 // Get gets a transaction from the cache.
 func (c *Cache) Get(tid types.TransactionID) *NanoTX {
 	c.mu.Lock()
@@ -684,6 +799,11 @@ func (c *Cache) ApplyLayer(
 			if err != nil {
 				return fmt.Errorf("add result tx=%s nonce=%d %w", rst.ID, rst.Nonce, err)
 			}
+			if len(rst.Events) > 0 {
+				if err := transactions.AddEvents(dbtx, rst.ID, rst.Events); err != nil {
+					return fmt.Errorf("add events tx=%s nonce=%d %w", rst.ID, rst.Nonce, err)
+				}
+			}
 		}
 		return nil
 	}); err != nil {
@@ -757,6 +877,7 @@ func (c *Cache) ApplyLayer(
 		}
 		acctResetDuration.Observe(float64(time.Since(t2)))
 	}
+	c.enforcePolicy(logger)
 	return nil
 }
 
@@ -784,15 +905,36 @@ func (c *Cache) GetProjection(addr types.Address) (uint64, uint64) {
 	return c.pending[addr].nextNonce(), c.pending[addr].availBalance()
 }
 
+// GetPending returns ids of the best pending transactions of the account in nonce order,
+// including transactions that are packed in proposals/blocks but not yet applied to the state.
+func (c *Cache) GetPending(addr types.Address) []types.TransactionID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	acc, ok := c.pending[addr]
+	if !ok {
+		return nil
+	}
+	rst := make([]types.TransactionID, 0, acc.txsByNonce.Len())
+	for e := acc.txsByNonce.Front(); e != nil; e = e.Next() {
+		rst = append(rst, e.Value.(*candidate).id())
+	}
+	return rst
+}
+
 // GetMempool returns all the transactions that eligible for a proposal/block.
 func (c *Cache) GetMempool(logger log.Log) map[types.Address][]*NanoTX {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	all := make(map[types.Address][]*NanoTX)
+	var (
+		all = make(map[types.Address][]*NanoTX)
+		cfg = c.policy.Config()
+		now = time.Now()
+	)
 	logger.With().Info("cache has pending accounts", log.Int("num_acct", len(c.pending)))
 	for addr, accCache := range c.pending {
-		txs := accCache.getMempool(logger.WithFields(addr))
+		txs := accCache.getMempool(logger.WithFields(addr), cfg, now)
 		if len(txs) > 0 {
 			all[addr] = txs
 		}
@@ -1345,6 +1345,106 @@ func TestCache_GetMempool(t *testing.T) {
 	checkMempool(t, tc.Cache, expectedMempool)
 }
 
+func TestCache_Policy_MinGasPrice(t *testing.T) {
+	tc, ta := createSingleAccountTestCache(t)
+	mtxs := make([]*types.MeshTransaction, 0, 3)
+	for i, fee := range []uint64{defaultFee, defaultFee, defaultFee - 1} {
+		mtx := &types.MeshTransaction{
+			Transaction: *newTx(t, ta.nonce+uint64(i), defaultAmount, fee, ta.signer),
+			Received:    time.Now(),
+		}
+		require.NoError(t, tc.Add(context.Background(), tc.db, &mtx.Transaction, mtx.Received, false))
+		mtxs = append(mtxs, mtx)
+	}
+	checkMempool(t, tc.Cache, map[types.Address][]*types.MeshTransaction{ta.principal: mtxs})
+
+	// txs below the price and the following nonces are not selected, but stay in the cache
+	tc.policy.SetMinGasPrice(defaultFee)
+	checkMempool(t, tc.Cache, map[types.Address][]*types.MeshTransaction{ta.principal: mtxs[:2]})
+	tc.policy.SetMinGasPrice(defaultFee + 1)
+	checkMempool(t, tc.Cache, nil)
+	for _, mtx := range mtxs {
+		checkTX(t, tc.Cache, mtx.ID, 0, types.EmptyBlockID)
+	}
+}
+
+func TestCache_Policy_MaxPerPrincipal(t *testing.T) {
+	tc, ta := createSingleAccountTestCache(t)
+	tc.policy.SetConfig(MempoolConfig{MaxTXsPerPrincipal: 2})
+	mtxs := genTXs(t, ta.signer, ta.nonce, ta.nonce+2, time.Now())
+	for _, mtx := range mtxs {
+		require.NoError(t, tc.Add(context.Background(), tc.db, &mtx.Transaction, mtx.Received, false))
+	}
+	checkNoTX(t, tc.Cache, mtxs[2].ID)
+	require.True(t, tc.MoreInDB(ta.principal))
+	checkMempool(t, tc.Cache, map[types.Address][]*types.MeshTransaction{ta.principal: mtxs[:2]})
+	checkTXStateFromDB(t, tc.db, mtxs, types.MEMPOOL)
+}
+
+func TestCache_Policy_MaxSize(t *testing.T) {
+	tc, accounts := createCache(t, 2)
+	tc.policy.SetConfig(MempoolConfig{MaxSize: 2})
+	var accts []*testAcct
+	for _, ta := range accounts {
+		accts = append(accts, ta)
+	}
+	first, second := accts[0], accts[1]
+	add := func(ta *testAcct, nonce, fee uint64) *types.MeshTransaction {
+		mtx := &types.MeshTransaction{
+			Transaction: *newTx(t, nonce, defaultAmount, fee, ta.signer),
+			Received:    time.Now(),
+		}
+		require.NoError(t, tc.Add(context.Background(), tc.db, &mtx.Transaction, mtx.Received, false))
+		return mtx
+	}
+	tx0 := add(first, first.nonce, 5)
+	tx1 := add(second, second.nonce, 3)
+	tx2 := add(first, first.nonce+1, 4)
+
+	// the tx with the lowest fee is evicted
+	checkTX(t, tc.Cache, tx0.ID, 0, types.EmptyBlockID)
+	checkTX(t, tc.Cache, tx2.ID, 0, types.EmptyBlockID)
+	checkNoTX(t, tc.Cache, tx1.ID)
+	require.True(t, tc.MoreInDB(second.principal))
+
+	// the new tx is evicted if it has the lowest fee
+	tx3 := add(second, second.nonce, 1)
+	checkNoTX(t, tc.Cache, tx3.ID)
+	checkMempool(t, tc.Cache, map[types.Address][]*types.MeshTransaction{first.principal: {tx0, tx2}})
+	checkTXStateFromDB(t, tc.db, []*types.MeshTransaction{tx0, tx1, tx2, tx3}, types.MEMPOOL)
+}
+
+func TestCache_Policy_TTL(t *testing.T) {
+	tc, ta := createSingleAccountTestCache(t)
+	tc.policy.SetConfig(MempoolConfig{TTL: time.Minute})
+	now := time.Now()
+	mtxs := []*types.MeshTransaction{
+		newMeshTX(t, ta.nonce, ta.signer, defaultAmount, now.Add(-2*time.Minute)),
+		newMeshTX(t, ta.nonce+1, ta.signer, defaultAmount, now),
+	}
+	saveTXs(t, tc.db, mtxs)
+	// the second tx is received within ttl, but can't be executed without the expired one
+	require.NoError(t, tc.buildFromScratch(tc.db))
+	for _, mtx := range mtxs {
+		checkNoTX(t, tc.Cache, mtx.ID)
+	}
+	require.True(t, tc.MoreInDB(ta.principal))
+	checkTXStateFromDB(t, tc.db, mtxs, types.MEMPOOL)
+
+	// txs are evicted once they expire
+	tc.policy.SetConfig(MempoolConfig{TTL: time.Hour})
+	require.NoError(t, tc.buildFromScratch(tc.db))
+	checkMempool(t, tc.Cache, map[types.Address][]*types.MeshTransaction{ta.principal: mtxs})
+	tc.policy.SetConfig(MempoolConfig{TTL: time.Minute})
+	checkMempool(t, tc.Cache, nil)
+	tc.mu.Lock()
+	tc.enforcePolicy(tc.logger)
+	tc.mu.Unlock()
+	for _, mtx := range mtxs {
+		checkNoTX(t, tc.Cache, mtx.ID)
+	}
+}
+
 func TestCache_GetProjection(t *testing.T) {
 	tc, accounts := createCache(t, 100)
 	mtxsByAccount := buildSmallCache(t, tc, accounts, 10)
@@ -20,12 +20,14 @@ import (
 type CSConfig struct {
 	BlockGasLimit     uint64
 	NumTXsPerProposal int
+	Mempool           MempoolConfig
 }
 
 func defaultCSConfig() CSConfig {
 	return CSConfig{
 		BlockGasLimit:     math.MaxUint64,
 		NumTXsPerProposal: 100,
+		Mempool:           DefaultMempoolConfig(),
 	}
 }
 
@@ -54,6 +56,7 @@ type ConservativeState struct {
 	logger log.Log
 	cfg    CSConfig
 	db     *sql.Database
+	policy *MempoolPolicy
 	cache  *Cache
 }
 
@@ -68,10 +71,21 @@ func NewConservativeState(state vmState, db *sql.Database, opts ...ConservativeS
 	for _, opt := range opts {
 		opt(cs)
 	}
-	cs.cache = NewCache(cs.getState, cs.logger)
+	cs.policy = NewMempoolPolicy(cs.cfg.Mempool)
+	cs.cache = newCache(cs.getState, cs.logger, cs.policy)
 	return cs
 }
 
+// MempoolPolicy returns the policy of the mempool, which can be updated at runtime.
+func (cs *ConservativeState) MempoolPolicy() *MempoolPolicy {
+	return cs.policy
+}
+
+// MinGasPrice returns the minimal gas price of transactions accepted into the mempool.
+func (cs *ConservativeState) MinGasPrice() uint64 {
+	return cs.policy.MinGasPrice()
+}
+
 func (cs *ConservativeState) getState(addr types.Address) (uint64, uint64) {
 	nonce, err := cs.vmState.GetNonce(addr)
 	if err != nil {
@@ -148,6 +162,30 @@ func (cs *ConservativeState) GetProjection(addr types.Address) (uint64, uint64)
 	return cs.cache.GetProjection(addr)
 }
 
+// Simulate executes the transaction on top of the latest applied state without persisting any changes.
+// If withPending is true, pending transactions of the principal are executed before it.
+func (cs *ConservativeState) Simulate(raw []byte, withPending bool) (*types.TransactionWithResult, error) {
+	tx := types.NewRawTx(raw)
+	var pending []types.Transaction
+	if withPending {
+		header, err := cs.vmState.Validation(tx).Parse()
+		if err != nil {
+			return nil, err
+		}
+		for _, tid := range cs.cache.GetPending(header.Principal) {
+			if tid == tx.ID {
+				continue
+			}
+			mtx, err := transactions.Get(cs.db, tid)
+			if err != nil {
+				return nil, fmt.Errorf("get pending tx %s: %w", tid, err)
+			}
+			pending = append(pending, mtx.Transaction)
+		}
+	}
+	return cs.vmState.Simulate(pending, tx)
+}
+
 // LinkTXsWithProposal associates the transactions to a proposal.
 func (cs *ConservativeState) LinkTXsWithProposal(lid types.LayerID, pid types.ProposalID, tids []types.TransactionID) error {
 	return cs.cache.LinkTXsWithProposal(cs.db, lid, pid, tids)
@@ -314,6 +314,34 @@ func TestGetProjection(t *testing.T) {
 	require.EqualValues(t, defaultBalance-2*(defaultAmount+defaultFee*defaultGas), balance)
 }
 
+func TestSimulate(t *testing.T) {
+	tcs := createConservativeState(t)
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	addr := types.GenerateAddress(signer.PublicKey().Bytes())
+	tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
+	tcs.mvm.EXPECT().GetNonce(addr).Return(nonce, nil).Times(1)
+	tx1 := newTx(t, nonce, defaultAmount, defaultFee, signer)
+	require.NoError(t, tcs.AddToCache(context.Background(), tx1))
+	tx2 := newTx(t, nonce+1, defaultAmount, defaultFee, signer)
+	require.NoError(t, tcs.AddToCache(context.Background(), tx2))
+
+	tx := newTx(t, nonce+2, defaultAmount, defaultFee, signer)
+	expected := &types.TransactionWithResult{Transaction: *tx}
+	tcs.mvm.EXPECT().Simulate(gomock.Len(0), tx.RawTx).Return(expected, nil)
+	rst, err := tcs.Simulate(tx.Raw, false)
+	require.NoError(t, err)
+	require.Equal(t, expected, rst)
+
+	req := smocks.NewMockValidationRequest(gomock.NewController(t))
+	req.EXPECT().Parse().Return(tx.TxHeader, nil)
+	tcs.mvm.EXPECT().Validation(tx.RawTx).Return(req)
+	tcs.mvm.EXPECT().Simulate([]types.Transaction{*tx1, *tx2}, tx.RawTx).Return(expected, nil)
+	rst, err = tcs.Simulate(tx.Raw, true)
+	require.NoError(t, err)
+	require.Equal(t, expected, rst)
+}
+
 func TestAddToCache(t *testing.T) {
 	tcs := createConservativeState(t)
 	signer, err := signing.NewEdSigner()
@@ -19,6 +19,7 @@ var (
 	errDuplicateTX = errors.New("tx already exists")
 	errParse       = errors.New("failed to parse tx")
 	errVerify      = errors.New("failed to verify tx")
+	errBelowMinGas = errors.New("gas price below the minimum")
 )
 
 // TxHandler handles the transactions received via gossip or sync.
@@ -49,6 +50,8 @@ func updateMetrics(err error, counter *prometheus.CounterVec) {
 		counter.WithLabelValues(cantParse).Inc()
 	case errors.Is(err, errVerify):
 		counter.WithLabelValues(cantVerify).Inc()
+	case errors.Is(err, errBelowMinGas):
+		counter.WithLabelValues(belowMinGas).Inc()
 	default:
 		counter.WithLabelValues(rejectedInternalErr).Inc()
 	}
@@ -70,8 +73,9 @@ func (th *TxHandler) HandleGossipTransaction(ctx context.Context, peer p2p.Peer,
 }
 
 // HandleProposalTransaction handles data received on the transactions synced as a part of proposal.
+// The mempool policy is not applied to them, as other nodes may use a lower minimal gas price.
 func (th *TxHandler) HandleProposalTransaction(ctx context.Context, _ p2p.Peer, msg []byte) error {
-	err := th.VerifyAndCacheTx(ctx, msg)
+	err := th.verifyAndCache(ctx, msg, false)
 	updateMetrics(err, proposalTxCount)
 	if errors.Is(err, errDuplicateTX) {
 		return nil
@@ -79,7 +83,13 @@ func (th *TxHandler) HandleProposalTransaction(ctx context.Context, _ p2p.Peer,
 	return err
 }
 
+// VerifyAndCacheTx verifies the transaction and adds it to the conservative cache.
+// Transactions with a gas price below the minimum of the mempool policy are rejected.
 func (th *TxHandler) VerifyAndCacheTx(ctx context.Context, msg []byte) error {
+	return th.verifyAndCache(ctx, msg, true)
+}
+
+func (th *TxHandler) verifyAndCache(ctx context.Context, msg []byte, withPolicy bool) error {
 	raw := types.NewRawTx(msg)
 	tx, err := th.state.GetMeshTransaction(raw.ID)
 	if err != nil && !errors.Is(err, sql.ErrNotFound) {
@@ -97,6 +107,11 @@ func (th *TxHandler) VerifyAndCacheTx(ctx context.Context, msg []byte) error {
 	if header.GasPrice == 0 {
 		return fmt.Errorf("%w: zero gas price %s", errParse, raw.ID)
 	}
+	if withPolicy {
+		if minGas := th.state.MinGasPrice(); header.GasPrice < minGas {
+			return fmt.Errorf("%w: %s gas price %d (min %d)", errBelowMinGas, raw.ID, header.GasPrice, minGas)
+		}
+	}
 	if !req.Verify() {
 		return fmt.Errorf("%w: %s", errVerify, raw.ID)
 	}
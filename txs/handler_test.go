@@ -16,6 +16,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
 	smocks "github.com/spacemeshos/go-spacemesh/system/mocks"
 )
 
@@ -117,6 +118,7 @@ func gossipExpectations(t *testing.T, fee uint64, hasErr, parseErr, addErr error
 		req.EXPECT().Parse().Times(1).Return(tx.TxHeader, parseErr)
 		cstate.EXPECT().Validation(tx.RawTx).Times(1).Return(req)
 		if parseErr == nil && fee != 0 {
+			cstate.EXPECT().MinGasPrice().Return(uint64(1)).AnyTimes()
 			req.EXPECT().Verify().Times(1).Return(verify)
 			if verify {
 				cstate.EXPECT().AddToCache(gomock.Any(), gomock.Any()).DoAndReturn(
@@ -204,6 +206,33 @@ func Test_HandleGossip(t *testing.T) {
 	}
 }
 
+func Test_HandleGossip_BelowMinGas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	cstate := NewMockconservativeState(ctrl)
+	_, pub, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	th := NewTxHandler(cstate, id, logtest.New(t))
+
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	tx := newTx(t, 3, 10, 1, signer)
+	req := smocks.NewMockValidationRequest(ctrl)
+	req.EXPECT().Parse().Return(tx.TxHeader, nil).Times(2)
+	cstate.EXPECT().GetMeshTransaction(tx.ID).Return(nil, sql.ErrNotFound).Times(2)
+	cstate.EXPECT().Validation(tx.RawTx).Return(req).Times(2)
+	cstate.EXPECT().MinGasPrice().Return(tx.GasPrice + 1)
+
+	err = th.VerifyAndCacheTx(context.Background(), tx.Raw)
+	require.ErrorIs(t, err, errBelowMinGas)
+
+	// the policy doesn't apply to transactions referenced by proposals
+	req.EXPECT().Verify().Return(true)
+	cstate.EXPECT().AddToCache(gomock.Any(), gomock.Any()).Return(nil)
+	require.NoError(t, th.HandleProposalTransaction(context.Background(), p2p.NoPeer, tx.Raw))
+}
+
 func Test_HandleOwnGossip(t *testing.T) {
 	_, pub, err := crypto.GenerateEd25519Key(nil)
 	require.NoError(t, err)
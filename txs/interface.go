@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/genvm/trie"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
@@ -16,6 +17,7 @@ type conservativeState interface {
 	AddToCache(context.Context, *types.Transaction) error
 	AddToDB(*types.Transaction) error
 	GetMeshTransaction(types.TransactionID) (*types.MeshTransaction, error)
+	MinGasPrice() uint64
 }
 
 type vmState interface {
@@ -26,6 +28,8 @@ type vmState interface {
 	GetAllAccounts() ([]*types.Account, error)
 	GetBalance(types.Address) (uint64, error)
 	GetNonce(types.Address) (types.Nonce, error)
+	GetAccountProof(types.Address, types.LayerID) (*trie.AccountProof, error)
+	Simulate([]types.Transaction, types.RawTx) (*types.TransactionWithResult, error)
 }
 
 type conStateCache interface {
@@ -24,7 +24,15 @@ const (
 	mempool         = "mempool"
 	balanceTooSmall = "balance"
 	tooManyNonce    = "too_many"
+	expired         = "expired"
 	accepted        = "ok"
+
+	// label for tx rejected by the mempool policy.
+	belowMinGas = "below_min_gas"
+
+	// labels for tx evicted from the mempool.
+	evictedExpired = "expired"
+	evictedLowFee  = "low_fee"
 )
 
 var (
@@ -58,6 +66,12 @@ var (
 		"number of transactions added to the mempool",
 		[]string{"outcome"},
 	)
+	mempoolEvictedCount = metrics.NewCounter(
+		"mempool_evicted_txs",
+		namespace,
+		"number of transactions evicted from the mempool",
+		[]string{"reason"},
+	)
 )
 
 var (
@@ -0,0 +1,74 @@
+package txs
+
+import (
+	"sync"
+	"time"
+)
+
+// MempoolConfig is the policy for transactions accepted into the mempool.
+type MempoolConfig struct {
+	// MinGasPrice is the minimal gas price of transactions accepted from gossip and the api.
+	MinGasPrice uint64 `mapstructure:"min-gas-price"`
+	// MaxTXsPerPrincipal is the maximal number of pending transactions of a principal in the mempool.
+	// Transactions with higher nonces are kept in the database until earlier ones are applied.
+	// Zero means the default of 100.
+	MaxTXsPerPrincipal int `mapstructure:"max-txs-per-principal"`
+	// MaxSize is the maximal number of transactions in the mempool. When it is exceeded transactions
+	// with the lowest fee are evicted to the database. Zero means no limit.
+	MaxSize int `mapstructure:"max-size"`
+	// TTL is how long a transaction can stay in the mempool without being included in a proposal
+	// or a block. Zero means no limit.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// DefaultMempoolConfig returns the default mempool policy.
+func DefaultMempoolConfig() MempoolConfig {
+	return MempoolConfig{
+		MinGasPrice:        1,
+		MaxTXsPerPrincipal: maxTXsPerAcct,
+	}
+}
+
+// MempoolPolicy holds the mempool config that can be updated while the node is running.
+type MempoolPolicy struct {
+	mu  sync.RWMutex
+	cfg MempoolConfig
+}
+
+// NewMempoolPolicy returns a MempoolPolicy with the given config.
+func NewMempoolPolicy(cfg MempoolConfig) *MempoolPolicy {
+	return &MempoolPolicy{cfg: cfg}
+}
+
+// Config returns the current config of the policy.
+func (p *MempoolPolicy) Config() MempoolConfig {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.cfg
+}
+
+// SetConfig replaces the config of the policy.
+func (p *MempoolPolicy) SetConfig(cfg MempoolConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg = cfg
+}
+
+// MinGasPrice returns the minimal gas price of transactions accepted into the mempool.
+func (p *MempoolPolicy) MinGasPrice() uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.cfg.MinGasPrice
+}
+
+// SetMinGasPrice updates the minimal gas price of transactions accepted into the mempool.
+func (p *MempoolPolicy) SetMinGasPrice(price uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg.MinGasPrice = price
+}
+
+// expired returns true if the transaction stayed in the mempool for longer than the TTL.
+func (cfg *MempoolConfig) expired(ntx *NanoTX, now time.Time) bool {
+	return cfg.TTL > 0 && ntx.Layer == 0 && now.Sub(ntx.Received) > cfg.TTL
+}
@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	types "github.com/spacemeshos/go-spacemesh/common/types"
+	trie "github.com/spacemeshos/go-spacemesh/genvm/trie"
 	log "github.com/spacemeshos/go-spacemesh/log"
 	system "github.com/spacemeshos/go-spacemesh/system"
 )
@@ -95,6 +96,20 @@ func (mr *MockconservativeStateMockRecorder) HasTx(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasTx", reflect.TypeOf((*MockconservativeState)(nil).HasTx), arg0)
 }
 
+// MinGasPrice mocks base method.
+func (m *MockconservativeState) MinGasPrice() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinGasPrice")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// MinGasPrice indicates an expected call of MinGasPrice.
+func (mr *MockconservativeStateMockRecorder) MinGasPrice() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinGasPrice", reflect.TypeOf((*MockconservativeState)(nil).MinGasPrice))
+}
+
 // Validation mocks base method.
 func (m *MockconservativeState) Validation(arg0 types.RawTx) system.ValidationRequest {
 	m.ctrl.T.Helper()
@@ -132,6 +147,21 @@ func (m *MockvmState) EXPECT() *MockvmStateMockRecorder {
 	return m.recorder
 }
 
+// GetAccountProof mocks base method.
+func (m *MockvmState) GetAccountProof(arg0 types.Address, arg1 types.LayerID) (*trie.AccountProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountProof", arg0, arg1)
+	ret0, _ := ret[0].(*trie.AccountProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountProof indicates an expected call of GetAccountProof.
+func (mr *MockvmStateMockRecorder) GetAccountProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountProof", reflect.TypeOf((*MockvmState)(nil).GetAccountProof), arg0, arg1)
+}
+
 // GetAllAccounts mocks base method.
 func (m *MockvmState) GetAllAccounts() ([]*types.Account, error) {
 	m.ctrl.T.Helper()
@@ -222,6 +252,21 @@ func (mr *MockvmStateMockRecorder) GetStateRoot() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStateRoot", reflect.TypeOf((*MockvmState)(nil).GetStateRoot))
 }
 
+// Simulate mocks base method.
+func (m *MockvmState) Simulate(arg0 []types.Transaction, arg1 types.RawTx) (*types.TransactionWithResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Simulate", arg0, arg1)
+	ret0, _ := ret[0].(*types.TransactionWithResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Simulate indicates an expected call of Simulate.
+func (mr *MockvmStateMockRecorder) Simulate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Simulate", reflect.TypeOf((*MockvmState)(nil).Simulate), arg0, arg1)
+}
+
 // Validation mocks base method.
 func (m *MockvmState) Validation(arg0 types.RawTx) system.ValidationRequest {
 	m.ctrl.T.Helper()